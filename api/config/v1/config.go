@@ -64,6 +64,21 @@ type Config struct {
 	AcceptEnvvarUnprivileged       bool   `toml:"accept-nvidia-visible-devices-envvar-when-unprivileged"`
 	AcceptDeviceListAsVolumeMounts bool   `toml:"accept-nvidia-visible-devices-as-volume-mounts"`
 	SupportedDriverCapabilities    string `toml:"supported-driver-capabilities"`
+	// DefaultVisibleDevices defines the devices that are made visible to a
+	// container if NVIDIA_VISIBLE_DEVICES (or the swarm-resource envvars) are
+	// not set in the container's environment. A value in the container's
+	// environment always takes precedence.
+	DefaultVisibleDevices string `toml:"default-visible-devices,omitempty"`
+	// VisibleDevicesFile defines the path to a file containing a
+	// newline-delimited list of the devices that are made visible to a
+	// container. This may also refer to a file descriptor passed to the
+	// runtime as /dev/fd/N. The file is only used for containers that set the
+	// NVIDIA_VISIBLE_DEVICES_FILE envvar to this path. For such containers,
+	// the devices listed in the file take precedence over the
+	// NVIDIA_VISIBLE_DEVICES envvar. Note that the path of the file that is
+	// read is only taken from the config and never from the environment of
+	// the container.
+	VisibleDevicesFile string `toml:"visible-devices-file,omitempty"`
 
 	NVIDIAContainerCLIConfig         ContainerCLIConfig `toml:"nvidia-container-cli"`
 	NVIDIACTKConfig                  CTKConfig          `toml:"nvidia-ctk"`
@@ -74,24 +89,54 @@ type Config struct {
 	Features features `toml:"features,omitempty"`
 }
 
-// GetConfigFilePath returns the path to the config file for the configured system
+// GetConfigFilePath returns the path to the config file for the configured system.
+// The first existing file in the config file search path is returned. If no
+// such file exists, the first entry in the search path is returned.
 func GetConfigFilePath() string {
+	return ResolveConfigFilePath(GetConfigFilePaths()...)
+}
+
+// GetConfigFilePaths returns the ordered list of paths that are searched for
+// the config file. If the NVIDIA_CTK_CONFIG_FILE_PATH envvar is set, this is
+// the only path returned. Otherwise the path relative to $XDG_CONFIG_HOME (if
+// set) takes precedence over the path relative to /etc.
+func GetConfigFilePaths() []string {
 	if configFilePathOverride := os.Getenv(FilePathOverrideEnvVar); configFilePathOverride != "" {
-		return configFilePathOverride
+		return []string{configFilePathOverride}
 	}
-	configRoot := "/etc"
+	var paths []string
 	if XDGConfigDir := os.Getenv(configRootOverride); len(XDGConfigDir) != 0 {
-		configRoot = XDGConfigDir
+		paths = append(paths, filepath.Join(XDGConfigDir, RelativeFilePath))
 	}
+	return append(paths, filepath.Join("/etc", RelativeFilePath))
+}
 
-	return filepath.Join(configRoot, RelativeFilePath)
+// ResolveConfigFilePath returns the first of the specified paths that refers
+// to an existing file. If none of the paths exist, the first path is returned so
+// that the built-in defaults are used when loading the config.
+func ResolveConfigFilePath(paths ...string) string {
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	return paths[0]
 }
 
 // GetConfig sets up the config struct. Values are read from a toml file
 // or set via the environment.
 func GetConfig() (*Config, error) {
+	return GetConfigFrom(GetConfigFilePath())
+}
+
+// GetConfigFrom sets up the config struct from the specified file. If the file
+// does not exist, the default config is returned.
+func GetConfigFrom(configFilePath string) (*Config, error) {
 	cfg, err := New(
-		WithConfigFile(GetConfigFilePath()),
+		WithConfigFile(configFilePath),
 	)
 	if err != nil {
 		return nil, err
@@ -145,6 +190,9 @@ func (c *Config) assertValid() error {
 	if err != nil {
 		return errors.Join(err, errInvalidConfig)
 	}
+	if err := c.NVIDIAContainerRuntimeConfig.assertValid(); err != nil {
+		return errors.Join(err, errInvalidConfig)
+	}
 	return nil
 }
 
@@ -218,20 +266,49 @@ func ResolveNVIDIACTKPath(logger logger.Interface, nvidiaCTKPath string) string
 // If the path is specified as an absolute path, it is used directly
 // without checking for existence of an executable at that path.
 func ResolveNVIDIACDIHookPath(logger logger.Interface, nvidiaCDIHookPath string) string {
-	if filepath.Base(nvidiaCDIHookPath) == "nvidia-ctk" {
-		return resolveWithDefault(
-			logger,
-			"NVIDIA Container Toolkit CLI",
-			nvidiaCDIHookPath,
-			nvidiaCTKDefaultFilePath,
-		)
+	return resolveNVIDIACDIHookPath(logger, lookup.NewExecutableLocator(logger, ""), nvidiaCDIHookPath, false)
+}
+
+// ResolveNVIDIACDIHookPathWithNVIDIACTKFallback resolves the path to the
+// executable used for hooks as ResolveNVIDIACDIHookPath does. If no path is
+// specified and the nvidia-cdi-hook binary cannot be found, the nvidia-ctk
+// binary is used instead. In this case the hooks are implemented as
+// subcommands of `nvidia-ctk hook`. If neither can be found,
+// /usr/bin/nvidia-cdi-hook is used.
+func ResolveNVIDIACDIHookPathWithNVIDIACTKFallback(logger logger.Interface, nvidiaCDIHookPath string) string {
+	return resolveNVIDIACDIHookPath(logger, lookup.NewExecutableLocator(logger, ""), nvidiaCDIHookPath, true)
+}
+
+func resolveNVIDIACDIHookPath(logger logger.Interface, locator lookup.Locator, path string, withNVIDIACTKFallback bool) string {
+	if filepath.IsAbs(path) {
+		logger.Debugf("Using specified CDI hook path %v", path)
+		return path
 	}
-	return resolveWithDefault(
-		logger,
-		"NVIDIA CDI Hook CLI",
-		nvidiaCDIHookPath,
-		nvidiaCDIHookDefaultFilePath,
-	)
+
+	candidates := []string{path}
+	if path == "" {
+		candidates = []string{filepath.Base(nvidiaCDIHookDefaultFilePath)}
+		if withNVIDIACTKFallback {
+			candidates = append(candidates, nvidiaCTKExecutable)
+		}
+	}
+
+	for _, candidate := range candidates {
+		targets, err := locator.Locate(candidate)
+		if err != nil || len(targets) == 0 {
+			logger.Debugf("Failed to locate %v: %v", candidate, err)
+			continue
+		}
+		logger.Debugf("Using CDI hook path %v", targets[0])
+		return targets[0]
+	}
+
+	if filepath.Base(path) == nvidiaCTKExecutable {
+		logger.Warningf("Could not locate %v; using %v", path, nvidiaCTKDefaultFilePath)
+		return nvidiaCTKDefaultFilePath
+	}
+	logger.Warningf("Could not locate CDI hook executable; using %v", nvidiaCDIHookDefaultFilePath)
+	return nvidiaCDIHookDefaultFilePath
 }
 
 // ResolveNVIDIAContainerRuntimeHookPath resolves the path the nvidia-container-runtime-hook binary.
@@ -17,14 +17,17 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test/to"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
 )
 
 func TestGetConfigWithCustomConfig(t *testing.T) {
@@ -61,6 +64,97 @@ func TestGetConfigWithConfigFilePathOverride(t *testing.T) {
 	require.Equal(t, "/nvidia-container-toolkit.log", cfg.NVIDIAContainerRuntimeConfig.DebugFilePath)
 }
 
+func TestGetConfigFilePaths(t *testing.T) {
+	testCases := []struct {
+		description   string
+		override      string
+		xdgConfigHome string
+		expectedPaths []string
+	}{
+		{
+			description:   "default search path",
+			expectedPaths: []string{"/etc/nvidia-container-runtime/config.toml"},
+		},
+		{
+			description:   "XDG_CONFIG_HOME is searched before /etc",
+			xdgConfigHome: "/home/user/.config",
+			expectedPaths: []string{
+				"/home/user/.config/nvidia-container-runtime/config.toml",
+				"/etc/nvidia-container-runtime/config.toml",
+			},
+		},
+		{
+			description:   "override replaces the search path",
+			override:      "/custom/config.toml",
+			xdgConfigHome: "/home/user/.config",
+			expectedPaths: []string{"/custom/config.toml"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Setenv(FilePathOverrideEnvVar, tc.override)
+			t.Setenv(configRootOverride, tc.xdgConfigHome)
+
+			require.EqualValues(t, tc.expectedPaths, GetConfigFilePaths())
+		})
+	}
+}
+
+func TestResolveConfigFilePath(t *testing.T) {
+	testDir := t.TempDir()
+	first := filepath.Join(testDir, "first", RelativeFilePath)
+	second := filepath.Join(testDir, "second", RelativeFilePath)
+	directory := filepath.Join(testDir, "directory", RelativeFilePath)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(second), 0755))
+	require.NoError(t, os.WriteFile(second, nil, 0600))
+	require.NoError(t, os.MkdirAll(directory, 0755))
+
+	testCases := []struct {
+		description  string
+		paths        []string
+		expectedPath string
+	}{
+		{
+			description:  "no paths",
+			expectedPath: "",
+		},
+		{
+			description:  "first existing file is selected",
+			paths:        []string{first, second},
+			expectedPath: second,
+		},
+		{
+			description:  "directories are skipped",
+			paths:        []string{directory, second},
+			expectedPath: second,
+		},
+		{
+			description:  "first path is returned if none exist",
+			paths:        []string{first, directory},
+			expectedPath: first,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expectedPath, ResolveConfigFilePath(tc.paths...))
+		})
+	}
+}
+
+func TestGetConfigFilePathPrefersXDGConfigHome(t *testing.T) {
+	xdgConfigHome := t.TempDir()
+	t.Setenv(FilePathOverrideEnvVar, "")
+	t.Setenv(configRootOverride, xdgConfigHome)
+
+	expectedPath := filepath.Join(xdgConfigHome, RelativeFilePath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(expectedPath), 0755))
+	require.NoError(t, os.WriteFile(expectedPath, nil, 0600))
+	require.Equal(t, expectedPath, GetConfigFilePath())
+}
+
 func TestGetConfig(t *testing.T) {
 	testCases := []struct {
 		description    string
@@ -428,6 +522,259 @@ func TestAssertValid(t *testing.T) {
 			},
 			expectedError: errInvalidConfig,
 		},
+		{
+			description: "known discoverers are valid",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					Discoverers: []string{"graphics", "gated-devices", "mps", "cuda-compat"},
+				},
+			},
+		},
+		{
+			description: "unknown discoverer is invalid",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					Discoverers: []string{"graphics", "mode"},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "valid mount propagation",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					MountPropagation: "rslave",
+				},
+			},
+		},
+		{
+			description: "invalid mount propagation",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					MountPropagation: "ro",
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "invalid driver health check timeout",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					DriverHealthCheck: driverHealthCheckConfig{
+						Enabled: true,
+						Timeout: "five seconds",
+					},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "invalid uvm-device.on-missing",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					UVMDevice: uvmDeviceConfig{
+						OnMissing: "ignore",
+					},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "invalid uvm-device.timeout",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					UVMDevice: uvmDeviceConfig{
+						OnMissing: UVMDeviceOnMissingLoad,
+						Timeout:   "-1s",
+					},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "invalid env-merge-policy",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					EnvMergePolicy: "append",
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "invalid modes.jit-cdi.on-no-devices",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					Modes: modesConfig{
+						JitCDI: jitCDIModeConfig{
+							OnNoDevices: "skip",
+						},
+					},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "valid pass-through mounts",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					PassThroughMounts: []PassThroughMountConfig{
+						{HostPath: "/etc/pki/ca-trust", ReadOnly: true},
+						{HostPath: "/run/telemetry", ContainerPath: "/var/run/telemetry"},
+					},
+				},
+			},
+		},
+		{
+			description: "relative pass-through mount host path",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					PassThroughMounts: []PassThroughMountConfig{
+						{HostPath: "etc/pki/ca-trust"},
+					},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "duplicate pass-through mount container path",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					PassThroughMounts: []PassThroughMountConfig{
+						{HostPath: "/run/telemetry"},
+						{HostPath: "/run/other-telemetry", ContainerPath: "/run/telemetry"},
+					},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "valid protected mount prefixes",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					ProtectedMountPrefixes: []string{"/usr/bin", "/etc/ssl"},
+				},
+			},
+		},
+		{
+			description: "relative protected mount prefix",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					ProtectedMountPrefixes: []string{"usr/bin"},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "valid additional device nodes",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					AdditionalDeviceNodes: []string{"/dev/null"},
+				},
+			},
+		},
+		{
+			description: "relative additional device node",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					AdditionalDeviceNodes: []string{"dev/null"},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "additional device node is only checked on create",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					AdditionalDeviceNodes: []string{"/dev/nvidia-does-not-exist"},
+				},
+			},
+		},
+		{
+			description: "relative spec path",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					SpecPath: "specs/container.json",
+				},
+			},
+		},
+		{
+			description: "absolute spec path",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					SpecPath: "/var/lib/specs/container.json",
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
+		{
+			description: "spec path outside the bundle directory",
+			config: &Config{
+				NVIDIAContainerCLIConfig: ContainerCLIConfig{
+					Ldconfig: "@/some/host/path",
+				},
+				NVIDIAContainerRuntimeConfig: RuntimeConfig{
+					SpecPath: "../container.json",
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
 		{
 			description: "feature flag allows non-host path",
 			config: &Config{
@@ -473,3 +820,75 @@ func setGetLdConfigPathForTest() func() {
 		getLdConfigPath = previous
 	}
 }
+
+func TestResolveNVIDIACDIHookPath(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description           string
+		path                  string
+		withNVIDIACTKFallback bool
+		executables           map[string]string
+		expectedPath          string
+	}{
+		{
+			description:  "absolute path is used as is",
+			path:         "/opt/bin/nvidia-cdi-hook",
+			executables:  map[string]string{"nvidia-cdi-hook": "/usr/bin/nvidia-cdi-hook"},
+			expectedPath: "/opt/bin/nvidia-cdi-hook",
+		},
+		{
+			description:           "nvidia-cdi-hook is preferred",
+			withNVIDIACTKFallback: true,
+			executables: map[string]string{
+				"nvidia-cdi-hook": "/usr/local/bin/nvidia-cdi-hook",
+				"nvidia-ctk":      "/usr/local/bin/nvidia-ctk",
+			},
+			expectedPath: "/usr/local/bin/nvidia-cdi-hook",
+		},
+		{
+			description:           "falls back to nvidia-ctk",
+			withNVIDIACTKFallback: true,
+			executables:           map[string]string{"nvidia-ctk": "/usr/local/bin/nvidia-ctk"},
+			expectedPath:          "/usr/local/bin/nvidia-ctk",
+		},
+		{
+			description:  "fallback disabled uses default",
+			executables:  map[string]string{"nvidia-ctk": "/usr/local/bin/nvidia-ctk"},
+			expectedPath: "/usr/bin/nvidia-cdi-hook",
+		},
+		{
+			description:           "nothing found uses default",
+			withNVIDIACTKFallback: true,
+			expectedPath:          "/usr/bin/nvidia-cdi-hook",
+		},
+		{
+			description:  "explicit nvidia-ctk is located",
+			path:         "nvidia-ctk",
+			executables:  map[string]string{"nvidia-ctk": "/usr/local/bin/nvidia-ctk"},
+			expectedPath: "/usr/local/bin/nvidia-ctk",
+		},
+		{
+			description:  "explicit nvidia-ctk not found uses nvidia-ctk default",
+			path:         "nvidia-ctk",
+			executables:  map[string]string{"nvidia-cdi-hook": "/usr/local/bin/nvidia-cdi-hook"},
+			expectedPath: "/usr/bin/nvidia-ctk",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			locator := &lookup.LocatorMock{
+				LocateFunc: func(name string) ([]string, error) {
+					if path, ok := tc.executables[name]; ok {
+						return []string{path}, nil
+					}
+					return nil, fmt.Errorf("%v not found", name)
+				},
+			}
+
+			path := resolveNVIDIACDIHookPath(logger, locator, tc.path, tc.withNVIDIACTKFallback)
+			require.Equal(t, tc.expectedPath, path)
+		})
+	}
+}
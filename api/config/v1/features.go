@@ -30,6 +30,11 @@ type features struct {
 	// container.
 	// If this is enabled, these fields are silently dropped.
 	AllowUnknownOCISpecFields *feature `toml:"allow-unknown-oci-spec-fields,omitempty"`
+	// DenyAllDevicesByDefault ensures that a deny-all device cgroup rule is
+	// present when device nodes are injected into a container. This means that
+	// only the injected device nodes, and those explicitly allowed by the
+	// container engine, are accessible.
+	DenyAllDevicesByDefault *feature `toml:"deny-all-devices-by-default,omitempty"`
 	// DisableCUDACompatLibHook, when enabled skips the injection of a specific
 	// hook to process CUDA compatibility libraries.
 	//
@@ -39,6 +44,10 @@ type features struct {
 	// DisableImexChannelCreation ensures that the implicit creation of
 	// requested IMEX channels is skipped when invoking the nvidia-container-cli.
 	DisableImexChannelCreation *feature `toml:"disable-imex-channel-creation,omitempty"`
+	// EnableMPS enables the injection of the CUDA Multi-Process Service (MPS)
+	// pipe and log directories into containers that request GPUs. The
+	// directories are configured in the nvidia-container-runtime.mps section.
+	EnableMPS *feature `toml:"enable-mps,omitempty"`
 	// IgnoreImexChannelRequests configures the NVIDIA Container Toolkit to
 	// ignore IMEX channel requests through the NVIDIA_IMEX_CHANNELS envvar or
 	// volume mounts.
@@ -50,6 +59,12 @@ type features struct {
 	// NoAdditionalGIDsForDeviceNodes disables the injection of additional GIDs
 	// for a device node when the node is not readable and writeable by the user.
 	NoAdditionalGIDsForDeviceNodes *feature `toml:"no-additional-gids-for-device-nodes,omitempty"`
+	// ValidateModifiedSpec enables a validation pass over the OCI spec after it
+	// has been modified by the nvidia-container-runtime. If the modified spec
+	// contains conditions that would be rejected by the low-level runtime
+	// (such as duplicate mount destinations or missing bind mount sources), an
+	// error is raised instead of invoking the low-level runtime.
+	ValidateModifiedSpec *feature `toml:"validate-modified-spec,omitempty"`
 }
 
 type feature bool
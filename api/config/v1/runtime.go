@@ -16,7 +16,13 @@
 
 package config
 
-import "github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
 
 // RuntimeConfig stores the config options for the NVIDIA Container Runtime
 type RuntimeConfig struct {
@@ -27,6 +33,338 @@ type RuntimeConfig struct {
 	Runtimes []string    `toml:"runtimes"`
 	Mode     string      `toml:"mode"`
 	Modes    modesConfig `toml:"modes"`
+	// VMRuntimes defines the low-level runtimes (by name or path) that run
+	// containers in a VM, such as kata-runtime. For these runtimes, the
+	// mounts and hooks injected for CDI devices are removed since host paths
+	// cannot be bind-mounted into the VM. The device nodes are kept so that
+	// the runtime can pass the devices through to the VM.
+	VMRuntimes []string `toml:"vm-runtimes,omitempty"`
+	// SkipMounts defines a list of container paths (mount destinations) that
+	// should not be injected into a container by the NVIDIA Container Runtime.
+	SkipMounts []string `toml:"skip-mounts,omitempty"`
+	// SkipImages defines a list of image references for which no
+	// modifications are made to the OCI spec of a container. A reference
+	// ending in * matches all images with the specified prefix. The image
+	// reference of a container is read from its OCI spec annotations.
+	SkipImages []string `toml:"skip-images,omitempty"`
+	// SkipContainers defines the containers, such as infrastructure
+	// containers, for which no modifications are made to the OCI spec even
+	// if these request devices.
+	SkipContainers skipContainersConfig `toml:"skip-containers,omitempty"`
+	// MPS defines the settings used when injecting the CUDA Multi-Process
+	// Service (MPS) directories. These only apply if the enable-mps feature
+	// is enabled.
+	MPS mpsConfig `toml:"mps,omitempty"`
+	// Discoverers defines the discoverers that are used to inject optional
+	// components into a container and the order in which they are applied.
+	// Discoverers that are not supported for the selected mode are ignored.
+	// The devices for the selected mode are always injected. If this is
+	// empty, the default discoverers for the mode are used.
+	Discoverers []string `toml:"discoverers,omitempty"`
+	// HookPath specifies the path to the nvidia-container-runtime-hook that
+	// is injected into the OCI spec of a container. This allows the hook to
+	// be referenced from a location such as a driver container. If this is
+	// not specified, the nvidia-container-runtime-hook.path is used.
+	HookPath string `toml:"hook-path,omitempty"`
+	// AllowModeOverride allows containers to select the legacy or cdi mode
+	// by setting the NVIDIA_CONTAINER_RUNTIME_MODE envvar. If this is not
+	// set, the configured mode is always used.
+	AllowModeOverride bool `toml:"allow-mode-override,omitempty"`
+	// MountPropagation overrides the propagation of the mounts that are
+	// injected into a container. Supported values are rprivate, private,
+	// rslave, slave, rshared, and shared. If this is not specified, the
+	// propagation of each mount is left as is (rprivate for libraries).
+	MountPropagation string `toml:"mount-propagation,omitempty"`
+	// DriverHealthCheck defines an optional check that the NVIDIA driver is
+	// functional before the OCI spec of a container requesting GPUs is
+	// modified.
+	DriverHealthCheck driverHealthCheckConfig `toml:"driver-health-check,omitempty"`
+	// PassThroughMounts defines host paths that are mounted into every
+	// container that requests devices, regardless of the devices that are
+	// requested.
+	PassThroughMounts []PassThroughMountConfig `toml:"pass-through-mounts,omitempty"`
+	// UVMDevice defines how a missing /dev/nvidia-uvm device node is handled
+	// for a container that requests GPUs.
+	UVMDevice uvmDeviceConfig `toml:"uvm-device,omitempty"`
+	// CUDAForwardCompat defines the handling of containers that require a
+	// newer CUDA version than the one supported by the host driver.
+	CUDAForwardCompat cudaForwardCompatConfig `toml:"cuda-forward-compat,omitempty"`
+	// EnvMergePolicy defines how envvars injected into a container are
+	// combined with envvars that are already set in the container.
+	// Supported values are preserve, union, and overwrite. If this is not
+	// specified, preserve is used.
+	EnvMergePolicy string `toml:"env-merge-policy,omitempty"`
+	// SpecPath overrides the path to the OCI runtime specification of a
+	// container that is loaded. The path must be relative and is resolved
+	// relative to the bundle directory. The modified spec is always written
+	// to config.json in the bundle directory since this is the file that is
+	// read by the low-level runtime and the hooks. If this is not specified,
+	// config.json in the bundle directory is used.
+	SpecPath string `toml:"spec-path,omitempty"`
+	// AnnotateInjectedDevices adds an nvidia.com/injected-devices annotation
+	// to the OCI spec of a container that requests devices. The annotation
+	// records the comma-separated list of requested device identifiers and
+	// is intended for auditing and debugging.
+	AnnotateInjectedDevices bool `toml:"annotate-injected-devices,omitempty"`
+	// ProtectedMountPrefixes defines a list of container paths that must not
+	// be shadowed by mounts injected by the NVIDIA Container Runtime. A mount
+	// with a destination equal to or below one of these paths is skipped and
+	// a warning is logged.
+	ProtectedMountPrefixes []string `toml:"protected-mount-prefixes,omitempty"`
+	// AdditionalDeviceNodes defines a list of host device nodes (e.g.
+	// /dev/nvidia-custom) that are injected into every container that
+	// requests devices. Each path must refer to a char or block device and a
+	// device cgroup rule is added to allow access to it. The device nodes are
+	// only checked when a container is created.
+	AdditionalDeviceNodes []string `toml:"additional-device-nodes,omitempty"`
+}
+
+// The following policies can be specified in the
+// nvidia-container-runtime.env-merge-policy config option.
+const (
+	// EnvMergePolicyPreserve keeps the value of an envvar that is already set
+	// in the container.
+	EnvMergePolicyPreserve = "preserve"
+	// EnvMergePolicyUnion keeps the value of an envvar that is already set in
+	// the container, but combines the elements of list-valued envvars such as
+	// NVIDIA_DRIVER_CAPABILITIES with the injected elements.
+	EnvMergePolicyUnion = "union"
+	// EnvMergePolicyOverwrite replaces the value of an envvar that is already
+	// set in the container with the injected value.
+	EnvMergePolicyOverwrite = "overwrite"
+)
+
+// GetEnvMergePolicy returns the configured env merge policy.
+func (c *RuntimeConfig) GetEnvMergePolicy() string {
+	if c.EnvMergePolicy == "" {
+		return EnvMergePolicyPreserve
+	}
+	return c.EnvMergePolicy
+}
+
+// The following discoverers can be specified in the
+// nvidia-container-runtime.discoverers config option.
+const (
+	// DiscovererGatedDevices discovers the devices for optional features such
+	// as GDS or MOFED that are requested by a container.
+	DiscovererGatedDevices = "gated-devices"
+	// DiscovererMPS discovers the CUDA MPS directories if the enable-mps
+	// feature is enabled.
+	DiscovererMPS = "mps"
+	// DiscovererCUDACompat discovers the hook that enables the CUDA
+	// compatibility libraries in a container.
+	DiscovererCUDACompat = "cuda-compat"
+	// DiscovererGraphics discovers the graphics libraries and devices.
+	DiscovererGraphics = "graphics"
+)
+
+// assertValid checks whether the runtime config is valid.
+func (c *RuntimeConfig) assertValid() error {
+	for _, discoverer := range c.Discoverers {
+		switch discoverer {
+		case DiscovererGatedDevices, DiscovererMPS, DiscovererCUDACompat, DiscovererGraphics:
+		default:
+			return fmt.Errorf("unknown discoverer %q in nvidia-container-runtime.discoverers", discoverer)
+		}
+	}
+	switch c.MountPropagation {
+	case "", "rprivate", "private", "rslave", "slave", "rshared", "shared":
+	default:
+		return fmt.Errorf("invalid nvidia-container-runtime.mount-propagation %q", c.MountPropagation)
+	}
+	if _, err := c.DriverHealthCheck.GetTimeout(); err != nil {
+		return fmt.Errorf("invalid nvidia-container-runtime.driver-health-check.timeout: %w", err)
+	}
+	switch c.UVMDevice.OnMissing {
+	case "", UVMDeviceOnMissingSkip, UVMDeviceOnMissingLoad:
+	default:
+		return fmt.Errorf("invalid nvidia-container-runtime.uvm-device.on-missing %q", c.UVMDevice.OnMissing)
+	}
+	if _, err := c.UVMDevice.GetTimeout(); err != nil {
+		return fmt.Errorf("invalid nvidia-container-runtime.uvm-device.timeout: %w", err)
+	}
+	switch c.EnvMergePolicy {
+	case "", EnvMergePolicyPreserve, EnvMergePolicyUnion, EnvMergePolicyOverwrite:
+	default:
+		return fmt.Errorf("invalid nvidia-container-runtime.env-merge-policy %q", c.EnvMergePolicy)
+	}
+	switch c.Modes.JitCDI.OnNoDevices {
+	case "", OnNoDevicesFail, OnNoDevicesNone, OnNoDevicesControlDevices:
+	default:
+		return fmt.Errorf("invalid nvidia-container-runtime.modes.jit-cdi.on-no-devices %q", c.Modes.JitCDI.OnNoDevices)
+	}
+	containerPaths := make(map[string]bool)
+	for _, mount := range c.PassThroughMounts {
+		if err := mount.assertValid(); err != nil {
+			return fmt.Errorf("invalid nvidia-container-runtime.pass-through-mounts entry: %w", err)
+		}
+		containerPath := mount.GetContainerPath()
+		if containerPaths[containerPath] {
+			return fmt.Errorf("invalid nvidia-container-runtime.pass-through-mounts entry: duplicate container path %q", containerPath)
+		}
+		containerPaths[containerPath] = true
+	}
+	if root := c.CUDAForwardCompat.HostCompatRoot; root != "" && !filepath.IsAbs(root) {
+		return fmt.Errorf("invalid nvidia-container-runtime.cuda-forward-compat.host-compat-root %q: path must be absolute", root)
+	}
+	if root := c.CUDAForwardCompat.ContainerCompatRoot; root != "" && !filepath.IsAbs(root) {
+		return fmt.Errorf("invalid nvidia-container-runtime.cuda-forward-compat.container-compat-root %q: path must be absolute", root)
+	}
+	for _, prefix := range c.ProtectedMountPrefixes {
+		if !filepath.IsAbs(prefix) {
+			return fmt.Errorf("invalid nvidia-container-runtime.protected-mount-prefixes entry %q: path must be absolute", prefix)
+		}
+	}
+	if c.SpecPath != "" && !filepath.IsLocal(c.SpecPath) {
+		return fmt.Errorf("invalid nvidia-container-runtime.spec-path %q: path must be relative to the bundle directory", c.SpecPath)
+	}
+	for _, path := range c.AdditionalDeviceNodes {
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("invalid nvidia-container-runtime.additional-device-nodes entry %q: path must be absolute", path)
+		}
+	}
+	return nil
+}
+
+// PassThroughMountConfig defines a host path that is mounted into all GPU
+// containers.
+type PassThroughMountConfig struct {
+	// HostPath is the absolute path of the file or directory on the host.
+	HostPath string `toml:"host-path"`
+	// ContainerPath is the absolute path at which the host path is mounted in
+	// the container. If this is not specified, the host path is used.
+	ContainerPath string `toml:"container-path,omitempty"`
+	// ReadOnly indicates whether the path is mounted read-only.
+	ReadOnly bool `toml:"read-only,omitempty"`
+}
+
+// GetContainerPath returns the path at which the pass-through mount is
+// mounted in the container.
+func (c PassThroughMountConfig) GetContainerPath() string {
+	if c.ContainerPath == "" {
+		return c.HostPath
+	}
+	return c.ContainerPath
+}
+
+func (c PassThroughMountConfig) assertValid() error {
+	if !filepath.IsAbs(c.HostPath) {
+		return fmt.Errorf("host path %q is not an absolute path", c.HostPath)
+	}
+	if c.ContainerPath != "" && !filepath.IsAbs(c.ContainerPath) {
+		return fmt.Errorf("container path %q is not an absolute path", c.ContainerPath)
+	}
+	return nil
+}
+
+// defaultDriverHealthCheckTimeout is the timeout used for the driver health
+// check if none is specified.
+const defaultDriverHealthCheckTimeout = 5 * time.Second
+
+type driverHealthCheckConfig struct {
+	// Enabled indicates whether nvidia-smi is run to check that the driver
+	// is functional before a container requesting GPUs is started.
+	Enabled bool `toml:"enabled,omitempty"`
+	// Timeout is the maximum duration of the check (e.g. "5s"). If this is
+	// not specified, a timeout of 5s is used.
+	Timeout string `toml:"timeout,omitempty"`
+}
+
+// GetTimeout returns the timeout for the driver health check.
+func (c driverHealthCheckConfig) GetTimeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return defaultDriverHealthCheckTimeout, nil
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("timeout must be positive")
+	}
+	return timeout, nil
+}
+
+// The following actions can be specified in the
+// nvidia-container-runtime.uvm-device.on-missing config option.
+const (
+	// UVMDeviceOnMissingSkip logs a warning and removes the nvidia-uvm device
+	// nodes (and their device cgroup rules) that would otherwise be injected
+	// if /dev/nvidia-uvm does not exist.
+	UVMDeviceOnMissingSkip = "skip"
+	// UVMDeviceOnMissingLoad loads the nvidia-uvm kernel module and creates
+	// the /dev/nvidia-uvm device nodes if these do not exist.
+	UVMDeviceOnMissingLoad = "load"
+)
+
+// defaultUVMDeviceTimeout is the time waited for the /dev/nvidia-uvm device
+// node to appear if none is specified.
+const defaultUVMDeviceTimeout = 1 * time.Second
+
+type uvmDeviceConfig struct {
+	// OnMissing defines the action taken if the /dev/nvidia-uvm device node
+	// does not exist. Supported values are skip and load. If this is not
+	// specified, no action is taken.
+	OnMissing string `toml:"on-missing,omitempty"`
+	// Timeout is the maximum duration to wait for the device node to appear
+	// after the nvidia-uvm kernel module has been loaded (e.g. "1s"). If this
+	// is not specified, a timeout of 1s is used.
+	Timeout string `toml:"timeout,omitempty"`
+}
+
+// GetTimeout returns the time to wait for the /dev/nvidia-uvm device node.
+func (c uvmDeviceConfig) GetTimeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return defaultUVMDeviceTimeout, nil
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("timeout must be positive")
+	}
+	return timeout, nil
+}
+
+type cudaForwardCompatConfig struct {
+	// HostCompatRoot is the folder (relative to the driver root) containing
+	// the CUDA Forward Compatibility libraries provided by the host. If this
+	// is specified, these libraries are injected into a container that
+	// requires a newer CUDA version than the one supported by the host
+	// driver.
+	HostCompatRoot string `toml:"host-compat-root,omitempty"`
+	// ContainerCompatRoot is the folder in the container to which the CUDA
+	// Forward Compatibility libraries of the host are mounted. If this is not
+	// specified, /usr/local/cuda/compat is used.
+	ContainerCompatRoot string `toml:"container-compat-root,omitempty"`
+	// CheckVersion enables a check that logs a warning if the CUDA version of
+	// a container is newer than the version supported by the host driver.
+	CheckVersion bool `toml:"check-version,omitempty"`
+}
+
+type skipContainersConfig struct {
+	// CgroupPaths defines a list of cgroup path prefixes. A container whose
+	// cgroups path (as specified in its OCI spec) starts with one of these
+	// prefixes is skipped.
+	CgroupPaths []string `toml:"cgroup-paths,omitempty"`
+	// Namespaces defines a list of Kubernetes namespaces. A container in one
+	// of these namespaces is skipped. The namespace of a container is read
+	// from its OCI spec annotations.
+	Namespaces []string `toml:"namespaces,omitempty"`
+}
+
+type mpsConfig struct {
+	// Root is the root on the host relative to which the MPS pipe and log
+	// directories are resolved. If this is not specified, / is used. Note
+	// that the driver root is not used for these directories.
+	Root string `toml:"root,omitempty"`
+	// PipeDirectory is the MPS pipe directory on the host. If this is not
+	// specified, /tmp/nvidia-mps is used.
+	PipeDirectory string `toml:"pipe-directory,omitempty"`
+	// LogDirectory is the MPS log directory on the host. If this is not
+	// specified, /var/log/nvidia-mps is used.
+	LogDirectory string `toml:"log-directory,omitempty"`
 }
 
 // modesConfig defines (optional) per-mode configs
@@ -49,8 +387,25 @@ type cdiModeConfig struct {
 type jitCDIModeConfig struct {
 	// NVCDIFeatureFlags sets a list of nvcdi features explicitly.
 	NVCDIFeatureFlags []nvcdi.FeatureFlag `toml:"nvcdi-feature-flags,omitempty"`
+	// OnNoDevices defines the behavior if a container requests GPUs but no
+	// GPUs are available on the node. Supported values are fail, none, and
+	// control-devices. If this is not specified, control-devices is used.
+	OnNoDevices string `toml:"on-no-devices,omitempty"`
 }
 
+// The following behaviors can be specified in the
+// nvidia-container-runtime.modes.jit-cdi.on-no-devices config option.
+const (
+	// OnNoDevicesFail fails the creation of the container.
+	OnNoDevicesFail = "fail"
+	// OnNoDevicesNone starts the container without injecting any devices or
+	// driver files.
+	OnNoDevicesNone = "none"
+	// OnNoDevicesControlDevices starts the container with only the control
+	// device nodes and driver files injected.
+	OnNoDevicesControlDevices = "control-devices"
+)
+
 type csvModeConfig struct {
 	MountSpecPath string `toml:"mount-spec-path"`
 	// CompatContainerRoot specifies the compat root used when the the standard
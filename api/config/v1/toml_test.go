@@ -297,6 +297,41 @@ func TestConfigFromToml(t *testing.T) {
 			},
 			expectedError: errInvalidConfig,
 		},
+		{
+			description: "discoverers are loaded",
+			contents: map[string]interface{}{
+				"nvidia-container-runtime": map[string]interface{}{
+					"discoverers": []interface{}{"graphics", "gated-devices"},
+				},
+			},
+			expectedConfig: func() *Config {
+				c, _ := GetDefault()
+				c.NVIDIAContainerRuntimeConfig.Discoverers = []string{"graphics", "gated-devices"}
+				return c
+			}(),
+		},
+		{
+			description: "hook-path is loaded",
+			contents: map[string]interface{}{
+				"nvidia-container-runtime": map[string]interface{}{
+					"hook-path": "/run/nvidia/driver/usr/bin/nvidia-container-runtime-hook",
+				},
+			},
+			expectedConfig: func() *Config {
+				c, _ := GetDefault()
+				c.NVIDIAContainerRuntimeConfig.HookPath = "/run/nvidia/driver/usr/bin/nvidia-container-runtime-hook"
+				return c
+			}(),
+		},
+		{
+			description: "unknown discoverer raises error",
+			contents: map[string]interface{}{
+				"nvidia-container-runtime": map[string]interface{}{
+					"discoverers": []interface{}{"devices", "graphics"},
+				},
+			},
+			expectedError: errInvalidConfig,
+		},
 		{
 			description: "feature allows ldconfig override",
 			contents: map[string]interface{}{
@@ -0,0 +1,178 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package checklibraries
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/moby/sys/symlink"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// defaultLibrarySearchPaths defines the folders in the container that are
+// searched for the required libraries in addition to the folders specified
+// on the command line.
+var defaultLibrarySearchPaths = []string{
+	"/lib64",
+	"/usr/lib64",
+	"/lib/x86_64-linux-gnu",
+	"/usr/lib/x86_64-linux-gnu",
+	"/lib/aarch64-linux-gnu",
+	"/usr/lib/aarch64-linux-gnu",
+	"/lib",
+	"/usr/lib",
+}
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	libraries []string
+	folders   []string
+	// containerSpec allows the path to the container spec to be specified for
+	// testing.
+	containerSpec string
+}
+
+// NewCommand constructs a check-libraries command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the check-libraries command
+func (m command) build() *cli.Command {
+	cfg := options{}
+
+	// Create the 'check-libraries' command
+	c := cli.Command{
+		Name:  "check-libraries",
+		Usage: "A hook to verify that the specified libraries resolve in the container. Container creation fails if a library is missing.",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(cmd, &cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(cmd, &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "library",
+				Usage:       "Specify a library (e.g. libcuda.so.1) that is required to resolve in the container. This can be specified multiple times.",
+				Value:       []string{"libcuda.so.1"},
+				Destination: &cfg.libraries,
+			},
+			&cli.StringSliceFlag{
+				Name:        "folder",
+				Usage:       "Specify an additional folder in the container to search for the required libraries. This can be specified multiple times.",
+				Destination: &cfg.folders,
+			},
+			&cli.StringFlag{
+				Name:        "container-spec",
+				Hidden:      true,
+				Category:    "testing-only",
+				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
+				Destination: &cfg.containerSpec,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(_ *cli.Command, cfg *options) error {
+	for _, library := range cfg.libraries {
+		if library == "" || strings.Contains(library, "/") {
+			return fmt.Errorf("invalid library name %q: a filename is expected", library)
+		}
+	}
+	for _, folder := range cfg.folders {
+		if !filepath.IsAbs(folder) {
+			return fmt.Errorf("invalid folder %q: an absolute path is expected", folder)
+		}
+	}
+	return nil
+}
+
+func (m command) run(cmd *cli.Command, cfg *options) error {
+	s, err := oci.LoadContainerState(cfg.containerSpec)
+	if err != nil {
+		return fmt.Errorf("failed to load container state: %w", err)
+	}
+
+	containerRoot, err := s.GetContainerRootInHostRoot(cmd.String("host-root"))
+	if err != nil {
+		return fmt.Errorf("failed to determined container root: %w", err)
+	}
+
+	return m.checkLibraries(containerRoot, cfg)
+}
+
+// checkLibraries checks whether each of the required libraries resolves to a
+// regular file in one of the search folders in the container root.
+// An error listing all missing libraries is returned if any are not found.
+func (m command) checkLibraries(containerRoot string, cfg *options) error {
+	searchPaths := append(slices.Clone(cfg.folders), defaultLibrarySearchPaths...)
+
+	var missing []string
+	for _, library := range cfg.libraries {
+		resolved := m.findLibrary(containerRoot, searchPaths, library)
+		if resolved == "" {
+			missing = append(missing, library)
+			continue
+		}
+		m.logger.Debugf("Found %v at %v", library, resolved)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required libraries not found in container: %v (searched %v)", strings.Join(missing, ", "), strings.Join(searchPaths, ", "))
+	}
+	return nil
+}
+
+// findLibrary returns the path in the container at which the specified
+// library was found. An empty string is returned if the library is not found.
+// Symlinks are followed but are guaranteed to resolve in the container root.
+func (m command) findLibrary(containerRoot string, searchPaths []string, library string) string {
+	for _, dir := range searchPaths {
+		candidate := filepath.Join(dir, library)
+		resolved, err := symlink.FollowSymlinkInScope(filepath.Join(containerRoot, candidate), containerRoot)
+		if err != nil {
+			m.logger.Debugf("Failed to resolve %v: %v", candidate, err)
+			continue
+		}
+		info, err := os.Stat(resolved)
+		if err != nil {
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		return candidate
+	}
+	return ""
+}
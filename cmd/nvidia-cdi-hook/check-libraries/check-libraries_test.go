@@ -0,0 +1,162 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package checklibraries
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLibraries(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		files         []string
+		symlinks      map[string]string
+		options       options
+		expectedError string
+	}{
+		{
+			description:   "empty root",
+			options:       options{libraries: []string{"libcuda.so.1"}},
+			expectedError: "required libraries not found in container: libcuda.so.1",
+		},
+		{
+			description: "library in default search path",
+			files:       []string{"/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+			options:     options{libraries: []string{"libcuda.so.1"}},
+		},
+		{
+			description: "library symlink resolves",
+			files:       []string{"/usr/lib64/libcuda.so.999.88.77"},
+			symlinks: map[string]string{
+				"/usr/lib64/libcuda.so.1": "libcuda.so.999.88.77",
+			},
+			options: options{libraries: []string{"libcuda.so.1"}},
+		},
+		{
+			description: "dangling library symlink",
+			symlinks: map[string]string{
+				"/usr/lib64/libcuda.so.1": "libcuda.so.999.88.77",
+			},
+			options:       options{libraries: []string{"libcuda.so.1"}},
+			expectedError: "required libraries not found in container: libcuda.so.1",
+		},
+		{
+			description: "absolute symlink resolves in root",
+			files:       []string{"/opt/nvidia/libcuda.so.999.88.77"},
+			symlinks: map[string]string{
+				"/usr/lib64/libcuda.so.1": "/opt/nvidia/libcuda.so.999.88.77",
+			},
+			options: options{libraries: []string{"libcuda.so.1"}},
+		},
+		{
+			description: "library in additional folder",
+			files:       []string{"/usr/local/nvidia/lib64/libcuda.so.1"},
+			options: options{
+				libraries: []string{"libcuda.so.1"},
+				folders:   []string{"/usr/local/nvidia/lib64"},
+			},
+		},
+		{
+			description:   "directory is not a library",
+			files:         []string{"/usr/lib64/libcuda.so.1/placeholder"},
+			options:       options{libraries: []string{"libcuda.so.1"}},
+			expectedError: "required libraries not found in container: libcuda.so.1",
+		},
+		{
+			description: "all missing libraries are reported",
+			files:       []string{"/usr/lib64/libcuda.so.1"},
+			options: options{
+				libraries: []string{"libcuda.so.1", "libnvidia-ml.so.1", "libnvidia-ptxjitcompiler.so.1"},
+			},
+			expectedError: "required libraries not found in container: libnvidia-ml.so.1, libnvidia-ptxjitcompiler.so.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			containerRoot := t.TempDir()
+			for _, f := range tc.files {
+				path := filepath.Join(containerRoot, f)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0600))
+			}
+			for link, target := range tc.symlinks {
+				path := filepath.Join(containerRoot, link)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.Symlink(target, path))
+			}
+
+			c := command{
+				logger: logger,
+			}
+			err := c.checkLibraries(containerRoot, &tc.options)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	testCases := []struct {
+		description string
+		options     options
+		expectError bool
+	}{
+		{
+			description: "valid",
+			options: options{
+				libraries: []string{"libcuda.so.1"},
+				folders:   []string{"/usr/local/nvidia/lib64"},
+			},
+		},
+		{
+			description: "library is a path",
+			options:     options{libraries: []string{"/usr/lib64/libcuda.so.1"}},
+			expectError: true,
+		},
+		{
+			description: "relative folder",
+			options: options{
+				libraries: []string{"libcuda.so.1"},
+				folders:   []string{"lib64"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := command{}.validateFlags(nil, &tc.options)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -107,13 +107,13 @@ func (m command) validateFlags(_ *cli.Command, cfg *config) error {
 	return nil
 }
 
-func (m command) run(_ *cli.Command, cfg *config) error {
+func (m command) run(cmd *cli.Command, cfg *config) error {
 	s, err := oci.LoadContainerState(cfg.containerSpec)
 	if err != nil {
 		return fmt.Errorf("failed to load container state: %v", err)
 	}
 
-	containerRoot, err := s.GetContainerRoot()
+	containerRoot, err := s.GetContainerRootInHostRoot(cmd.String("host-root"))
 	if err != nil {
 		return fmt.Errorf("failed to determined container root: %v", err)
 	}
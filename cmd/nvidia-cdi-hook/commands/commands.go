@@ -22,6 +22,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	checklibraries "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/check-libraries"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/chmod"
 	symlinks "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/create-symlinks"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/cudacompat"
@@ -83,6 +84,15 @@ func ConfigureCDIHookCommand(logger logger.Interface, base *cli.Command) *cli.Co
 		return issueUnsupportedHookWarning(logger, cmd)
 	}
 
+	// The host root is used by the hooks to resolve the host paths of the
+	// container bundle and root when the hook is not run in the host mount
+	// namespace.
+	base.Flags = append(base.Flags, &cli.StringFlag{
+		Name:    "host-root",
+		Usage:   "Specify the path at which the host root filesystem is mounted. This is required if the hook is run in a container instead of in the host mount namespace.",
+		Sources: cli.EnvVars("NVIDIA_CTK_HOST_ROOT"),
+	})
+
 	// Define the supported hooks.
 	base.Commands = []*cli.Command{
 		ldcache.NewCommand(logger),
@@ -90,6 +100,7 @@ func ConfigureCDIHookCommand(logger logger.Interface, base *cli.Command) *cli.Co
 		chmod.NewCommand(logger),
 		cudacompat.NewCommand(logger),
 		disabledevicenodemodification.NewCommand(logger),
+		checklibraries.NewCommand(logger),
 		{
 			Name:   "noop",
 			Usage:  "The noop hook performs no actions and is only added to facilitate basic testing of the CLI",
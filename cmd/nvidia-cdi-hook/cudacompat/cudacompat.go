@@ -108,7 +108,7 @@ func (m command) validateFlags(_ *cli.Command, _ *options) error {
 	return nil
 }
 
-func (m command) run(_ *cli.Command, o *options) error {
+func (m command) run(cmd *cli.Command, o *options) error {
 	// If neither the host driver version nor the host cuda version is specified
 	// the hook is a no-op.
 	if o.hostDriverVersion == "" && o.hostCudaVersion == "" {
@@ -120,7 +120,7 @@ func (m command) run(_ *cli.Command, o *options) error {
 		return fmt.Errorf("failed to load container state: %w", err)
 	}
 
-	containerRootDir, err := s.GetContainerRoot()
+	containerRootDir, err := s.GetContainerRootInHostRoot(cmd.String("host-root"))
 	if err != nil {
 		return fmt.Errorf("failed to determined container root: %w", err)
 	}
@@ -71,7 +71,7 @@ func validateFlags(_ *cli.Command, _ *options) error {
 	return nil
 }
 
-func run(_ context.Context, _ *cli.Command, cfg *options) error {
+func run(_ context.Context, cmd *cli.Command, cfg *options) error {
 	modifiedParamsFileContents, err := getModifiedNVIDIAParamsContents()
 	if err != nil {
 		return fmt.Errorf("failed to get modified params file contents: %w", err)
@@ -85,7 +85,7 @@ func run(_ context.Context, _ *cli.Command, cfg *options) error {
 		return fmt.Errorf("failed to load container state: %w", err)
 	}
 
-	containerRootDirPath, err := s.GetContainerRoot()
+	containerRootDirPath, err := s.GetContainerRootInHostRoot(cmd.String("host-root"))
 	if err != nil {
 		return fmt.Errorf("failed to determined container root: %w", err)
 	}
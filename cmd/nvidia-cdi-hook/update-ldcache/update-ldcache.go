@@ -104,13 +104,13 @@ func (m command) validateFlags(_ *cli.Command, cfg *options) error {
 	return nil
 }
 
-func (m command) run(_ *cli.Command, cfg *options) error {
+func (m command) run(cmd *cli.Command, cfg *options) error {
 	s, err := oci.LoadContainerState(cfg.containerSpec)
 	if err != nil {
 		return fmt.Errorf("failed to load container state: %v", err)
 	}
 
-	containerRootDir, err := s.GetContainerRoot()
+	containerRootDir, err := s.GetContainerRootInHostRoot(cmd.String("host-root"))
 	if err != nil || containerRootDir == "" || containerRootDir == "/" {
 		return fmt.Errorf("failed to determined container root: %v", err)
 	}
@@ -246,6 +246,8 @@ func (hookConfig *hookConfig) getContainerConfig() (config *containerConfig) {
 		image.WithDisableRequire(hookConfig.DisableRequire),
 		image.WithAcceptDeviceListAsVolumeMounts(hookConfig.AcceptDeviceListAsVolumeMounts),
 		image.WithAcceptEnvvarUnprivileged(hookConfig.AcceptEnvvarUnprivileged),
+		image.WithDefaultVisibleDevices(hookConfig.DefaultVisibleDevices),
+		image.WithVisibleDevicesFile(hookConfig.VisibleDevicesFile),
 		image.WithPreferredVisibleDevicesEnvVars(hookConfig.getSwarmResource()),
 		image.WithIgnoreImexChannelRequests(hookConfig.Features.IgnoreImexChannelRequests.IsEnabled()),
 	)
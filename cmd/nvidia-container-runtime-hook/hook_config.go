@@ -121,6 +121,7 @@ func (c *hookConfig) assertModeIsLegacy() error {
 		info.WithLogger(&logInterceptor{}),
 		info.WithImage(&c.containerConfig.Image),
 		info.WithDefaultMode(info.LegacyRuntimeMode),
+		info.WithAllowModeOverride(c.NVIDIAContainerRuntimeConfig.AllowModeOverride),
 	)
 
 	mode := mr.ResolveRuntimeMode(c.NVIDIAContainerRuntimeConfig.Mode)
@@ -20,9 +20,10 @@ import (
 )
 
 var (
-	debugflag   = flag.Bool("debug", false, "enable debug output")
-	versionflag = flag.Bool("version", false, "enable version output")
-	configflag  = flag.String("config", "", "configuration file")
+	debugflag      = flag.Bool("debug", false, "enable debug output")
+	versionflag    = flag.Bool("version", false, "enable version output")
+	configflag     = flag.String("config", "", "configuration file")
+	driverrootflag = flag.String("driver-root", "", "driver root (overrides nvidia-container-cli.root)")
 )
 
 func exit() {
@@ -74,6 +75,9 @@ func doPrestart() {
 		log.Panicln("error getting hook config:", err)
 	}
 	cli := hook.NVIDIAContainerCLIConfig
+	if *driverrootflag != "" {
+		cli.Root = *driverrootflag
+	}
 
 	container := hook.getContainerConfig()
 	nvidia := container.Nvidia
@@ -22,6 +22,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -36,6 +37,10 @@ const (
 	restartModeSystemd = "systemd"
 )
 
+// restartInitialBackoff is the time to wait before retrying a failed restart.
+// This is doubled for each subsequent attempt.
+var restartInitialBackoff = 2 * time.Second
+
 // Options defines the shared options for the CLIs to configure containers runtimes.
 type Options struct {
 	DropInConfig         string
@@ -55,6 +60,12 @@ type Options struct {
 	SetAsDefault  bool
 	RestartMode   string
 	HostRootMount string
+	// RestartMaxAttempts is the maximum number of times that restarting the
+	// runtime is attempted.
+	RestartMaxAttempts int
+	// RestartTimeout is the total time after which no further attempts to
+	// restart the runtime are made. A value of 0 disables the timeout.
+	RestartTimeout time.Duration
 
 	ConfigSources []string
 }
@@ -155,14 +166,48 @@ func (o Options) Restart(service string, withSignal func(string) error) error {
 		logrus.Warningf("Skipping restart of %v due to --restart-mode=%v", service, o.RestartMode)
 		return nil
 	case restartModeSignal:
-		return withSignal(o.Socket)
+		return o.withRestartRetries(service, func() error {
+			return withSignal(o.Socket)
+		})
 	case restartModeSystemd:
-		return o.SystemdRestart(service)
+		return o.withRestartRetries(service, func() error {
+			return o.SystemdRestart(service)
+		})
 	}
 
 	return fmt.Errorf("invalid restart mode specified: %v", o.RestartMode)
 }
 
+// withRestartRetries calls the specified restart function until it succeeds,
+// the maximum number of attempts is reached, or the restart timeout expires.
+// The wait time between attempts is doubled after each failure.
+func (o Options) withRestartRetries(service string, restart func() error) error {
+	maxAttempts := max(o.RestartMaxAttempts, 1)
+
+	var deadline time.Time
+	if o.RestartTimeout > 0 {
+		deadline = time.Now().Add(o.RestartTimeout)
+	}
+
+	backoff := restartInitialBackoff
+	for attempt := 1; ; attempt++ {
+		logrus.Infof("Restarting %v (attempt %d/%d)", service, attempt, maxAttempts)
+		err := restart()
+		if err == nil {
+			return nil
+		}
+		if attempt >= maxAttempts {
+			return fmt.Errorf("failed to restart %v after %d attempt(s): %w", service, attempt, err)
+		}
+		if !deadline.IsZero() && time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("timed out restarting %v after %d attempt(s): %w", service, attempt, err)
+		}
+		logrus.Warningf("Failed to restart %v (attempt %d/%d): %v; retrying in %v", service, attempt, maxAttempts, err, backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
 // SystemdRestart restarts the specified service using systemd
 func (o Options) SystemdRestart(service string) error {
 	var args []string
@@ -0,0 +1,126 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package container
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRestarter fails the specified number of times before succeeding.
+type fakeRestarter struct {
+	failures int
+	calls    int
+}
+
+func (f *fakeRestarter) restart(string) error {
+	f.calls++
+	if f.calls <= f.failures {
+		return fmt.Errorf("restart failure %d", f.calls)
+	}
+	return nil
+}
+
+func TestRestartRetries(t *testing.T) {
+	defer func(backoff time.Duration) {
+		restartInitialBackoff = backoff
+	}(restartInitialBackoff)
+	restartInitialBackoff = 10 * time.Millisecond
+
+	testCases := []struct {
+		description   string
+		options       Options
+		failures      int
+		expectedError error
+		expectedCalls int
+	}{
+		{
+			description: "restart mode none does not restart",
+			options: Options{
+				RestartMode:        restartModeNone,
+				RestartMaxAttempts: 3,
+			},
+			failures:      5,
+			expectedCalls: 0,
+		},
+		{
+			description: "success on first attempt",
+			options: Options{
+				RestartMode:        restartModeSignal,
+				RestartMaxAttempts: 3,
+			},
+			expectedCalls: 1,
+		},
+		{
+			description: "success after failures",
+			options: Options{
+				RestartMode:        restartModeSignal,
+				RestartMaxAttempts: 3,
+			},
+			failures:      2,
+			expectedCalls: 3,
+		},
+		{
+			description: "max attempts reached",
+			options: Options{
+				RestartMode:        restartModeSignal,
+				RestartMaxAttempts: 3,
+			},
+			failures:      5,
+			expectedError: fmt.Errorf("failed to restart containerd after 3 attempt(s): restart failure 3"),
+			expectedCalls: 3,
+		},
+		{
+			description: "zero max attempts restarts once",
+			options: Options{
+				RestartMode: restartModeSignal,
+			},
+			failures:      1,
+			expectedError: fmt.Errorf("failed to restart containerd after 1 attempt(s): restart failure 1"),
+			expectedCalls: 1,
+		},
+		{
+			description: "timeout stops retries",
+			options: Options{
+				RestartMode:        restartModeSignal,
+				RestartMaxAttempts: 10,
+				RestartTimeout:     15 * time.Millisecond,
+			},
+			failures:      5,
+			expectedError: fmt.Errorf("timed out restarting containerd after 2 attempt(s): restart failure 2"),
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			restarter := &fakeRestarter{failures: tc.failures}
+
+			err := tc.options.Restart("containerd", restarter.restart)
+			if tc.expectedError == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError.Error())
+			}
+			require.Equal(t, tc.expectedCalls, restarter.calls)
+		})
+	}
+}
@@ -46,6 +46,7 @@ const (
 type Options struct {
 	useLegacyConfig bool
 	runtimeType     string
+	sandboxer       string
 
 	ContainerRuntimeModesCDIAnnotationPrefixes []string
 
@@ -70,6 +71,12 @@ func Flags(opts *Options) []cli.Flag {
 			Destination: &opts.runtimeType,
 			Sources:     cli.EnvVars("CONTAINERD_RUNTIME_TYPE"),
 		},
+		&cli.StringFlag{
+			Name:        "sandboxer",
+			Usage:       "The sandboxer to use for the configured runtime classes. This is only applicable for containerd 2.x. If not set, the sandboxer of the default runtime is used.",
+			Destination: &opts.sandboxer,
+			Sources:     cli.EnvVars("CONTAINERD_SANDBOXER"),
+		},
 		&cli.StringSliceFlag{
 			Name:        "nvidia-container-runtime-modes.cdi.annotation-prefixes",
 			Destination: &opts.ContainerRuntimeModesCDIAnnotationPrefixes,
@@ -184,6 +191,7 @@ func getRuntimeConfig(o *container.Options, co *Options) (engine.Interface, erro
 			),
 		),
 		containerd.WithRuntimeType(co.runtimeType),
+		containerd.WithSandboxer(co.sandboxer),
 		containerd.WithUseLegacyConfig(co.useLegacyConfig),
 		containerd.WithContainerAnnotations(co.containerAnnotationsFromCDIPrefixes()...),
 	}
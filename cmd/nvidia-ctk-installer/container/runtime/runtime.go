@@ -18,6 +18,7 @@ package runtime
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
@@ -35,6 +36,9 @@ const (
 	defaultRuntimeName   = "nvidia"
 	defaultHostRootMount = "/host"
 
+	defaultRestartMaxAttempts = 3
+	defaultRestartTimeout     = 2 * time.Minute
+
 	runtimeSpecificDefault = "RUNTIME_SPECIFIC_DEFAULT"
 )
 
@@ -88,6 +92,20 @@ func Flags(opts *Options) []cli.Flag {
 			Destination: &opts.RestartMode,
 			Sources:     cli.EnvVars("RUNTIME_RESTART_MODE"),
 		},
+		&cli.IntFlag{
+			Name:        "restart-max-attempts",
+			Usage:       "Specify the maximum number of attempts made to restart the runtime",
+			Value:       defaultRestartMaxAttempts,
+			Destination: &opts.RestartMaxAttempts,
+			Sources:     cli.EnvVars("RUNTIME_RESTART_MAX_ATTEMPTS"),
+		},
+		&cli.DurationFlag{
+			Name:        "restart-timeout",
+			Usage:       "Specify the total time allowed for restarting the runtime, including retries. A value of 0 disables the timeout",
+			Value:       defaultRestartTimeout,
+			Destination: &opts.RestartTimeout,
+			Sources:     cli.EnvVars("RUNTIME_RESTART_TIMEOUT"),
+		},
 		&cli.BoolFlag{
 			Name:        "enable-cdi-in-runtime",
 			Usage:       "Enable CDI in the configured runt	ime",
@@ -158,6 +158,10 @@ swarm-resource = ""
   path = "{{ .toolkitRoot }}/toolkit/nvidia-ctk"
 `,
 			expectedRuntimeConfig: `{
+    "cdi-spec-dirs": [
+        "/etc/cdi",
+        "/var/run/cdi"
+    ],
     "default-runtime": "nvidia",
     "features": {
         "cdi": true
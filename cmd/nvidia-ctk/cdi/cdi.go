@@ -22,6 +22,7 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/generate"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/list"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/verify"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -49,6 +50,7 @@ func (m command) build() *cli.Command {
 			generate.NewCommand(m.logger, m.configFilePath),
 			list.NewCommand(m.logger),
 			transform.NewCommand(m.logger),
+			verify.NewCommand(m.logger),
 		},
 	}
 
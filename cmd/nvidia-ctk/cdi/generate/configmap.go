@@ -0,0 +1,110 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// formatConfigMap is the output format for specs that are wrapped in a
+	// Kubernetes ConfigMap manifest.
+	formatConfigMap = "configmap"
+
+	defaultConfigMapName = "nvidia-cdi-spec"
+)
+
+// configMapOptions define the metadata of a generated ConfigMap.
+type configMapOptions struct {
+	name      string
+	namespace string
+}
+
+// configMap is a minimal representation of a Kubernetes ConfigMap manifest.
+type configMap struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   configMapMetadata `json:"metadata"`
+	Data       map[string]string `json:"data"`
+}
+
+type configMapMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// saveAsConfigMap writes the spec wrapped in a ConfigMap to the specified
+// file. If the filename is empty, the ConfigMap is written to STDOUT and is
+// preceded by a YAML document separator if it follows another ConfigMap.
+func (g *generatedSpecs) saveAsConfigMap(filename string) error {
+	contents, err := g.toConfigMap()
+	if err != nil {
+		return fmt.Errorf("failed to create ConfigMap: %w", err)
+	}
+
+	if filename == "" {
+		if g.followsDocument {
+			contents = append([]byte("---\n"), contents...)
+		}
+		if _, err := os.Stdout.Write(contents); err != nil {
+			return fmt.Errorf("failed to write ConfigMap to STDOUT: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(filename, contents, 0644); err != nil {
+		return fmt.Errorf("failed to write ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// toConfigMap returns the YAML manifest of a ConfigMap containing the spec.
+// The spec is stored under a key derived from its kind. The filename infix of
+// the spec is appended to the configured ConfigMap name so that the
+// ConfigMaps for multiple device classes do not conflict.
+func (g *generatedSpecs) toConfigMap() ([]byte, error) {
+	var spec bytes.Buffer
+	if _, err := g.Interface.WriteTo(&spec); err != nil {
+		return nil, fmt.Errorf("failed to render spec: %w", err)
+	}
+
+	extension := ".yaml"
+	if bytes.HasPrefix(bytes.TrimSpace(spec.Bytes()), []byte("{")) {
+		extension = ".json"
+	}
+	key := strings.ReplaceAll(g.Raw().Kind, "/", "-") + extension
+
+	cm := configMap{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata: configMapMetadata{
+			Name:      g.configMap.name + strings.ReplaceAll(g.filenameInfix, ".", "-"),
+			Namespace: g.configMap.namespace,
+		},
+		Data: map[string]string{
+			key: spec.String(),
+		},
+	}
+
+	return yaml.Marshal(cm)
+}
@@ -0,0 +1,243 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestSaveAsConfigMap(t *testing.T) {
+	testCases := []struct {
+		description       string
+		format            string
+		filenameInfix     string
+		options           configMapOptions
+		expectedName      string
+		expectedNamespace string
+		expectedKey       string
+	}{
+		{
+			description:  "yaml spec without namespace",
+			format:       spec.FormatYAML,
+			options:      configMapOptions{name: "nvidia-cdi-spec"},
+			expectedName: "nvidia-cdi-spec",
+			expectedKey:  "example.com-device.yaml",
+		},
+		{
+			description:       "json spec with namespace",
+			format:            spec.FormatJSON,
+			options:           configMapOptions{name: "nvidia-cdi-spec", namespace: "gpu-operator"},
+			expectedName:      "nvidia-cdi-spec",
+			expectedNamespace: "gpu-operator",
+			expectedKey:       "example.com-device.json",
+		},
+		{
+			description:   "filename infix is appended to the name",
+			format:        spec.FormatYAML,
+			filenameInfix: ".mig-1g.5gb",
+			options:       configMapOptions{name: "nvidia-cdi-spec"},
+			expectedName:  "nvidia-cdi-spec-mig-1g-5gb",
+			expectedKey:   "example.com-device.yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s, err := spec.New(
+				spec.WithVendor("example.com"),
+				spec.WithClass("device"),
+				spec.WithFormat(tc.format),
+				spec.WithDeviceSpecs([]specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+						},
+					},
+				}),
+			)
+			require.NoError(t, err)
+
+			g := &generatedSpecs{
+				Interface:     s,
+				filenameInfix: tc.filenameInfix,
+				configMap:     &tc.options,
+			}
+
+			filename := filepath.Join(t.TempDir(), "configmap.yaml")
+			require.NoError(t, g.Save(filename))
+
+			contents, err := os.ReadFile(g.updateFilename(filename))
+			require.NoError(t, err)
+
+			var cm configMap
+			require.NoError(t, yaml.UnmarshalStrict(contents, &cm))
+			require.Equal(t, "v1", cm.APIVersion)
+			require.Equal(t, "ConfigMap", cm.Kind)
+			require.Equal(t, tc.expectedName, cm.Metadata.Name)
+			require.Equal(t, tc.expectedNamespace, cm.Metadata.Namespace)
+			require.Len(t, cm.Data, 1)
+			require.Contains(t, cm.Data, tc.expectedKey)
+
+			raw, err := cdi.ParseSpec([]byte(cm.Data[tc.expectedKey]))
+			require.NoError(t, err)
+			require.Equal(t, "example.com/device", raw.Kind)
+			require.NoError(t, specs.ValidateVersion(raw))
+			require.Len(t, raw.Devices, 1)
+			require.Equal(t, "0", raw.Devices[0].Name)
+		})
+	}
+}
+
+func TestSaveSpecsConfigMapToStdout(t *testing.T) {
+	var specsToSave []generatedSpecs
+	for _, class := range []string{"gpu", "mig"} {
+		s, err := spec.New(
+			spec.WithVendor("example.com"),
+			spec.WithClass(class),
+			spec.WithFormat(spec.FormatYAML),
+			spec.WithDeviceSpecs([]specs.Device{
+				{
+					Name: "0",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+					},
+				},
+			}),
+		)
+		require.NoError(t, err)
+		infix := ""
+		if class == "mig" {
+			infix = ".mig"
+		}
+		specsToSave = append(specsToSave, generatedSpecs{Interface: s, filenameInfix: infix})
+	}
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+	opts := options{
+		format:    formatConfigMap,
+		configMap: configMapOptions{name: defaultConfigMapName},
+	}
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	saveErr := c.saveSpecs(&opts, specsToSave)
+	os.Stdout = stdout
+	require.NoError(t, w.Close())
+	require.NoError(t, saveErr)
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	documents := strings.Split(string(output), "\n---\n")
+	require.Len(t, documents, 2)
+
+	expected := []struct {
+		name string
+		key  string
+		kind string
+	}{
+		{name: "nvidia-cdi-spec", key: "example.com-gpu.yaml", kind: "example.com/gpu"},
+		{name: "nvidia-cdi-spec-mig", key: "example.com-mig.yaml", kind: "example.com/mig"},
+	}
+	for i, document := range documents {
+		var cm configMap
+		require.NoError(t, yaml.UnmarshalStrict([]byte(document), &cm))
+		require.Equal(t, "ConfigMap", cm.Kind)
+		require.Equal(t, expected[i].name, cm.Metadata.Name)
+		require.Contains(t, cm.Data, expected[i].key)
+
+		raw, err := cdi.ParseSpec([]byte(cm.Data[expected[i].key]))
+		require.NoError(t, err)
+		require.Equal(t, expected[i].kind, raw.Kind)
+	}
+}
+
+func TestValidateFlagsConfigMapFormat(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	testCases := []struct {
+		description        string
+		options            options
+		expectedError      string
+		expectedSpecFormat string
+	}{
+		{
+			description: "configmap format renders specs as yaml",
+			options: options{
+				format:    "ConfigMap",
+				mode:      "nvml",
+				vendor:    "nvidia.com",
+				class:     "gpu",
+				configMap: configMapOptions{name: defaultConfigMapName},
+			},
+			expectedSpecFormat: spec.FormatYAML,
+		},
+		{
+			description: "configmap name is required",
+			options: options{
+				format: formatConfigMap,
+				mode:   "nvml",
+				vendor: "nvidia.com",
+				class:  "gpu",
+			},
+			expectedError: "a ConfigMap name is required for output format configmap",
+		},
+		{
+			description: "configmap format cannot be merged",
+			options: options{
+				format:    formatConfigMap,
+				mergeInto: "/etc/cdi/nvidia.yaml",
+				configMap: configMapOptions{name: defaultConfigMapName},
+			},
+			expectedError: "--merge-into cannot be combined with output format configmap",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := c.validateFlags(nil, &tc.options)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSpecFormat, tc.options.specFormat())
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -42,6 +43,11 @@ import (
 
 const (
 	allDeviceName = "all"
+
+	deviceClassGPU        = "gpu"
+	deviceClassMIG        = "mig"
+	deviceClassMIGProfile = "mig-profile"
+	deviceClassIMEX       = "imex"
 )
 
 type command struct {
@@ -56,27 +62,57 @@ type options struct {
 	deviceNameStrategies []string
 	driverRoot           string
 	devRoot              string
+	containerRoot        string
+	relativeTo           string
 	nvidiaCDIHookPath    string
 	ldconfigPath         string
 	mode                 string
 	vendor               string
 	class                string
+	specVersion          string
+	deviceClasses        []string
+	gpuType              string
+	driverCapabilities   []string
+	// deviceNodePermissions are the permissions to set on the generated
+	// device nodes as class=permissions pairs.
+	deviceNodePermissions []string
+	// replicas is the number of replica devices generated for each full GPU.
+	replicas int
 
 	configSearchPaths  []string
 	librarySearchPaths []string
+	libraryDenylist    []string
 	disabledHooks      []string
 	enabledHooks       []string
+	hookEnv            []string
 
 	featureFlags []string
 
+	nvidiaCTKHookFallback bool
+
+	configMap configMapOptions
+
 	csv struct {
 		files               []string
 		ignorePatterns      []string
 		CompatContainerRoot string
 	}
 
-	noAllDevice bool
-	deviceIDs   []string
+	noAllDevice    bool
+	devCharPaths   bool
+	withDigest     bool
+	withProvenance bool
+	fromLegacy     bool
+	deviceIDs      []string
+
+	refreshDir string
+	mergeInto  string
+
+	explain   bool
+	explainer *nvcdi.Explainer
+
+	// annotations are the provenance annotations added to the generated specs.
+	annotations map[string]string
 
 	// the following are used for dependency injection during spec generation.
 	nvmllib nvml.Interface
@@ -121,12 +157,26 @@ func (m command) build() *cli.Command {
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_OUTPUT_FILE_PATH"),
 			},
 			&cli.StringFlag{
-				Name:        "format",
-				Usage:       "The output format for the generated spec [json | yaml]. This overrides the format defined by the output file extension (if specified).",
+				Name: "format",
+				Usage: "The output format for the generated spec [json | yaml | " + formatConfigMap + "]. This overrides the format defined by the output file extension (if specified). " +
+					"If " + formatConfigMap + " is specified, each generated CDI specification is output as YAML wrapped in a Kubernetes ConfigMap manifest.",
 				Value:       spec.FormatYAML,
 				Destination: &opts.format,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_OUTPUT_FORMAT"),
 			},
+			&cli.StringFlag{
+				Name:        "configmap-name",
+				Usage:       "The name of the generated ConfigMap if --format=" + formatConfigMap + ". The device class is appended for additional specs.",
+				Value:       defaultConfigMapName,
+				Destination: &opts.configMap.name,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CONFIGMAP_NAME"),
+			},
+			&cli.StringFlag{
+				Name:        "configmap-namespace",
+				Usage:       "The namespace of the generated ConfigMap if --format=" + formatConfigMap + ". If this is not specified, no namespace is set.",
+				Destination: &opts.configMap.namespace,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CONFIGMAP_NAMESPACE"),
+			},
 			&cli.StringFlag{
 				Name:    "mode",
 				Aliases: []string{"discovery-mode"},
@@ -145,9 +195,21 @@ func (m command) build() *cli.Command {
 				Destination: &opts.devRoot,
 				Sources:     cli.EnvVars("NVIDIA_CTK_DEV_ROOT"),
 			},
+			&cli.StringFlag{
+				Name:        "container-root",
+				Usage:       "Specify the root in the container under which container paths are located. Host paths are not affected. If this is not specified, / is assumed.",
+				Destination: &opts.containerRoot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CONTAINER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "relative-to",
+				Usage:       "Specify a root relative to which host paths are recorded in the generated spec. This allows the spec to be applied on a host where this root is at a different location once the paths are anchored using 'nvidia-ctk cdi transform root --anchor'. Host paths outside this root are not affected.",
+				Destination: &opts.relativeTo,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_RELATIVE_TO"),
+			},
 			&cli.StringSliceFlag{
 				Name:        "device-name-strategy",
-				Usage:       "Specify the strategy for generating device names. If this is specified multiple times, the devices will be duplicated for each strategy. One of [index | uuid | type-index]",
+				Usage:       "Specify the strategy for generating device names. If this is specified multiple times, the devices will be duplicated for each strategy. One of [index | uuid | type-index | bdf]",
 				Value:       []string{nvcdi.DeviceNameStrategyIndex, nvcdi.DeviceNameStrategyUUID},
 				Destination: &opts.deviceNameStrategies,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_NAME_STRATEGIES"),
@@ -163,10 +225,16 @@ func (m command) build() *cli.Command {
 			},
 			&cli.StringSliceFlag{
 				Name:        "library-search-path",
-				Usage:       "Specify the path to search for libraries when discovering the entities that should be included in the CDI specification.\n\tNote: This option only applies to CSV mode.",
+				Usage:       "Specify a path to search for driver libraries in addition to the standard locations and the ldcache when discovering the entities that should be included in the CDI specification. This can be specified multiple times.",
 				Destination: &opts.librarySearchPaths,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_LIBRARY_SEARCH_PATHS"),
 			},
+			&cli.StringSliceFlag{
+				Name:        "library-denylist",
+				Usage:       "Specify a glob pattern for driver libraries that should not be included in the CDI specification. This can be specified multiple times.",
+				Destination: &opts.libraryDenylist,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_LIBRARY_DENYLIST"),
+			},
 			&cli.StringFlag{
 				Name:    "nvidia-cdi-hook-path",
 				Aliases: []string{"nvidia-ctk-path"},
@@ -176,6 +244,14 @@ func (m command) build() *cli.Command {
 				Destination: &opts.nvidiaCDIHookPath,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_HOOK_PATH"),
 			},
+			&cli.BoolFlag{
+				Name: "nvidia-ctk-hook-fallback",
+				Usage: "If no nvidia-cdi-hook path is specified and `nvidia-cdi-hook` is not found in the PATH, " +
+					"use `nvidia-ctk hook` for the hooks in the generated CDI specification if available.",
+				Value:       true,
+				Destination: &opts.nvidiaCTKHookFallback,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NVIDIA_CTK_HOOK_FALLBACK"),
+			},
 			&cli.StringFlag{
 				Name:        "ldconfig-path",
 				Usage:       "Specify the path to use for ldconfig in the generated CDI specification",
@@ -200,6 +276,57 @@ func (m command) build() *cli.Command {
 				Destination: &opts.class,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CLASS"),
 			},
+			&cli.StringFlag{
+				Name:        "spec-version",
+				Usage:       "the CDI specification version to use for the generated CDI specification. If this is not specified, the minimum version supporting the required features is used.",
+				Destination: &opts.specVersion,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_SPEC_VERSION"),
+			},
+			&cli.StringSliceFlag{
+				Name: "device-classes",
+				Usage: "Generate specifications for the specified device classes in a single pass. " +
+					"One of [" + strings.Join([]string{deviceClassGPU, deviceClassMIG, deviceClassMIGProfile, deviceClassIMEX}, " | ") + "]. " +
+					"A specification is generated for each device class with the class name added to the output filename. " +
+					"Full GPUs use the class specified by --class. " +
+					"For " + deviceClassMIGProfile + " a specification is generated for each MIG profile using the class mig-<profile> (e.g. mig-1g.5gb).",
+				Destination: &opts.deviceClasses,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_CLASSES"),
+			},
+			&cli.StringSliceFlag{
+				Name:    "driver-capability",
+				Aliases: []string{"driver-capabilities"},
+				Usage: "Generate a separate specification for each of the specified driver capabilities (e.g. compute, graphics, or video). " +
+					"Each specification only includes the driver files required for the capability and uses the class <class>-<capability> (e.g. gpu-compute). " +
+					"The capability is added to the output filename.",
+				Destination: &opts.driverCapabilities,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DRIVER_CAPABILITIES"),
+			},
+			&cli.StringSliceFlag{
+				Name: "device-node-permissions",
+				Usage: "Set the permissions of the generated device nodes for a device class as <class>=<permissions> (e.g. gpu=rw). " +
+					"The permissions are a combination of r, w, and m. " +
+					"The class is one of [" + strings.Join([]string{nvcdi.DeviceTypeGPU, nvcdi.DeviceTypeMIG, "imex-channel", nvcdi.DeviceClassCommon}, " | ") + "] or the class of the spec for other devices. " +
+					"The " + nvcdi.DeviceClassCommon + " class applies to device nodes such as /dev/nvidiactl that are included for all devices.",
+				Destination: &opts.deviceNodePermissions,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_NODE_PERMISSIONS"),
+			},
+			&cli.IntFlag{
+				Name: "replicas",
+				Usage: "Generate the specified number of replica devices for each full GPU to allow a GPU to be shared by time-slicing. " +
+					"The replicas are named <name>::<index>. MIG devices are not replicated.",
+				Value:       1,
+				Destination: &opts.replicas,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_REPLICAS"),
+			},
+			&cli.StringFlag{
+				Name: "gpu-type",
+				Usage: "Only include GPUs of the specified type when generating devices for all GPUs. " +
+					"One of [" + strings.Join([]string{string(nvcdi.GPUTypeAll), string(nvcdi.GPUTypeCompute), string(nvcdi.GPUTypeDisplay)}, " | ") + "]. " +
+					"Display GPUs have a display attached or prohibit compute applications.",
+				Value:       string(nvcdi.GPUTypeAll),
+				Destination: &opts.gpuType,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_GPU_TYPE"),
+			},
 			&cli.StringSliceFlag{
 				Name:        "csv.file",
 				Usage:       "The path to the list of CSV files to use when generating the CDI specification in CSV mode.",
@@ -236,9 +363,17 @@ func (m command) build() *cli.Command {
 				Destination: &opts.enabledHooks,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_ENABLED_HOOKS"),
 			},
+			// The hook envvars are not split on commas since their values
+			// may contain commas.
+			&cli.GenericFlag{
+				Name:    "hook-env",
+				Usage:   "Specify an environment variable of the form KEY=VALUE to include in the generated hooks. The value is not split on commas. This can be specified multiple times.",
+				Value:   (*repeatedStringFlag)(&opts.hookEnv),
+				Sources: cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_HOOK_ENV"),
+			},
 			&cli.StringSliceFlag{
 				Name:        "feature-flag",
-				Aliases:     []string{"feature-flags"},
+				Aliases:     []string{"feature-flags", "feature"},
 				Usage:       "specify feature flags for CDI spec generation",
 				Destination: &opts.featureFlags,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_FEATURE_FLAGS"),
@@ -249,6 +384,47 @@ func (m command) build() *cli.Command {
 				Destination: &opts.noAllDevice,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NO_ALL_DEVICE"),
 			},
+			&cli.BoolFlag{
+				Name:        "dev-char-paths",
+				Usage:       "Reference device nodes by their /dev/char/MAJOR:MINOR paths in the container. A hook is added to create symlinks at the original device node paths. This is intended for use with 'nvidia-ctk system create-dev-char-symlinks'.",
+				Destination: &opts.devCharPaths,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEV_CHAR_PATHS"),
+			},
+			&cli.BoolFlag{
+				Name:        "from-legacy",
+				Usage:       "Generate a CDI specification with an `all` device containing the edits that the NVIDIA Container Runtime applies in legacy mode. Note that the driver files injected by the nvidia-container-runtime-hook are not included.",
+				Destination: &opts.fromLegacy,
+			},
+			&cli.BoolFlag{
+				Name:        "with-digest",
+				Usage:       "Add a content digest annotation to the generated CDI specification. This can be checked using 'nvidia-ctk cdi verify'.",
+				Destination: &opts.withDigest,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_WITH_DIGEST"),
+			},
+			&cli.BoolFlag{
+				Name:        "with-provenance",
+				Usage:       "Record the options used to generate the CDI specification and the driver version in spec annotations. This allows the specification to be regenerated using --refresh-dir.",
+				Destination: &opts.withProvenance,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_WITH_PROVENANCE"),
+			},
+			&cli.StringFlag{
+				Name:        "refresh-dir",
+				Usage:       "Regenerate the CDI specifications in the specified directory in place using the options recorded in their provenance annotations. Specifications without provenance annotations are skipped. All other options are ignored.",
+				Destination: &opts.refreshDir,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_REFRESH_DIR"),
+			},
+			&cli.StringFlag{
+				Name:        "merge-into",
+				Usage:       "Merge the generated devices into the specified existing CDI specification instead of overwriting it. The vendor and class are taken from the kind of the existing specification. Devices that are not generated are preserved and devices that differ from the generated devices are reported and updated. Spec-level container edits that are not generated are reported and dropped.",
+				Destination: &opts.mergeInto,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MERGE_INTO"),
+			},
+			&cli.BoolFlag{
+				Name:        "explain",
+				Usage:       "Log what each discoverer found or skipped and why instead of writing a CDI specification. This is a dry run in which NVML is not initialized; the GPU devices are not enumerated",
+				Destination: &opts.explain,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_EXPLAIN"),
+			},
 			&cli.StringSliceFlag{
 				Name:        "device-id",
 				Aliases:     []string{"device-ids", "device", "devices"},
@@ -264,10 +440,39 @@ func (m command) build() *cli.Command {
 }
 
 func (m command) validateFlags(c *cli.Command, opts *options) error {
+	if opts.refreshDir != "" {
+		if opts.output != "" {
+			return fmt.Errorf("--refresh-dir cannot be combined with --output")
+		}
+		if opts.explain {
+			return fmt.Errorf("--refresh-dir cannot be combined with --explain")
+		}
+		if opts.mergeInto != "" {
+			return fmt.Errorf("--refresh-dir cannot be combined with --merge-into")
+		}
+		return nil
+	}
+
 	opts.format = strings.ToLower(opts.format)
+	if opts.mergeInto != "" {
+		if opts.output != "" {
+			return fmt.Errorf("--merge-into cannot be combined with --output")
+		}
+		if opts.explain {
+			return fmt.Errorf("--merge-into cannot be combined with --explain")
+		}
+		if opts.format == formatConfigMap {
+			return fmt.Errorf("--merge-into cannot be combined with output format %v", opts.format)
+		}
+	}
+
 	switch opts.format {
 	case spec.FormatJSON:
 	case spec.FormatYAML:
+	case formatConfigMap:
+		if opts.configMap.name == "" {
+			return fmt.Errorf("a ConfigMap name is required for output format %v", opts.format)
+		}
 	default:
 		return fmt.Errorf("invalid output format: %v", opts.format)
 	}
@@ -277,6 +482,11 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 		return fmt.Errorf("invalid discovery mode: %v", opts.mode)
 	}
 
+	opts.gpuType = strings.ToLower(opts.gpuType)
+	if opts.gpuType != "" && !nvcdi.IsValidGPUType(opts.gpuType) {
+		return fmt.Errorf("invalid GPU type: %v", opts.gpuType)
+	}
+
 	for _, strategy := range opts.deviceNameStrategies {
 		_, err := nvcdi.NewDeviceNamer(strategy)
 		if err != nil {
@@ -284,13 +494,35 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 		}
 	}
 
-	opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPath(m.logger, opts.nvidiaCDIHookPath)
+	if opts.containerRoot != "" && !filepath.IsAbs(opts.containerRoot) {
+		return fmt.Errorf("container root must be an absolute path: %v", opts.containerRoot)
+	}
+
+	if opts.relativeTo != "" && !filepath.IsAbs(opts.relativeTo) {
+		return fmt.Errorf("the relative-to root must be an absolute path: %v", opts.relativeTo)
+	}
+
+	for _, env := range opts.hookEnv {
+		if key, _, found := strings.Cut(env, "="); !found || key == "" {
+			return fmt.Errorf("invalid hook envvar %q: expected KEY=VALUE", env)
+		}
+	}
+
+	if opts.nvidiaCTKHookFallback {
+		opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPathWithNVIDIACTKFallback(m.logger, opts.nvidiaCDIHookPath)
+	} else {
+		opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPath(m.logger, opts.nvidiaCDIHookPath)
+	}
 
-	if outputFileFormat := formatFromFilename(opts.output); outputFileFormat != "" {
+	outputFilename := opts.output
+	if opts.mergeInto != "" {
+		outputFilename = opts.mergeInto
+	}
+	if outputFileFormat := formatFromFilename(outputFilename); outputFileFormat != "" {
 		m.logger.Debugf("Inferred output format as %q from output file name", outputFileFormat)
 		if !c.IsSet("format") {
 			opts.format = outputFileFormat
-		} else if outputFileFormat != opts.format {
+		} else if outputFileFormat != opts.specFormat() {
 			m.logger.Warningf("Requested output format %q does not match format implied by output file name: %q", opts.format, outputFileFormat)
 		}
 	}
@@ -302,12 +534,68 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 		return fmt.Errorf("invalid CDI class name: %v", err)
 	}
 
+	if opts.specVersion != "" {
+		if err := specs.ValidateVersion(&specs.Spec{Version: opts.specVersion}); err != nil {
+			return fmt.Errorf("invalid CDI spec version: %w", err)
+		}
+	}
+
+	for _, deviceClass := range opts.deviceClasses {
+		switch deviceClass {
+		case deviceClassGPU, deviceClassMIG, deviceClassMIGProfile, deviceClassIMEX:
+		default:
+			return fmt.Errorf("invalid device class: %v", deviceClass)
+		}
+	}
+	if len(opts.deviceClasses) > 0 && !slices.Equal(opts.deviceIDs, []string{"all"}) {
+		return fmt.Errorf("device classes cannot be combined with specific device IDs")
+	}
+
+	for _, capability := range opts.driverCapabilities {
+		if capability == "all" || !nvcdi.IsValidDriverCapability(capability) {
+			return fmt.Errorf("invalid driver capability: %v", capability)
+		}
+	}
+	if len(opts.driverCapabilities) > 0 && len(opts.deviceClasses) > 0 {
+		return fmt.Errorf("driver capabilities cannot be combined with device classes")
+	}
+
+	deviceNodePermissions, err := parseDeviceNodePermissions(opts.deviceNodePermissions)
+	if err != nil {
+		return err
+	}
+	if err := nvcdi.ValidateDeviceNodePermissions(deviceNodePermissions); err != nil {
+		return err
+	}
+
+	if opts.replicas < 0 {
+		return fmt.Errorf("invalid number of replicas %d", opts.replicas)
+	}
+
+	for _, pattern := range opts.libraryDenylist {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid library denylist pattern %q: %w", pattern, err)
+		}
+	}
+
 	for _, hook := range opts.enabledHooks {
 		if hook == "all" {
 			return fmt.Errorf("enabling all hooks is not supported")
 		}
 	}
 
+	if opts.fromLegacy {
+		if len(opts.deviceClasses) > 0 {
+			return fmt.Errorf("device classes cannot be combined with --from-legacy")
+		}
+		if len(opts.driverCapabilities) > 0 {
+			return fmt.Errorf("driver capabilities cannot be combined with --from-legacy")
+		}
+		if !slices.Equal(opts.deviceIDs, []string{"all"}) {
+			return fmt.Errorf("specific device IDs cannot be combined with --from-legacy")
+		}
+	}
+
 	if slices.Contains(opts.deviceIDs, "none") && !opts.noAllDevice {
 		m.logger.Warningf("Disabling generation of 'all' device")
 		opts.noAllDevice = true
@@ -316,13 +604,40 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 }
 
 func (m command) run(opts *options) error {
+	if opts.refreshDir != "" {
+		return m.refreshSpecs(opts)
+	}
+	if opts.mergeInto != "" {
+		return m.mergeSpecs(opts)
+	}
+
+	if opts.explain {
+		opts.explainer = nvcdi.NewExplainer()
+	}
+
 	specs, err := m.generateSpecs(opts)
 	if err != nil {
 		return fmt.Errorf("failed to generate CDI spec: %v", err)
 	}
 
+	if opts.explain {
+		for _, entry := range opts.explainer.Entries() {
+			m.logger.Infof("%v", entry)
+		}
+		return nil
+	}
+
+	return m.saveSpecs(opts, specs)
+}
+
+// saveSpecs saves the generated specs to the configured output.
+func (m command) saveSpecs(opts *options, specs []generatedSpecs) error {
 	var errs error
-	for _, spec := range specs {
+	for i, spec := range specs {
+		if opts.format == formatConfigMap {
+			spec.configMap = &opts.configMap
+			spec.followsDocument = i > 0
+		}
 		errs = errors.Join(errs, spec.Save(opts.output))
 		// We query the raw spec version after calling spec.Save since this may
 		// update the spec version to the minimum required version.
@@ -332,6 +647,15 @@ func (m command) run(opts *options) error {
 	return errs
 }
 
+// specFormat returns the format in which the generated specs are rendered.
+// Specs that are wrapped in a ConfigMap are rendered as YAML.
+func (o *options) specFormat() string {
+	if o.format == formatConfigMap {
+		return spec.FormatYAML
+	}
+	return o.format
+}
+
 func formatFromFilename(filename string) string {
 	ext := filepath.Ext(filename)
 	switch strings.ToLower(ext) {
@@ -347,10 +671,18 @@ func formatFromFilename(filename string) string {
 type generatedSpecs struct {
 	spec.Interface
 	filenameInfix string
+	// configMap is set if the spec is to be output as a Kubernetes ConfigMap.
+	configMap *configMapOptions
+	// followsDocument is set if the ConfigMap is written to STDOUT after
+	// another document and must be separated from it.
+	followsDocument bool
 }
 
 func (g *generatedSpecs) Save(filename string) error {
 	filename = g.updateFilename(filename)
+	if g.configMap != nil {
+		return g.saveAsConfigMap(filename)
+	}
 
 	if filename == "" {
 		_, err := g.WriteTo(os.Stdout)
@@ -372,6 +704,278 @@ func (g generatedSpecs) updateFilename(filename string) string {
 }
 
 func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
+	if opts.withProvenance {
+		annotations, err := m.getProvenanceAnnotations(opts)
+		if err != nil {
+			return nil, err
+		}
+		opts.annotations = annotations
+	}
+
+	if opts.fromLegacy {
+		return m.generateSpecsFromLegacy(opts)
+	}
+	if len(opts.deviceClasses) > 0 {
+		return m.generateSpecsForDeviceClasses(opts)
+	}
+	if len(opts.driverCapabilities) > 0 {
+		return m.generateSpecsForDriverCapabilities(opts)
+	}
+
+	cdiOptions, err := m.getCDIOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	cdilib, err := nvcdi.New(cdiOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CDI library: %v", err)
+	}
+
+	allDeviceSpecs, err := cdilib.GetDeviceSpecsByID(opts.deviceIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device CDI specs: %v", err)
+	}
+
+	commonEdits, err := cdilib.GetCommonEdits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
+	}
+
+	return m.newSpecsForClass(withReplicasAnnotations(withPartialDiscoveryAnnotations(opts, cdilib), cdilib), *commonEdits.ContainerEdits, opts.class, "", allDeviceSpecs)
+}
+
+// withPartialDiscoveryAnnotations returns a copy of the options where the
+// annotations for the discoverers of the specified CDI library that fell back
+// to a partial discovery are added to the spec annotations.
+func withPartialDiscoveryAnnotations(opts *options, cdilib nvcdi.Interface) *options {
+	reporter, ok := cdilib.(nvcdi.PartialDiscoveryReporter)
+	if !ok {
+		return opts
+	}
+	return withSpecAnnotations(opts, reporter.PartialDiscoveryAnnotations())
+}
+
+// withReplicasAnnotations returns a copy of the options where the annotation
+// that records the number of replicas generated by the specified CDI library
+// for each full GPU is added to the spec annotations.
+func withReplicasAnnotations(opts *options, cdilib nvcdi.Interface) *options {
+	reporter, ok := cdilib.(nvcdi.ReplicasReporter)
+	if !ok {
+		return opts
+	}
+	return withSpecAnnotations(opts, reporter.ReplicasAnnotations())
+}
+
+// withSpecAnnotations returns a copy of the options where the specified
+// annotations are added to the spec annotations. The options are returned
+// unmodified if there are no annotations to add.
+func withSpecAnnotations(opts *options, annotations map[string]string) *options {
+	if len(annotations) == 0 {
+		return opts
+	}
+
+	withAnnotations := *opts
+	withAnnotations.annotations = maps.Clone(opts.annotations)
+	if withAnnotations.annotations == nil {
+		withAnnotations.annotations = make(map[string]string)
+	}
+	maps.Copy(withAnnotations.annotations, annotations)
+	return &withAnnotations
+}
+
+// generateSpecsForDriverCapabilities generates a spec for each of the
+// requested driver capabilities. Each spec only includes the driver files
+// required for the capability and uses the class <class>-<capability>.
+func (m command) generateSpecsForDriverCapabilities(opts *options) ([]generatedSpecs, error) {
+	var allSpecs []generatedSpecs
+	for _, capability := range opts.driverCapabilities {
+		cdiOptions, err := m.getCDIOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		cdiOptions = append(cdiOptions, nvcdi.WithDriverCapabilities(capability))
+
+		cdilib, err := nvcdi.New(cdiOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CDI library: %v", err)
+		}
+
+		allDeviceSpecs, err := cdilib.GetDeviceSpecsByID(opts.deviceIDs...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create device CDI specs: %v", err)
+		}
+
+		commonEdits, err := cdilib.GetCommonEdits()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
+		}
+
+		class := opts.class + "-" + capability
+		capabilitySpecs, err := m.newSpecsForClass(withReplicasAnnotations(withPartialDiscoveryAnnotations(opts, cdilib), cdilib), *commonEdits.ContainerEdits, class, "."+capability, allDeviceSpecs)
+		if err != nil {
+			return nil, err
+		}
+		allSpecs = append(allSpecs, capabilitySpecs...)
+	}
+	return allSpecs, nil
+}
+
+// generateSpecsForDeviceClasses generates a spec for each of the requested
+// device classes. The NVML-based discovery and common edits for full GPUs and
+// MIG devices are shared between the corresponding specs.
+func (m command) generateSpecsForDeviceClasses(opts *options) ([]generatedSpecs, error) {
+	var allSpecs []generatedSpecs
+
+	withMIGProfiles := slices.Contains(opts.deviceClasses, deviceClassMIGProfile)
+	if slices.Contains(opts.deviceClasses, deviceClassGPU) || slices.Contains(opts.deviceClasses, deviceClassMIG) || withMIGProfiles {
+		cdiOptions, err := m.getCDIOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		cdiOptions = append(cdiOptions, nvcdi.WithFeatureFlags(nvcdi.FeatureEnableDeviceTypeAnnotations))
+		if withMIGProfiles {
+			cdiOptions = append(cdiOptions, nvcdi.WithFeatureFlags(nvcdi.FeatureEnableMIGProfileAnnotations))
+		}
+
+		cdilib, err := nvcdi.New(cdiOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CDI library: %v", err)
+		}
+
+		allDeviceSpecs, err := cdilib.GetDeviceSpecsByID(opts.deviceIDs...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create device CDI specs: %v", err)
+		}
+
+		commonEdits, err := cdilib.GetCommonEdits()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
+		}
+		classOpts := withPartialDiscoveryAnnotations(opts, cdilib)
+
+		deviceSpecsByType := (deviceSpecs)(allDeviceSpecs).splitOnAnnotation(nvcdi.DeviceTypeAnnotation)
+		// Splitting the MIG devices by profile also removes the profile
+		// annotation from the MIG device specs.
+		migDeviceSpecsByProfile := (deviceSpecs)(deviceSpecsByType[nvcdi.DeviceTypeAnnotation+"="+deviceClassMIG]).splitOnAnnotation(nvcdi.MIGProfileAnnotation)
+
+		for _, deviceClass := range []string{deviceClassGPU, deviceClassMIG} {
+			if !slices.Contains(opts.deviceClasses, deviceClass) {
+				continue
+			}
+			classDeviceSpecs := deviceSpecsByType[nvcdi.DeviceTypeAnnotation+"="+deviceClass]
+			if len(classDeviceSpecs) == 0 {
+				m.logger.Warningf("No devices found for device class %q; skipping", deviceClass)
+				continue
+			}
+
+			class, infix, specOpts := opts.class, "", withReplicasAnnotations(classOpts, cdilib)
+			if deviceClass == deviceClassMIG {
+				class, infix, specOpts = deviceClassMIG, "."+deviceClassMIG, classOpts
+			}
+			classSpecs, err := m.newSpecsForClass(specOpts, *commonEdits.ContainerEdits, class, infix, classDeviceSpecs)
+			if err != nil {
+				return nil, err
+			}
+			allSpecs = append(allSpecs, classSpecs...)
+		}
+
+		if withMIGProfiles {
+			profileSpecs, err := m.newSpecsForMIGProfiles(classOpts, *commonEdits.ContainerEdits, migDeviceSpecsByProfile)
+			if err != nil {
+				return nil, err
+			}
+			allSpecs = append(allSpecs, profileSpecs...)
+		}
+	}
+
+	if slices.Contains(opts.deviceClasses, deviceClassIMEX) {
+		deviceNodePermissions, err := parseDeviceNodePermissions(opts.deviceNodePermissions)
+		if err != nil {
+			return nil, err
+		}
+		imexlib, err := nvcdi.New(
+			nvcdi.WithLogger(m.logger),
+			nvcdi.WithDriverRoot(opts.driverRoot),
+			nvcdi.WithDevRoot(opts.devRoot),
+			nvcdi.WithMode(nvcdi.ModeImex),
+			nvcdi.WithExplainer(opts.explainer),
+			nvcdi.WithDeviceNodePermissions(deviceNodePermissions),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CDI library: %v", err)
+		}
+
+		imexDeviceSpecs, err := imexlib.GetDeviceSpecsByID("all")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create IMEX channel CDI specs: %v", err)
+		}
+
+		if len(imexDeviceSpecs) == 0 {
+			m.logger.Warningf("No devices found for device class %q; skipping", deviceClassIMEX)
+			return allSpecs, nil
+		}
+
+		commonEdits, err := imexlib.GetCommonEdits()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
+		}
+
+		classSpecs, err := m.newSpecsForClass(opts, *commonEdits.ContainerEdits, "imex-channel", ".imex-channel", imexDeviceSpecs)
+		if err != nil {
+			return nil, err
+		}
+		allSpecs = append(allSpecs, classSpecs...)
+	}
+
+	return allSpecs, nil
+}
+
+// newSpecsForMIGProfiles generates a spec for each MIG profile. The MIG device
+// specs are keyed by their MIG profile annotation as returned by
+// splitOnAnnotation.
+func (m command) newSpecsForMIGProfiles(opts *options, commonEdits specs.ContainerEdits, deviceSpecsByProfile map[string][]specs.Device) ([]generatedSpecs, error) {
+	if len(deviceSpecsByProfile) == 0 {
+		m.logger.Warningf("No devices found for device class %q; skipping", deviceClassMIGProfile)
+		return nil, nil
+	}
+
+	var profiles []string
+	for key := range deviceSpecsByProfile {
+		profiles = append(profiles, strings.TrimPrefix(key, nvcdi.MIGProfileAnnotation+"="))
+	}
+	slices.Sort(profiles)
+
+	var allSpecs []generatedSpecs
+	for _, profile := range profiles {
+		class := migProfileClass(profile)
+		profileSpecs, err := m.newSpecsForClass(opts, commonEdits, class, "."+class, deviceSpecsByProfile[nvcdi.MIGProfileAnnotation+"="+profile])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create spec for MIG profile %v: %w", profile, err)
+		}
+		allSpecs = append(allSpecs, profileSpecs...)
+	}
+	return allSpecs, nil
+}
+
+// migProfileClass returns the CDI class for the specified MIG profile.
+// Characters that are not valid in a CDI class name (such as the + in
+// 1g.5gb+me) are replaced by a dash.
+func migProfileClass(profile string) string {
+	class := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '_' || r == '-' || r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, profile)
+	return deviceClassMIG + "-" + class
+}
+
+func (m command) getCDIOptions(opts *options) ([]nvcdi.Option, error) {
 	var deviceNamers []nvcdi.DeviceNamer
 	for _, strategy := range opts.deviceNameStrategies {
 		deviceNamer, err := nvcdi.NewDeviceNamer(strategy)
@@ -381,6 +985,11 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 		deviceNamers = append(deviceNamers, deviceNamer)
 	}
 
+	deviceNodePermissions, err := parseDeviceNodePermissions(opts.deviceNodePermissions)
+	if err != nil {
+		return nil, err
+	}
+
 	cdiOptions := []nvcdi.Option{
 		nvcdi.WithLogger(m.logger),
 		nvcdi.WithDriverRoot(opts.driverRoot),
@@ -389,38 +998,57 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 		nvcdi.WithLdconfigPath(opts.ldconfigPath),
 		nvcdi.WithDeviceNamers(deviceNamers...),
 		nvcdi.WithMode(opts.mode),
+		nvcdi.WithVendor(opts.vendor),
+		nvcdi.WithClass(opts.class),
 		nvcdi.WithConfigSearchPaths(opts.configSearchPaths),
 		nvcdi.WithLibrarySearchPaths(opts.librarySearchPaths),
+		nvcdi.WithLibraryDenylist(opts.libraryDenylist),
+		nvcdi.WithDevCharPaths(opts.devCharPaths),
+		nvcdi.WithGPUType(opts.gpuType),
 		nvcdi.WithCSVFiles(opts.csv.files),
 		nvcdi.WithCSVIgnorePatterns(opts.csv.ignorePatterns),
 		nvcdi.WithCSVCompatContainerRoot(opts.csv.CompatContainerRoot),
 		nvcdi.WithDisabledHooks(opts.disabledHooks...),
 		nvcdi.WithEnabledHooks(opts.enabledHooks...),
+		nvcdi.WithHookEnv(opts.hookEnv...),
 		nvcdi.WithFeatureFlags(opts.featureFlags...),
+		nvcdi.WithExplainer(opts.explainer),
+		nvcdi.WithDeviceNodePermissions(deviceNodePermissions),
+		nvcdi.WithReplicas(opts.replicas),
 		// We set the following to allow for dependency injection:
 		nvcdi.WithNvmlLib(opts.nvmllib),
 	}
+	return cdiOptions, nil
+}
 
-	cdilib, err := nvcdi.New(cdiOptions...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create CDI library: %v", err)
-	}
-
-	allDeviceSpecs, err := cdilib.GetDeviceSpecsByID(opts.deviceIDs...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create device CDI specs: %v", err)
-	}
-
-	commonEdits, err := cdilib.GetCommonEdits()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
+// parseDeviceNodePermissions parses the specified class=permissions pairs. The
+// permissions themselves are validated by the nvcdi library.
+func parseDeviceNodePermissions(values []string) (map[string]string, error) {
+	permissions := make(map[string]string)
+	for _, value := range values {
+		class, p, ok := strings.Cut(value, "=")
+		if !ok || class == "" {
+			return nil, fmt.Errorf("invalid device node permissions %q: expected <class>=<permissions>", value)
+		}
+		permissions[class] = p
 	}
+	return permissions, nil
+}
 
+// newSpecsForClass constructs the specs for the specified class and device
+// specs. In addition to the full spec, separate specs are constructed for
+// coherent and non-coherent devices if these are annotated.
+func (m command) newSpecsForClass(opts *options, commonEdits specs.ContainerEdits, class string, filenameInfix string, allDeviceSpecs []specs.Device) ([]generatedSpecs, error) {
 	commonSpecOptions := []spec.Option{
 		spec.WithVendor(opts.vendor),
-		spec.WithEdits(*commonEdits.ContainerEdits),
-		spec.WithFormat(opts.format),
+		spec.WithEdits(commonEdits),
+		spec.WithFormat(opts.specFormat()),
 		spec.WithPermissions(0644),
+		spec.WithContainerRoot(opts.containerRoot),
+		spec.WithRelativeTo(opts.relativeTo),
+		spec.WithDigest(opts.withDigest),
+		spec.WithVersion(opts.specVersion),
+		spec.WithAnnotations(opts.annotations),
 	}
 
 	if !opts.noAllDevice {
@@ -434,7 +1062,7 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 
 	fullSpec, err := spec.New(
 		append(commonSpecOptions,
-			spec.WithClass(opts.class),
+			spec.WithClass(class),
 			spec.WithDeviceSpecs(allDeviceSpecs),
 		)...,
 	)
@@ -443,7 +1071,7 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 	}
 	var allSpecs []generatedSpecs
 
-	allSpecs = append(allSpecs, generatedSpecs{Interface: fullSpec, filenameInfix: ""})
+	allSpecs = append(allSpecs, generatedSpecs{Interface: fullSpec, filenameInfix: filenameInfix})
 
 	deviceSpecsByDeviceCoherence := (deviceSpecs)(allDeviceSpecs).splitOnAnnotation("gpu.nvidia.com/coherent")
 
@@ -451,21 +1079,21 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 		infix := ".coherent"
 		coherentSpecs, err := spec.New(
 			append(commonSpecOptions,
-				spec.WithClass(opts.class+infix),
+				spec.WithClass(class+infix),
 				spec.WithDeviceSpecs(coherentDeviceSpecs),
 			)...,
 		)
 		if err != nil {
 			return nil, err
 		}
-		allSpecs = append(allSpecs, generatedSpecs{Interface: coherentSpecs, filenameInfix: infix})
+		allSpecs = append(allSpecs, generatedSpecs{Interface: coherentSpecs, filenameInfix: filenameInfix + infix})
 	}
 
 	if noncoherentDeviceSpecs := deviceSpecsByDeviceCoherence["gpu.nvidia.com/coherent=false"]; len(noncoherentDeviceSpecs) > 0 {
 		infix := ".noncoherent"
 		noncoherentSpecs, err := spec.New(
 			append(commonSpecOptions,
-				spec.WithClass(opts.class+infix),
+				spec.WithClass(class+infix),
 				spec.WithDeviceSpecs(noncoherentDeviceSpecs),
 			)...,
 		)
@@ -473,7 +1101,7 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 		if err != nil {
 			return nil, err
 		}
-		allSpecs = append(allSpecs, generatedSpecs{Interface: noncoherentSpecs, filenameInfix: infix})
+		allSpecs = append(allSpecs, generatedSpecs{Interface: noncoherentSpecs, filenameInfix: filenameInfix + infix})
 	}
 
 	return allSpecs, nil
@@ -504,3 +1132,32 @@ func (d deviceSpecs) splitOnAnnotation(key string) map[string][]specs.Device {
 
 	return splitSpecs
 }
+
+// A repeatedStringFlag collects the values of a flag that can be specified
+// multiple times. In contrast to a cli.StringSliceFlag, the values are not
+// split on commas.
+type repeatedStringFlag []string
+
+var _ cli.Value = (*repeatedStringFlag)(nil)
+
+// Set appends the specified value.
+func (f *repeatedStringFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// String returns the values joined by commas.
+func (f *repeatedStringFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(*f, ",")
+}
+
+// Get returns the values as a string slice.
+func (f *repeatedStringFlag) Get() any {
+	if f == nil {
+		return []string(nil)
+	}
+	return []string(*f)
+}
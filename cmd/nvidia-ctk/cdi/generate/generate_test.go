@@ -18,19 +18,25 @@ package generate
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
 	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
 )
 
 func TestGenerateSpec(t *testing.T) {
@@ -69,7 +75,7 @@ func TestGenerateSpec(t *testing.T) {
 				deviceIDs:         []string{"99"},
 				driverRoot:        driverRoot,
 			},
-			expectedError: fmt.Errorf("failed to create device CDI specs: failed to construct device spec generators: failed to get device handle from index: ERROR_INVALID_ARGUMENT"),
+			expectedError: fmt.Errorf("failed to create device CDI specs: failed to construct device spec generators: failed to get device handle from index: device not found: ERROR_INVALID_ARGUMENT"),
 		},
 		{
 			description: "default",
@@ -162,6 +168,91 @@ containerEdits:
             - nodev
             - rbind
             - rprivate
+`,
+		},
+		{
+			description: "library denylist",
+			options: options{
+				format:          "yaml",
+				mode:            "nvml",
+				vendor:          "example.com",
+				class:           "device",
+				driverRoot:      driverRoot,
+				libraryDenylist: []string{"libvdpau_nvidia.so.*"},
+			},
+			expectedOptions: options{
+				format:            "yaml",
+				mode:              "nvml",
+				vendor:            "example.com",
+				class:             "device",
+				nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+				driverRoot:        driverRoot,
+				libraryDenylist:   []string{"libvdpau_nvidia.so.*"},
+			},
+			expectedSpec: `---
+cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+    - name: "0"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+              hostPath: {{ .driverRoot }}/dev/nvidia0
+    - name: all
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+              hostPath: {{ .driverRoot }}/dev/nvidia0
+containerEdits:
+    env:
+        - NVIDIA_CTK_LIBCUDA_DIR=/lib/x86_64-linux-gnu
+        - NVIDIA_VISIBLE_DEVICES=void
+    deviceNodes:
+        - path: /dev/nvidiactl
+          hostPath: {{ .driverRoot }}/dev/nvidiactl
+    hooks:
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - create-symlinks
+            - --link
+            - libcuda.so.1::/lib/x86_64-linux-gnu/libcuda.so
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - enable-cuda-compat
+            - --host-driver-version=999.88.77
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - update-ldcache
+            - --folder
+            - /lib/x86_64-linux-gnu
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - disable-device-node-modification
+          env:
+            - NVIDIA_CTK_DEBUG=false
+    mounts:
+        - hostPath: {{ .driverRoot }}/lib/x86_64-linux-gnu/libcuda.so.999.88.77
+          containerPath: /lib/x86_64-linux-gnu/libcuda.so.999.88.77
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
 `,
 		},
 		{
@@ -325,6 +416,93 @@ containerEdits:
             - nodev
             - rbind
             - rprivate
+`,
+		},
+		{
+			description: "enableCheckLibrariesHook",
+			options: options{
+				format:        "yaml",
+				mode:          "nvml",
+				vendor:        "example.com",
+				class:         "device",
+				driverRoot:    driverRoot,
+				enabledHooks:  []string{"check-libraries"},
+				disabledHooks: []string{"enable-cuda-compat", "update-ldcache"},
+			},
+			expectedOptions: options{
+				format:            "yaml",
+				mode:              "nvml",
+				vendor:            "example.com",
+				class:             "device",
+				nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+				driverRoot:        driverRoot,
+				enabledHooks:      []string{"check-libraries"},
+				disabledHooks:     []string{"enable-cuda-compat", "update-ldcache"},
+			},
+			expectedSpec: `---
+cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+    - name: "0"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+              hostPath: {{ .driverRoot }}/dev/nvidia0
+    - name: all
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+              hostPath: {{ .driverRoot }}/dev/nvidia0
+containerEdits:
+    env:
+        - NVIDIA_CTK_LIBCUDA_DIR=/lib/x86_64-linux-gnu
+        - NVIDIA_VISIBLE_DEVICES=void
+    deviceNodes:
+        - path: /dev/nvidiactl
+          hostPath: {{ .driverRoot }}/dev/nvidiactl
+    hooks:
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - create-symlinks
+            - --link
+            - libcuda.so.1::/lib/x86_64-linux-gnu/libcuda.so
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - check-libraries
+            - --folder
+            - /lib/x86_64-linux-gnu
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - disable-device-node-modification
+          env:
+            - NVIDIA_CTK_DEBUG=false
+    mounts:
+        - hostPath: {{ .driverRoot }}/lib/x86_64-linux-gnu/libcuda.so.999.88.77
+          containerPath: /lib/x86_64-linux-gnu/libcuda.so.999.88.77
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
+        - hostPath: {{ .driverRoot }}/lib/x86_64-linux-gnu/vdpau/libvdpau_nvidia.so.999.88.77
+          containerPath: /lib/x86_64-linux-gnu/vdpau/libvdpau_nvidia.so.999.88.77
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
 `,
 		},
 		{
@@ -522,102 +700,789 @@ containerEdits:
 	}
 }
 
-func TestSplitOnAnnotation(t *testing.T) {
+func TestGenerateSpecVendorAndClass(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
 	testCases := []struct {
-		description            string
-		input                  deviceSpecs
-		annotation             string
-		expectedInputPostSplit deviceSpecs
-		expectedOutput         map[string][]specs.Device
+		description           string
+		vendor                string
+		class                 string
+		expectedValidateError string
+		expectedKind          string
 	}{
 		{
-			description: "non-matching annotation",
-			input: deviceSpecs{
-				specs.Device{
-					Name: "foo",
-					Annotations: map[string]string{
-						"key": "value",
-					},
-				},
-			},
-			annotation: "not-key",
-			expectedInputPostSplit: deviceSpecs{
-				specs.Device{
-					Name: "foo",
-					Annotations: map[string]string{
-						"key": "value",
-					},
-				},
-			},
-			expectedOutput: map[string][]specs.Device{},
+			description:  "default vendor and class",
+			vendor:       "nvidia.com",
+			class:        "gpu",
+			expectedKind: "nvidia.com/gpu",
 		},
 		{
-			description: "single annotation present",
-			input: deviceSpecs{
-				specs.Device{
-					Name: "foo",
-					Annotations: map[string]string{
-						"key": "value",
-					},
-				},
-			},
-			annotation: "key",
-			expectedInputPostSplit: deviceSpecs{
-				specs.Device{
-					Name:        "foo",
-					Annotations: map[string]string{},
-				},
-			},
-			expectedOutput: map[string][]specs.Device{
-				"key=value": {
-					{
-						Name:        "foo",
-						Annotations: map[string]string{},
-					},
-				},
-			},
+			description:  "custom vendor and class",
+			vendor:       "acme.example.com",
+			class:        "accelerator_v1",
+			expectedKind: "acme.example.com/accelerator_v1",
 		},
 		{
-			description: "non-matching annotations are not removed",
-			input: deviceSpecs{
-				specs.Device{
-					Name: "foo",
-					Annotations: map[string]string{
-						"key":     "value",
-						"another": "foo",
-					},
-				},
-			},
-			annotation: "key",
-			expectedInputPostSplit: deviceSpecs{
-				specs.Device{
-					Name:        "foo",
-					Annotations: map[string]string{"another": "foo"},
-				},
-			},
-			expectedOutput: map[string][]specs.Device{
-				"key=value": {
-					{
-						Name:        "foo",
-						Annotations: map[string]string{"another": "foo"},
-					},
-				},
-			},
+			description:           "vendor with invalid characters",
+			vendor:                "acme/example.com",
+			class:                 "gpu",
+			expectedValidateError: "invalid CDI vendor name: invalid vendor. invalid character '/' in name \"acme/example.com\"",
 		},
 		{
-			description: "duplicated annotations different names",
-			input: func() deviceSpecs {
-				annotations := map[string]string{
-					"key": "value",
-				}
-				return deviceSpecs{
-					{Name: "0", Annotations: annotations},
-					{Name: "first", Annotations: annotations},
-				}
-			}(),
-			annotation: "key",
-			expectedInputPostSplit: deviceSpecs{
-				specs.Device{Name: "0", Annotations: map[string]string{}},
+			description:           "empty vendor",
+			vendor:                "",
+			class:                 "gpu",
+			expectedValidateError: "invalid CDI vendor name: invalid vendor. empty name",
+		},
+		{
+			description:           "class with invalid characters",
+			vendor:                "nvidia.com",
+			class:                 "gpu:0",
+			expectedValidateError: "invalid CDI class name: invalid class. invalid character ':' in name \"gpu:0\"",
+		},
+		{
+			description:           "class ending in a non-alphanumeric character",
+			vendor:                "nvidia.com",
+			class:                 "gpu-",
+			expectedValidateError: "invalid CDI class name: invalid class. \"gpu-\", should end with a letter or digit",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+
+			opts := options{
+				format:               "yaml",
+				mode:                 "nvml",
+				vendor:               tc.vendor,
+				class:                tc.class,
+				deviceNameStrategies: []string{"index"},
+				deviceIDs:            []string{"all"},
+				driverRoot:           driverRoot,
+				nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+			}
+
+			err := c.validateFlags(nil, &opts)
+			if tc.expectedValidateError != "" {
+				require.EqualError(t, err, tc.expectedValidateError)
+				return
+			}
+			require.NoError(t, err)
+
+			server := dgxa100.New()
+			server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+				return "999.88.77", nvml.SUCCESS
+			}
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 1, nvml.SUCCESS
+			}
+			for _, d := range server.Devices {
+				(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				}
+			}
+			opts.nvmllib = server
+
+			generated, err := c.generateSpecs(&opts)
+			require.NoError(t, err)
+			require.Len(t, generated, 1)
+			require.Equal(t, tc.expectedKind, generated[0].Raw().Kind)
+		})
+	}
+}
+
+func TestGenerateSpecVersion(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description           string
+		specVersion           string
+		expectedValidateError string
+		expectedVersion       string
+	}{
+		{
+			description:     "explicit version is used",
+			specVersion:     "1.0.0",
+			expectedVersion: "1.0.0",
+		},
+		{
+			description:           "unknown version is invalid",
+			specVersion:           "0.9.9",
+			expectedValidateError: "invalid CDI spec version: invalid version \"0.9.9\"",
+		},
+		{
+			description:           "version older than required is invalid",
+			specVersion:           "0.2.0",
+			expectedValidateError: "invalid CDI spec version: the spec version must be at least v0.3.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+
+			opts := options{
+				format:               "yaml",
+				mode:                 "nvml",
+				vendor:               "nvidia.com",
+				class:                "gpu",
+				specVersion:          tc.specVersion,
+				deviceNameStrategies: []string{"index"},
+				deviceIDs:            []string{"all"},
+				driverRoot:           driverRoot,
+				nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+			}
+
+			err := c.validateFlags(nil, &opts)
+			if tc.expectedValidateError != "" {
+				require.EqualError(t, err, tc.expectedValidateError)
+				return
+			}
+			require.NoError(t, err)
+
+			server := dgxa100.New()
+			server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+				return "999.88.77", nvml.SUCCESS
+			}
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 1, nvml.SUCCESS
+			}
+			for _, d := range server.Devices {
+				(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				}
+			}
+			opts.nvmllib = server
+
+			generated, err := c.generateSpecs(&opts)
+			require.NoError(t, err)
+			require.Len(t, generated, 1)
+
+			_, err = generated[0].WriteTo(&bytes.Buffer{})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVersion, generated[0].Raw().Version)
+		})
+	}
+}
+
+func TestGenerateSpecDeviceNodePermissions(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description                 string
+		deviceNodePermissions       []string
+		expectedValidateError       string
+		expectedDevicePermissions   string
+		expectedCommonPermissions   string
+		expectedSerializedFragments []string
+	}{
+		{
+			description: "permissions are not set by default",
+		},
+		{
+			description:                 "permissions are set per device class",
+			deviceNodePermissions:       []string{"gpu=rw", "common=r"},
+			expectedDevicePermissions:   "rw",
+			expectedCommonPermissions:   "r",
+			expectedSerializedFragments: []string{"permissions: rw\n", "permissions: r\n"},
+		},
+		{
+			description:           "permissions for other classes are ignored",
+			deviceNodePermissions: []string{"mig=r"},
+		},
+		{
+			description:           "invalid permissions are rejected",
+			deviceNodePermissions: []string{"gpu=rx"},
+			expectedValidateError: `invalid device node permissions "rx" for device class "gpu"`,
+		},
+		{
+			description:           "missing class is rejected",
+			deviceNodePermissions: []string{"rw"},
+			expectedValidateError: `invalid device node permissions "rw": expected <class>=<permissions>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+
+			opts := options{
+				format:                "yaml",
+				mode:                  "nvml",
+				vendor:                "nvidia.com",
+				class:                 "gpu",
+				deviceNameStrategies:  []string{"index"},
+				deviceIDs:             []string{"all"},
+				deviceNodePermissions: tc.deviceNodePermissions,
+				driverRoot:            driverRoot,
+				nvidiaCDIHookPath:     "/usr/bin/nvidia-cdi-hook",
+			}
+
+			err := c.validateFlags(nil, &opts)
+			if tc.expectedValidateError != "" {
+				require.EqualError(t, err, tc.expectedValidateError)
+				return
+			}
+			require.NoError(t, err)
+
+			server := dgxa100.New()
+			server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+				return "999.88.77", nvml.SUCCESS
+			}
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 1, nvml.SUCCESS
+			}
+			for _, d := range server.Devices {
+				(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				}
+			}
+			opts.nvmllib = server
+
+			generated, err := c.generateSpecs(&opts)
+			require.NoError(t, err)
+			require.Len(t, generated, 1)
+
+			raw := generated[0].Raw()
+			require.NotEmpty(t, raw.Devices)
+			for _, device := range raw.Devices {
+				require.NotEmpty(t, device.ContainerEdits.DeviceNodes)
+				for _, dn := range device.ContainerEdits.DeviceNodes {
+					require.Equal(t, tc.expectedDevicePermissions, dn.Permissions, dn.Path)
+				}
+			}
+			require.NotEmpty(t, raw.ContainerEdits.DeviceNodes)
+			for _, dn := range raw.ContainerEdits.DeviceNodes {
+				require.Equal(t, tc.expectedCommonPermissions, dn.Permissions, dn.Path)
+			}
+
+			var buf bytes.Buffer
+			_, err = generated[0].WriteTo(&buf)
+			require.NoError(t, err)
+			if len(tc.expectedSerializedFragments) == 0 {
+				require.NotContains(t, buf.String(), "permissions:")
+			}
+			for _, fragment := range tc.expectedSerializedFragments {
+				require.Contains(t, buf.String(), fragment)
+			}
+		})
+	}
+}
+
+func TestGenerateSpecReplicas(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description           string
+		replicas              int
+		expectedValidateError string
+		expectedDeviceNames   []string
+		expectedAnnotations   map[string]string
+	}{
+		{
+			description:         "a single device is generated by default",
+			replicas:            1,
+			expectedDeviceNames: []string{"0", "all"},
+		},
+		{
+			description:         "replicas are generated for each GPU",
+			replicas:            2,
+			expectedDeviceNames: []string{"0::0", "0::1", "all"},
+			expectedAnnotations: map[string]string{
+				nvcdi.ReplicasAnnotation: "2",
+			},
+		},
+		{
+			description:         "a GPU is split four ways",
+			replicas:            4,
+			expectedDeviceNames: []string{"0::0", "0::1", "0::2", "0::3", "all"},
+			expectedAnnotations: map[string]string{
+				nvcdi.ReplicasAnnotation: "4",
+			},
+		},
+		{
+			description:           "negative replicas are rejected",
+			replicas:              -1,
+			expectedValidateError: "invalid number of replicas -1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+
+			opts := options{
+				format:               "yaml",
+				mode:                 "nvml",
+				vendor:               "nvidia.com",
+				class:                "gpu",
+				deviceNameStrategies: []string{"index"},
+				deviceIDs:            []string{"all"},
+				replicas:             tc.replicas,
+				driverRoot:           driverRoot,
+				nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+			}
+
+			err := c.validateFlags(nil, &opts)
+			if tc.expectedValidateError != "" {
+				require.EqualError(t, err, tc.expectedValidateError)
+				return
+			}
+			require.NoError(t, err)
+
+			server := dgxa100.New()
+			server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+				return "999.88.77", nvml.SUCCESS
+			}
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 1, nvml.SUCCESS
+			}
+			for _, d := range server.Devices {
+				(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				}
+			}
+			opts.nvmllib = server
+
+			generated, err := c.generateSpecs(&opts)
+			require.NoError(t, err)
+			require.Len(t, generated, 1)
+
+			var names []string
+			for _, device := range generated[0].Raw().Devices {
+				names = append(names, device.Name)
+			}
+			require.Equal(t, tc.expectedDeviceNames, names)
+			require.Equal(t, tc.expectedAnnotations, generated[0].Raw().Annotations)
+		})
+	}
+}
+
+func TestGenerateSpecsForDeviceClasses(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description           string
+		deviceClasses         []string
+		deviceIDs             []string
+		expectedValidateError error
+		expectedKinds         []string
+		expectedDeviceNames   [][]string
+	}{
+		{
+			description:           "invalid device class",
+			deviceClasses:         []string{"gpu", "invalid"},
+			expectedValidateError: fmt.Errorf("invalid device class: invalid"),
+		},
+		{
+			description:           "device classes with device IDs",
+			deviceClasses:         []string{"gpu"},
+			deviceIDs:             []string{"0"},
+			expectedValidateError: fmt.Errorf("device classes cannot be combined with specific device IDs"),
+		},
+		{
+			description:         "gpu only",
+			deviceClasses:       []string{"gpu"},
+			expectedKinds:       []string{"example.com/device"},
+			expectedDeviceNames: [][]string{{"0", "all"}},
+		},
+		{
+			description:         "imex only",
+			deviceClasses:       []string{"imex"},
+			expectedKinds:       []string{"example.com/imex-channel"},
+			expectedDeviceNames: [][]string{{"0", "1", "2047", "all"}},
+		},
+		{
+			description:   "gpu and mig-profile",
+			deviceClasses: []string{"gpu", "mig-profile"},
+			// The mock does not include any MIG devices.
+			expectedKinds:       []string{"example.com/device"},
+			expectedDeviceNames: [][]string{{"0", "all"}},
+		},
+		{
+			description:   "gpu, mig, and imex",
+			deviceClasses: []string{"gpu", "mig", "imex"},
+			// The mock does not include any MIG devices.
+			expectedKinds:       []string{"example.com/device", "example.com/imex-channel"},
+			expectedDeviceNames: [][]string{{"0", "all"}, {"0", "1", "2047", "all"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+
+			opts := options{
+				format:               "yaml",
+				mode:                 "nvml",
+				vendor:               "example.com",
+				class:                "device",
+				deviceClasses:        tc.deviceClasses,
+				deviceNameStrategies: []string{"index"},
+				deviceIDs:            tc.deviceIDs,
+				driverRoot:           driverRoot,
+				nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+			}
+			if opts.deviceIDs == nil {
+				opts.deviceIDs = []string{"all"}
+			}
+
+			err := c.validateFlags(nil, &opts)
+			require.EqualValues(t, tc.expectedValidateError, err)
+			if tc.expectedValidateError != nil {
+				return
+			}
+
+			server := dgxa100.New()
+			server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+				return "999.88.77", nvml.SUCCESS
+			}
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 1, nvml.SUCCESS
+			}
+			for _, d := range server.Devices {
+				(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				}
+			}
+			opts.nvmllib = server
+
+			generated, err := c.generateSpecs(&opts)
+			require.NoError(t, err)
+
+			var kinds []string
+			var deviceNames [][]string
+			for _, s := range generated {
+				kinds = append(kinds, s.Raw().Kind)
+				var names []string
+				for _, device := range s.Raw().Devices {
+					require.NotContains(t, device.Annotations, "gpu.nvidia.com/device-type")
+					names = append(names, device.Name)
+				}
+				deviceNames = append(deviceNames, names)
+			}
+			require.EqualValues(t, tc.expectedKinds, kinds)
+			require.EqualValues(t, tc.expectedDeviceNames, deviceNames)
+		})
+	}
+}
+
+func TestNewSpecsForMIGProfiles(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	migDevice := func(name string, profile string) specs.Device {
+		return specs.Device{
+			Name: name,
+			Annotations: map[string]string{
+				"gpu.nvidia.com/mig-profile": profile,
+			},
+			ContainerEdits: specs.ContainerEdits{
+				DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia-caps/nvidia-cap" + name}},
+			},
+		}
+	}
+
+	// A node with mixed MIG profiles across two GPUs.
+	input := deviceSpecs{
+		migDevice("0:0", "3g.20gb"),
+		migDevice("0:1", "1g.5gb"),
+		migDevice("0:2", "1g.5gb"),
+		migDevice("1:0", "1g.5gb"),
+		migDevice("1:1", "1g.5gb+me"),
+		migDevice("1:2", "2g.10gb"),
+	}
+
+	c := command{
+		logger: logger,
+	}
+	opts := &options{
+		format: "yaml",
+		vendor: "nvidia.com",
+	}
+
+	generated, err := c.newSpecsForMIGProfiles(opts, specs.ContainerEdits{}, input.splitOnAnnotation("gpu.nvidia.com/mig-profile"))
+	require.NoError(t, err)
+
+	deviceNamesByKind := make(map[string][]string)
+	var kinds []string
+	for _, s := range generated {
+		kind := s.Raw().Kind
+		kinds = append(kinds, kind)
+		require.Equal(t, "."+strings.TrimPrefix(kind, "nvidia.com/"), s.filenameInfix)
+		for _, device := range s.Raw().Devices {
+			require.NotContains(t, device.Annotations, "gpu.nvidia.com/mig-profile")
+			deviceNamesByKind[kind] = append(deviceNamesByKind[kind], device.Name)
+		}
+	}
+
+	require.EqualValues(t,
+		[]string{
+			"nvidia.com/mig-1g.5gb",
+			"nvidia.com/mig-1g.5gb-me",
+			"nvidia.com/mig-2g.10gb",
+			"nvidia.com/mig-3g.20gb",
+		},
+		kinds,
+	)
+	require.EqualValues(t,
+		map[string][]string{
+			"nvidia.com/mig-1g.5gb":    {"0:1", "0:2", "1:0", "all"},
+			"nvidia.com/mig-1g.5gb-me": {"1:1", "all"},
+			"nvidia.com/mig-2g.10gb":   {"1:2", "all"},
+			"nvidia.com/mig-3g.20gb":   {"0:0", "all"},
+		},
+		deviceNamesByKind,
+	)
+}
+
+func TestGenerateExplain(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, hook := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	output := filepath.Join(t.TempDir(), "nvidia.yaml")
+	opts := options{
+		output:               output,
+		format:               "yaml",
+		mode:                 "nvml",
+		vendor:               "example.com",
+		class:                "device",
+		deviceNameStrategies: []string{"index"},
+		deviceIDs:            []string{"all"},
+		driverRoot:           driverRoot,
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+		explain:              true,
+	}
+
+	server := dgxa100.New()
+	server.InitFunc = func() nvml.Return {
+		t.Error("NVML must not be initialized when explaining")
+		return nvml.ERROR_UNKNOWN
+	}
+	opts.nvmllib = server
+
+	require.NoError(t, c.run(&opts))
+	require.NoFileExists(t, output)
+
+	var messages []string
+	for _, entry := range hook.AllEntries() {
+		messages = append(messages, entry.Message)
+	}
+	require.Contains(t, messages, "found control devices: device /dev/nvidiactl")
+	require.Contains(t, messages, "skipped GPU devices: NVML is not queried in a dry run")
+	require.Contains(t, messages, `skipped confidential computing devices: feature flag "cc" not set`)
+}
+
+func TestGenerateSpecCSVWithoutNVML(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-orin")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	output := filepath.Join(t.TempDir(), "nvidia.yaml")
+	opts := options{
+		output:               output,
+		format:               "yaml",
+		mode:                 "csv",
+		vendor:               "nvidia.com",
+		class:                "gpu",
+		deviceNameStrategies: []string{"index"},
+		deviceIDs:            []string{"all"},
+		driverRoot:           driverRoot,
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+	}
+	opts.csv.files = []string{
+		filepath.Join(driverRoot, "etc/nvidia-container-runtime/host-files-for-container.d/devices.csv"),
+		filepath.Join(driverRoot, "etc/nvidia-container-runtime/host-files-for-container.d/drivers.csv"),
+	}
+	// Simulate a system where NVML is not available.
+	opts.nvmllib = &mock.Interface{
+		InitFunc: func() nvml.Return {
+			return nvml.ERROR_LIBRARY_NOT_FOUND
+		},
+	}
+
+	require.NoError(t, c.run(&opts))
+
+	spec, err := cdi.ReadSpec(output, 0)
+	require.NoError(t, err)
+
+	require.Equal(t, "nvidia.com/gpu", spec.Kind)
+
+	var deviceNames []string
+	for _, d := range spec.Devices {
+		deviceNames = append(deviceNames, d.Name)
+	}
+	require.ElementsMatch(t, []string{"0", "all"}, deviceNames)
+
+	var hostPaths []string
+	for _, d := range spec.Devices {
+		for _, dn := range d.ContainerEdits.DeviceNodes {
+			hostPaths = append(hostPaths, dn.HostPath)
+		}
+	}
+	for _, m := range spec.ContainerEdits.Mounts {
+		hostPaths = append(hostPaths, m.HostPath)
+	}
+	require.NotEmpty(t, hostPaths)
+	for _, hostPath := range hostPaths {
+		_, err := os.Stat(hostPath)
+		require.NoError(t, err, "missing host path %v", hostPath)
+	}
+}
+
+func TestSplitOnAnnotation(t *testing.T) {
+	testCases := []struct {
+		description            string
+		input                  deviceSpecs
+		annotation             string
+		expectedInputPostSplit deviceSpecs
+		expectedOutput         map[string][]specs.Device
+	}{
+		{
+			description: "non-matching annotation",
+			input: deviceSpecs{
+				specs.Device{
+					Name: "foo",
+					Annotations: map[string]string{
+						"key": "value",
+					},
+				},
+			},
+			annotation: "not-key",
+			expectedInputPostSplit: deviceSpecs{
+				specs.Device{
+					Name: "foo",
+					Annotations: map[string]string{
+						"key": "value",
+					},
+				},
+			},
+			expectedOutput: map[string][]specs.Device{},
+		},
+		{
+			description: "single annotation present",
+			input: deviceSpecs{
+				specs.Device{
+					Name: "foo",
+					Annotations: map[string]string{
+						"key": "value",
+					},
+				},
+			},
+			annotation: "key",
+			expectedInputPostSplit: deviceSpecs{
+				specs.Device{
+					Name:        "foo",
+					Annotations: map[string]string{},
+				},
+			},
+			expectedOutput: map[string][]specs.Device{
+				"key=value": {
+					{
+						Name:        "foo",
+						Annotations: map[string]string{},
+					},
+				},
+			},
+		},
+		{
+			description: "non-matching annotations are not removed",
+			input: deviceSpecs{
+				specs.Device{
+					Name: "foo",
+					Annotations: map[string]string{
+						"key":     "value",
+						"another": "foo",
+					},
+				},
+			},
+			annotation: "key",
+			expectedInputPostSplit: deviceSpecs{
+				specs.Device{
+					Name:        "foo",
+					Annotations: map[string]string{"another": "foo"},
+				},
+			},
+			expectedOutput: map[string][]specs.Device{
+				"key=value": {
+					{
+						Name:        "foo",
+						Annotations: map[string]string{"another": "foo"},
+					},
+				},
+			},
+		},
+		{
+			description: "duplicated annotations different names",
+			input: func() deviceSpecs {
+				annotations := map[string]string{
+					"key": "value",
+				}
+				return deviceSpecs{
+					{Name: "0", Annotations: annotations},
+					{Name: "first", Annotations: annotations},
+				}
+			}(),
+			annotation: "key",
+			expectedInputPostSplit: deviceSpecs{
+				specs.Device{Name: "0", Annotations: map[string]string{}},
 				specs.Device{Name: "first", Annotations: map[string]string{}},
 			},
 			expectedOutput: map[string][]specs.Device{
@@ -680,3 +1545,122 @@ func TestSplitOnAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshSpecs(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	libDir := filepath.Join(driverRoot, "lib/x86_64-linux-gnu")
+	require.NoError(t, os.MkdirAll(libDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcuda.so.550.54.15"), nil, 0600))
+	devDir := filepath.Join(driverRoot, "dev")
+	require.NoError(t, os.MkdirAll(devDir, 0755))
+	for _, deviceNode := range []string{"nvidia0", "nvidiactl"} {
+		require.NoError(t, os.WriteFile(filepath.Join(devDir, deviceNode), nil, 0600))
+	}
+
+	driverVersion := "550.54.15"
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return driverVersion, nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+	}
+
+	c := command{
+		logger: logger,
+	}
+
+	specDir := t.TempDir()
+	output := filepath.Join(specDir, "nvidia.yaml")
+	opts := options{
+		output:                output,
+		format:                "yaml",
+		mode:                  "nvml",
+		vendor:                "nvidia.com",
+		class:                 "gpu",
+		deviceNameStrategies:  []string{"index"},
+		deviceIDs:             []string{"all"},
+		driverRoot:            driverRoot,
+		nvidiaCDIHookPath:     "/usr/bin/nvidia-cdi-hook",
+		nvidiaCTKHookFallback: true,
+		replicas:              4,
+		withProvenance:        true,
+		nvmllib:               server,
+	}
+	require.NoError(t, c.run(&opts))
+
+	// A spec without provenance annotations is not modified.
+	otherSpec := filepath.Join(specDir, "other.yaml")
+	otherContents := []byte("cdiVersion: 0.5.0\nkind: example.com/device\ndevices:\n- name: \"0\"\n  containerEdits:\n    env:\n    - FOO=bar\n")
+	require.NoError(t, os.WriteFile(otherSpec, otherContents, 0644))
+
+	spec, err := cdi.ReadSpec(output, 0)
+	require.NoError(t, err)
+	require.Equal(t, "550.54.15", spec.Annotations[driverVersionAnnotation])
+	require.Contains(t, spec.Annotations[generatorOptionsAnnotation], `"nvidiaCTKHookFallback":true`)
+	require.Contains(t, spec.Annotations[generatorOptionsAnnotation], `"replicas":4`)
+	require.Equal(t, "4", spec.Annotations[nvcdi.ReplicasAnnotation])
+	require.Contains(t, getMountHostPaths(spec.Spec), filepath.Join(libDir, "libcuda.so.550.54.15"))
+
+	// Simulate a driver upgrade.
+	require.NoError(t, os.Remove(filepath.Join(libDir, "libcuda.so.550.54.15")))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcuda.so.570.86.10"), nil, 0600))
+	driverVersion = "570.86.10"
+
+	refreshOpts := options{
+		refreshDir: specDir,
+		nvmllib:    server,
+	}
+	require.NoError(t, c.validateFlags(nil, &refreshOpts))
+	require.NoError(t, c.run(&refreshOpts))
+
+	refreshed, err := cdi.ReadSpec(output, 0)
+	require.NoError(t, err)
+	require.Equal(t, "nvidia.com/gpu", refreshed.Kind)
+	require.Equal(t, "570.86.10", refreshed.Annotations[driverVersionAnnotation])
+	require.Equal(t, spec.Annotations[generatorOptionsAnnotation], refreshed.Annotations[generatorOptionsAnnotation])
+	require.Equal(t, "4", refreshed.Annotations[nvcdi.ReplicasAnnotation])
+	require.Len(t, refreshed.Devices, len(spec.Devices))
+	require.Equal(t, "0::3", refreshed.Devices[3].Name)
+
+	hostPaths := getMountHostPaths(refreshed.Spec)
+	require.Contains(t, hostPaths, filepath.Join(libDir, "libcuda.so.570.86.10"))
+	require.NotContains(t, hostPaths, filepath.Join(libDir, "libcuda.so.550.54.15"))
+
+	contents, err := os.ReadFile(otherSpec)
+	require.NoError(t, err)
+	require.Equal(t, otherContents, contents)
+}
+
+func getMountHostPaths(s *specs.Spec) []string {
+	var hostPaths []string
+	for _, m := range s.ContainerEdits.Mounts {
+		hostPaths = append(hostPaths, m.HostPath)
+	}
+	return hostPaths
+}
+
+func TestHookEnvFlagIsNotSplitOnCommas(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	var hookEnv []string
+	c := command{logger: logger}.build()
+	c.Before = nil
+	c.Action = func(ctx context.Context, cmd *cli.Command) error {
+		hookEnv = cmd.Value("hook-env").([]string)
+		return nil
+	}
+
+	err := c.Run(context.Background(), []string{"generate", "--hook-env", "FOO=a,b", "--hook-env", "BAR=c"})
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"FOO=a,b", "BAR=c"}, hookEnv)
+}
@@ -0,0 +1,95 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+// generateSpecsFromLegacy generates a spec with a single `all` device that
+// contains the edits that the NVIDIA Container Runtime applies in legacy mode
+// when all devices and capabilities are requested.
+//
+// Note that in legacy mode the driver libraries, binaries, and device nodes
+// are injected by the nvidia-container-runtime-hook (libnvidia-container) and
+// are not included here.
+func (m command) generateSpecsFromLegacy(opts *options) ([]generatedSpecs, error) {
+	d, err := m.newLegacyDiscoverer(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create legacy discoverer: %w", err)
+	}
+
+	containerEdits, err := edits.NewFactory(edits.WithLogger(m.logger)).FromDiscoverer(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create edits from legacy discoverer: %w", err)
+	}
+
+	deviceSpecs := []specs.Device{
+		{
+			Name:           allDeviceName,
+			ContainerEdits: *containerEdits.ContainerEdits,
+		},
+	}
+	return m.newSpecsForClass(opts, specs.ContainerEdits{}, opts.class, "", deviceSpecs)
+}
+
+// newLegacyDiscoverer creates the discoverers that are used by the graphics
+// modifier of the NVIDIA Container Runtime in legacy mode for all devices.
+func (m command) newLegacyDiscoverer(opts *options) (discover.Discover, error) {
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(opts.driverRoot),
+		root.WithDevRoot(opts.devRoot),
+		root.WithLibrarySearchPaths(opts.librarySearchPaths...),
+		root.WithConfigSearchPaths(opts.configSearchPaths...),
+	)
+
+	hookCreator := discover.NewHookCreator(
+		discover.WithNVIDIACDIHookPath(opts.nvidiaCDIHookPath),
+		discover.WithLdconfigPath(opts.ldconfigPath),
+		discover.WithEnv(opts.hookEnv...),
+	)
+
+	mounts, err := discover.NewGraphicsMountsDiscoverer(m.logger, driver, hookCreator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create graphics mounts discoverer: %w", err)
+	}
+
+	devRoot := opts.devRoot
+	if devRoot == "" {
+		devRoot = opts.driverRoot
+	}
+	drmNodes, err := discover.NewDRMNodesDiscoverer(
+		m.logger,
+		image.NewVisibleDevices("all"),
+		devRoot,
+		hookCreator,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DRM nodes discoverer: %w", err)
+	}
+
+	return discover.Merge(drmNodes, mounts), nil
+}
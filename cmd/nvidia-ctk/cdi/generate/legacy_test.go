@@ -0,0 +1,109 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestGenerateSpecsFromLegacy(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	driverRoot := t.TempDir()
+	libDir := filepath.Join(driverRoot, "usr/lib64")
+	require.NoError(t, os.MkdirAll(libDir, 0755))
+	for _, file := range []string{
+		"libcuda.so.123.45.67",
+		"libGLX_nvidia.so.123.45.67",
+		"libEGL_nvidia.so.123.45.67",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(libDir, file), nil, 0600))
+	}
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+	opts := &options{
+		format:            "yaml",
+		mode:              "auto",
+		vendor:            "example.com",
+		class:             "device",
+		driverRoot:        driverRoot,
+		devRoot:           filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-drm"),
+		nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+		deviceIDs:         []string{"all"},
+		fromLegacy:        true,
+	}
+
+	generated, err := c.generateSpecs(opts)
+	require.NoError(t, err)
+	require.Len(t, generated, 1)
+
+	raw := generated[0].Raw()
+	require.Equal(t, "example.com/device", raw.Kind)
+	require.Len(t, raw.Devices, 1)
+	require.Equal(t, "all", raw.Devices[0].Name)
+	edits := raw.Devices[0].ContainerEdits
+
+	d, err := c.newLegacyDiscoverer(opts)
+	require.NoError(t, err)
+
+	legacyDevices, err := d.Devices()
+	require.NoError(t, err)
+	var expectedDeviceNodes []string
+	for _, device := range legacyDevices {
+		expectedDeviceNodes = append(expectedDeviceNodes, device.Path)
+	}
+	var deviceNodes []string
+	for _, dn := range edits.DeviceNodes {
+		deviceNodes = append(deviceNodes, dn.Path)
+	}
+	require.ElementsMatch(t, expectedDeviceNodes, deviceNodes)
+
+	legacyMounts, err := d.Mounts()
+	require.NoError(t, err)
+	var expectedMounts []string
+	for _, m := range legacyMounts {
+		expectedMounts = append(expectedMounts, m.HostPath)
+	}
+	var mounts []string
+	for _, m := range edits.Mounts {
+		mounts = append(mounts, m.HostPath)
+	}
+	require.NotEmpty(t, mounts)
+	require.ElementsMatch(t, expectedMounts, mounts)
+
+	legacyHooks, err := d.Hooks()
+	require.NoError(t, err)
+	require.Len(t, edits.Hooks, len(legacyHooks))
+	for i, hook := range legacyHooks {
+		require.Equal(t, hook.Args, edits.Hooks[i].Args)
+	}
+}
@@ -0,0 +1,184 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+
+	cdiapi "tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// mergeSpecs generates the CDI spec for the kind of the existing spec at the
+// merge-into path and merges the generated devices into it. The vendor and
+// class of the generated spec are taken from the kind of the existing spec.
+// If the specs are generated per device class or driver capability, the
+// classes are derived from the configured class instead and only the vendor
+// is taken from the existing spec.
+//
+// The spec-level container edits of the existing spec are replaced by the
+// generated edits and each existing edit that is not generated is reported.
+// Existing spec-level annotations that are not generated are preserved.
+func (m command) mergeSpecs(opts *options) error {
+	contents, err := os.ReadFile(opts.mergeInto)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	existing, err := cdiapi.ParseSpec(contents)
+	if err != nil {
+		return fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	vendor, class := parser.ParseQualifier(existing.Kind)
+	if vendor == "" {
+		return fmt.Errorf("invalid kind %q in spec", existing.Kind)
+	}
+	kindOpts := *opts
+	kindOpts.vendor = vendor
+	if len(opts.deviceClasses) == 0 && len(opts.driverCapabilities) == 0 {
+		kindOpts.class = class
+	}
+
+	generated, err := m.generateSpecs(&kindOpts)
+	if err != nil {
+		return fmt.Errorf("failed to generate CDI spec: %w", err)
+	}
+
+	for _, g := range generated {
+		if g.Raw().Kind != existing.Kind {
+			continue
+		}
+		g.Raw().Devices = m.mergeDevices(existing.Devices, g.Raw().Devices)
+		m.reportDroppedContainerEdits(existing.ContainerEdits, g.Raw().ContainerEdits)
+		g.Raw().Annotations = m.mergeAnnotations(existing.Annotations, g.Raw().Annotations)
+		if err := g.Interface.Save(opts.mergeInto); err != nil {
+			return err
+		}
+		m.logger.Infof("Merged generated devices into CDI spec %v", opts.mergeInto)
+		return nil
+	}
+	return fmt.Errorf("no spec generated for kind %v", existing.Kind)
+}
+
+// mergeDevices merges the generated devices into the existing devices.
+// Existing devices that are not generated are preserved and existing devices
+// that are also generated are replaced. Devices that are replaced with a
+// different definition are reported as conflicts. Generated devices that do
+// not exist are appended.
+func (m command) mergeDevices(existing []specs.Device, generated []specs.Device) []specs.Device {
+	generatedByName := make(map[string]specs.Device)
+	for _, device := range generated {
+		generatedByName[device.Name] = device
+	}
+
+	var merged []specs.Device
+	seen := make(map[string]bool)
+	for _, device := range existing {
+		seen[device.Name] = true
+		g, ok := generatedByName[device.Name]
+		if !ok {
+			m.logger.Infof("Preserving device %q that was not generated", device.Name)
+			merged = append(merged, device)
+			continue
+		}
+		if !reflect.DeepEqual(device, g) {
+			m.logger.Warningf("Conflicting definitions for device %q; using generated definition", device.Name)
+		}
+		merged = append(merged, g)
+	}
+	for _, device := range generated {
+		if seen[device.Name] {
+			continue
+		}
+		m.logger.Infof("Adding device %q", device.Name)
+		merged = append(merged, device)
+	}
+	return merged
+}
+
+// reportDroppedContainerEdits reports each spec-level container edit of the
+// existing spec that is not included in the generated edits and is therefore
+// dropped by the merge.
+func (m command) reportDroppedContainerEdits(existing specs.ContainerEdits, generated specs.ContainerEdits) {
+	for _, env := range existing.Env {
+		if !containsEqual(generated.Env, env) {
+			m.logger.Warningf("Dropping spec-level environment variable %q that was not generated", env)
+		}
+	}
+	for _, deviceNode := range existing.DeviceNodes {
+		if !containsEqual(generated.DeviceNodes, deviceNode) {
+			m.logger.Warningf("Dropping spec-level device node %q that was not generated", deviceNode.Path)
+		}
+	}
+	for _, netDevice := range existing.NetDevices {
+		if !containsEqual(generated.NetDevices, netDevice) {
+			m.logger.Warningf("Dropping spec-level network device %q that was not generated", netDevice.HostInterfaceName)
+		}
+	}
+	for _, hook := range existing.Hooks {
+		if !containsEqual(generated.Hooks, hook) {
+			m.logger.Warningf("Dropping spec-level %v hook %q that was not generated", hook.HookName, hook.Path)
+		}
+	}
+	for _, mount := range existing.Mounts {
+		if !containsEqual(generated.Mounts, mount) {
+			m.logger.Warningf("Dropping spec-level mount %q that was not generated", mount.ContainerPath)
+		}
+	}
+	if existing.IntelRdt != nil && !reflect.DeepEqual(existing.IntelRdt, generated.IntelRdt) {
+		m.logger.Warningf("Dropping spec-level Intel RDT configuration that was not generated")
+	}
+	for _, gid := range existing.AdditionalGIDs {
+		if !containsEqual(generated.AdditionalGIDs, gid) {
+			m.logger.Warningf("Dropping spec-level additional GID %d that was not generated", gid)
+		}
+	}
+}
+
+// containsEqual checks whether the specified values contain a value that is
+// deeply equal to the specified value.
+func containsEqual[T any](values []T, value T) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeAnnotations merges the generated spec-level annotations into the
+// existing annotations. Existing annotations that are not generated are
+// preserved and existing annotations with a different generated value are
+// reported and updated.
+func (m command) mergeAnnotations(existing map[string]string, generated map[string]string) map[string]string {
+	if len(existing) == 0 {
+		return generated
+	}
+	merged := maps.Clone(existing)
+	for key, value := range generated {
+		if existingValue, ok := existing[key]; ok && existingValue != value {
+			m.logger.Infof("Updating annotation %q from %q to %q", key, existingValue, value)
+		}
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,200 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	"github.com/sirupsen/logrus"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+)
+
+func TestMergeSpecs(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, hook := testlog.NewNullLogger()
+
+	driverRoot := setupMergeDriverRoot(t)
+	libDir := filepath.Join(driverRoot, "lib/x86_64-linux-gnu")
+
+	existingSpec := `cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+annotations:
+  example.com/owner: cluster-admin
+devices:
+- name: "0"
+  containerEdits:
+    env:
+    - STALE=true
+- name: custom
+  containerEdits:
+    env:
+    - CUSTOM=true
+containerEdits:
+  env:
+  - CUSTOM_SPEC_LEVEL=true
+`
+	mergeInto := filepath.Join(t.TempDir(), "nvidia.yaml")
+	require.NoError(t, os.WriteFile(mergeInto, []byte(existingSpec), 0644))
+
+	c := command{
+		logger: logger,
+	}
+	opts := options{
+		mergeInto:            mergeInto,
+		format:               "yaml",
+		mode:                 "nvml",
+		vendor:               "nvidia.com",
+		class:                "gpu",
+		deviceNameStrategies: []string{"index"},
+		deviceIDs:            []string{"all"},
+		driverRoot:           driverRoot,
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+		nvmllib:              newMergeNVMLServer(),
+	}
+	require.NoError(t, c.run(&opts))
+
+	merged, err := cdi.ReadSpec(mergeInto, 0)
+	require.NoError(t, err)
+	require.Equal(t, "nvidia.com/gpu", merged.Kind)
+
+	var deviceNames []string
+	for _, device := range merged.Devices {
+		deviceNames = append(deviceNames, device.Name)
+	}
+	require.Equal(t, []string{"0", "custom", "all"}, deviceNames)
+
+	// The conflicting device is replaced by the generated device.
+	require.Empty(t, merged.Devices[0].ContainerEdits.Env)
+	require.Len(t, merged.Devices[0].ContainerEdits.DeviceNodes, 1)
+	require.Equal(t, "/dev/nvidia0", merged.Devices[0].ContainerEdits.DeviceNodes[0].Path)
+
+	// The hand-added device is preserved.
+	require.Equal(t, []string{"CUSTOM=true"}, merged.Devices[1].ContainerEdits.Env)
+
+	require.Contains(t, getMountHostPaths(merged.Spec), filepath.Join(libDir, "libcuda.so.550.54.15"))
+
+	// The spec-level annotations are preserved.
+	require.Equal(t, "cluster-admin", merged.Annotations["example.com/owner"])
+
+	// The spec-level edit that is not generated is reported.
+	require.NotContains(t, merged.ContainerEdits.Env, "CUSTOM_SPEC_LEVEL=true")
+	var warnings []string
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+	require.Contains(t, warnings, `Dropping spec-level environment variable "CUSTOM_SPEC_LEVEL=true" that was not generated`)
+}
+
+func TestMergeSpecsUsesExistingKind(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	existingSpec := `cdiVersion: 0.5.0
+kind: acme.com/gpu
+devices:
+- name: custom
+  containerEdits:
+    env:
+    - CUSTOM=true
+`
+	mergeInto := filepath.Join(t.TempDir(), "acme.yaml")
+	require.NoError(t, os.WriteFile(mergeInto, []byte(existingSpec), 0644))
+
+	c := command{
+		logger: logger,
+	}
+	// The default vendor and class are replaced by the vendor and class of
+	// the existing spec.
+	opts := options{
+		mergeInto:            mergeInto,
+		format:               "yaml",
+		mode:                 "nvml",
+		vendor:               "nvidia.com",
+		class:                "gpu",
+		deviceNameStrategies: []string{"index"},
+		deviceIDs:            []string{"all"},
+		driverRoot:           setupMergeDriverRoot(t),
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+		nvmllib:              newMergeNVMLServer(),
+	}
+	require.NoError(t, c.run(&opts))
+
+	merged, err := cdi.ReadSpec(mergeInto, 0)
+	require.NoError(t, err)
+	require.Equal(t, "acme.com/gpu", merged.Kind)
+
+	var deviceNames []string
+	for _, device := range merged.Devices {
+		deviceNames = append(deviceNames, device.Name)
+	}
+	require.Equal(t, []string{"custom", "0", "all"}, deviceNames)
+}
+
+// setupMergeDriverRoot creates a driver root with a single GPU.
+func setupMergeDriverRoot(t *testing.T) string {
+	driverRoot := t.TempDir()
+	libDir := filepath.Join(driverRoot, "lib/x86_64-linux-gnu")
+	require.NoError(t, os.MkdirAll(libDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcuda.so.550.54.15"), nil, 0600))
+	devDir := filepath.Join(driverRoot, "dev")
+	require.NoError(t, os.MkdirAll(devDir, 0755))
+	for _, deviceNode := range []string{"nvidia0", "nvidiactl"} {
+		require.NoError(t, os.WriteFile(filepath.Join(devDir, deviceNode), nil, 0600))
+	}
+	return driverRoot
+}
+
+// newMergeNVMLServer returns a mock NVML server with a single GPU.
+func newMergeNVMLServer() *dgxa100.Server {
+	server := dgxa100.New()
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+	}
+	return server
+}
+
+func TestValidateFlagsMergeInto(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	opts := options{
+		mergeInto: "/etc/cdi/nvidia.yaml",
+		output:    "/var/run/cdi/nvidia.yaml",
+		format:    "yaml",
+	}
+	require.EqualError(t, c.validateFlags(nil, &opts), "--merge-into cannot be combined with --output")
+}
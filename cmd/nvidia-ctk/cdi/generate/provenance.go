@@ -0,0 +1,247 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cdiapi "tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+const (
+	// generatorOptionsAnnotation is the spec-level annotation that records the
+	// options used to generate a CDI specification.
+	generatorOptionsAnnotation = "nvidia.com/cdi-generator-options"
+	// driverVersionAnnotation is the spec-level annotation that records the
+	// driver version at the time a CDI specification was generated.
+	driverVersionAnnotation = "nvidia.com/cdi-driver-version"
+)
+
+// provenance records the options used to generate a CDI specification so that
+// the specification can be regenerated, for example after a driver upgrade.
+type provenance struct {
+	Mode                   string   `json:"mode,omitempty"`
+	Vendor                 string   `json:"vendor,omitempty"`
+	Class                  string   `json:"class,omitempty"`
+	DeviceClasses          []string `json:"deviceClasses,omitempty"`
+	GPUType                string   `json:"gpuType,omitempty"`
+	DriverCapabilities     []string `json:"driverCapabilities,omitempty"`
+	DeviceNodePermissions  []string `json:"deviceNodePermissions,omitempty"`
+	Replicas               int      `json:"replicas,omitempty"`
+	DeviceNameStrategies   []string `json:"deviceNameStrategies,omitempty"`
+	DeviceIDs              []string `json:"deviceIDs,omitempty"`
+	DriverRoot             string   `json:"driverRoot,omitempty"`
+	DevRoot                string   `json:"devRoot,omitempty"`
+	ContainerRoot          string   `json:"containerRoot,omitempty"`
+	RelativeTo             string   `json:"relativeTo,omitempty"`
+	NVIDIACDIHookPath      string   `json:"nvidiaCDIHookPath,omitempty"`
+	NVIDIACTKHookFallback  bool     `json:"nvidiaCTKHookFallback"`
+	LdconfigPath           string   `json:"ldconfigPath,omitempty"`
+	SpecVersion            string   `json:"specVersion,omitempty"`
+	ConfigSearchPaths      []string `json:"configSearchPaths,omitempty"`
+	LibrarySearchPaths     []string `json:"librarySearchPaths,omitempty"`
+	LibraryDenylist        []string `json:"libraryDenylist,omitempty"`
+	DisabledHooks          []string `json:"disabledHooks,omitempty"`
+	EnabledHooks           []string `json:"enabledHooks,omitempty"`
+	HookEnv                []string `json:"hookEnv,omitempty"`
+	FeatureFlags           []string `json:"featureFlags,omitempty"`
+	CSVFiles               []string `json:"csvFiles,omitempty"`
+	CSVIgnorePatterns      []string `json:"csvIgnorePatterns,omitempty"`
+	CSVCompatContainerRoot string   `json:"csvCompatContainerRoot,omitempty"`
+	NoAllDevice            bool     `json:"noAllDevice,omitempty"`
+	DevCharPaths           bool     `json:"devCharPaths,omitempty"`
+	WithDigest             bool     `json:"withDigest,omitempty"`
+	FromLegacy             bool     `json:"fromLegacy,omitempty"`
+}
+
+func newProvenance(opts *options) *provenance {
+	return &provenance{
+		Mode:                   opts.mode,
+		Vendor:                 opts.vendor,
+		Class:                  opts.class,
+		DeviceClasses:          opts.deviceClasses,
+		GPUType:                opts.gpuType,
+		DriverCapabilities:     opts.driverCapabilities,
+		DeviceNodePermissions:  opts.deviceNodePermissions,
+		Replicas:               opts.replicas,
+		DeviceNameStrategies:   opts.deviceNameStrategies,
+		DeviceIDs:              opts.deviceIDs,
+		DriverRoot:             opts.driverRoot,
+		DevRoot:                opts.devRoot,
+		ContainerRoot:          opts.containerRoot,
+		RelativeTo:             opts.relativeTo,
+		NVIDIACDIHookPath:      opts.nvidiaCDIHookPath,
+		NVIDIACTKHookFallback:  opts.nvidiaCTKHookFallback,
+		LdconfigPath:           opts.ldconfigPath,
+		SpecVersion:            opts.specVersion,
+		ConfigSearchPaths:      opts.configSearchPaths,
+		LibrarySearchPaths:     opts.librarySearchPaths,
+		LibraryDenylist:        opts.libraryDenylist,
+		DisabledHooks:          opts.disabledHooks,
+		EnabledHooks:           opts.enabledHooks,
+		HookEnv:                opts.hookEnv,
+		FeatureFlags:           opts.featureFlags,
+		CSVFiles:               opts.csv.files,
+		CSVIgnorePatterns:      opts.csv.ignorePatterns,
+		CSVCompatContainerRoot: opts.csv.CompatContainerRoot,
+		NoAllDevice:            opts.noAllDevice,
+		DevCharPaths:           opts.devCharPaths,
+		WithDigest:             opts.withDigest,
+		FromLegacy:             opts.fromLegacy,
+	}
+}
+
+// toOptions returns the generate options for the recorded provenance. The
+// output format is inferred from the specified filename.
+func (p *provenance) toOptions(filename string) *options {
+	opts := &options{
+		format:                formatFromFilename(filename),
+		mode:                  p.Mode,
+		vendor:                p.Vendor,
+		class:                 p.Class,
+		deviceClasses:         p.DeviceClasses,
+		gpuType:               p.GPUType,
+		driverCapabilities:    p.DriverCapabilities,
+		deviceNodePermissions: p.DeviceNodePermissions,
+		replicas:              p.Replicas,
+		deviceNameStrategies:  p.DeviceNameStrategies,
+		deviceIDs:             p.DeviceIDs,
+		driverRoot:            p.DriverRoot,
+		devRoot:               p.DevRoot,
+		containerRoot:         p.ContainerRoot,
+		relativeTo:            p.RelativeTo,
+		nvidiaCDIHookPath:     p.NVIDIACDIHookPath,
+		nvidiaCTKHookFallback: p.NVIDIACTKHookFallback,
+		ldconfigPath:          p.LdconfigPath,
+		specVersion:           p.SpecVersion,
+		configSearchPaths:     p.ConfigSearchPaths,
+		librarySearchPaths:    p.LibrarySearchPaths,
+		libraryDenylist:       p.LibraryDenylist,
+		disabledHooks:         p.DisabledHooks,
+		enabledHooks:          p.EnabledHooks,
+		hookEnv:               p.HookEnv,
+		featureFlags:          p.FeatureFlags,
+		noAllDevice:           p.NoAllDevice,
+		devCharPaths:          p.DevCharPaths,
+		withDigest:            p.WithDigest,
+		withProvenance:        true,
+		fromLegacy:            p.FromLegacy,
+	}
+	opts.csv.files = p.CSVFiles
+	opts.csv.ignorePatterns = p.CSVIgnorePatterns
+	opts.csv.CompatContainerRoot = p.CSVCompatContainerRoot
+	return opts
+}
+
+// getProvenanceAnnotations returns the spec-level annotations that record the
+// options used to generate a spec and the current driver version.
+func (m command) getProvenanceAnnotations(opts *options) (map[string]string, error) {
+	generatorOptions, err := json.Marshal(newProvenance(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal generator options: %w", err)
+	}
+
+	annotations := map[string]string{
+		generatorOptionsAnnotation: string(generatorOptions),
+	}
+
+	driver := root.New(
+		root.WithLogger(m.logger),
+		root.WithDriverRoot(opts.driverRoot),
+		root.WithLibrarySearchPaths(opts.librarySearchPaths...),
+	)
+	version, err := driver.Version()
+	if err != nil {
+		m.logger.Warningf("Could not determine driver version: %v", err)
+	} else {
+		annotations[driverVersionAnnotation] = version
+	}
+
+	return annotations, nil
+}
+
+// refreshSpecs regenerates the CDI specs in the refresh directory in place.
+// Specs that do not include provenance annotations are skipped.
+func (m command) refreshSpecs(opts *options) error {
+	entries, err := os.ReadDir(opts.refreshDir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	var errs error
+	for _, entry := range entries {
+		if entry.IsDir() || formatFromFilename(entry.Name()) == "" {
+			continue
+		}
+		filename := filepath.Join(opts.refreshDir, entry.Name())
+		if err := m.refreshSpec(opts, filename); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to refresh %v: %w", filename, err))
+		}
+	}
+	return errs
+}
+
+// refreshSpec regenerates the specified CDI spec using the options recorded
+// in its provenance annotations and overwrites the existing file.
+func (m command) refreshSpec(opts *options, filename string) error {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	raw, err := cdiapi.ParseSpec(contents)
+	if err != nil {
+		return fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	generatorOptions, ok := raw.Annotations[generatorOptionsAnnotation]
+	if !ok {
+		m.logger.Infof("Skipping %v without provenance annotations", filename)
+		return nil
+	}
+	var p provenance
+	if err := json.Unmarshal([]byte(generatorOptions), &p); err != nil {
+		return fmt.Errorf("failed to parse generator options: %w", err)
+	}
+
+	refreshOpts := p.toOptions(filename)
+	// We propagate the following to allow for dependency injection:
+	refreshOpts.nvmllib = opts.nvmllib
+
+	generated, err := m.generateSpecs(refreshOpts)
+	if err != nil {
+		return fmt.Errorf("failed to generate CDI spec: %w", err)
+	}
+
+	for _, g := range generated {
+		if g.Raw().Kind != raw.Kind {
+			continue
+		}
+		if err := g.Interface.Save(filename); err != nil {
+			return err
+		}
+		m.logger.Infof("Refreshed CDI spec %v", filename)
+		return nil
+	}
+	return fmt.Errorf("no spec generated for kind %v", raw.Kind)
+}
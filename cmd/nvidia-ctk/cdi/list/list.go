@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/urfave/cli/v3"
 	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
@@ -33,6 +35,7 @@ type command struct {
 
 type config struct {
 	cdiSpecDirs []string
+	check       bool
 }
 
 // NewCommand constructs a cdi list command with the specified logger
@@ -65,6 +68,14 @@ func (m command) build() *cli.Command {
 				Destination: &cfg.cdiSpecDirs,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_SPEC_DIRS"),
 			},
+			&cli.BoolFlag{
+				Name: "check",
+				Usage: "check that the device nodes, mounts, and hooks referenced by each listed device exist on the host. " +
+					"Devices that reference missing paths are reported as stale and an error is returned if any are found. " +
+					"This can be used to detect specifications that are out of date after a driver upgrade.",
+				Destination: &cfg.check,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_LIST_CHECK"),
+			},
 		},
 	}
 
@@ -97,9 +108,75 @@ func (m command) run(cfg *config) error {
 
 	devices := registry.ListDevices()
 	m.logger.Infof("Found %d CDI devices", len(devices))
+	var staleDevices []string
 	for _, device := range devices {
 		fmt.Printf("%s\n", device)
+		if !cfg.check {
+			continue
+		}
+		missing := missingPaths(registry.GetDevice(device))
+		if len(missing) == 0 {
+			continue
+		}
+		staleDevices = append(staleDevices, device)
+		for _, path := range missing {
+			m.logger.Warningf("CDI device %v references missing path %v", device, path)
+		}
+	}
+
+	if len(staleDevices) > 0 {
+		return fmt.Errorf("found %d stale CDI devices: %v", len(staleDevices), staleDevices)
 	}
 
 	return nil
 }
+
+// missingPaths returns the host paths referenced by the specified device that
+// do not exist. Both the edits of the device and the common edits of the spec
+// that defines it are considered.
+func missingPaths(device *cdi.Device) []string {
+	if device == nil {
+		return nil
+	}
+	var missing []string
+	for _, path := range hostPaths(&device.ContainerEdits, &device.GetSpec().ContainerEdits) {
+		if _, err := os.Stat(path); err != nil {
+			missing = append(missing, path)
+		}
+	}
+	return missing
+}
+
+// hostPaths returns the unique host paths referenced by the specified edits.
+// This includes the host paths of device nodes and mounts as well as the
+// paths of hook executables.
+func hostPaths(edits ...*specs.ContainerEdits) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if path == "" || seen[path] {
+			return
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	for _, e := range edits {
+		if e == nil {
+			continue
+		}
+		for _, dn := range e.DeviceNodes {
+			if dn.HostPath != "" {
+				add(dn.HostPath)
+			} else {
+				add(dn.Path)
+			}
+		}
+		for _, m := range e.Mounts {
+			add(m.HostPath)
+		}
+		for _, h := range e.Hooks {
+			add(h.Path)
+		}
+	}
+	return paths
+}
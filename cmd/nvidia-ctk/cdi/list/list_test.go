@@ -0,0 +1,96 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCheck(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	hostRoot := t.TempDir()
+	existingLibrary := filepath.Join(hostRoot, "libcuda.so.550.54.15")
+	require.NoError(t, os.WriteFile(existingLibrary, nil, 0600))
+	missingLibrary := filepath.Join(hostRoot, "libcuda.so.535.104.05")
+	existingDeviceNode := filepath.Join(hostRoot, "nvidia0")
+	require.NoError(t, os.WriteFile(existingDeviceNode, nil, 0600))
+
+	testCases := []struct {
+		description   string
+		library       string
+		check         bool
+		expectedError string
+	}{
+		{
+			description: "all paths exist",
+			library:     existingLibrary,
+			check:       true,
+		},
+		{
+			description:   "missing library is reported",
+			library:       missingLibrary,
+			check:         true,
+			expectedError: "found 1 stale CDI devices: [example.com/device=gpu0]",
+		},
+		{
+			description: "missing library is ignored without check",
+			library:     missingLibrary,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			specDir := t.TempDir()
+			spec := `---
+cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+- name: gpu0
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+      hostPath: ` + existingDeviceNode + `
+containerEdits:
+  mounts:
+  - hostPath: ` + tc.library + `
+    containerPath: /usr/lib/libcuda.so
+    options: [ro, nosuid, nodev, bind]
+`
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, "example.yaml"), []byte(spec), 0600))
+
+			c := command{
+				logger: logger,
+			}
+			err := c.run(&config{
+				cdiSpecDirs: []string{specDir},
+				check:       tc.check,
+			})
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -19,14 +19,12 @@ package root
 import (
 	"context"
 	"fmt"
-	"io"
-	"os"
+	"path/filepath"
 
 	"github.com/urfave/cli/v3"
-	"tags.cncf.io/container-device-interface/pkg/cdi"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/flags"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
-	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 	transformroot "github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform/root"
 )
 
@@ -34,16 +32,12 @@ type command struct {
 	logger logger.Interface
 }
 
-type transformOptions struct {
-	input  string
-	output string
-}
-
 type options struct {
-	transformOptions
+	flags.Options
 	from       string
 	to         string
 	relativeTo string
+	anchor     string
 }
 
 // NewCommand constructs a generate-cdi command with the specified logger
@@ -70,6 +64,11 @@ func (m command) build() *cli.Command {
 			return m.run(&opts)
 		},
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "anchor",
+				Usage:       "specify the root to anchor relative host paths to before the root transform is applied. This is used for specs generated with 'nvidia-ctk cdi generate --relative-to'",
+				Destination: &opts.anchor,
+			},
 			&cli.StringFlag{
 				Name:        "from",
 				Usage:       "specify the root to be transformed",
@@ -79,12 +78,12 @@ func (m command) build() *cli.Command {
 				Name:        "input",
 				Usage:       "Specify the file to read the CDI specification from. If this is '-' the specification is read from STDIN",
 				Value:       "-",
-				Destination: &opts.input,
+				Destination: &opts.Input,
 			},
 			&cli.StringFlag{
 				Name:        "output",
 				Usage:       "Specify the file to output the generated CDI specification to. If this is '' the specification is output to STDOUT",
-				Destination: &opts.output,
+				Destination: &opts.Output,
 			},
 			&cli.StringFlag{
 				Name:        "relative-to",
@@ -111,6 +110,9 @@ func (m command) validateFlags(opts *options) error {
 	default:
 		return fmt.Errorf("invalid --relative-to value: %v", opts.relativeTo)
 	}
+	if opts.anchor != "" && !filepath.IsAbs(opts.anchor) {
+		return fmt.Errorf("the anchor root must be an absolute path: %v", opts.anchor)
+	}
 	return nil
 }
 
@@ -120,6 +122,13 @@ func (m command) run(opts *options) error {
 		return fmt.Errorf("failed to load CDI specification: %w", err)
 	}
 
+	if opts.anchor != "" {
+		err := transformroot.NewAnchorTransformer(opts.anchor).Transform(spec.Raw())
+		if err != nil {
+			return fmt.Errorf("failed to anchor relative host paths: %w", err)
+		}
+	}
+
 	err = transformroot.New(
 		transformroot.WithRoot(opts.from),
 		transformroot.WithTargetRoot(opts.to),
@@ -131,41 +140,3 @@ func (m command) run(opts *options) error {
 
 	return opts.Save(spec)
 }
-
-// Load lodas the input CDI specification
-func (o transformOptions) Load() (spec.Interface, error) {
-	contents, err := o.getContents()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read spec contents: %v", err)
-	}
-
-	raw, err := cdi.ParseSpec(contents)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse CDI spec: %v", err)
-	}
-
-	return spec.New(
-		spec.WithRawSpec(raw),
-	)
-}
-
-func (o transformOptions) getContents() ([]byte, error) {
-	if o.input == "-" {
-		return io.ReadAll(os.Stdin)
-	}
-
-	return os.ReadFile(o.input)
-}
-
-// Save saves the CDI specification to the output file
-func (o transformOptions) Save(s spec.Interface) error {
-	if o.output == "" {
-		_, err := s.WriteTo(os.Stdout)
-		if err != nil {
-			return fmt.Errorf("failed to write CDI spec to STDOUT: %v", err)
-		}
-		return nil
-	}
-
-	return s.Save(o.output)
-}
@@ -0,0 +1,99 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package striphooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+)
+
+func TestStripHooks(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	input := `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: "0"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+    hooks:
+    - hookName: createContainer
+      path: /usr/bin/nvidia-cdi-hook
+      args:
+      - nvidia-cdi-hook
+      - create-symlinks
+      - --link
+      - ../card1::/dev/dri/by-path/pci-0000:0f:00.0-card
+containerEdits:
+  deviceNodes:
+  - path: /dev/nvidiactl
+  hooks:
+  - hookName: createContainer
+    path: /usr/bin/nvidia-cdi-hook
+    args:
+    - nvidia-cdi-hook
+    - update-ldcache
+    - --folder
+    - /usr/lib/x86_64-linux-gnu
+  mounts:
+  - hostPath: /usr/lib/x86_64-linux-gnu/libcuda.so.999.88.77
+    containerPath: /usr/lib/x86_64-linux-gnu/libcuda.so.999.88.77
+    options:
+    - ro
+    - nosuid
+    - nodev
+    - rbind
+    - rprivate
+`
+	testDir := t.TempDir()
+	inputPath := filepath.Join(testDir, "input.yaml")
+	outputPath := filepath.Join(testDir, "output.yaml")
+	require.NoError(t, os.WriteFile(inputPath, []byte(input), 0600))
+
+	app := &cli.Command{
+		Name:     "test",
+		Commands: []*cli.Command{NewCommand(logger)},
+	}
+	err := app.Run(context.Background(), []string{"test", "strip-hooks", "--input", inputPath, "--output", outputPath})
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	output, err := cdi.ParseSpec(contents)
+	require.NoError(t, err)
+
+	require.Empty(t, output.ContainerEdits.Hooks)
+	require.Len(t, output.ContainerEdits.Mounts, 1)
+	require.Equal(t, "/usr/lib/x86_64-linux-gnu/libcuda.so.999.88.77", output.ContainerEdits.Mounts[0].ContainerPath)
+	require.Len(t, output.ContainerEdits.DeviceNodes, 1)
+	require.Equal(t, "/dev/nvidiactl", output.ContainerEdits.DeviceNodes[0].Path)
+
+	require.Len(t, output.Devices, 1)
+	require.Empty(t, output.Devices[0].ContainerEdits.Hooks)
+	require.Len(t, output.Devices[0].ContainerEdits.DeviceNodes, 1)
+	require.Equal(t, "/dev/nvidia0", output.Devices[0].ContainerEdits.DeviceNodes[0].Path)
+}
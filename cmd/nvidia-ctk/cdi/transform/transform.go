@@ -20,6 +20,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/root"
+	striphooks "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/strip-hooks"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -42,6 +43,7 @@ func (m command) build() *cli.Command {
 		Usage: "Apply a transform to a CDI specification",
 		Commands: []*cli.Command{
 			root.NewCommand(m.logger),
+			striphooks.NewCommand(m.logger),
 		},
 	}
 
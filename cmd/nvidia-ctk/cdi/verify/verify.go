@@ -0,0 +1,79 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+// NewCommand constructs a cdi verify command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	c := cli.Command{
+		Name:      "verify",
+		Usage:     "Verify that the content digest of a CDI specification matches its contents",
+		ArgsUsage: "<spec>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return errors.New("exactly one CDI specification must be specified")
+			}
+			return m.run(cmd.Args().First())
+		},
+	}
+
+	return &c
+}
+
+func (m command) run(path string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read CDI specification: %w", err)
+	}
+
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		return fmt.Errorf("failed to parse CDI specification: %w", err)
+	}
+
+	if err := spec.VerifyDigest(raw); err != nil {
+		return fmt.Errorf("failed to verify %v: %w", path, err)
+	}
+
+	m.logger.Infof("The digest of %v matches its contents", path)
+	return nil
+}
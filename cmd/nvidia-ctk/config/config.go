@@ -29,6 +29,7 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
 	createdefault "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config/create-default"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config/flags"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config/generate"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -69,6 +70,7 @@ func (m command) build() *cli.Command {
 		},
 		Commands: []*cli.Command{
 			createdefault.NewCommand(m.logger),
+			generate.NewCommand(m.logger),
 		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -0,0 +1,161 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/config/flags"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// modeSections maps each supported runtime mode to the sections of
+// nvidia-container-runtime.modes that are applicable to the mode.
+var modeSections = map[string][]string{
+	"auto":    {"cdi", "csv", "legacy"},
+	"cdi":     {"cdi"},
+	"csv":     {"csv"},
+	"jit-cdi": {"cdi", "jit-cdi"},
+	"legacy":  {"legacy"},
+}
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	flags.Options
+	mode string
+}
+
+// NewCommand constructs a generate command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	// Create the 'generate' command
+	c := cli.Command{
+		Name:  "generate",
+		Usage: "Generate an NVIDIA Container Toolkit configuration file for the specified mode",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "mode",
+				Usage: "Specify the mode of the NVIDIA Container Runtime to generate the config for. " +
+					"One of [" + strings.Join(supportedModes(), " | ") + "]. " +
+					"Only the mode-specific settings that apply to the selected mode are included.",
+				Value:       "auto",
+				Destination: &opts.mode,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "Specify the output file to write to; If not specified, the output is written to stdout",
+				Destination: &opts.Output,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if _, ok := modeSections[opts.mode]; !ok {
+		return fmt.Errorf("invalid mode %q; supported modes are %v", opts.mode, supportedModes())
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	cfgToml, err := generateConfig(opts.mode)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.EnsureOutputFolder(); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+	output, err := opts.CreateOutput()
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %v", err)
+	}
+	defer output.Close()
+
+	if _, err := cfgToml.Save(output); err != nil {
+		return fmt.Errorf("failed to save config: %v", err)
+	}
+
+	return nil
+}
+
+// generateConfig returns the default config with the runtime mode set to the
+// specified mode. Mode-specific settings that do not apply to the mode are
+// removed.
+func generateConfig(mode string) (*config.Toml, error) {
+	cfgToml, err := config.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create default config: %v", err)
+	}
+
+	cfgToml.Set("nvidia-container-runtime.mode", mode)
+	for _, section := range []string{"cdi", "csv", "legacy"} {
+		if slices.Contains(modeSections[mode], section) {
+			continue
+		}
+		if err := cfgToml.Delete("nvidia-container-runtime.modes." + section); err != nil {
+			return nil, fmt.Errorf("failed to remove %v mode settings: %w", section, err)
+		}
+	}
+	if slices.Contains(modeSections[mode], "jit-cdi") {
+		cfgToml.Set("nvidia-container-runtime.modes.jit-cdi.on-no-devices", config.OnNoDevicesControlDevices)
+	}
+
+	if _, err := cfgToml.Config(); err != nil {
+		return nil, fmt.Errorf("generated config is invalid: %w", err)
+	}
+	return cfgToml, nil
+}
+
+// supportedModes returns the sorted list of modes that a config can be
+// generated for.
+func supportedModes() []string {
+	var modes []string
+	for mode := range modeSections {
+		modes = append(modes, mode)
+	}
+	slices.Sort(modes)
+	return modes
+}
@@ -0,0 +1,97 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+)
+
+func TestGenerate(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		mode             string
+		expectedSections []string
+		expectedMissing  []string
+	}{
+		{
+			mode:             "auto",
+			expectedSections: []string{"cdi", "csv", "legacy"},
+			expectedMissing:  []string{"jit-cdi"},
+		},
+		{
+			mode:             "cdi",
+			expectedSections: []string{"cdi"},
+			expectedMissing:  []string{"csv", "jit-cdi", "legacy"},
+		},
+		{
+			mode:             "csv",
+			expectedSections: []string{"csv"},
+			expectedMissing:  []string{"cdi", "jit-cdi", "legacy"},
+		},
+		{
+			mode:             "jit-cdi",
+			expectedSections: []string{"cdi", "jit-cdi"},
+			expectedMissing:  []string{"csv", "legacy"},
+		},
+		{
+			mode:             "legacy",
+			expectedSections: []string{"legacy"},
+			expectedMissing:  []string{"cdi", "csv", "jit-cdi"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			c := command{logger: logger}
+			opts := &options{mode: tc.mode}
+			opts.Output = filepath.Join(t.TempDir(), "config.toml")
+
+			require.NoError(t, c.validateFlags(opts))
+			require.NoError(t, c.run(opts))
+
+			cfgToml, err := config.New(config.WithConfigFile(opts.Output), config.WithRequired(true))
+			require.NoError(t, err)
+
+			cfg, err := cfgToml.Config()
+			require.NoError(t, err)
+			require.Equal(t, tc.mode, cfg.NVIDIAContainerRuntimeConfig.Mode)
+
+			for _, section := range tc.expectedSections {
+				require.NotNil(t, cfgToml.Get("nvidia-container-runtime.modes."+section), section)
+			}
+			for _, section := range tc.expectedMissing {
+				require.Nil(t, cfgToml.Get("nvidia-container-runtime.modes."+section), section)
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidMode(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	c := command{logger: logger}
+	err := c.validateFlags(&options{mode: "management"})
+	require.ErrorContains(t, err, `invalid mode "management"`)
+}
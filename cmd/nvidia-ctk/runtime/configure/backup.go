@@ -0,0 +1,226 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	rawconfig "github.com/NVIDIA/nvidia-container-toolkit/pkg/config"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
+)
+
+const (
+	// backupInfix separates the path of the original config from the
+	// timestamp in the name of a backup file.
+	backupInfix           = ".bak."
+	backupTimestampFormat = "20060102T150405Z"
+)
+
+// getBackupPaths returns the config files that are modified when the config is
+// saved. This is the output path and, for containerd drop-in configs, the
+// top-level config that is updated to import the drop-in file.
+func (c *config) getBackupPaths() []string {
+	outputPath := c.getOutputConfigPath()
+	if outputPath == "" || outputPath == stdioPath {
+		return nil
+	}
+	paths := []string{outputPath}
+	if c.runtime == "containerd" && c.dropInConfigPath != "" && c.outputPath == "" {
+		if topLevelPath := c.topLevelConfigPath(); topLevelPath != "" && topLevelPath != outputPath {
+			paths = append(paths, topLevelPath)
+		}
+	}
+	return paths
+}
+
+// backupConfigs creates a timestamped backup of each of the specified config
+// files. Files that do not exist are skipped.
+func (m command) backupConfigs(paths []string) error {
+	timestamp := time.Now().UTC().Format(backupTimestampFormat)
+	for _, path := range paths {
+		backupPath, err := backupFile(path, timestamp)
+		if err != nil {
+			return fmt.Errorf("failed to back up %v: %w", path, err)
+		}
+		if backupPath == "" {
+			m.logger.Debugf("Skipping backup of non-existent config %v", path)
+			continue
+		}
+		m.logger.Infof("Backed up %v to %v", path, backupPath)
+	}
+	return nil
+}
+
+// backupFile copies the specified file to <path>.bak.<timestamp>. If the file
+// does not exist, no backup is created and an empty path is returned.
+func backupFile(path string, timestamp string) (string, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	backupPath := path + backupInfix + timestamp
+	if err := os.WriteFile(backupPath, contents, info.Mode().Perm()); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// restoreConfig restores a config from the specified backup. The backup must
+// be a valid config for the selected runtime. If a top-level config is
+// restored, the drop-in config is also restored from the backup created at the
+// same time or, if there is no such backup, removed.
+func (m command) restoreConfig(config *config) error {
+	if err := m.restoreBackup(config, config.restorePath); err != nil {
+		return err
+	}
+	if err := m.restoreDropInConfig(config, config.restorePath); err != nil {
+		return err
+	}
+	m.logger.Infof("It is recommended that %v daemon be restarted.", config.runtime)
+	return nil
+}
+
+// restoreDropInConfig restores the drop-in config to its state at the time
+// that the specified backup of a top-level config was created. If the drop-in
+// config was backed up at the same time, the backup is restored. Otherwise the
+// drop-in config did not exist and is removed.
+func (m command) restoreDropInConfig(config *config, backupPath string) error {
+	if config.dropInConfigPath == "" {
+		return nil
+	}
+	originalPath, err := getOriginalPathFromBackup(backupPath)
+	if err != nil {
+		return err
+	}
+	if originalPath == config.dropInConfigPath {
+		return nil
+	}
+
+	dropInBackupPath := config.dropInConfigPath + strings.TrimPrefix(backupPath, originalPath)
+	if _, err := os.Stat(dropInBackupPath); err == nil {
+		return m.restoreBackup(config, dropInBackupPath)
+	}
+
+	if config.dryRun {
+		m.logger.Infof("Would remove drop-in config %v", config.dropInConfigPath)
+		return nil
+	}
+	err = os.Remove(config.dropInConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove drop-in config file: %w", err)
+	}
+	m.logger.Infof("Removed drop-in config %v", config.dropInConfigPath)
+	return nil
+}
+
+// restoreBackup restores the config from which the specified backup was
+// created.
+func (m command) restoreBackup(config *config, backupPath string) error {
+	originalPath, err := getOriginalPathFromBackup(backupPath)
+	if err != nil {
+		return err
+	}
+
+	contents, err := os.ReadFile(backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	if err := validateConfigContents(config.runtime, contents); err != nil {
+		return fmt.Errorf("backup %v is not a valid %v config: %w", backupPath, config.runtime, err)
+	}
+
+	if config.dryRun {
+		m.logger.Infof("Would restore %v to %v", backupPath, originalPath)
+		return nil
+	}
+
+	// The config is written to a temporary file that is renamed into place
+	// so that a partially-restored config is never observed.
+	if _, err := rawconfig.Raw(originalPath).Write(contents); err != nil {
+		return fmt.Errorf("failed to restore config: %w", err)
+	}
+	m.logger.Infof("Restored %v from %v", originalPath, backupPath)
+	return nil
+}
+
+// getLatestBackup returns the most recent backup of the specified config
+// file. If no backup exists, an empty path is returned.
+func getLatestBackup(path string) (string, error) {
+	candidates, err := filepath.Glob(path + backupInfix + "*")
+	if err != nil {
+		return "", err
+	}
+	var latest string
+	for _, candidate := range candidates {
+		if originalPath, err := getOriginalPathFromBackup(candidate); err != nil || originalPath != path {
+			continue
+		}
+		// Since the timestamps have a fixed width, the most recent backup
+		// is the last in lexical order.
+		if candidate > latest {
+			latest = candidate
+		}
+	}
+	return latest, nil
+}
+
+// getOriginalPathFromBackup returns the path of the config from which the
+// specified backup was created.
+func getOriginalPathFromBackup(backupPath string) (string, error) {
+	i := strings.LastIndex(backupPath, backupInfix)
+	if i <= 0 {
+		return "", fmt.Errorf("invalid backup path %q: expected <path>%s<timestamp>", backupPath, backupInfix)
+	}
+	timestamp := backupPath[i+len(backupInfix):]
+	if _, err := time.Parse(backupTimestampFormat, timestamp); err != nil {
+		return "", fmt.Errorf("invalid backup path %q: invalid timestamp %q", backupPath, timestamp)
+	}
+	return backupPath[:i], nil
+}
+
+// validateConfigContents checks whether the specified contents can be parsed
+// as a config for the specified runtime.
+func validateConfigContents(runtime string, contents []byte) error {
+	switch runtime {
+	case "containerd", "crio":
+		_, err := toml.FromString(string(contents)).Load()
+		return err
+	case "docker":
+		var cfg map[string]interface{}
+		return json.Unmarshal(contents, &cfg)
+	default:
+		return fmt.Errorf("unrecognized runtime '%v'", runtime)
+	}
+}
@@ -17,11 +17,16 @@
 package configure
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
 	"path/filepath"
 
 	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
@@ -49,10 +54,25 @@ const (
 	defaultContainerdDropInConfigFilePath = "/etc/containerd/conf.d/99-nvidia.toml"
 	defaultCrioDropInConfigFilePath       = "/etc/crio/conf.d/99-nvidia.toml"
 
+	// The config paths for rootless containerd are relative to the user's
+	// config directory ($XDG_CONFIG_HOME or $HOME/.config).
+	rootlessContainerdConfigFilePath       = "containerd/config.toml"
+	rootlessContainerdDropInConfigFilePath = "containerd/conf.d/99-nvidia.toml"
+	rootlessDockerConfigFilePath           = "docker/daemon.json"
+
+	// rootlessDockerSocket is the name of the socket created by a rootless
+	// Docker daemon in $XDG_RUNTIME_DIR.
+	rootlessDockerSocket = "docker.sock"
+
 	defaultConfigSource = configSourceFile
 	configSourceCommand = "command"
+	configSourceDump    = "dump"
 	configSourceFile    = "file"
 
+	// stdioPath is used to indicate that the config should be read from STDIN
+	// or written to STDOUT.
+	stdioPath = "-"
+
 	// TODO: We may want to spend some time unifying the handling of config
 	// files here with the Setup-Cleanup logic in nvidia-ctk-installer.
 	runtimeSpecificDefault = "RUNTIME_SPECIFIC_DEFAULT"
@@ -75,12 +95,26 @@ func NewCommand(logger logger.Interface) *cli.Command {
 type config struct {
 	dryRun           bool
 	runtime          string
+	all              bool
 	configFilePath   string
 	dropInConfigPath string
-	executablePath   string
-	configSource     string
-	mode             string
-	hookFilePath     string
+	// disableDropIn is set if the use of a drop-in config was explicitly
+	// disabled by specifying an empty drop-in config path or by reading the
+	// config from STDIN. In this case the top-level config is updated
+	// directly.
+	disableDropIn  bool
+	outputPath     string
+	executablePath string
+	configSource   string
+	configDumpPath string
+	mode           string
+	hookFilePath   string
+	rootless       bool
+	backup         bool
+	restorePath    string
+	list           bool
+	undo           bool
+	strict         bool
 
 	nvidiaRuntime struct {
 		name         string
@@ -89,13 +123,26 @@ type config struct {
 		setAsDefault bool
 	}
 
+	// runtimeNames holds the raw NAME[:mode=MODE] values of the
+	// --runtime-name flag. These are parsed into runtimes.
+	runtimeNames []string
+	runtimes     []namedRuntime
+
 	// cdi-specific options
 	cdi struct {
-		enabled bool
+		enabled           bool
+		enableAnnotations bool
 	}
+
+	// stdin and stdout are used if the config is read from or written to '-'.
+	stdin  io.Reader
+	stdout io.Writer
 }
 
 func (m command) build() *cli.Command {
+	// configs holds the validated configs for the runtime engines that are
+	// configured.
+	var configs []*config
 	// Create a config struct to hold the parsed environment variables or command line flags
 	config := config{}
 
@@ -104,10 +151,19 @@ func (m command) build() *cli.Command {
 		Name:  "configure",
 		Usage: "Add a runtime to the specified container engine",
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
-			return ctx, m.validateFlags(&config)
+			var err error
+			configs, err = m.resolveRuntimeConfigs(&config)
+			return ctx, err
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			return m.configureWrapper(&config)
+			for _, c := range configs {
+				c.stdin = cmd.Root().Reader
+				c.stdout = cmd.Root().Writer
+				if err := m.configureWrapper(c); err != nil {
+					return err
+				}
+			}
+			return nil
 		},
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -117,13 +173,18 @@ func (m command) build() *cli.Command {
 			},
 			&cli.StringFlag{
 				Name:        "runtime",
-				Usage:       "the target runtime engine; one of [containerd, crio, docker]",
+				Usage:       "the target runtime engine; one of [auto, containerd, crio, docker]. If auto is specified, the installed runtime engine is detected from the presence of its socket or config file",
 				Value:       defaultRuntime,
 				Destination: &config.runtime,
 			},
+			&cli.BoolFlag{
+				Name:        "all",
+				Usage:       "configure all detected runtime engines instead of only the preferred one. This is only supported if --runtime=auto is specified",
+				Destination: &config.all,
+			},
 			&cli.StringFlag{
 				Name:        "config",
-				Usage:       "path to the config file for the target runtime",
+				Usage:       "path to the config file for the target runtime. If this is '-' the config is read from STDIN",
 				Destination: &config.configFilePath,
 			},
 			&cli.StringFlag{
@@ -132,6 +193,36 @@ func (m command) build() *cli.Command {
 				Value:       runtimeSpecificDefault,
 				Destination: &config.dropInConfigPath,
 			},
+			&cli.BoolFlag{
+				Name:        "rootless",
+				Usage:       "configure a rootless runtime. The default config paths are resolved relative to $XDG_CONFIG_HOME (or $HOME/.config) and the systemd cgroup driver is disabled for the added runtimes. For containerd, the default NVIDIA runtime executable is resolved using the PATH of the current user. This is only supported for containerd and docker. For docker, rootless mode is detected automatically if no config path is specified",
+				Destination: &config.rootless,
+			},
+			&cli.BoolFlag{
+				Name:        "backup",
+				Usage:       "create a timestamped backup (<path>.bak.<timestamp>) of each config file before it is modified",
+				Destination: &config.backup,
+			},
+			&cli.StringFlag{
+				Name:        "restore",
+				Usage:       "restore a config from the specified backup created using --backup instead of adding a runtime. The backup is checked to be a valid config for the target runtime before it is restored. If a top-level config is restored, the drop-in config is restored from the backup created at the same time or removed if there is no such backup",
+				Destination: &config.restorePath,
+			},
+			&cli.BoolFlag{
+				Name:        "undo",
+				Usage:       "reverse a prior configure instead of adding a runtime. The NVIDIA runtimes are removed and, if --cdi.enabled is specified, CDI is disabled. The same flags as for the prior configure should be specified. If backups created using --backup exist, the most recent backup of each config file is restored",
+				Destination: &config.undo,
+			},
+			&cli.BoolFlag{
+				Name:        "list",
+				Usage:       "list the runtimes that are configured for the target runtime instead of adding a runtime. The name, binary path, type, and whether each runtime is the default are shown",
+				Destination: &config.list,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "path to write the updated config to instead of the drop-in or top-level config file. If this is '-' the config is written to STDOUT",
+				Destination: &config.outputPath,
+			},
 			&cli.StringFlag{
 				Name:        "executable-path",
 				Usage:       "The path to the runtime executable. This is used to extract the current config",
@@ -144,10 +235,15 @@ func (m command) build() *cli.Command {
 			},
 			&cli.StringFlag{
 				Name:        "config-source",
-				Usage:       "the source to retrieve the container runtime configuration; one of [command, file]\"",
+				Usage:       "the source to retrieve the container runtime configuration; one of [command, dump, file]\"",
 				Destination: &config.configSource,
 				Value:       defaultConfigSource,
 			},
+			&cli.StringFlag{
+				Name:        "config-dump-path",
+				Usage:       "the path to the output of 'containerd config dump' to use as the source config if --config-source=dump is specified. If this is '-' the output is read from STDIN",
+				Destination: &config.configDumpPath,
+			},
 			&cli.StringFlag{
 				Name:        "oci-hook-path",
 				Usage:       "the path to the OCI runtime hook to create if --config-mode=oci-hook is specified. If no path is specified, the generated hook is output to STDOUT.\n\tNote: The use of OCI hooks is deprecated.",
@@ -159,6 +255,11 @@ func (m command) build() *cli.Command {
 				Value:       defaultNVIDIARuntimeName,
 				Destination: &config.nvidiaRuntime.name,
 			},
+			&cli.StringSliceFlag{
+				Name:        "runtime-name",
+				Usage:       "specify a NAME[:mode=MODE] for an NVIDIA runtime to add. This can be repeated to add multiple runtime variants in a single config update and overrides --nvidia-runtime-name. MODE is one of [auto, cdi, legacy] and selects the runtime executable (e.g. nvidia-container-runtime.cdi for cdi). If --set-as-default is specified, the first runtime is set as the default",
+				Destination: &config.runtimeNames,
+			},
 			&cli.StringFlag{
 				Name:        "nvidia-runtime-path",
 				Aliases:     []string{"runtime-path"},
@@ -178,12 +279,23 @@ func (m command) build() *cli.Command {
 				Usage:       "set the NVIDIA runtime as the default runtime",
 				Destination: &config.nvidiaRuntime.setAsDefault,
 			},
+			&cli.BoolFlag{
+				Name:        "strict",
+				Usage:       "fail instead of warning if a configured NVIDIA runtime executable does not exist",
+				Destination: &config.strict,
+			},
 			&cli.BoolFlag{
 				Name:        "cdi.enabled",
 				Aliases:     []string{"cdi.enable", "enable-cdi"},
 				Usage:       "Enable CDI in the configured runtime",
 				Destination: &config.cdi.enabled,
 			},
+			&cli.BoolFlag{
+				Name:        "enable-cdi-annotations",
+				Aliases:     []string{"cdi.annotations"},
+				Usage:       "Enable CDI in the configured runtime and allow CDI device annotations (" + cdi.AnnotationPrefix + "*) to be passed to it. This is only supported for containerd and crio",
+				Destination: &config.cdi.enableAnnotations,
+			},
 		},
 	}
 
@@ -210,6 +322,10 @@ func (m command) validateFlags(config *config) error {
 		return fmt.Errorf("unrecognized runtime '%v'", config.runtime)
 	}
 
+	if config.rootless && config.runtime == "containerd" && config.nvidiaRuntime.path == defaultNVIDIARuntimeExecutable {
+		config.nvidiaRuntime.path = resolveUserRuntimePath(config.nvidiaRuntime.path)
+	}
+
 	switch config.runtime {
 	case "containerd", "crio":
 		if config.nvidiaRuntime.path == defaultNVIDIARuntimeExecutable {
@@ -220,6 +336,21 @@ func (m command) validateFlags(config *config) error {
 		}
 	}
 
+	runtimes, err := parseRuntimeNames(config.runtimeNames)
+	if err != nil {
+		return err
+	}
+	config.runtimes = runtimes
+
+	if config.cdi.enableAnnotations {
+		if config.runtime != "containerd" && config.runtime != "crio" {
+			return fmt.Errorf("the enable-cdi-annotations flag is not supported for %v", config.runtime)
+		}
+		if config.runtime == "containerd" {
+			config.cdi.enabled = true
+		}
+	}
+
 	if config.runtime != "containerd" && config.runtime != "docker" {
 		if config.cdi.enabled {
 			m.logger.Warningf("Ignoring cdi.enabled flag for %v", config.runtime)
@@ -238,12 +369,64 @@ func (m command) validateFlags(config *config) error {
 			m.logger.Warningf("A %v Config Source is not supported for %v; using %v", config.configSource, config.runtime, configSourceFile)
 			config.configSource = configSourceFile
 		}
+	case configSourceDump:
+		if config.runtime != "containerd" {
+			return fmt.Errorf("a %v config source is not supported for %v", config.configSource, config.runtime)
+		}
+		if config.configDumpPath == "" {
+			return fmt.Errorf("a config dump path is required for a %v config source", config.configSource)
+		}
+		if config.configDumpPath == stdioPath && config.configFilePath == stdioPath {
+			return fmt.Errorf("the config and the config dump cannot both be read from STDIN")
+		}
 	case configSourceFile:
 		break
 	default:
 		return fmt.Errorf("unrecognized Config Source: %v", config.configSource)
 	}
 
+	if config.configDumpPath != "" && config.configSource != configSourceDump {
+		m.logger.Warningf("Ignoring config-dump-path=%q flag for config source %v", config.configDumpPath, config.configSource)
+		config.configDumpPath = ""
+	}
+
+	if config.list && config.restorePath != "" {
+		return fmt.Errorf("the list and restore flags cannot be specified together")
+	}
+	if config.undo && (config.list || config.restorePath != "") {
+		return fmt.Errorf("the undo flag cannot be specified together with the list or restore flags")
+	}
+
+	if config.rootless && config.runtime != "containerd" && config.runtime != "docker" {
+		return fmt.Errorf("rootless mode is not supported for runtime %v", config.runtime)
+	}
+
+	if !config.rootless && config.runtime == "docker" && config.configFilePath == "" && isRootlessDocker() {
+		m.logger.Infof("Detected a rootless docker daemon; using rootless config paths")
+		config.rootless = true
+	}
+
+	config.disableDropIn = config.dropInConfigPath == ""
+	if config.configFilePath == stdioPath {
+		if config.configSource != configSourceFile {
+			return fmt.Errorf("reading the config from STDIN is not supported for config source %v", config.configSource)
+		}
+		if config.dropInConfigPath != runtimeSpecificDefault && config.dropInConfigPath != "" {
+			return fmt.Errorf("drop-in configs are not supported when reading the config from STDIN")
+		}
+		config.dropInConfigPath = ""
+		config.disableDropIn = true
+		if config.outputPath == "" {
+			config.outputPath = stdioPath
+		}
+	}
+
+	if config.rootless {
+		if err := config.resolveRootlessConfigPaths(); err != nil {
+			return err
+		}
+	}
+
 	if config.configFilePath == "" {
 		switch config.runtime {
 		case "containerd":
@@ -279,6 +462,15 @@ func (m command) validateFlags(config *config) error {
 
 // configureWrapper updates the specified container engine config to enable the NVIDIA runtime
 func (m command) configureWrapper(config *config) error {
+	if config.list {
+		return m.listRuntimes(config)
+	}
+	if config.restorePath != "" {
+		return m.restoreConfig(config)
+	}
+	if config.undo {
+		return m.undoConfigure(config)
+	}
 	switch config.mode {
 	case "oci-hook", "hook":
 		return m.configureOCIHook(config)
@@ -290,80 +482,279 @@ func (m command) configureWrapper(config *config) error {
 
 // configureConfigFile updates the specified container engine config file to enable the NVIDIA runtime.
 func (m command) configureConfigFile(config *config) error {
-	configSource, err := config.resolveConfigSource()
+	cfg, err := m.loadConfig(config)
 	if err != nil {
 		return err
 	}
 
+	runtimes := config.getNVIDIARuntimes()
+	if err := m.checkRuntimeExecutables(runtimes, config.strict); err != nil {
+		return err
+	}
+
+	for _, runtime := range runtimes {
+		err = cfg.AddRuntime(
+			runtime.name,
+			runtime.path,
+			runtime.setAsDefault,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to update config for runtime %v: %v", runtime.name, err)
+		}
+	}
+
+	// A rootless runtime cannot use the systemd cgroup driver since it does
+	// not have access to the system instance of systemd.
+	if config.rootless {
+		for _, runtime := range runtimes {
+			if err := cfg.SetSystemdCgroup(runtime.name, false); err != nil {
+				return fmt.Errorf("unable to disable the systemd cgroup driver for runtime %v: %v", runtime.name, err)
+			}
+		}
+	}
+
+	if config.cdi.enabled {
+		cfg.EnableCDI()
+	}
+
+	return m.saveConfig(config, cfg, config.backup)
+}
+
+// saveConfig writes the updated config to the configured output path. If
+// backup is specified, the config files are backed up before they are
+// overwritten. No backups are created if the config is written to STDOUT.
+func (m command) saveConfig(config *config, cfg engine.Interface, backup bool) error {
+	outputPath := config.getOutputConfigPath()
+	if outputPath == stdioPath {
+		if _, err := cfg.WriteTo(config.stdout); err != nil {
+			return fmt.Errorf("unable to write config to STDOUT: %v", err)
+		}
+		return nil
+	}
+
+	if backup && outputPath != engine.SaveToSTDOUT {
+		if err := m.backupConfigs(config.getBackupPaths()); err != nil {
+			return err
+		}
+	}
+
+	n, err := cfg.Save(outputPath)
+	if err != nil {
+		return fmt.Errorf("unable to flush config: %v", err)
+	}
+
+	if outputPath != "" {
+		if n == 0 {
+			m.logger.Infof("Removed empty config from %v", outputPath)
+		} else {
+			m.logger.Infof("Wrote updated config to %v", outputPath)
+		}
+		if config.rootless {
+			m.logger.Infof("It is recommended that the rootless %v daemon be restarted (e.g. using 'systemctl --user restart %v').", config.runtime, config.runtime)
+			m.logger.Infof("Note that the NVIDIA Container Runtime must be configured with 'nvidia-container-cli.no-cgroups = true' to run rootless containers.")
+		} else {
+			m.logger.Infof("It is recommended that %v daemon be restarted.", config.runtime)
+		}
+	}
+
+	return nil
+}
+
+// loadConfig loads the config for the target runtime from the configured
+// config source.
+func (m command) loadConfig(config *config) (engine.Interface, error) {
+	var configContents []byte
+	if config.configFilePath == stdioPath || config.configDumpPath == stdioPath {
+		contents, err := io.ReadAll(config.stdin)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read config from STDIN: %v", err)
+		}
+		configContents = contents
+	}
+
+	configSource, err := config.resolveConfigSource(configContents)
+	if err != nil {
+		return nil, err
+	}
+
 	var cfg engine.Interface
 	switch config.runtime {
 	case "containerd":
 		cfg, err = containerd.New(
 			containerd.WithLogger(m.logger),
-			containerd.WithTopLevelConfigPath(config.configFilePath),
+			containerd.WithTopLevelConfigPath(config.topLevelConfigPath()),
 			containerd.WithConfigSource(configSource),
+			containerd.WithDisableDropIn(config.disableDropIn),
+			containerd.WithContainerAnnotations(config.cdiAnnotations()...),
 		)
 	case "crio":
-		cfg, err = crio.New(
+		options := []crio.Option{
 			crio.WithLogger(m.logger),
-			crio.WithTopLevelConfigPath(config.configFilePath),
+			crio.WithTopLevelConfigPath(config.topLevelConfigPath()),
 			crio.WithConfigSource(configSource),
-		)
+			crio.WithAllowedAnnotations(config.cdiAnnotations()...),
+		}
+		if config.disableDropIn {
+			options = append(options, crio.WithConfigDestination(config.resolveConfigDestination(configContents)))
+		}
+		cfg, err = crio.New(options...)
 	case "docker":
-		cfg, err = docker.New(
+		options := []docker.Option{
 			docker.WithLogger(m.logger),
-			docker.WithPath(config.configFilePath),
-		)
+			docker.WithPath(config.topLevelConfigPath()),
+		}
+		if config.configFilePath == stdioPath {
+			options = append(options, docker.WithConfigSource(bytes.NewReader(configContents)))
+		}
+		cfg, err = docker.New(options...)
 	default:
 		err = fmt.Errorf("unrecognized runtime '%v'", config.runtime)
 	}
 	if err != nil || cfg == nil {
-		return fmt.Errorf("unable to load config for runtime %v: %v", config.runtime, err)
+		return nil, fmt.Errorf("unable to load config for runtime %v: %v", config.runtime, err)
 	}
+	return cfg, nil
+}
 
-	err = cfg.AddRuntime(
-		config.nvidiaRuntime.name,
-		config.nvidiaRuntime.path,
-		config.nvidiaRuntime.setAsDefault,
-	)
-	if err != nil {
-		return fmt.Errorf("unable to update config: %v", err)
+// cdiAnnotations returns the annotations that are to be passed to the
+// configured NVIDIA runtimes if CDI annotations are enabled.
+func (c *config) cdiAnnotations() []string {
+	if !c.cdi.enableAnnotations {
+		return nil
 	}
+	return []string{cdi.AnnotationPrefix + "*"}
+}
 
-	if config.cdi.enabled {
-		cfg.EnableCDI()
+// resolveRootlessConfigPaths sets the default config and drop-in config paths
+// for a rootless runtime. These are located in the user's config directory.
+// Paths that have been explicitly specified are not updated.
+func (c *config) resolveRootlessConfigPaths() error {
+	var configFilePath, dropInConfigPath string
+	switch c.runtime {
+	case "containerd":
+		configFilePath = rootlessContainerdConfigFilePath
+		dropInConfigPath = rootlessContainerdDropInConfigFilePath
+	case "docker":
+		// Docker does not support drop-in config files.
+		configFilePath = rootlessDockerConfigFilePath
 	}
 
-	outputPath := config.getOutputConfigPath()
-	n, err := cfg.Save(outputPath)
-	if err != nil {
-		return fmt.Errorf("unable to flush config: %v", err)
+	resolveConfigFilePath := c.configFilePath == ""
+	resolveDropInConfigPath := c.dropInConfigPath == runtimeSpecificDefault && dropInConfigPath != ""
+	if !resolveConfigFilePath && !resolveDropInConfigPath {
+		return nil
 	}
 
-	if outputPath != "" {
-		if n == 0 {
-			m.logger.Infof("Removed empty config from %v", outputPath)
-		} else {
-			m.logger.Infof("Wrote updated config to %v", outputPath)
-		}
-		m.logger.Infof("It is recommended that %v daemon be restarted.", config.runtime)
+	userConfigDir, err := getUserConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine the user config directory for rootless mode: %w", err)
 	}
 
+	if resolveConfigFilePath {
+		c.configFilePath = filepath.Join(userConfigDir, configFilePath)
+	}
+	if resolveDropInConfigPath {
+		c.dropInConfigPath = filepath.Join(userConfigDir, dropInConfigPath)
+	}
 	return nil
 }
 
-// resolveConfigSource returns the default config source or the user provided config source
-func (c *config) resolveConfigSource() (toml.Loader, error) {
+// isRootlessDocker checks whether the current user is running a rootless
+// Docker daemon. This is the case if the user is not root and the rootless
+// Docker socket exists in $XDG_RUNTIME_DIR.
+func isRootlessDocker() bool {
+	if geteuid() == 0 {
+		return false
+	}
+	xdgRuntimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if xdgRuntimeDir == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(xdgRuntimeDir, rootlessDockerSocket))
+	return err == nil
+}
+
+// resolveUserRuntimePath resolves the specified NVIDIA runtime executable using
+// the PATH of the current user. This allows a runtime that is installed for
+// the user (e.g. in $HOME/.local/bin) to be used by a rootless runtime. If the
+// executable is not found, the system-wide default path is returned.
+func resolveUserRuntimePath(executable string) string {
+	path, err := lookPath(executable)
+	if err != nil || !filepath.IsAbs(path) {
+		return defaultNVIDIARuntimeExpecutablePath
+	}
+	return path
+}
+
+// lookPath searches for an executable in the PATH of the current user.
+// We use a function variable here to allow this to be overridden for testing.
+var lookPath = exec.LookPath
+
+// geteuid returns the effective user ID of the caller.
+// We use a function variable here to allow this to be overridden for testing.
+var geteuid = os.Geteuid
+
+// getUserConfigDir returns the config directory for the current user. This is
+// $XDG_CONFIG_HOME if set, otherwise $HOME/.config.
+func getUserConfigDir() (string, error) {
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+		if !filepath.IsAbs(xdgConfigHome) {
+			return "", fmt.Errorf("XDG_CONFIG_HOME=%q is not an absolute path", xdgConfigHome)
+		}
+		return xdgConfigHome, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config"), nil
+}
+
+// resolveConfigSource returns the default config source or the user provided config source.
+// If the config (or config dump) is read from STDIN, the specified contents are used.
+func (c *config) resolveConfigSource(configContents []byte) (toml.Loader, error) {
 	switch c.configSource {
 	case configSourceCommand:
 		return c.getCommandConfigSource(), nil
+	case configSourceDump:
+		if c.configDumpPath == stdioPath {
+			return toml.FromString(string(configContents)), nil
+		}
+		// A missing file is treated as an empty config by the file source.
+		// Since the dump is expected to contain the full config, this is an
+		// error instead.
+		if _, err := os.Stat(c.configDumpPath); err != nil {
+			return nil, fmt.Errorf("unable to read config dump: %w", err)
+		}
+		return toml.FromFile(c.configDumpPath), nil
 	case configSourceFile:
+		if c.configFilePath == stdioPath {
+			return toml.FromString(string(configContents)), nil
+		}
 		return toml.FromFile(c.configFilePath), nil
 	default:
 		return nil, fmt.Errorf("unrecognized config source: %s", c.configSource)
 	}
 }
 
+// resolveConfigDestination returns the loader for the config that is updated
+// when the use of a drop-in config is explicitly disabled.
+func (c *config) resolveConfigDestination(configContents []byte) toml.Loader {
+	if c.configFilePath == stdioPath {
+		return toml.FromString(string(configContents))
+	}
+	return toml.FromFile(c.configFilePath)
+}
+
+// topLevelConfigPath returns the path to the top-level config file.
+// If the config is read from STDIN, no path is returned.
+func (c *config) topLevelConfigPath() string {
+	if c.configFilePath == stdioPath {
+		return ""
+	}
+	return c.configFilePath
+}
+
 // getConfigSourceCommand returns the default cli command to fetch the current runtime config
 func (c *config) getCommandConfigSource() toml.Loader {
 	switch c.runtime {
@@ -380,6 +771,9 @@ func (c *config) getOutputConfigPath() string {
 	if c.dryRun {
 		return engine.SaveToSTDOUT
 	}
+	if c.outputPath != "" {
+		return c.outputPath
+	}
 	if c.dropInConfigPath != "" {
 		return c.dropInConfigPath
 	}
@@ -17,8 +17,10 @@
 package configure
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -641,7 +643,1310 @@ fi
 	}
 }
 
+// sampleContainerdConfigDump is a (truncated) sample of the output of
+// containerd config dump for a host where the on-disk config only sets the
+// version.
+const sampleContainerdConfigDump = `version = 2
+root = "/var/lib/containerd"
+state = "/run/containerd"
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    sandbox_image = "registry.k8s.io/pause:3.8"
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "runc"
+      snapshotter = "overlayfs"
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+          [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+            BinaryName = ""
+            SystemdCgroup = true
+`
+
+func TestConfigureConfigDump(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	// The SystemdCgroup setting of the default runtime is only included in
+	// the config dump and not in the on-disk config.
+	expectedNvidiaOptions := `[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+            BinaryName = "/usr/bin/nvidia-container-runtime"
+            SystemdCgroup = true`
+
+	testCases := []struct {
+		description        string
+		args               []string
+		input              string
+		writeDump          bool
+		expectedError      string
+		expectedDropInPart string
+	}{
+		{
+			description: "config dump from file",
+			args: []string{
+				"--config-source", "dump",
+				"--config-dump-path", "{{ .testRoot }}/containerd-config-dump.toml",
+			},
+			writeDump:          true,
+			expectedDropInPart: expectedNvidiaOptions,
+		},
+		{
+			description: "config dump from STDIN",
+			args: []string{
+				"--config-source", "dump",
+				"--config-dump-path", "-",
+			},
+			input:              sampleContainerdConfigDump,
+			expectedDropInPart: expectedNvidiaOptions,
+		},
+		{
+			description: "missing config dump is an error",
+			args: []string{
+				"--config-source", "dump",
+				"--config-dump-path", "{{ .testRoot }}/containerd-config-dump.toml",
+			},
+			expectedError: "unable to read config dump",
+		},
+		{
+			description: "config dump path is required",
+			args: []string{
+				"--config-source", "dump",
+			},
+			expectedError: "a config dump path is required for a dump config source",
+		},
+		{
+			description: "config dump is not supported for crio",
+			args: []string{
+				"--runtime", "crio",
+				"--config-source", "dump",
+				"--config-dump-path", "{{ .testRoot }}/containerd-config-dump.toml",
+			},
+			expectedError: "a dump config source is not supported for crio",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			testRoot := t.TempDir()
+
+			configPath := filepath.Join(testRoot, "etc/containerd/config.toml")
+			require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+			require.NoError(t, os.WriteFile(configPath, []byte("version = 2\n"), 0644)) //nolint:gosec
+			if tc.writeDump {
+				require.NoError(t, os.WriteFile(filepath.Join(testRoot, "containerd-config-dump.toml"), []byte(sampleContainerdConfigDump), 0644)) //nolint:gosec
+			}
+
+			args := []string{
+				"test", "configure",
+				"--runtime", "containerd",
+				"--config", configPath,
+				"--drop-in-config", filepath.Join(testRoot, "etc/containerd/conf.d/99-nvidia.toml"),
+			}
+			for _, arg := range tc.args {
+				args = append(args, strings.ReplaceAll(arg, "{{ .testRoot }}", testRoot))
+			}
+
+			app := &cli.Command{
+				Name:     "test",
+				Reader:   strings.NewReader(tc.input),
+				Writer:   &bytes.Buffer{},
+				Commands: []*cli.Command{NewCommand(logger)},
+			}
+
+			err := app.Run(context.Background(), args)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			dropIn, err := os.ReadFile(filepath.Join(testRoot, "etc/containerd/conf.d/99-nvidia.toml"))
+			require.NoError(t, err)
+			require.Contains(t, string(dropIn), tc.expectedDropInPart)
+		})
+	}
+}
+
+// TestConfigureStdio tests reading the config from STDIN and writing it to STDOUT
+func TestConfigureStdio(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		args           []string
+		input          string
+		expectedError  error
+		expectedOutput string
+	}{
+		{
+			description: "containerd: existing config",
+			args: []string{
+				"--runtime", "containerd",
+				"--config", "-",
+				"--nvidia-set-as-default",
+			},
+			input: `version = 2
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "runc"
+
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+  [plugins."io.containerd.internal.v1.opt"]
+    path = "/opt/containerd"
+`,
+			expectedOutput: `version = 2
+
+[plugins]
+
+  [plugins."io.containerd.grpc.v1.cri"]
+
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "nvidia"
+
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+          runtime_type = "io.containerd.runc.v2"
+
+          [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+            BinaryName = "/usr/bin/nvidia-container-runtime"
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+
+  [plugins."io.containerd.internal.v1.opt"]
+    path = "/opt/containerd"
+`,
+		},
+		{
+			description: "containerd: multiple named runtimes",
+			args: []string{
+				"--runtime", "containerd",
+				"--config", "-",
+				"--set-as-default",
+				"--runtime-name", "nvidia",
+				"--runtime-name", "nvidia-cdi:mode=cdi",
+				"--runtime-name", "nvidia-legacy:mode=legacy",
+			},
+			input: `version = 2
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "runc"
+
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+`,
+			expectedOutput: `version = 2
+
+[plugins]
+
+  [plugins."io.containerd.grpc.v1.cri"]
+
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "nvidia"
+
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+          runtime_type = "io.containerd.runc.v2"
+
+          [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+            BinaryName = "/usr/bin/nvidia-container-runtime"
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia-cdi]
+          runtime_type = "io.containerd.runc.v2"
+
+          [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia-cdi.options]
+            BinaryName = "/usr/bin/nvidia-container-runtime.cdi"
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia-legacy]
+          runtime_type = "io.containerd.runc.v2"
+
+          [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia-legacy.options]
+            BinaryName = "/usr/bin/nvidia-container-runtime.legacy"
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+`,
+		},
+		{
+			description: "containerd: unsupported runtime mode",
+			args: []string{
+				"--runtime", "containerd",
+				"--config", "-",
+				"--runtime-name", "nvidia-csv:mode=csv",
+			},
+			expectedError: fmt.Errorf(`invalid runtime name "nvidia-csv:mode=csv": unsupported mode "csv"`),
+		},
+		{
+			description: "containerd: duplicate runtime names",
+			args: []string{
+				"--runtime", "containerd",
+				"--config", "-",
+				"--runtime-name", "nvidia",
+				"--runtime-name", "nvidia:mode=cdi",
+			},
+			expectedError: fmt.Errorf(`duplicate runtime name "nvidia"`),
+		},
+		{
+			description: "crio: existing config",
+			args: []string{
+				"--runtime", "crio",
+				"--config", "-",
+				"--output", "-",
+			},
+			input: `[crio.image]
+signature_policy = "/etc/crio/policy.json"
+
+[crio.runtime.runtimes.runc]
+runtime_path = "/usr/libexec/crio/runc"
+`,
+			expectedOutput: `
+[crio]
+
+  [crio.image]
+    signature_policy = "/etc/crio/policy.json"
+
+  [crio.runtime]
+
+    [crio.runtime.runtimes]
+
+      [crio.runtime.runtimes.nvidia]
+        runtime_path = "/usr/bin/nvidia-container-runtime"
+        runtime_type = "oci"
+
+      [crio.runtime.runtimes.runc]
+        runtime_path = "/usr/libexec/crio/runc"
+`,
+		},
+		{
+			description: "containerd: CDI annotations enabled",
+			args: []string{
+				"--runtime", "containerd",
+				"--config", "-",
+				"--enable-cdi-annotations",
+			},
+			input: `version = 2
+`,
+			expectedOutput: `version = 2
+
+[plugins]
+
+  [plugins."io.containerd.grpc.v1.cri"]
+    enable_cdi = true
+
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+          container_annotations = ["cdi.k8s.io/*"]
+          privileged_without_host_devices = false
+          runtime_engine = ""
+          runtime_root = ""
+          runtime_type = "io.containerd.runc.v2"
+
+          [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+            BinaryName = "/usr/bin/nvidia-container-runtime"
+`,
+		},
+		{
+			description: "crio: CDI annotations enabled",
+			args: []string{
+				"--runtime", "crio",
+				"--config", "-",
+				"--output", "-",
+				"--enable-cdi-annotations",
+			},
+			input: `[crio.runtime.runtimes.runc]
+runtime_path = "/usr/libexec/crio/runc"
+`,
+			expectedOutput: `
+[crio]
+
+  [crio.runtime]
+
+    [crio.runtime.runtimes]
+
+      [crio.runtime.runtimes.nvidia]
+        allowed_annotations = ["cdi.k8s.io/*"]
+        runtime_path = "/usr/bin/nvidia-container-runtime"
+        runtime_type = "oci"
+
+      [crio.runtime.runtimes.runc]
+        runtime_path = "/usr/libexec/crio/runc"
+`,
+		},
+		{
+			description: "docker: CDI annotations are not supported",
+			args: []string{
+				"--runtime", "docker",
+				"--config", "-",
+				"--enable-cdi-annotations",
+			},
+			expectedError: fmt.Errorf("the enable-cdi-annotations flag is not supported for docker"),
+		},
+		{
+			description: "docker: empty input",
+			args: []string{
+				"--runtime", "docker",
+				"--config", "-",
+			},
+			expectedOutput: `{
+    "runtimes": {
+        "nvidia": {
+            "args": [],
+            "path": "nvidia-container-runtime"
+        }
+    }
+}`,
+		},
+		{
+			description: "docker: existing config with CDI enabled",
+			args: []string{
+				"--runtime", "docker",
+				"--config", "-",
+				"--enable-cdi",
+			},
+			input: `{"log-level": "debug"}`,
+			expectedOutput: `{
+    "cdi-spec-dirs": [
+        "/etc/cdi",
+        "/var/run/cdi"
+    ],
+    "features": {
+        "cdi": true
+    },
+    "log-level": "debug",
+    "runtimes": {
+        "nvidia": {
+            "args": [],
+            "path": "nvidia-container-runtime"
+        }
+    }
+}`,
+		},
+		{
+			description: "drop-in config is not supported",
+			args: []string{
+				"--runtime", "containerd",
+				"--config", "-",
+				"--drop-in-config", "/etc/containerd/conf.d/99-nvidia.toml",
+			},
+			expectedError: fmt.Errorf("drop-in configs are not supported when reading the config from STDIN"),
+		},
+		{
+			description: "command source is not supported",
+			args: []string{
+				"--runtime", "containerd",
+				"--config", "-",
+				"--config-source", "command",
+			},
+			expectedError: fmt.Errorf("reading the config from STDIN is not supported for config source command"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+
+			cmd := NewCommand(logger)
+			app := &cli.Command{
+				Name:     "test",
+				Commands: []*cli.Command{cmd},
+				Reader:   strings.NewReader(tc.input),
+				Writer:   stdout,
+			}
+
+			fullArgs := append([]string{"test", "configure"}, tc.args...)
+			err := app.Run(context.Background(), fullArgs)
+
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, stdout.String())
+		})
+	}
+}
+
 // Helper functions for expected errors
 func errUnrecognizedRuntime(runtime string) error {
 	return cli.Exit("unrecognized runtime '"+runtime+"'", 1)
 }
+
+func TestConfigureRootlessPaths(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description              string
+		xdgConfigHome            string
+		home                     string
+		config                   config
+		expectedError            error
+		expectedConfigFilePath   string
+		expectedDropInConfigPath string
+	}{
+		{
+			description:   "XDG_CONFIG_HOME is used",
+			xdgConfigHome: "/home/user/.xdg",
+			home:          "/home/user",
+			config: config{
+				runtime:          "containerd",
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+			expectedConfigFilePath:   "/home/user/.xdg/containerd/config.toml",
+			expectedDropInConfigPath: "/home/user/.xdg/containerd/conf.d/99-nvidia.toml",
+		},
+		{
+			description: "HOME is used if XDG_CONFIG_HOME is not set",
+			home:        "/home/user",
+			config: config{
+				runtime:          "containerd",
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+			expectedConfigFilePath:   "/home/user/.config/containerd/config.toml",
+			expectedDropInConfigPath: "/home/user/.config/containerd/conf.d/99-nvidia.toml",
+		},
+		{
+			description:   "explicit paths are not overridden",
+			xdgConfigHome: "/home/user/.xdg",
+			config: config{
+				runtime:          "containerd",
+				configFilePath:   "/custom/config.toml",
+				dropInConfigPath: "",
+			},
+			expectedConfigFilePath:   "/custom/config.toml",
+			expectedDropInConfigPath: "",
+		},
+		{
+			description:   "relative XDG_CONFIG_HOME is an error",
+			xdgConfigHome: "relative/path",
+			config: config{
+				runtime:          "containerd",
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+			expectedError: fmt.Errorf(`failed to determine the user config directory for rootless mode: XDG_CONFIG_HOME="relative/path" is not an absolute path`),
+		},
+		{
+			description:   "docker uses daemon.json in XDG_CONFIG_HOME",
+			xdgConfigHome: "/home/user/.xdg",
+			config: config{
+				runtime:          "docker",
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+			expectedConfigFilePath:   "/home/user/.xdg/docker/daemon.json",
+			expectedDropInConfigPath: "",
+		},
+		{
+			description: "docker uses daemon.json in HOME if XDG_CONFIG_HOME is not set",
+			home:        "/home/user",
+			config: config{
+				runtime:          "docker",
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+			expectedConfigFilePath:   "/home/user/.config/docker/daemon.json",
+			expectedDropInConfigPath: "",
+		},
+		{
+			description:   "explicit docker config path is not overridden",
+			xdgConfigHome: "relative/path",
+			config: config{
+				runtime:          "docker",
+				configFilePath:   "/custom/daemon.json",
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+			expectedConfigFilePath:   "/custom/daemon.json",
+			expectedDropInConfigPath: "",
+		},
+		{
+			description:   "crio is not supported",
+			xdgConfigHome: "/home/user/.xdg",
+			config: config{
+				runtime:          "crio",
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+			expectedError: fmt.Errorf("rootless mode is not supported for runtime crio"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", tc.xdgConfigHome)
+			t.Setenv("HOME", tc.home)
+
+			c := command{logger: logger}
+
+			cfg := tc.config
+			cfg.rootless = true
+			cfg.configSource = configSourceFile
+			cfg.nvidiaRuntime.path = defaultNVIDIARuntimeExpecutablePath
+
+			err := c.validateFlags(&cfg)
+			if tc.expectedError != nil {
+				require.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedConfigFilePath, cfg.configFilePath)
+			require.Equal(t, tc.expectedDropInConfigPath, cfg.dropInConfigPath)
+		})
+	}
+}
+
+func TestConfigureDockerRootlessDetection(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description            string
+		euid                   int
+		createSocket           bool
+		config                 config
+		expectedRootless       bool
+		expectedConfigFilePath string
+	}{
+		{
+			description:            "root user uses system config",
+			euid:                   0,
+			createSocket:           true,
+			config:                 config{runtime: "docker"},
+			expectedConfigFilePath: defaultDockerConfigFilePath,
+		},
+		{
+			description:            "non-root user without rootless socket uses system config",
+			euid:                   1000,
+			config:                 config{runtime: "docker"},
+			expectedConfigFilePath: defaultDockerConfigFilePath,
+		},
+		{
+			description:            "non-root user with rootless socket uses rootless config",
+			euid:                   1000,
+			createSocket:           true,
+			config:                 config{runtime: "docker"},
+			expectedRootless:       true,
+			expectedConfigFilePath: "/home/user/.xdg/docker/daemon.json",
+		},
+		{
+			description:            "explicit config path disables detection",
+			euid:                   1000,
+			createSocket:           true,
+			config:                 config{runtime: "docker", configFilePath: "/custom/daemon.json"},
+			expectedConfigFilePath: "/custom/daemon.json",
+		},
+		{
+			description:            "detection only applies to docker",
+			euid:                   1000,
+			createSocket:           true,
+			config:                 config{runtime: "containerd", dropInConfigPath: ""},
+			expectedConfigFilePath: defaultContainerdConfigFilePath,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			xdgRuntimeDir := t.TempDir()
+			if tc.createSocket {
+				require.NoError(t, os.WriteFile(filepath.Join(xdgRuntimeDir, "docker.sock"), nil, 0600))
+			}
+			t.Setenv("XDG_RUNTIME_DIR", xdgRuntimeDir)
+			t.Setenv("XDG_CONFIG_HOME", "/home/user/.xdg")
+
+			defer setGeteuid(tc.euid)()
+
+			c := command{logger: logger}
+
+			cfg := tc.config
+			cfg.configSource = configSourceFile
+			cfg.nvidiaRuntime.path = defaultNVIDIARuntimeExpecutablePath
+			if cfg.runtime == "docker" {
+				cfg.dropInConfigPath = runtimeSpecificDefault
+			}
+
+			require.NoError(t, c.validateFlags(&cfg))
+			require.Equal(t, tc.expectedRootless, cfg.rootless)
+			require.Equal(t, tc.expectedConfigFilePath, cfg.configFilePath)
+		})
+	}
+}
+
+func setGeteuid(euid int) func() {
+	original := geteuid
+	geteuid = func() int {
+		return euid
+	}
+	return func() {
+		geteuid = original
+	}
+}
+
+func TestConfigureRootless(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	userBinDir := t.TempDir()
+	userRuntimePath := filepath.Join(userBinDir, "nvidia-container-runtime")
+	require.NoError(t, os.WriteFile(userRuntimePath, nil, 0755))
+
+	testCases := []struct {
+		description         string
+		path                string
+		expectedRuntimePath string
+	}{
+		{
+			description:         "runtime is resolved from the user PATH",
+			path:                userBinDir,
+			expectedRuntimePath: userRuntimePath,
+		},
+		{
+			description:         "system runtime is used if not in the user PATH",
+			path:                t.TempDir(),
+			expectedRuntimePath: "/usr/bin/nvidia-container-runtime",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			xdgConfigHome := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+			t.Setenv("PATH", tc.path)
+
+			c := NewCommand(logger)
+			app := &cli.Command{
+				Name:     "test",
+				Commands: []*cli.Command{c},
+			}
+
+			err := app.Run(context.Background(), []string{"test", "configure", "--runtime", "containerd", "--rootless"})
+			require.NoError(t, err)
+
+			topLevelConfig, err := os.ReadFile(filepath.Join(xdgConfigHome, "containerd", "config.toml"))
+			require.NoError(t, err)
+			require.Contains(t, string(topLevelConfig), filepath.Join(xdgConfigHome, "containerd", "conf.d", "*.toml"))
+
+			dropInConfig, err := os.ReadFile(filepath.Join(xdgConfigHome, "containerd", "conf.d", "99-nvidia.toml"))
+			require.NoError(t, err)
+			require.Contains(t, string(dropInConfig), fmt.Sprintf("BinaryName = %q", tc.expectedRuntimePath))
+			require.Contains(t, string(dropInConfig), "SystemdCgroup = false")
+		})
+	}
+}
+
+func TestConfigureBackupAndRestore(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	runConfigure := func(args ...string) error {
+		app := &cli.Command{
+			Name:     "test",
+			Commands: []*cli.Command{NewCommand(logger)},
+		}
+		return app.Run(context.Background(), append([]string{"test", "configure"}, args...))
+	}
+
+	configDir := t.TempDir()
+	configPath := filepath.Join(configDir, "daemon.json")
+	originalConfig := `{"log-level": "debug"}`
+	require.NoError(t, os.WriteFile(configPath, []byte(originalConfig), 0600))
+
+	err := runConfigure("--runtime", "docker", "--config", configPath, "--backup")
+	require.NoError(t, err)
+
+	backups, err := filepath.Glob(configPath + ".bak.*")
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	backupContents, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	require.Equal(t, originalConfig, string(backupContents))
+
+	info, err := os.Stat(backups[0])
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	modifiedConfig, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	require.Contains(t, string(modifiedConfig), "nvidia-container-runtime")
+
+	err = runConfigure("--runtime", "docker", "--config", configPath, "--restore", backups[0])
+	require.NoError(t, err)
+
+	restoredConfig, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	require.Equal(t, originalConfig, string(restoredConfig))
+
+	t.Run("invalid backup is not restored", func(t *testing.T) {
+		invalidBackup := configPath + ".bak.20260101T000000Z"
+		require.NoError(t, os.WriteFile(invalidBackup, []byte(`{"runtimes":`), 0600))
+
+		err := runConfigure("--runtime", "docker", "--config", configPath, "--restore", invalidBackup)
+		require.ErrorContains(t, err, "is not a valid docker config")
+
+		contents, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		require.Equal(t, originalConfig, string(contents))
+	})
+
+	t.Run("backup path without timestamp", func(t *testing.T) {
+		err := runConfigure("--runtime", "docker", "--config", configPath, "--restore", configPath)
+		require.ErrorContains(t, err, "invalid backup path")
+	})
+
+	t.Run("no backup is created when writing to STDOUT", func(t *testing.T) {
+		existingBackups, err := filepath.Glob(configPath + ".bak.*")
+		require.NoError(t, err)
+
+		for _, args := range [][]string{{"--dry-run"}, {"--output", "-"}} {
+			err := runConfigure(append([]string{"--runtime", "docker", "--config", configPath, "--backup"}, args...)...)
+			require.NoError(t, err)
+		}
+
+		backups, err := filepath.Glob(configPath + ".bak.*")
+		require.NoError(t, err)
+		require.EqualValues(t, existingBackups, backups)
+	})
+
+	t.Run("containerd drop-in backs up top-level config", func(t *testing.T) {
+		topLevelConfigPath := filepath.Join(configDir, "config.toml")
+		dropInConfigPath := filepath.Join(configDir, "conf.d", "99-nvidia.toml")
+		require.NoError(t, os.WriteFile(topLevelConfigPath, []byte("version = 2\n"), 0600))
+
+		err := runConfigure("--runtime", "containerd", "--config", topLevelConfigPath, "--drop-in-config", dropInConfigPath, "--backup")
+		require.NoError(t, err)
+
+		backups, err := filepath.Glob(topLevelConfigPath + ".bak.*")
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+
+		// The drop-in file did not exist before it was written.
+		dropInBackups, err := filepath.Glob(dropInConfigPath + ".bak.*")
+		require.NoError(t, err)
+		require.Empty(t, dropInBackups)
+		require.FileExists(t, dropInConfigPath)
+
+		err = runConfigure("--runtime", "containerd", "--config", topLevelConfigPath, "--drop-in-config", dropInConfigPath, "--restore", backups[0])
+		require.NoError(t, err)
+
+		restoredConfig, err := os.ReadFile(topLevelConfigPath)
+		require.NoError(t, err)
+		require.Equal(t, "version = 2\n", string(restoredConfig))
+		require.NoFileExists(t, dropInConfigPath)
+
+		// No temporary files are left behind by the restore.
+		entries, err := os.ReadDir(configDir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			require.NotContains(t, entry.Name(), ".tmp")
+		}
+	})
+}
+
+func TestConfigureUndo(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	runConfigure := func(args ...string) error {
+		app := &cli.Command{
+			Name:     "test",
+			Commands: []*cli.Command{NewCommand(logger)},
+		}
+		return app.Run(context.Background(), append([]string{"test", "configure"}, args...))
+	}
+
+	containerdConfig := `version = 2
+
+[plugins]
+
+  [plugins."io.containerd.grpc.v1.cri"]
+    sandbox_image = "registry.k8s.io/pause:3.9"
+
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "runc"
+
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+`
+	crioConfig := `
+[crio]
+
+  [crio.runtime]
+    default_runtime = "crun"
+`
+	dockerConfig := `{
+    "log-level": "debug"
+}`
+
+	testCases := []struct {
+		description    string
+		runtime        string
+		originalConfig string
+		withDropIn     bool
+		args           []string
+		// expectedConfig is the expected config after the undo if this
+		// differs from the original config.
+		expectedConfig string
+	}{
+		{
+			description:    "containerd drop-in with backup",
+			runtime:        "containerd",
+			originalConfig: containerdConfig,
+			withDropIn:     true,
+			args:           []string{"--set-as-default", "--cdi.enabled", "--backup"},
+		},
+		{
+			description:    "containerd drop-in without backup",
+			runtime:        "containerd",
+			originalConfig: containerdConfig,
+			withDropIn:     true,
+			args:           []string{"--set-as-default", "--cdi.enabled"},
+			// Without a backup, the import of the drop-in directory that
+			// was added to the top-level config is retained.
+			expectedConfig: `imports = ["{{ .configDir }}/conf.d/*.toml"]
+` + containerdConfig,
+		},
+		{
+			description:    "containerd top-level config with backup",
+			runtime:        "containerd",
+			originalConfig: containerdConfig,
+			args:           []string{"--set-as-default", "--cdi.enabled", "--backup"},
+		},
+		{
+			description:    "containerd top-level config without backup",
+			runtime:        "containerd",
+			originalConfig: containerdConfig,
+			args:           []string{"--cdi.enabled"},
+		},
+		{
+			description:    "crio drop-in",
+			runtime:        "crio",
+			originalConfig: crioConfig,
+			withDropIn:     true,
+			args:           []string{"--set-as-default"},
+		},
+		{
+			description:    "crio top-level config with backup",
+			runtime:        "crio",
+			originalConfig: crioConfig,
+			args:           []string{"--set-as-default", "--backup"},
+		},
+		{
+			description:    "docker with backup",
+			runtime:        "docker",
+			originalConfig: dockerConfig,
+			args:           []string{"--set-as-default", "--cdi.enabled", "--backup"},
+		},
+		{
+			description:    "docker without backup",
+			runtime:        "docker",
+			originalConfig: dockerConfig,
+			args:           []string{"--cdi.enabled"},
+		},
+		{
+			description:    "docker default runtime is reset without backup",
+			runtime:        "docker",
+			originalConfig: dockerConfig,
+			args:           []string{"--set-as-default"},
+			expectedConfig: `{
+    "default-runtime": "runc",
+    "log-level": "debug"
+}`,
+		},
+		{
+			description: "docker config created by configure is left empty",
+			runtime:     "docker",
+			args:        []string{"--cdi.enabled"},
+			// An empty docker config is written instead of removing the
+			// config file.
+			expectedConfig: `{}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			configDir := t.TempDir()
+			configPath := filepath.Join(configDir, "config")
+			if tc.originalConfig != "" {
+				require.NoError(t, os.WriteFile(configPath, []byte(tc.originalConfig), 0600))
+			}
+
+			args := []string{"--runtime", tc.runtime, "--config", configPath}
+			dropInConfigPath := filepath.Join(configDir, "conf.d", "99-nvidia.toml")
+			switch {
+			case tc.withDropIn:
+				args = append(args, "--drop-in-config", dropInConfigPath)
+			case tc.runtime != "docker":
+				args = append(args, "--drop-in-config", "")
+			}
+			args = append(args, tc.args...)
+
+			require.NoError(t, runConfigure(args...))
+			require.NoError(t, runConfigure(append(args, "--undo")...))
+
+			expectedConfig := tc.originalConfig
+			if tc.expectedConfig != "" {
+				expectedConfig = strings.ReplaceAll(tc.expectedConfig, "{{ .configDir }}", configDir)
+			}
+			if expectedConfig == "" {
+				require.NoFileExists(t, configPath)
+			} else {
+				contents, err := os.ReadFile(configPath)
+				require.NoError(t, err)
+				require.Equal(t, expectedConfig, string(contents))
+			}
+			require.NoFileExists(t, dropInConfigPath)
+		})
+	}
+
+	t.Run("undo cannot be combined with list", func(t *testing.T) {
+		err := runConfigure("--runtime", "docker", "--config", filepath.Join(t.TempDir(), "daemon.json"), "--undo", "--list")
+		require.ErrorContains(t, err, "the undo flag cannot be specified together with the list or restore flags")
+	})
+}
+
+func TestConfigureList(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		args           []string
+		input          string
+		expectedError  error
+		expectedOutput string
+	}{
+		{
+			description: "containerd",
+			args:        []string{"--runtime", "containerd"},
+			input: `
+version = 2
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      default_runtime_name = "nvidia"
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+          runtime_type = "io.containerd.runc.v2"
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+          runtime_type = "io.containerd.runc.v2"
+          [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+            BinaryName = "/usr/bin/nvidia-container-runtime"
+`,
+			expectedOutput: `NAME    BINARY PATH                        TYPE                   DEFAULT
+nvidia  /usr/bin/nvidia-container-runtime  io.containerd.runc.v2  true
+runc    -                                  io.containerd.runc.v2  false
+`,
+		},
+		{
+			description: "crio",
+			args:        []string{"--runtime", "crio"},
+			input: `
+[crio]
+  [crio.runtime]
+    default_runtime = "crun"
+    [crio.runtime.runtimes]
+      [crio.runtime.runtimes.crun]
+        runtime_path = "/usr/libexec/crio/crun"
+        runtime_type = "oci"
+      [crio.runtime.runtimes.nvidia]
+        runtime_path = "/usr/bin/nvidia-container-runtime"
+        runtime_type = "oci"
+`,
+			expectedOutput: `NAME    BINARY PATH                        TYPE  DEFAULT
+crun    /usr/libexec/crio/crun             oci   true
+nvidia  /usr/bin/nvidia-container-runtime  oci   false
+`,
+		},
+		{
+			description: "docker",
+			args:        []string{"--runtime", "docker"},
+			input: `{
+    "default-runtime": "nvidia",
+    "runtimes": {
+        "nvidia": {
+            "args": [],
+            "path": "nvidia-container-runtime"
+        },
+        "crun": {
+            "path": "/usr/bin/crun",
+            "runtimeType": "io.containerd.runc.v2"
+        }
+    }
+}`,
+			expectedOutput: `NAME    BINARY PATH               TYPE                   DEFAULT
+crun    /usr/bin/crun             io.containerd.runc.v2  false
+nvidia  nvidia-container-runtime  -                      true
+`,
+		},
+		{
+			description: "empty config",
+			args:        []string{"--runtime", "docker"},
+			expectedOutput: `NAME  BINARY PATH  TYPE  DEFAULT
+`,
+		},
+		{
+			description:   "restore is not supported",
+			args:          []string{"--runtime", "docker", "--restore", "/etc/docker/daemon.json.bak.20260101T000000Z"},
+			expectedError: fmt.Errorf("the list and restore flags cannot be specified together"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+
+			cmd := NewCommand(logger)
+			app := &cli.Command{
+				Name:     "test",
+				Commands: []*cli.Command{cmd},
+				Reader:   strings.NewReader(tc.input),
+				Writer:   stdout,
+			}
+
+			fullArgs := append([]string{"test", "configure", "--list", "--config", "-"}, tc.args...)
+			err := app.Run(context.Background(), fullArgs)
+
+			if tc.expectedError != nil {
+				require.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, stdout.String())
+		})
+	}
+}
+
+func TestConfigureRuntimeExecutableCheck(t *testing.T) {
+	testRoot := t.TempDir()
+	existingRuntime := filepath.Join(testRoot, "nvidia-container-runtime")
+	require.NoError(t, os.WriteFile(existingRuntime, nil, 0755))
+	missingRuntime := filepath.Join(testRoot, "missing", "nvidia-container-runtime")
+
+	testCases := []struct {
+		description     string
+		args            []string
+		expectedError   string
+		expectedWarning bool
+	}{
+		{
+			description: "existing runtime",
+			args:        []string{"--nvidia-runtime-path", existingRuntime},
+		},
+		{
+			description: "existing runtime strict",
+			args:        []string{"--nvidia-runtime-path", existingRuntime, "--strict"},
+		},
+		{
+			description:     "missing runtime",
+			args:            []string{"--nvidia-runtime-path", missingRuntime},
+			expectedWarning: true,
+		},
+		{
+			description:   "missing runtime strict",
+			args:          []string{"--nvidia-runtime-path", missingRuntime, "--strict"},
+			expectedError: "the executable for runtime nvidia was not found: stat " + missingRuntime + ": no such file or directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, hook := testlog.NewNullLogger()
+			stdout := &bytes.Buffer{}
+
+			cmd := NewCommand(logger)
+			app := &cli.Command{
+				Name:     "test",
+				Commands: []*cli.Command{cmd},
+				Reader:   strings.NewReader(""),
+				Writer:   stdout,
+			}
+
+			fullArgs := append([]string{"test", "configure", "--runtime", "containerd", "--config", "-"}, tc.args...)
+			err := app.Run(context.Background(), fullArgs)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				require.Empty(t, stdout.String())
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, stdout.String(), tc.args[1])
+
+			var warned bool
+			for _, entry := range hook.AllEntries() {
+				if strings.Contains(entry.Message, "was not found") {
+					warned = true
+				}
+			}
+			require.Equal(t, tc.expectedWarning, warned)
+		})
+	}
+}
+
+func TestConfigureMissingConfig(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		args           []string
+		configIsDir    bool
+		expectedError  string
+		expectedConfig string
+	}{
+		{
+			description: "containerd",
+			args:        []string{"--runtime", "containerd", "--drop-in-config", ""},
+			expectedConfig: `version = 2
+
+[plugins]
+
+  [plugins."io.containerd.grpc.v1.cri"]
+
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+          privileged_without_host_devices = false
+          runtime_engine = ""
+          runtime_root = ""
+          runtime_type = "io.containerd.runc.v2"
+
+          [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+            BinaryName = "/usr/bin/nvidia-container-runtime"
+`,
+		},
+		{
+			description: "crio",
+			args:        []string{"--runtime", "crio", "--drop-in-config", ""},
+			expectedConfig: `
+[crio]
+
+  [crio.runtime]
+
+    [crio.runtime.runtimes]
+
+      [crio.runtime.runtimes.nvidia]
+        runtime_path = "/usr/bin/nvidia-container-runtime"
+        runtime_type = "oci"
+`,
+		},
+		{
+			description: "docker",
+			args:        []string{"--runtime", "docker"},
+			expectedConfig: `{
+    "runtimes": {
+        "nvidia": {
+            "args": [],
+            "path": "nvidia-container-runtime"
+        }
+    }
+}`,
+		},
+		{
+			description:   "containerd config is a directory",
+			args:          []string{"--runtime", "containerd", "--drop-in-config", ""},
+			configIsDir:   true,
+			expectedError: "is a directory",
+		},
+		{
+			description:   "docker config is a directory",
+			args:          []string{"--runtime", "docker"},
+			configIsDir:   true,
+			expectedError: "config file is a directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// The config file is created in a directory that does not exist.
+			configPath := filepath.Join(t.TempDir(), "etc", "config")
+			if tc.configIsDir {
+				require.NoError(t, os.MkdirAll(configPath, 0755))
+			}
+
+			cmd := NewCommand(logger)
+			app := &cli.Command{
+				Name:     "test",
+				Commands: []*cli.Command{cmd},
+			}
+
+			fullArgs := append([]string{"test", "configure", "--config", configPath}, tc.args...)
+			err := app.Run(context.Background(), fullArgs)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(configPath)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedConfig, string(content))
+		})
+	}
+}
+
+func TestConfigureDisableDropIn(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description           string
+		config                config
+		expectedDisableDropIn bool
+	}{
+		{
+			description: "drop-in config is used by default",
+			config: config{
+				runtime:          "containerd",
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+		},
+		{
+			description: "empty drop-in config path disables drop-in config",
+			config: config{
+				runtime:          "containerd",
+				dropInConfigPath: "",
+			},
+			expectedDisableDropIn: true,
+		},
+		{
+			description: "reading from STDIN disables drop-in config",
+			config: config{
+				runtime:          "containerd",
+				configFilePath:   stdioPath,
+				dropInConfigPath: runtimeSpecificDefault,
+			},
+			expectedDisableDropIn: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{logger: logger}
+
+			cfg := tc.config
+			cfg.configSource = configSourceFile
+			cfg.nvidiaRuntime.path = defaultNVIDIARuntimeExpecutablePath
+
+			require.NoError(t, c.validateFlags(&cfg))
+			require.Equal(t, tc.expectedDisableDropIn, cfg.disableDropIn)
+		})
+	}
+}
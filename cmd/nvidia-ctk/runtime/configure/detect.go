@@ -0,0 +1,130 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// autoDetectRuntime is the --runtime value that selects the installed
+// runtime engine(s).
+const autoDetectRuntime = "auto"
+
+// runtimeDetectionPaths lists the supported runtime engines in order of
+// preference together with the sockets and config files whose presence
+// indicates that the engine is installed. Docker is preferred since a Docker
+// installation also includes containerd.
+var runtimeDetectionPaths = []struct {
+	runtime string
+	paths   []string
+}{
+	{
+		runtime: "docker",
+		paths: []string{
+			"/run/docker.sock",
+			"/var/run/docker.sock",
+			defaultDockerConfigFilePath,
+		},
+	},
+	{
+		runtime: "crio",
+		paths: []string{
+			"/run/crio/crio.sock",
+			"/var/run/crio/crio.sock",
+			defaultCrioConfigFilePath,
+		},
+	},
+	{
+		runtime: "containerd",
+		paths: []string{
+			"/run/containerd/containerd.sock",
+			"/var/run/containerd/containerd.sock",
+			defaultContainerdConfigFilePath,
+		},
+	},
+}
+
+// detectInstalledRuntimes returns the runtime engines that are installed on
+// the host.
+// We use a function variable here to allow this to be overridden for testing.
+var detectInstalledRuntimes = func() []string {
+	return detectRuntimes("/")
+}
+
+// detectRuntimes returns the runtime engines for which a socket or config
+// file exists at the specified root. The runtimes are returned in order of
+// preference.
+func detectRuntimes(root string) []string {
+	var runtimes []string
+	for _, candidate := range runtimeDetectionPaths {
+		for _, path := range candidate.paths {
+			if _, err := os.Stat(filepath.Join(root, path)); err == nil {
+				runtimes = append(runtimes, candidate.runtime)
+				break
+			}
+		}
+	}
+	return runtimes
+}
+
+// resolveRuntimeConfigs returns the validated configs for the runtime engines
+// that are to be configured. If the runtime is auto, the installed runtime
+// engines are detected and the preferred engine (or all engines if --all is
+// specified) are configured. Otherwise the specified runtime is configured.
+func (m command) resolveRuntimeConfigs(base *config) ([]*config, error) {
+	if base.runtime != autoDetectRuntime {
+		if base.all {
+			return nil, fmt.Errorf("the all flag is only supported for runtime %v", autoDetectRuntime)
+		}
+		if err := m.validateFlags(base); err != nil {
+			return nil, err
+		}
+		return []*config{base}, nil
+	}
+
+	detected := detectInstalledRuntimes()
+	if len(detected) == 0 {
+		return nil, fmt.Errorf("no supported runtime engine detected; specify one of [containerd, crio, docker] using --runtime")
+	}
+	m.logger.Infof("Detected runtime engines: %v", strings.Join(detected, ", "))
+
+	if base.all {
+		if len(detected) > 1 && base.configFilePath != "" {
+			return nil, fmt.Errorf("the config flag cannot be specified when configuring multiple runtime engines")
+		}
+	} else {
+		if len(detected) > 1 {
+			m.logger.Infof("Configuring %v; specify --all to configure all detected runtime engines", detected[0])
+		}
+		detected = detected[:1]
+	}
+
+	var configs []*config
+	for _, runtime := range detected {
+		c := *base
+		c.runtime = runtime
+		if err := m.validateFlags(&c); err != nil {
+			return nil, fmt.Errorf("invalid flags for runtime %v: %w", runtime, err)
+		}
+		configs = append(configs, &c)
+	}
+	return configs, nil
+}
@@ -0,0 +1,213 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectRuntimes(t *testing.T) {
+	testCases := []struct {
+		description      string
+		files            []string
+		expectedRuntimes []string
+	}{
+		{
+			description: "no runtime installed",
+		},
+		{
+			description:      "docker socket",
+			files:            []string{"/run/docker.sock"},
+			expectedRuntimes: []string{"docker"},
+		},
+		{
+			description:      "docker config",
+			files:            []string{"/etc/docker/daemon.json"},
+			expectedRuntimes: []string{"docker"},
+		},
+		{
+			description:      "containerd config",
+			files:            []string{"/etc/containerd/config.toml"},
+			expectedRuntimes: []string{"containerd"},
+		},
+		{
+			description:      "crio socket in /var/run",
+			files:            []string{"/var/run/crio/crio.sock"},
+			expectedRuntimes: []string{"crio"},
+		},
+		{
+			description: "docker with containerd",
+			files: []string{
+				"/run/containerd/containerd.sock",
+				"/etc/containerd/config.toml",
+				"/var/run/docker.sock",
+			},
+			expectedRuntimes: []string{"docker", "containerd"},
+		},
+		{
+			description: "all runtimes",
+			files: []string{
+				"/run/containerd/containerd.sock",
+				"/etc/crio/crio.conf",
+				"/etc/docker/daemon.json",
+			},
+			expectedRuntimes: []string{"docker", "crio", "containerd"},
+		},
+		{
+			description: "unrelated files are ignored",
+			files: []string{
+				"/etc/containerd/conf.d/99-nvidia.toml",
+				"/run/podman/podman.sock",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			for _, file := range tc.files {
+				path := filepath.Join(root, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0600))
+			}
+
+			require.EqualValues(t, tc.expectedRuntimes, detectRuntimes(root))
+		})
+	}
+}
+
+func TestResolveRuntimeConfigs(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description      string
+		runtime          string
+		all              bool
+		configFilePath   string
+		detected         []string
+		expectedError    string
+		expectedRuntimes []string
+		expectedConfigs  []string
+	}{
+		{
+			description:      "explicit runtime is not detected",
+			runtime:          "crio",
+			detected:         []string{"docker"},
+			expectedRuntimes: []string{"crio"},
+			expectedConfigs:  []string{defaultCrioConfigFilePath},
+		},
+		{
+			description:   "all requires auto",
+			runtime:       "docker",
+			all:           true,
+			expectedError: "the all flag is only supported for runtime auto",
+		},
+		{
+			description:   "auto with no detected runtime",
+			runtime:       "auto",
+			expectedError: "no supported runtime engine detected",
+		},
+		{
+			description:      "auto selects the detected runtime",
+			runtime:          "auto",
+			detected:         []string{"containerd"},
+			expectedRuntimes: []string{"containerd"},
+			expectedConfigs:  []string{defaultContainerdConfigFilePath},
+		},
+		{
+			description:      "auto selects the preferred runtime",
+			runtime:          "auto",
+			detected:         []string{"docker", "containerd"},
+			expectedRuntimes: []string{"docker"},
+			expectedConfigs:  []string{defaultDockerConfigFilePath},
+		},
+		{
+			description:      "auto with all selects all detected runtimes",
+			runtime:          "auto",
+			all:              true,
+			detected:         []string{"docker", "containerd"},
+			expectedRuntimes: []string{"docker", "containerd"},
+			expectedConfigs:  []string{defaultDockerConfigFilePath, defaultContainerdConfigFilePath},
+		},
+		{
+			description:      "auto with all and config for single runtime",
+			runtime:          "auto",
+			all:              true,
+			configFilePath:   "/custom/config.toml",
+			detected:         []string{"containerd"},
+			expectedRuntimes: []string{"containerd"},
+			expectedConfigs:  []string{"/custom/config.toml"},
+		},
+		{
+			description:    "auto with all and config for multiple runtimes",
+			runtime:        "auto",
+			all:            true,
+			configFilePath: "/custom/config.toml",
+			detected:       []string{"crio", "containerd"},
+			expectedError:  "the config flag cannot be specified when configuring multiple runtime engines",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			defer setDetectInstalledRuntimesForTest(tc.detected)()
+
+			base := &config{
+				runtime:          tc.runtime,
+				all:              tc.all,
+				configFilePath:   tc.configFilePath,
+				dropInConfigPath: runtimeSpecificDefault,
+				configSource:     configSourceFile,
+			}
+			base.nvidiaRuntime.path = defaultNVIDIARuntimeExecutable
+
+			configs, err := command{logger: logger}.resolveRuntimeConfigs(base)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			var runtimes []string
+			var configFilePaths []string
+			for _, c := range configs {
+				runtimes = append(runtimes, c.runtime)
+				configFilePaths = append(configFilePaths, c.configFilePath)
+			}
+			require.EqualValues(t, tc.expectedRuntimes, runtimes)
+			require.EqualValues(t, tc.expectedConfigs, configFilePaths)
+		})
+	}
+}
+
+// setDetectInstalledRuntimesForTest overrides the runtime engines that are
+// detected as installed.
+func setDetectInstalledRuntimesForTest(runtimes []string) func() {
+	original := detectInstalledRuntimes
+	detectInstalledRuntimes = func() []string {
+		return runtimes
+	}
+	return func() {
+		detectInstalledRuntimes = original
+	}
+}
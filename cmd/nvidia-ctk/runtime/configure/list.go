@@ -0,0 +1,60 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+)
+
+// listRuntimes writes the runtimes that are configured for the target runtime
+// to STDOUT. For each runtime the name, binary path, type, and whether it is
+// the default runtime are shown.
+func (m command) listRuntimes(config *config) error {
+	cfg, err := m.loadConfig(config)
+	if err != nil {
+		return err
+	}
+
+	defaultRuntime := cfg.DefaultRuntime()
+
+	w := tabwriter.NewWriter(config.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tBINARY PATH\tTYPE\tDEFAULT")
+	for _, name := range cfg.GetRuntimeNames() {
+		runtimeConfig, err := cfg.GetRuntimeConfig(name)
+		if err != nil {
+			return fmt.Errorf("unable to get config for runtime %v: %w", name, err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			name,
+			valueOrNone(runtimeConfig.GetBinaryPath()),
+			valueOrNone(runtimeConfig.GetRuntimeType()),
+			strconv.FormatBool(name == defaultRuntime),
+		)
+	}
+	return w.Flush()
+}
+
+// valueOrNone returns the specified value or "-" if this is empty.
+func valueOrNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
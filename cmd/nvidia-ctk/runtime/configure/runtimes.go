@@ -0,0 +1,152 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	runtimeModeAuto   = "auto"
+	runtimeModeCDI    = "cdi"
+	runtimeModeLegacy = "legacy"
+)
+
+// A namedRuntime is an NVIDIA runtime variant specified using the
+// --runtime-name flag.
+type namedRuntime struct {
+	name string
+	mode string
+}
+
+// A runtimeEntry defines the runtime entry that is added to the config of a
+// container engine.
+type runtimeEntry struct {
+	name         string
+	path         string
+	setAsDefault bool
+}
+
+// parseRuntimeNames parses values of the form NAME[:mode=MODE].
+func parseRuntimeNames(values []string) ([]namedRuntime, error) {
+	var runtimes []namedRuntime
+	seen := make(map[string]bool)
+	for _, value := range values {
+		runtime, err := parseRuntimeName(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen[runtime.name] {
+			return nil, fmt.Errorf("duplicate runtime name %q", runtime.name)
+		}
+		seen[runtime.name] = true
+		runtimes = append(runtimes, runtime)
+	}
+	return runtimes, nil
+}
+
+func parseRuntimeName(value string) (namedRuntime, error) {
+	name, options, _ := strings.Cut(value, ":")
+	if name == "" {
+		return namedRuntime{}, fmt.Errorf("invalid runtime name %q: name is empty", value)
+	}
+	runtime := namedRuntime{
+		name: name,
+		mode: runtimeModeAuto,
+	}
+	if options == "" {
+		return runtime, nil
+	}
+
+	key, mode, found := strings.Cut(options, "=")
+	if !found || key != "mode" {
+		return namedRuntime{}, fmt.Errorf("invalid runtime name %q: expected NAME[:mode=MODE]", value)
+	}
+	switch mode {
+	case runtimeModeAuto, runtimeModeCDI, runtimeModeLegacy:
+		runtime.mode = mode
+	default:
+		return namedRuntime{}, fmt.Errorf("invalid runtime name %q: unsupported mode %q", value, mode)
+	}
+	return runtime, nil
+}
+
+// getNVIDIARuntimes returns the runtime entries to add to the config. If no
+// runtimes were specified using --runtime-name, a single entry for the
+// runtime specified by --nvidia-runtime-name is returned.
+func (c *config) getNVIDIARuntimes() []runtimeEntry {
+	if len(c.runtimes) == 0 {
+		return []runtimeEntry{
+			{
+				name:         c.nvidiaRuntime.name,
+				path:         c.nvidiaRuntime.path,
+				setAsDefault: c.nvidiaRuntime.setAsDefault,
+			},
+		}
+	}
+
+	var entries []runtimeEntry
+	for i, runtime := range c.runtimes {
+		path := c.nvidiaRuntime.path
+		if runtime.mode != runtimeModeAuto {
+			path += "." + runtime.mode
+		}
+		entries = append(entries, runtimeEntry{
+			name:         runtime.name,
+			path:         path,
+			setAsDefault: c.nvidiaRuntime.setAsDefault && i == 0,
+		})
+	}
+	return entries
+}
+
+// checkRuntimeExecutables checks that the executable for each runtime entry
+// exists. Relative paths are resolved using the PATH. A missing executable is
+// logged as a warning unless strict is set, in which case an error is returned.
+func (m command) checkRuntimeExecutables(entries []runtimeEntry, strict bool) error {
+	for _, entry := range entries {
+		err := checkExecutable(entry.path)
+		if err == nil {
+			continue
+		}
+		if strict {
+			return fmt.Errorf("the executable for runtime %v was not found: %w", entry.name, err)
+		}
+		m.logger.Warningf("The executable for runtime %v was not found; containers using this runtime will fail to start: %v", entry.name, err)
+	}
+	return nil
+}
+
+func checkExecutable(path string) error {
+	if !filepath.IsAbs(path) {
+		_, err := exec.LookPath(path)
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%v is a directory", path)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package configure
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// undoConfigure reverses a prior configure of the target runtime. The NVIDIA
+// runtimes are removed from the config and CDI is disabled if requested.
+// Removing a runtime that is the default also resets the default runtime.
+// Since the prior default runtime cannot be determined from the updated config,
+// the most recent backup of each modified config file is then restored if one
+// exists.
+func (m command) undoConfigure(config *config) error {
+	if config.mode != "config-file" && config.mode != "config" {
+		return fmt.Errorf("undo is not supported for config-mode %v", config.mode)
+	}
+
+	cfg, err := m.loadConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// CDI is disabled before the runtimes are removed so that config
+	// sections that are left empty are removed.
+	if config.cdi.enabled {
+		cfg.DisableCDI()
+	}
+
+	for _, runtime := range config.getNVIDIARuntimes() {
+		if err := cfg.RemoveRuntime(runtime.name); err != nil {
+			return fmt.Errorf("unable to remove runtime %v: %v", runtime.name, err)
+		}
+	}
+
+	// The backups are restored below, so no new backups are created.
+	if err := m.saveConfig(config, cfg, false); err != nil {
+		return err
+	}
+
+	// When a drop-in config is used, we remove the drop-in file explicitly
+	// since it may include a copy of the runtime config and is not empty once
+	// the NVIDIA runtimes are removed.
+	if outputPath := config.getOutputConfigPath(); outputPath != "" && outputPath == config.dropInConfigPath {
+		err := os.Remove(outputPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove drop-in config file: %w", err)
+		}
+		m.logger.Infof("Removed drop-in config %v", outputPath)
+	}
+
+	for _, path := range config.getBackupPaths() {
+		backupPath, err := getLatestBackup(path)
+		if err != nil {
+			return fmt.Errorf("failed to find backups of %v: %w", path, err)
+		}
+		if backupPath == "" {
+			m.logger.Debugf("No backup of %v found", path)
+			continue
+		}
+		if err := m.restoreBackup(config, backupPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
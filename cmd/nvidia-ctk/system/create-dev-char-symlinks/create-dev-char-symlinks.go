@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 
@@ -40,6 +41,7 @@ type command struct {
 type config struct {
 	devCharPath       string
 	driverRoot        string
+	devRoot           string
 	dryRun            bool
 	createAll         bool
 	createDeviceNodes bool
@@ -71,8 +73,7 @@ func (m command) build() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:        "dev-char-path",
-				Usage:       "The path at which the symlinks will be created. Symlinks will be created as `DEV_CHAR`/MAJOR:MINOR where MAJOR and MINOR are the major and minor numbers of a corresponding device node.",
-				Value:       defaultDevCharPath,
+				Usage:       "The path at which the symlinks will be created. Symlinks will be created as `DEV_CHAR`/MAJOR:MINOR where MAJOR and MINOR are the major and minor numbers of a corresponding device node. If this is not specified, DEV_ROOT/dev/char is used.",
 				Destination: &cfg.devCharPath,
 				Sources:     cli.EnvVars("DEV_CHAR_PATH"),
 			},
@@ -83,6 +84,15 @@ func (m command) build() *cli.Command {
 				Destination: &cfg.driverRoot,
 				Sources:     cli.EnvVars("NVIDIA_DRIVER_ROOT", "DRIVER_ROOT"),
 			},
+			&cli.StringFlag{
+				Name: "dev-root",
+				Usage: "The path to the root where /dev is located. `DEV_ROOT`/dev is searched for NVIDIA device nodes. " +
+					"This allows the command to be run in a driver container where the host /dev is available at a prefix. " +
+					"The created symlinks point to the device nodes relative to the dev root.",
+				Value:       "/",
+				Destination: &cfg.devRoot,
+				Sources:     cli.EnvVars("NVIDIA_DEV_ROOT", "DEV_ROOT"),
+			},
 			&cli.BoolFlag{
 				Name:        "create-all",
 				Usage:       "Create all possible /dev/char symlinks instead of limiting these to existing device nodes.",
@@ -133,6 +143,7 @@ func (m command) run(cfg *config) error {
 		WithLogger(m.logger),
 		WithDevCharPath(cfg.devCharPath),
 		WithDriverRoot(cfg.driverRoot),
+		WithDevRoot(cfg.devRoot),
 		WithDryRun(cfg.dryRun),
 		WithCreateAll(cfg.createAll),
 		WithLoadKernelModules(cfg.loadKernelModules),
@@ -185,7 +196,7 @@ func NewSymlinkCreator(opts ...Option) (Creator, error) {
 		c.devRoot = "/"
 	}
 	if c.devCharPath == "" {
-		c.devCharPath = defaultDevCharPath
+		c.devCharPath = filepath.Join(c.devRoot, defaultDevCharPath)
 	}
 
 	if err := c.setup(); err != nil {
@@ -252,6 +263,7 @@ func WithDevRoot(root string) Option {
 }
 
 // WithDevCharPath sets the path at which the symlinks will be created.
+// If this is not set, the symlinks are created in /dev/char in the dev root.
 func WithDevCharPath(path string) Option {
 	return func(c *linkCreator) {
 		c.devCharPath = path
@@ -308,7 +320,7 @@ func (m linkCreator) CreateLinks() error {
 	}
 
 	for _, deviceNode := range deviceNodes {
-		target := deviceNode.path
+		target := m.linkTarget(deviceNode.path)
 		linkPath := filepath.Join(m.devCharPath, deviceNode.devCharName())
 
 		m.logger.Infof("Creating link %s => %s", linkPath, target)
@@ -325,6 +337,20 @@ func (m linkCreator) CreateLinks() error {
 	return nil
 }
 
+// linkTarget returns the target of the symlink for the specified device node.
+// Since the symlinks are resolved relative to the dev root (e.g. on the host
+// when running in a driver container), the dev root prefix is removed.
+func (m linkCreator) linkTarget(path string) string {
+	if m.devRoot == "/" {
+		return path
+	}
+	relative, err := filepath.Rel(m.devRoot, path)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return path
+	}
+	return filepath.Join("/", relative)
+}
+
 type deviceNode struct {
 	path  string
 	major uint32
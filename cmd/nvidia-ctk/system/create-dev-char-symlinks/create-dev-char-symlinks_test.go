@@ -0,0 +1,126 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package devchar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+)
+
+type nodeListerMock []deviceNode
+
+func (m nodeListerMock) DeviceNodes() ([]deviceNode, error) {
+	return m, nil
+}
+
+func TestCreateLinks(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		devRoot       string
+		deviceNodes   []deviceNode
+		expectedLinks map[string]string
+	}{
+		{
+			description: "host dev root",
+			devRoot:     "/",
+			deviceNodes: []deviceNode{
+				{path: "/dev/nvidia0", major: 195, minor: 0},
+				{path: "/dev/nvidiactl", major: 195, minor: 255},
+			},
+			expectedLinks: map[string]string{
+				"195:0":   "/dev/nvidia0",
+				"195:255": "/dev/nvidiactl",
+			},
+		},
+		{
+			description: "prefixed dev root",
+			devRoot:     "/driver-root",
+			deviceNodes: []deviceNode{
+				{path: "/driver-root/dev/nvidia0", major: 195, minor: 0},
+				{path: "/driver-root/dev/nvidia-caps/nvidia-cap1", major: 237, minor: 1},
+			},
+			expectedLinks: map[string]string{
+				"195:0": "/dev/nvidia0",
+				"237:1": "/dev/nvidia-caps/nvidia-cap1",
+			},
+		},
+		{
+			description: "device node outside dev root is unchanged",
+			devRoot:     "/driver-root",
+			deviceNodes: []deviceNode{
+				{path: "/dev/nvidia0", major: 195, minor: 0},
+			},
+			expectedLinks: map[string]string{
+				"195:0": "/dev/nvidia0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devCharPath := filepath.Join(t.TempDir(), "dev/char")
+			creator := linkCreator{
+				logger:      logger,
+				lister:      nodeListerMock(tc.deviceNodes),
+				devRoot:     tc.devRoot,
+				devCharPath: devCharPath,
+			}
+
+			require.NoError(t, creator.CreateLinks())
+
+			entries, err := os.ReadDir(devCharPath)
+			require.NoError(t, err)
+			require.Len(t, entries, len(tc.expectedLinks))
+
+			for name, expectedTarget := range tc.expectedLinks {
+				target, err := os.Readlink(filepath.Join(devCharPath, name))
+				require.NoError(t, err)
+				require.Equal(t, expectedTarget, target)
+			}
+		})
+	}
+}
+
+func TestSymlinkCreatorWithDevRoot(t *testing.T) {
+	defer devices.SetAllForTest()()
+	logger, _ := testlog.NewNullLogger()
+
+	devRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev/nvidia0"), nil, 0600))
+
+	creator, err := NewSymlinkCreator(
+		WithLogger(logger),
+		WithDevRoot(devRoot),
+	)
+	require.NoError(t, err)
+	require.NoError(t, creator.CreateLinks())
+
+	// Regular files used as device nodes in the test have a 0:0 device number.
+	target, err := os.Readlink(filepath.Join(devRoot, "dev/char/0:0"))
+	require.NoError(t, err)
+	require.Equal(t, "/dev/nvidia0", target)
+}
@@ -0,0 +1,192 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package printconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// A source indicates where the effective value of a config option was taken
+// from.
+type source string
+
+const (
+	sourceDefault = source("default")
+	sourceFile    = source("file")
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	configFile string
+}
+
+// A resolvedValue represents the effective value of a single config option.
+type resolvedValue struct {
+	key    string
+	value  interface{}
+	source source
+}
+
+// NewCommand constructs a print-config command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "print-config",
+		Usage: "Print the effective NVIDIA Container Toolkit config annotated with the source of each value",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(os.Stdout, &opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "config-file",
+				Aliases:     []string{"config", "c"},
+				Usage:       "Specify the config file to resolve. The default is determined by the " + config.FilePathOverrideEnvVar + " and XDG_CONFIG_HOME envvars.",
+				Value:       config.GetConfigFilePath(),
+				Destination: &opts.configFile,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) run(w io.Writer, opts *options) error {
+	values, fileFound, err := resolveConfig(opts.configFile)
+	if err != nil {
+		return err
+	}
+
+	if fileFound {
+		fmt.Fprintf(w, "# config file: %v\n", opts.configFile)
+	} else {
+		fmt.Fprintf(w, "# config file: %v (not found)\n", opts.configFile)
+	}
+	for _, v := range values {
+		fmt.Fprintf(w, "%v = %v # %v\n", v.key, formatValue(v.value), v.source)
+	}
+	return nil
+}
+
+// resolveConfig returns the effective value of each config option when the
+// specified config file is applied on top of the defaults. The values are
+// sorted by key.
+func resolveConfig(configFile string) ([]resolvedValue, bool, error) {
+	var fileToml *config.Toml
+	if configFile != "" {
+		t, err := config.New(
+			config.WithConfigFile(configFile),
+			config.WithRequired(true),
+		)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, false, fmt.Errorf("failed to load config file: %w", err)
+		}
+		fileToml = t
+	}
+
+	cfg, err := fileToml.Config()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve config: %w", err)
+	}
+
+	contents, err := toml.Marshal(cfg)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	effective, err := toml.LoadBytes(contents)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load resolved config: %w", err)
+	}
+
+	var values []resolvedValue
+	for _, key := range leafKeys(effective, nil) {
+		v := resolvedValue{
+			key:    key,
+			value:  effective.Get(key),
+			source: sourceDefault,
+		}
+		if fileToml != nil && fileToml.Get(key) != nil {
+			v.source = sourceFile
+		}
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].key < values[j].key
+	})
+
+	return values, fileToml != nil, nil
+}
+
+// leafKeys returns the dotted keys of all non-table values in the tree.
+func leafKeys(tree *toml.Tree, prefix []string) []string {
+	var keys []string
+	for _, k := range tree.Keys() {
+		path := append(append([]string{}, prefix...), k)
+		if subtree, ok := tree.GetPath([]string{k}).(*toml.Tree); ok {
+			keys = append(keys, leafKeys(subtree, path)...)
+			continue
+		}
+		keys = append(keys, strings.Join(path, "."))
+	}
+	return keys
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		var elements []string
+		for _, e := range v {
+			elements = append(elements, formatValue(e))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case []string:
+		var elements []string
+		for _, e := range v {
+			elements = append(elements, strconv.Quote(e))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,142 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package printconfig
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveConfig(t *testing.T) {
+	testCases := []struct {
+		description       string
+		contents          *string
+		expectedFileFound bool
+		expectedValues    map[string]resolvedValue
+	}{
+		{
+			description:       "missing file uses defaults",
+			expectedFileFound: false,
+			expectedValues: map[string]resolvedValue{
+				"nvidia-container-runtime.mode": {
+					key:    "nvidia-container-runtime.mode",
+					value:  "auto",
+					source: sourceDefault,
+				},
+				"nvidia-container-runtime.log-level": {
+					key:    "nvidia-container-runtime.log-level",
+					value:  "info",
+					source: sourceDefault,
+				},
+			},
+		},
+		{
+			description: "file values override defaults",
+			contents: ptr(`
+#accept-nvidia-visible-devices-as-volume-mounts = true
+accept-nvidia-visible-devices-envvar-when-unprivileged = true
+
+[nvidia-container-runtime]
+log-level = "debug"
+runtimes = ["runc"]
+
+[features]
+enable-mps = true
+`),
+			expectedFileFound: true,
+			expectedValues: map[string]resolvedValue{
+				"accept-nvidia-visible-devices-as-volume-mounts": {
+					key:    "accept-nvidia-visible-devices-as-volume-mounts",
+					value:  false,
+					source: sourceDefault,
+				},
+				"accept-nvidia-visible-devices-envvar-when-unprivileged": {
+					key:    "accept-nvidia-visible-devices-envvar-when-unprivileged",
+					value:  true,
+					source: sourceFile,
+				},
+				"nvidia-container-runtime.mode": {
+					key:    "nvidia-container-runtime.mode",
+					value:  "auto",
+					source: sourceDefault,
+				},
+				"nvidia-container-runtime.log-level": {
+					key:    "nvidia-container-runtime.log-level",
+					value:  "debug",
+					source: sourceFile,
+				},
+				"nvidia-container-runtime.runtimes": {
+					key:    "nvidia-container-runtime.runtimes",
+					value:  []interface{}{"runc"},
+					source: sourceFile,
+				},
+				"features.enable-mps": {
+					key:    "features.enable-mps",
+					value:  true,
+					source: sourceFile,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.toml")
+			if tc.contents != nil {
+				require.NoError(t, os.WriteFile(configFile, []byte(*tc.contents), 0600))
+			}
+
+			values, fileFound, err := resolveConfig(configFile)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFileFound, fileFound)
+
+			resolved := make(map[string]resolvedValue)
+			for _, v := range values {
+				resolved[v.key] = v
+			}
+			for key, expected := range tc.expectedValues {
+				require.Contains(t, resolved, key)
+				require.EqualValues(t, expected, resolved[key])
+			}
+		})
+	}
+}
+
+func TestRunPrintsSources(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[nvidia-container-runtime]\nmode = \"cdi\"\n"), 0600))
+
+	output := &bytes.Buffer{}
+	err := command{logger: logger}.run(output, &options{configFile: configFile})
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "# config file: "+configFile+"\n")
+	require.Contains(t, output.String(), "nvidia-container-runtime.mode = \"cdi\" # file\n")
+	require.Contains(t, output.String(), "nvidia-container-runtime.log-level = \"info\" # default\n")
+}
+
+func ptr[T any](x T) *T {
+	return &x
+}
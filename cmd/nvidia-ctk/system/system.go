@@ -21,6 +21,7 @@ import (
 
 	devchar "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-dev-char-symlinks"
 	devicenodes "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/create-device-nodes"
+	printconfig "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system/print-config"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -44,6 +45,7 @@ func (m command) build() *cli.Command {
 		Commands: []*cli.Command{
 			devchar.NewCommand(m.logger),
 			devicenodes.NewCommand(m.logger),
+			printconfig.NewCommand(m.logger),
 		},
 	}
 
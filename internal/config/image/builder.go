@@ -28,7 +28,8 @@ import (
 type builder struct {
 	CUDA
 
-	disableRequire bool
+	disableRequire     bool
+	visibleDevicesFile string
 }
 
 // Option is a functional option for creating a CUDA image.
@@ -63,6 +64,8 @@ func (b builder) build() (CUDA, error) {
 		b.env[EnvVarNvidiaDisableRequire] = "true"
 	}
 
+	b.readVisibleDevicesFile()
+
 	return b.CUDA, nil
 }
 
@@ -94,6 +97,15 @@ func WithAnnotationsPrefixes(annotationsPrefixes ...string) Option {
 	}
 }
 
+// WithDefaultVisibleDevices sets the visible devices to use if these are not
+// requested through the environment of the container.
+func WithDefaultVisibleDevices(defaultVisibleDevices string) Option {
+	return func(b *builder) error {
+		b.defaultVisibleDevices = defaultVisibleDevices
+		return nil
+	}
+}
+
 // WithDisableRequire sets the disable require option.
 func WithDisableRequire(disableRequire bool) Option {
 	return func(b *builder) error {
@@ -103,6 +115,8 @@ func WithDisableRequire(disableRequire bool) Option {
 }
 
 // WithEnv sets the environment variables to use when creating the CUDA image.
+// If an environment variable is specified more than once, the last value is
+// used. This matches the behaviour of execve for the container process.
 // Note that this also overwrites the values set with WithEnvMap.
 func WithEnv(env []string) Option {
 	return func(b *builder) error {
@@ -178,3 +192,14 @@ func WithPrivileged(isPrivileged bool) Option {
 		return nil
 	}
 }
+
+// WithVisibleDevicesFile sets the path to the file from which the visible
+// devices are read if the container requests this file. This path must only be
+// taken from a trusted source such as the runtime config and never from the
+// environment of the container.
+func WithVisibleDevicesFile(visibleDevicesFile string) Option {
+	return func(b *builder) error {
+		b.visibleDevicesFile = visibleDevicesFile
+		return nil
+	}
+}
@@ -37,6 +37,21 @@ const (
 	volumeMountDevicePrefixImex = "imex/"
 )
 
+// imageNameAnnotations are the OCI spec annotations that container engines use
+// to record the image reference that a container was created from.
+var imageNameAnnotations = []string{
+	"io.kubernetes.cri.image-name",
+	"io.kubernetes.cri-o.ImageName",
+	"org.opencontainers.image.ref.name",
+}
+
+// namespaceAnnotations are the OCI spec annotations that container engines use
+// to record the Kubernetes namespace of the pod that a container belongs to.
+var namespaceAnnotations = []string{
+	"io.kubernetes.cri.sandbox-namespace",
+	"io.kubernetes.pod.namespace",
+}
+
 // CUDA represents a CUDA image that can be used for GPU computing. This wraps
 // a map of environment variable to values that can be used to perform lookups
 // such as requirements.
@@ -51,8 +66,14 @@ type CUDA struct {
 	annotationsPrefixes            []string
 	acceptDeviceListAsVolumeMounts bool
 	acceptEnvvarUnprivileged       bool
+	defaultVisibleDevices          string
 	ignoreImexChannelRequests      bool
 	preferredVisibleDeviceEnvVars  []string
+	// useVisibleDevicesFile is set if the container requested the devices
+	// listed in the configured visible devices file. The devices read from
+	// the file are stored in visibleDevicesFromFile.
+	useVisibleDevicesFile  bool
+	visibleDevicesFromFile []string
 }
 
 // NewCUDAImageFromSpec creates a CUDA image from the input OCI runtime spec.
@@ -145,6 +166,53 @@ func (i CUDA) HasDisableRequire() bool {
 	return false
 }
 
+// HasDisableLDCacheUpdate checks for the value of the
+// NVIDIA_DISABLE_LDCACHE_UPDATE envvar. If set to a valid (true) boolean value
+// the update-ldcache hook is not injected into the container.
+func (i CUDA) HasDisableLDCacheUpdate() bool {
+	d, _ := strconv.ParseBool(i.env[EnvVarNvidiaDisableLDCacheUpdate])
+	return d
+}
+
+// SkipMounts returns the list of mount destinations that the image has
+// requested not be injected. These are specified as a comma-separated list in
+// the NVIDIA_SKIP_MOUNTS environment variable.
+func (i CUDA) SkipMounts() []string {
+	var destinations []string
+	for _, destination := range strings.Split(i.env[EnvVarNvidiaSkipMounts], ",") {
+		destination = strings.TrimSpace(destination)
+		if destination == "" {
+			continue
+		}
+		destinations = append(destinations, destination)
+	}
+	return destinations
+}
+
+// ImageName returns the image reference that the container was created from.
+// This is read from the OCI spec annotations set by the container engine. If
+// no such annotation is present, an empty string is returned.
+func (i CUDA) ImageName() string {
+	for _, key := range imageNameAnnotations {
+		if name := strings.TrimSpace(i.annotations[key]); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// Namespace returns the Kubernetes namespace of the pod that the container
+// belongs to. This is read from the OCI spec annotations set by the container
+// engine. If no such annotation is present, an empty string is returned.
+func (i CUDA) Namespace() string {
+	for _, key := range namespaceAnnotations {
+		if namespace := strings.TrimSpace(i.annotations[key]); namespace != "" {
+			return namespace
+		}
+	}
+	return ""
+}
+
 // devicesFromEnvvars returns the devices requested by the image through environment variables
 func (i CUDA) devicesFromEnvvars(envVars ...string) []string {
 	// We concantenate all the devices from the specified env.
@@ -327,8 +395,25 @@ func (i CUDA) cdiDeviceRequestsFromAnnotations() []string {
 // If any of the preferredVisibleDeviceEnvVars are present in the image, they
 // are used to determine the visible devices. If this is not the case, the
 // NVIDIA_VISIBLE_DEVICES environment variable is used.
+//
+// If the container requested the configured visible devices file, the devices
+// read from this file are used instead.
+//
+// The environment of the container always takes precedence over the runtime
+// default. The default visible devices are only used if none of these
+// environment variables are set in the container.
 func (i CUDA) visibleDevicesFromEnvVar() []string {
+	if i.useVisibleDevicesFile {
+		return i.visibleDevicesFromFile
+	}
 	envVars := i.visibleEnvVars()
+	if i.defaultVisibleDevices != "" && !slices.ContainsFunc(envVars, i.HasEnvvar) {
+		i.logger.Debugf("No visible devices set in container; using default %q", i.defaultVisibleDevices)
+		defaults := CUDA{
+			env: map[string]string{EnvVarNvidiaVisibleDevices: i.defaultVisibleDevices},
+		}
+		return defaults.devicesFromEnvvars(EnvVarNvidiaVisibleDevices)
+	}
 	return i.devicesFromEnvvars(envVars...)
 }
 
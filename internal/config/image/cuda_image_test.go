@@ -60,6 +60,19 @@ func TestNewCUDAImageFromSpec(t *testing.T) {
 				acceptEnvvarUnprivileged: true,
 			},
 		},
+		{
+			description: "last duplicate NVIDIA_VISIBLE_DEVICES wins",
+			spec: &specs.Spec{
+				Process: &specs.Process{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_VISIBLE_DEVICES=0,1"},
+				},
+			},
+			expected: CUDA{
+				logger:                   logger,
+				env:                      map[string]string{"NVIDIA_VISIBLE_DEVICES": "0,1"},
+				acceptEnvvarUnprivileged: true,
+			},
+		},
 		{
 			description: "Spec overrides options",
 			spec: &specs.Spec{
@@ -224,6 +237,7 @@ func TestGetDevicesFromEnvvar(t *testing.T) {
 	var tests = []struct {
 		description                   string
 		preferredVisibleDeviceEnvVars []string
+		defaultVisibleDevices         string
 		env                           map[string]string
 		expectedDevices               []string
 	}{
@@ -416,6 +430,35 @@ func TestGetDevicesFromEnvvar(t *testing.T) {
 			},
 			expectedDevices: []string{anotherGPUID},
 		},
+		{
+			description:           "default visible devices are used if NVIDIA_VISIBLE_DEVICES is unset",
+			defaultVisibleDevices: gpuID + "," + anotherGPUID,
+			expectedDevices:       []string{gpuID, anotherGPUID},
+		},
+		{
+			description:           "NVIDIA_VISIBLE_DEVICES takes precedence over default visible devices",
+			defaultVisibleDevices: "all",
+			env: map[string]string{
+				EnvVarNvidiaVisibleDevices: gpuID,
+			},
+			expectedDevices: []string{gpuID},
+		},
+		{
+			description:           "void NVIDIA_VISIBLE_DEVICES takes precedence over default visible devices",
+			defaultVisibleDevices: "all",
+			env: map[string]string{
+				EnvVarNvidiaVisibleDevices: "void",
+			},
+		},
+		{
+			description:                   "swarm resource envvar takes precedence over default visible devices",
+			preferredVisibleDeviceEnvVars: []string{"DOCKER_RESOURCE_GPUS"},
+			defaultVisibleDevices:         "all",
+			env: map[string]string{
+				"DOCKER_RESOURCE_GPUS": thirdGPUID,
+			},
+			expectedDevices: []string{thirdGPUID},
+		},
 	}
 
 	for _, tc := range tests {
@@ -426,6 +469,7 @@ func TestGetDevicesFromEnvvar(t *testing.T) {
 				WithAcceptDeviceListAsVolumeMounts(false),
 				WithAcceptEnvvarUnprivileged(false),
 				WithPreferredVisibleDevicesEnvVars(tc.preferredVisibleDeviceEnvVars...),
+				WithDefaultVisibleDevices(tc.defaultVisibleDevices),
 			)
 
 			require.NoError(t, err)
@@ -723,6 +767,38 @@ func TestImexChannelsFromEnvVar(t *testing.T) {
 	}
 }
 
+func TestSkipMounts(t *testing.T) {
+	testCases := []struct {
+		description string
+		env         []string
+		expected    []string
+	}{
+		{
+			description: "no skipped mounts specified",
+		},
+		{
+			description: "empty envvar",
+			env:         []string{"NVIDIA_SKIP_MOUNTS="},
+		},
+		{
+			description: "skipped mounts specified",
+			env: []string{
+				"NVIDIA_SKIP_MOUNTS=/usr/lib/x86_64-linux-gnu/libcuda.so.1, /usr/bin/nvidia-smi,",
+			},
+			expected: []string{"/usr/lib/x86_64-linux-gnu/libcuda.so.1", "/usr/bin/nvidia-smi"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			i, err := newCUDAImageFromEnv(tc.env)
+			require.NoError(t, err)
+
+			require.EqualValues(t, tc.expected, i.SkipMounts())
+		})
+	}
+}
+
 func TestCDIDeviceRequestsFromAnnotations(t *testing.T) {
 	testCases := []struct {
 		description     string
@@ -0,0 +1,92 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// readVisibleDevicesFile reads the visible devices from the configured visible
+// devices file if this is requested by the container. Since the file is read by
+// the runtime on the host, this also allows a file descriptor passed by the
+// caller to be referenced as /dev/fd/N. The file is only read once when the
+// image is built since such a file descriptor can only be read once.
+//
+// A container requests the devices listed in the file by setting the
+// NVIDIA_VISIBLE_DEVICES_FILE envvar to the configured path. The path of the
+// file is always taken from the runtime config and not from the environment of
+// the container since an arbitrary host file would otherwise be read on behalf
+// of the container.
+func (b *builder) readVisibleDevicesFile() {
+	requestedFile, requested := b.env[EnvVarNvidiaVisibleDevicesFile]
+	if !requested {
+		return
+	}
+	if b.visibleDevicesFile == "" {
+		b.logger.Warningf("Ignoring %v set in container; no visible devices file is configured", EnvVarNvidiaVisibleDevicesFile)
+		return
+	}
+	if requestedFile != b.visibleDevicesFile {
+		b.logger.Warningf("Ignoring %v=%v set in container; only the configured visible devices file %v can be requested", EnvVarNvidiaVisibleDevicesFile, requestedFile, b.visibleDevicesFile)
+		return
+	}
+
+	b.useVisibleDevicesFile = true
+
+	file, err := os.Open(b.visibleDevicesFile)
+	if err != nil {
+		b.logger.Warningf("Ignoring visible devices file: %v", err)
+		return
+	}
+	defer file.Close()
+
+	requestedDevices, err := parseDeviceList(file)
+	if err != nil {
+		b.logger.Warningf("Ignoring visible devices file: failed to parse %v: %v", b.visibleDevicesFile, err)
+		return
+	}
+
+	fromFile := CUDA{
+		env: map[string]string{EnvVarNvidiaVisibleDevices: strings.Join(requestedDevices, ",")},
+	}
+	b.visibleDevicesFromFile = fromFile.devicesFromEnvvars(EnvVarNvidiaVisibleDevices)
+}
+
+// parseDeviceList parses a newline-delimited list of devices. Blank lines are
+// ignored and a # starts a comment that extends to the end of the line. As is
+// the case for NVIDIA_VISIBLE_DEVICES, a line may also contain a
+// comma-separated list of devices.
+func parseDeviceList(r io.Reader) ([]string, error) {
+	var devices []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		device := strings.TrimSpace(line)
+		if device == "" {
+			continue
+		}
+		devices = append(devices, device)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
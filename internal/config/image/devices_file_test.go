@@ -0,0 +1,234 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package image
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibleDevicesFromFile(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		contents        *string
+		requestFile     bool
+		env             map[string]string
+		privileged      bool
+		expectedDevices []string
+	}{
+		{
+			description:     "devices are read from file",
+			contents:        ptr("GPU0\nGPU1\n"),
+			requestFile:     true,
+			privileged:      true,
+			expectedDevices: []string{"GPU0", "GPU1"},
+		},
+		{
+			description: "comments and blank lines are ignored",
+			contents: ptr(`# Devices requested by the scheduler
+GPU-12345
+
+  GPU-67890  # second device
+
+nvidia.com/gpu=2
+`),
+			requestFile:     true,
+			privileged:      true,
+			expectedDevices: []string{"GPU-12345", "GPU-67890", "nvidia.com/gpu=2"},
+		},
+		{
+			description:     "comma-separated devices in a line are split",
+			contents:        ptr("0,1\n2"),
+			requestFile:     true,
+			privileged:      true,
+			expectedDevices: []string{"0", "1", "2"},
+		},
+		{
+			description:     "all is supported",
+			contents:        ptr("all\n"),
+			requestFile:     true,
+			privileged:      true,
+			expectedDevices: []string{"all"},
+		},
+		{
+			description:     "file with only comments requests no devices",
+			contents:        ptr("# no devices\n\n"),
+			requestFile:     true,
+			privileged:      true,
+			expectedDevices: nil,
+		},
+		{
+			description:     "file takes precedence over NVIDIA_VISIBLE_DEVICES",
+			contents:        ptr("GPU0\n"),
+			requestFile:     true,
+			env:             map[string]string{EnvVarNvidiaVisibleDevices: "all"},
+			privileged:      true,
+			expectedDevices: []string{"GPU0"},
+		},
+		{
+			description:     "missing file requests no devices",
+			requestFile:     true,
+			env:             map[string]string{EnvVarNvidiaVisibleDevices: "all"},
+			privileged:      true,
+			expectedDevices: nil,
+		},
+		{
+			description:     "file is used for unprivileged container",
+			contents:        ptr("GPU0\n"),
+			requestFile:     true,
+			expectedDevices: []string{"GPU0"},
+		},
+		{
+			description:     "file is not used for container that does not request it",
+			contents:        ptr("GPU0\n"),
+			expectedDevices: nil,
+		},
+		{
+			description:     "NVIDIA_VISIBLE_DEVICES is used for container that does not request the file",
+			contents:        ptr("GPU0\n"),
+			env:             map[string]string{EnvVarNvidiaVisibleDevices: "GPU1"},
+			expectedDevices: []string{"GPU1"},
+		},
+		{
+			description: "file is not used if a different path is requested",
+			contents:    ptr("GPU0\n"),
+			env: map[string]string{
+				EnvVarNvidiaVisibleDevicesFile: "/some/other/file",
+				EnvVarNvidiaVisibleDevices:     "GPU1",
+			},
+			expectedDevices: []string{"GPU1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devicesFile := filepath.Join(t.TempDir(), "devices")
+			if tc.contents != nil {
+				require.NoError(t, os.WriteFile(devicesFile, []byte(*tc.contents), 0600))
+			}
+			env := make(map[string]string)
+			for k, v := range tc.env {
+				env[k] = v
+			}
+			if tc.requestFile {
+				env[EnvVarNvidiaVisibleDevicesFile] = devicesFile
+			}
+
+			image, err := New(
+				WithLogger(logger),
+				WithEnvMap(env),
+				WithPrivileged(tc.privileged),
+				WithVisibleDevicesFile(devicesFile),
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDevices, image.VisibleDevices())
+		})
+	}
+}
+
+func TestVisibleDevicesFileIsReadOnce(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	_, err = w.WriteString("GPU0\nGPU1\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	devicesFile := fmt.Sprintf("/dev/fd/%d", r.Fd())
+	image, err := New(
+		WithLogger(logger),
+		WithEnvMap(map[string]string{EnvVarNvidiaVisibleDevicesFile: devicesFile}),
+		WithVisibleDevicesFile(devicesFile),
+	)
+	require.NoError(t, err)
+
+	for range 3 {
+		require.Equal(t, []string{"GPU0", "GPU1"}, image.VisibleDevices())
+	}
+}
+
+func TestVisibleDevicesFileFromContainerIsRejected(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	devicesFile := filepath.Join(t.TempDir(), "devices")
+	require.NoError(t, os.WriteFile(devicesFile, []byte("GPU0\n"), 0600))
+
+	testCases := []struct {
+		description     string
+		privileged      bool
+		env             map[string]string
+		expectedDevices []string
+	}{
+		{
+			description: "image-supplied path is ignored",
+			privileged:  true,
+			env: map[string]string{
+				EnvVarNvidiaVisibleDevicesFile: devicesFile,
+			},
+			expectedDevices: nil,
+		},
+		{
+			description: "image-supplied path is ignored for unprivileged container",
+			env: map[string]string{
+				EnvVarNvidiaVisibleDevicesFile: devicesFile,
+			},
+			expectedDevices: nil,
+		},
+		{
+			description: "NVIDIA_VISIBLE_DEVICES is used instead of image-supplied path",
+			privileged:  true,
+			env: map[string]string{
+				EnvVarNvidiaVisibleDevicesFile: devicesFile,
+				EnvVarNvidiaVisibleDevices:     "GPU1",
+			},
+			expectedDevices: []string{"GPU1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			image, err := New(
+				WithLogger(logger),
+				WithEnvMap(tc.env),
+				WithPrivileged(tc.privileged),
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDevices, image.VisibleDevices())
+		})
+	}
+}
+
+func TestParseDeviceList(t *testing.T) {
+	devices, err := parseDeviceList(strings.NewReader("\n# comment\nGPU0 # trailing\n\t\nGPU1"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"GPU0", "GPU1"}, devices)
+}
+
+func ptr[T any](x T) *T {
+	return &x
+}
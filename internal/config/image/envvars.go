@@ -17,15 +17,19 @@
 package image
 
 const (
-	EnvVarCudaVersion              = "CUDA_VERSION"
-	EnvVarNvidiaDisableRequire     = "NVIDIA_DISABLE_REQUIRE"
-	EnvVarNvidiaDriverCapabilities = "NVIDIA_DRIVER_CAPABILITIES"
-	EnvVarNvidiaImexChannels       = "NVIDIA_IMEX_CHANNELS"
-	EnvVarNvidiaMigConfigDevices   = "NVIDIA_MIG_CONFIG_DEVICES"
-	EnvVarNvidiaMigMonitorDevices  = "NVIDIA_MIG_MONITOR_DEVICES"
-	EnvVarNvidiaRequireCuda        = NvidiaRequirePrefix + "CUDA"
-	EnvVarNvidiaRequireJetpack     = NvidiaRequirePrefix + "JETPACK"
-	EnvVarNvidiaVisibleDevices     = "NVIDIA_VISIBLE_DEVICES"
+	EnvVarCudaVersion                = "CUDA_VERSION"
+	EnvVarNvidiaContainerRuntimeMode = "NVIDIA_CONTAINER_RUNTIME_MODE"
+	EnvVarNvidiaDisableLDCacheUpdate = "NVIDIA_DISABLE_LDCACHE_UPDATE"
+	EnvVarNvidiaDisableRequire       = "NVIDIA_DISABLE_REQUIRE"
+	EnvVarNvidiaDriverCapabilities   = "NVIDIA_DRIVER_CAPABILITIES"
+	EnvVarNvidiaImexChannels         = "NVIDIA_IMEX_CHANNELS"
+	EnvVarNvidiaMigConfigDevices     = "NVIDIA_MIG_CONFIG_DEVICES"
+	EnvVarNvidiaMigMonitorDevices    = "NVIDIA_MIG_MONITOR_DEVICES"
+	EnvVarNvidiaRequireCuda          = NvidiaRequirePrefix + "CUDA"
+	EnvVarNvidiaRequireJetpack       = NvidiaRequirePrefix + "JETPACK"
+	EnvVarNvidiaSkipMounts           = "NVIDIA_SKIP_MOUNTS"
+	EnvVarNvidiaVisibleDevices       = "NVIDIA_VISIBLE_DEVICES"
+	EnvVarNvidiaVisibleDevicesFile   = "NVIDIA_VISIBLE_DEVICES_FILE"
 
 	NvidiaRequirePrefix = "NVIDIA_REQUIRE_"
 )
@@ -61,7 +61,14 @@ var lib *dl.DynamicLibrary
 // Version returns the CUDA version of the driver as a string or an error if this
 // cannot be determined.
 func Version() (string, error) {
-	lib, err := load()
+	return VersionFromLibrary(libraryName)
+}
+
+// VersionFromLibrary returns the CUDA version of the driver as a string using
+// the specified CUDA driver library. This allows the version of a driver
+// installed at a driver root other than / to be determined.
+func VersionFromLibrary(library string) (string, error) {
+	lib, err := load(library)
 	if err != nil {
 		return "", err
 	}
@@ -85,7 +92,7 @@ func Version() (string, error) {
 // ComputeCapability returns the CUDA compute capability of a device with the specified index as a string
 // or an error if this cannot be determined.
 func ComputeCapability(index int) (string, error) {
-	lib, err := load()
+	lib, err := load(libraryName)
 	if err != nil {
 		return "", err
 	}
@@ -124,8 +131,8 @@ func ComputeCapability(index int) (string, error) {
 	return fmt.Sprintf("%d.%d", major, minor), nil
 }
 
-func load() (*dl.DynamicLibrary, error) {
-	lib := dl.New(libraryName, libraryLoadFlags)
+func load(library string) (*dl.DynamicLibrary, error) {
+	lib := dl.New(library, libraryLoadFlags)
 	if lib == nil {
 		return nil, fmt.Errorf("error instantiating DynamicLibrary for CUDA")
 	}
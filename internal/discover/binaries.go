@@ -0,0 +1,94 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+// driverBinaries defines the driver executables that are required for a
+// specific driver capability.
+type driverBinaries struct {
+	capability  image.DriverCapability
+	executables []string
+}
+
+// driverBinariesByCapability lists the driver binaries that are injected for
+// each driver capability. This matches the binaries that libnvidia-container
+// injects for these capabilities.
+var driverBinariesByCapability = []driverBinaries{
+	{
+		capability: image.DriverCapabilityUtility,
+		executables: []string{
+			"nvidia-smi",          /* System management interface */
+			"nvidia-debugdump",    /* GPU coredump utility */
+			"nvidia-persistenced", /* Persistence mode utility */
+		},
+	},
+	{
+		capability: image.DriverCapabilityCompute,
+		executables: []string{
+			"nvidia-cuda-mps-control", /* Multi process service CLI */
+			"nvidia-cuda-mps-server",  /* Multi process service server */
+			"nvidia-imex",             /* NVIDIA IMEX Daemon */
+			"nvidia-imex-ctl",         /* NVIDIA IMEX control */
+		},
+	},
+}
+
+// NewDriverExecutablesDiscoverer creates a discoverer for the driver
+// executables that are required for the specified driver capabilities. The
+// libraries that these executables depend on are not included since these are
+// discovered as part of the driver libraries.
+func NewDriverExecutablesDiscoverer(logger logger.Interface, driverRoot string, capabilities image.DriverCapabilities) Discover {
+	executables := DriverExecutables(capabilities)
+	if len(executables) == 0 {
+		return None{}
+	}
+
+	return NewMounts(
+		logger,
+		lookup.NewExecutableLocator(logger, driverRoot),
+		driverRoot,
+		executables,
+	)
+}
+
+// DriverExecutables returns the names of the driver executables that are
+// required for the specified driver capabilities.
+func DriverExecutables(capabilities image.DriverCapabilities) []string {
+	var executables []string
+	for _, b := range driverBinariesForCapabilities(capabilities) {
+		executables = append(executables, b.executables...)
+	}
+	return executables
+}
+
+// driverBinariesForCapabilities returns the driver binaries for the specified
+// capabilities.
+func driverBinariesForCapabilities(capabilities image.DriverCapabilities) []driverBinaries {
+	var selected []driverBinaries
+	for _, b := range driverBinariesByCapability {
+		if capabilities.Has(b.capability) {
+			selected = append(selected, b)
+		}
+	}
+	return selected
+}
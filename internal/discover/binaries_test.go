@@ -0,0 +1,106 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestDriverExecutablesDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	binDir := filepath.Join(driverRoot, "usr/bin")
+	require.NoError(t, os.MkdirAll(binDir, 0755))
+	for _, binary := range []string{
+		"nvidia-smi",
+		"nvidia-debugdump",
+		"nvidia-persistenced",
+		"nvidia-cuda-mps-control",
+		"nvidia-cuda-mps-server",
+		"nvidia-imex",
+		"nvidia-imex-ctl",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, binary), nil, 0755))
+	}
+
+	testCases := []struct {
+		description    string
+		capabilities   string
+		expectedMounts []string
+	}{
+		{
+			description:  "utility",
+			capabilities: "utility",
+			expectedMounts: []string{
+				"/usr/bin/nvidia-smi",
+				"/usr/bin/nvidia-debugdump",
+				"/usr/bin/nvidia-persistenced",
+			},
+		},
+		{
+			description:  "compute",
+			capabilities: "compute",
+			expectedMounts: []string{
+				"/usr/bin/nvidia-cuda-mps-control",
+				"/usr/bin/nvidia-cuda-mps-server",
+				"/usr/bin/nvidia-imex",
+				"/usr/bin/nvidia-imex-ctl",
+			},
+		},
+		{
+			description:  "default capabilities",
+			capabilities: "compute,utility",
+			expectedMounts: []string{
+				"/usr/bin/nvidia-smi",
+				"/usr/bin/nvidia-debugdump",
+				"/usr/bin/nvidia-persistenced",
+				"/usr/bin/nvidia-cuda-mps-control",
+				"/usr/bin/nvidia-cuda-mps-server",
+				"/usr/bin/nvidia-imex",
+				"/usr/bin/nvidia-imex-ctl",
+			},
+		},
+		{
+			description:  "graphics only",
+			capabilities: "graphics",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			d := NewDriverExecutablesDiscoverer(logger, driverRoot, image.NewDriverCapabilities(tc.capabilities))
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+
+			var paths []string
+			for _, m := range mounts {
+				paths = append(paths, m.Path)
+			}
+			require.Equal(t, tc.expectedMounts, paths)
+		})
+	}
+}
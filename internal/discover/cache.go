@@ -93,3 +93,7 @@ func (c *cache) Mounts() ([]Mount, error) {
 	}
 	return c.mounts, nil
 }
+
+func (c *cache) missing() []string {
+	return missingFrom(c.d)
+}
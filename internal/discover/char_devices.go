@@ -42,6 +42,11 @@ func (d *charDevices) Mounts() ([]Mount, error) {
 	return nil, nil
 }
 
+// missing returns the device nodes that could not be located.
+func (d *charDevices) missing() []string {
+	return (*mounts)(d).missing()
+}
+
 // Devices returns the discovered devices for the charDevices.
 // Here the device nodes are first discovered as mounts and these are converted to devices.
 func (d *charDevices) Devices() ([]Device, error) {
@@ -0,0 +1,67 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// NewCheckLibrariesHook creates a discoverer that adds a hook to verify that
+// libcuda.so.1 resolves in the container if a libcuda library is included in
+// the specified mounts. The folders of the libcuda libraries are passed to the
+// hook as additional search paths.
+func NewCheckLibrariesHook(logger logger.Interface, mounts Discover, hookCreator HookCreator) Discover {
+	return &checkLibraries{
+		logger:      logger,
+		hookCreator: hookCreator,
+		mountsFrom:  mounts,
+	}
+}
+
+type checkLibraries struct {
+	None
+	logger      logger.Interface
+	hookCreator HookCreator
+	mountsFrom  Discover
+}
+
+// Hooks returns a check-libraries hook for the folders of the discovered
+// libcuda libraries. No hook is returned if no libcuda library is discovered.
+func (d checkLibraries) Hooks() ([]Hook, error) {
+	mounts, err := d.mountsFrom.Mounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover mounts for library check: %w", err)
+	}
+
+	var libcudaPaths []string
+	for _, m := range mounts {
+		if match, _ := filepath.Match("libcuda.so.*", filepath.Base(m.Path)); !match {
+			continue
+		}
+		libcudaPaths = append(libcudaPaths, m.Path)
+	}
+	if len(libcudaPaths) == 0 {
+		d.logger.Debugf("No libcuda library found; skipping library check")
+		return nil, nil
+	}
+
+	return d.hookCreator.Create(CheckLibrariesHook, uniqueFolders(libcudaPaths)...).Hooks()
+}
@@ -0,0 +1,114 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLibrariesHook(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		enabledHooks  []HookName
+		mounts        []Mount
+		mountError    error
+		expectedError error
+		expectedHooks []Hook
+	}{
+		{
+			description:  "no mounts",
+			enabledHooks: []HookName{CheckLibrariesHook},
+		},
+		{
+			description:   "mount error",
+			enabledHooks:  []HookName{CheckLibrariesHook},
+			mountError:    fmt.Errorf("mountError"),
+			expectedError: fmt.Errorf("mountError"),
+		},
+		{
+			description:  "no libcuda library",
+			enabledHooks: []HookName{CheckLibrariesHook},
+			mounts: []Mount{
+				{
+					Path: "/usr/lib/libnvidia-ml.so.1.2.3",
+				},
+			},
+		},
+		{
+			description:  "libcuda folders are added to args",
+			enabledHooks: []HookName{CheckLibrariesHook},
+			mounts: []Mount{
+				{
+					Path: "/usr/lib/libnvidia-ml.so.1.2.3",
+				},
+				{
+					Path: "/usr/lib/libcuda.so.1.2.3",
+				},
+				{
+					Path: "/usr/lib/compat/libcuda.so.1.2.3",
+				},
+			},
+			expectedHooks: []Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      testNvidiaCDIHookPath,
+					Args:      []string{"nvidia-cdi-hook", "check-libraries", "--folder", "/usr/lib", "--folder", "/usr/lib/compat"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+		{
+			description: "hook is disabled by default",
+			mounts: []Mount{
+				{
+					Path: "/usr/lib/libcuda.so.1.2.3",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hookCreator := NewHookCreator(
+				WithNVIDIACDIHookPath(testNvidiaCDIHookPath),
+				WithEnabledHooks(tc.enabledHooks...),
+			)
+			mountMock := &DiscoverMock{
+				MountsFunc: func() ([]Mount, error) {
+					return tc.mounts, tc.mountError
+				},
+			}
+			d := NewCheckLibrariesHook(logger, mountMock, hookCreator)
+
+			hooks, err := d.Hooks()
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedHooks, hooks)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+const (
+	defaultCUDAForwardCompatContainerRoot = "/usr/local/cuda/compat"
+)
+
+// cudaForwardCompatLibraries lists the libraries that are included in the
+// CUDA Forward Compatibility package.
+var cudaForwardCompatLibraries = []string{
+	"libcuda.so.*",
+	"libcudadebugger.so.*",
+	"libnvidia-nvvm.so.*",
+	"libnvidia-ptxjitcompiler.so.*",
+}
+
+// CUDAForwardCompatOptions defines the options that can be specified when
+// creating a discoverer for the CUDA Forward Compatibility libraries provided
+// by the host.
+type CUDAForwardCompatOptions struct {
+	// HostCompatRoot is the folder containing the CUDA Forward Compatibility
+	// libraries. The configured locator is used to resolve this folder.
+	HostCompatRoot string
+	// ContainerCompatRoot is the folder in the container to which the CUDA
+	// Forward Compatibility libraries are mounted.
+	ContainerCompatRoot string
+	// HostCUDAVersion is the CUDA version supported by the host driver.
+	HostCUDAVersion string
+	// ContainerCUDAVersion is the CUDA version required by the container.
+	ContainerCUDAVersion string
+}
+
+type cudaForwardCompat struct {
+	None
+	logger      logger.Interface
+	hookCreator HookCreator
+	locator     lookup.Locator
+	CUDAForwardCompatOptions
+}
+
+// NewCUDAForwardCompatDiscoverer creates a discoverer for the CUDA Forward
+// Compatibility libraries provided by the host. The libraries, and a hook to
+// add them to the ldcache in the container, are only returned if the CUDA
+// version required by the container is newer than the CUDA version supported
+// by the host driver.
+func NewCUDAForwardCompatDiscoverer(logger logger.Interface, hookCreator HookCreator, locator lookup.Locator, o *CUDAForwardCompatOptions) Discover {
+	if o == nil || o.HostCompatRoot == "" {
+		return None{}
+	}
+
+	d := &cudaForwardCompat{
+		logger:                   logger,
+		hookCreator:              hookCreator,
+		locator:                  locator,
+		CUDAForwardCompatOptions: *o,
+	}
+	if d.ContainerCompatRoot == "" {
+		d.ContainerCompatRoot = defaultCUDAForwardCompatContainerRoot
+	}
+
+	if !d.isRequired() {
+		return None{}
+	}
+	return d
+}
+
+// Mounts returns the CUDA Forward Compatibility libraries located in the host
+// compat root. These are mounted to the container compat root.
+func (d *cudaForwardCompat) Mounts() ([]Mount, error) {
+	var mounts []Mount
+	for _, library := range cudaForwardCompatLibraries {
+		candidates, err := d.locator.Locate(filepath.Join(d.HostCompatRoot, library))
+		if err != nil {
+			d.logger.Debugf("Could not locate %v in %v: %v", library, d.HostCompatRoot, err)
+			continue
+		}
+		for _, hostPath := range candidates {
+			mount := Mount{
+				HostPath: hostPath,
+				Path:     filepath.Join(d.ContainerCompatRoot, filepath.Base(hostPath)),
+				Options: []string{
+					"ro",
+					"nosuid",
+					"nodev",
+					"rbind",
+					"rprivate",
+				},
+			}
+			mounts = append(mounts, mount)
+		}
+	}
+	return mounts, nil
+}
+
+// Hooks returns a hook to add the container compat root to the ldcache in the
+// container. No hook is returned if no compat libraries are located.
+func (d *cudaForwardCompat) Hooks() ([]Hook, error) {
+	mounts, err := d.Mounts()
+	if err != nil {
+		return nil, err
+	}
+	if len(mounts) == 0 {
+		return nil, nil
+	}
+	return d.hookCreator.Create(UpdateLDCacheHook, d.ContainerCompatRoot).Hooks()
+}
+
+// isRequired checks whether the CUDA version required by the container is
+// newer than the CUDA version supported by the host driver.
+func (d *cudaForwardCompat) isRequired() bool {
+	if d.HostCUDAVersion == "" || d.ContainerCUDAVersion == "" {
+		d.logger.Debugf("Skipping CUDA Forward Compatibility; host CUDA version %q, container CUDA version %q", d.HostCUDAVersion, d.ContainerCUDAVersion)
+		return false
+	}
+
+	isNewer, err := IsNewerCUDAVersion(d.ContainerCUDAVersion, d.HostCUDAVersion)
+	if err != nil {
+		d.logger.Warningf("Skipping CUDA Forward Compatibility: %v", err)
+		return false
+	}
+	if !isNewer {
+		d.logger.Debugf("CUDA Forward Compatibility not required; container CUDA version %v is supported by host CUDA version %v", d.ContainerCUDAVersion, d.HostCUDAVersion)
+		return false
+	}
+	d.logger.Infof("Using CUDA Forward Compatibility for container CUDA version %v on host CUDA version %v", d.ContainerCUDAVersion, d.HostCUDAVersion)
+	return true
+}
+
+// IsNewerCUDAVersion checks whether the MAJOR.MINOR version of a is greater
+// than that of b. Any patch version is ignored. This is used to determine
+// whether the CUDA version required by a container is supported by the host
+// driver.
+func IsNewerCUDAVersion(a string, b string) (bool, error) {
+	aMajor, aMinor, err := parseCUDAVersion(a)
+	if err != nil {
+		return false, err
+	}
+	bMajor, bMinor, err := parseCUDAVersion(b)
+	if err != nil {
+		return false, err
+	}
+	if aMajor != bMajor {
+		return aMajor > bMajor, nil
+	}
+	return aMinor > bMinor, nil
+}
+
+// parseCUDAVersion returns the major and minor components of the specified
+// CUDA version. If no minor version is specified, 0 is assumed.
+func parseCUDAVersion(version string) (int, int, error) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid CUDA version %q: %w", version, err)
+	}
+	if len(parts) == 1 {
+		return major, 0, nil
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid CUDA version %q: %w", version, err)
+	}
+	return major, minor, nil
+}
@@ -0,0 +1,223 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+func TestCUDAForwardCompatDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	hostCompatLibraries := map[string][]string{
+		"/host/compat/libcuda.so.*":                  {"/host/compat/libcuda.so.580.65.06"},
+		"/host/compat/libnvidia-nvvm.so.*":           {"/host/compat/libnvidia-nvvm.so.580.65.06"},
+		"/host/compat/libcudadebugger.so.*":          nil,
+		"/host/compat/libnvidia-ptxjitcompiler.so.*": nil,
+	}
+	locator := &lookup.LocatorMock{
+		LocateFunc: func(pattern string) ([]string, error) {
+			candidates := hostCompatLibraries[pattern]
+			if len(candidates) == 0 {
+				return nil, fmt.Errorf("pattern %v not found", pattern)
+			}
+			return candidates, nil
+		},
+	}
+
+	compatMounts := []Mount{
+		{
+			HostPath: "/host/compat/libcuda.so.580.65.06",
+			Path:     "/usr/local/cuda/compat/libcuda.so.580.65.06",
+			Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+		},
+		{
+			HostPath: "/host/compat/libnvidia-nvvm.so.580.65.06",
+			Path:     "/usr/local/cuda/compat/libnvidia-nvvm.so.580.65.06",
+			Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+		},
+	}
+	compatHooks := []Hook{
+		{
+			Lifecycle: "createContainer",
+			Path:      testNvidiaCDIHookPath,
+			Args:      []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/usr/local/cuda/compat"},
+			Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+		},
+	}
+
+	testCases := []struct {
+		description    string
+		options        *CUDAForwardCompatOptions
+		expectedMounts []Mount
+		expectedHooks  []Hook
+	}{
+		{
+			description: "nil options",
+		},
+		{
+			description: "no host compat root",
+			options: &CUDAForwardCompatOptions{
+				HostCUDAVersion:      "12.4",
+				ContainerCUDAVersion: "13.0",
+			},
+		},
+		{
+			description: "no container CUDA version",
+			options: &CUDAForwardCompatOptions{
+				HostCompatRoot:  "/host/compat",
+				HostCUDAVersion: "12.4",
+			},
+		},
+		{
+			description: "container CUDA version is older than host",
+			options: &CUDAForwardCompatOptions{
+				HostCompatRoot:       "/host/compat",
+				HostCUDAVersion:      "12.4",
+				ContainerCUDAVersion: "12.2.1",
+			},
+		},
+		{
+			description: "container CUDA version matches host",
+			options: &CUDAForwardCompatOptions{
+				HostCompatRoot:       "/host/compat",
+				HostCUDAVersion:      "12.4",
+				ContainerCUDAVersion: "12.4.1",
+			},
+		},
+		{
+			description: "invalid container CUDA version",
+			options: &CUDAForwardCompatOptions{
+				HostCompatRoot:       "/host/compat",
+				HostCUDAVersion:      "12.4",
+				ContainerCUDAVersion: "latest",
+			},
+		},
+		{
+			description: "newer container minor version requires compat",
+			options: &CUDAForwardCompatOptions{
+				HostCompatRoot:       "/host/compat",
+				HostCUDAVersion:      "12.4",
+				ContainerCUDAVersion: "12.8.0",
+			},
+			expectedMounts: compatMounts,
+			expectedHooks:  compatHooks,
+		},
+		{
+			description: "newer container major version requires compat",
+			options: &CUDAForwardCompatOptions{
+				HostCompatRoot:       "/host/compat",
+				HostCUDAVersion:      "12.9",
+				ContainerCUDAVersion: "13.0",
+			},
+			expectedMounts: compatMounts,
+			expectedHooks:  compatHooks,
+		},
+		{
+			description: "container compat root is used",
+			options: &CUDAForwardCompatOptions{
+				HostCompatRoot:       "/host/compat",
+				ContainerCompatRoot:  "/compat",
+				HostCUDAVersion:      "12.4",
+				ContainerCUDAVersion: "13.0",
+			},
+			expectedMounts: []Mount{
+				{
+					HostPath: "/host/compat/libcuda.so.580.65.06",
+					Path:     "/compat/libcuda.so.580.65.06",
+					Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+				},
+				{
+					HostPath: "/host/compat/libnvidia-nvvm.so.580.65.06",
+					Path:     "/compat/libnvidia-nvvm.so.580.65.06",
+					Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+				},
+			},
+			expectedHooks: []Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      testNvidiaCDIHookPath,
+					Args:      []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/compat"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+		{
+			description: "compat required but not provided by host",
+			options: &CUDAForwardCompatOptions{
+				HostCompatRoot:       "/host/missing",
+				HostCUDAVersion:      "12.4",
+				ContainerCUDAVersion: "13.0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hookCreator := NewHookCreator(WithNVIDIACDIHookPath(testNvidiaCDIHookPath))
+			d := NewCUDAForwardCompatDiscoverer(logger, hookCreator, locator, tc.options)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, mounts)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedHooks, hooks)
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+			require.Empty(t, devices)
+		})
+	}
+}
+
+func TestIsNewerCUDAVersion(t *testing.T) {
+	testCases := []struct {
+		a             string
+		b             string
+		expected      bool
+		expectedError bool
+	}{
+		{a: "12.8", b: "12.4", expected: true},
+		{a: "13.0", b: "12.9", expected: true},
+		{a: "12.4.1", b: "12.4", expected: false},
+		{a: "12", b: "12.0", expected: false},
+		{a: "11.8", b: "12.0", expected: false},
+		{a: "12.x", b: "12.0", expectedError: true},
+		{a: "12.0", b: "", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+" > "+tc.b, func(t *testing.T) {
+			isNewer, err := IsNewerCUDAVersion(tc.a, tc.b)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, isNewer)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// mountsDenylist is a discoverer that removes mounts matching one of a set of
+// glob patterns from the wrapped discoverer.
+type mountsDenylist struct {
+	Discover
+	logger   logger.Interface
+	patterns []string
+}
+
+var _ Discover = (*mountsDenylist)(nil)
+
+// WithMountsDenylist decorates the specified discoverer so that mounts
+// matching any of the specified glob patterns are removed. A pattern is
+// matched against both the file name and the full container path of a mount.
+// If no patterns are specified, the discoverer is returned as is.
+func WithMountsDenylist(logger logger.Interface, d Discover, patterns ...string) Discover {
+	if len(patterns) == 0 {
+		return d
+	}
+	return &mountsDenylist{
+		Discover: d,
+		logger:   logger,
+		patterns: patterns,
+	}
+}
+
+// Mounts returns the mounts from the wrapped discoverer that do not match any
+// of the denied patterns.
+func (d *mountsDenylist) Mounts() ([]Mount, error) {
+	mounts, err := d.Discover.Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []Mount
+	for _, mount := range mounts {
+		if pattern := d.matchingPattern(mount.Path); pattern != "" {
+			d.logger.Infof("Skipping %v; matches denied pattern %q", mount.HostPath, pattern)
+			continue
+		}
+		selected = append(selected, mount)
+	}
+	return selected, nil
+}
+
+// matchingPattern returns the first pattern that matches the specified path.
+// If no pattern matches, the empty string is returned.
+func (d *mountsDenylist) matchingPattern(path string) string {
+	for _, pattern := range d.patterns {
+		if match, _ := filepath.Match(pattern, filepath.Base(path)); match {
+			return pattern
+		}
+		if match, _ := filepath.Match(pattern, path); match {
+			return pattern
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMountsDenylist(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	mounts := []Mount{
+		{HostPath: "/lib/libcuda.so.1", Path: "/lib/libcuda.so.1"},
+		{HostPath: "/lib/libnvidia-ml.so.550.54.15", Path: "/lib/libnvidia-ml.so.550.54.15"},
+		{HostPath: "/lib/vdpau/libvdpau_nvidia.so.1", Path: "/lib/vdpau/libvdpau_nvidia.so.1"},
+	}
+
+	testCases := []struct {
+		description    string
+		patterns       []string
+		expectedMounts []Mount
+	}{
+		{
+			description:    "no patterns returns all mounts",
+			expectedMounts: mounts,
+		},
+		{
+			description:    "file name pattern removes matching mount",
+			patterns:       []string{"libnvidia-ml.so.*"},
+			expectedMounts: []Mount{mounts[0], mounts[2]},
+		},
+		{
+			description:    "path pattern removes matching mount",
+			patterns:       []string{"/lib/vdpau/*"},
+			expectedMounts: []Mount{mounts[0], mounts[1]},
+		},
+		{
+			description:    "multiple patterns are applied",
+			patterns:       []string{"libcuda.so.*", "libvdpau_*"},
+			expectedMounts: []Mount{mounts[1]},
+		},
+		{
+			description:    "non-matching pattern removes nothing",
+			patterns:       []string{"libfoo.so"},
+			expectedMounts: mounts,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			d := WithMountsDenylist(
+				logger,
+				&DiscoverMock{
+					MountsFunc: func() ([]Mount, error) {
+						return mounts, nil
+					},
+				},
+				tc.patterns...,
+			)
+
+			filtered, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, filtered)
+		})
+	}
+}
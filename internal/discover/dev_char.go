@@ -0,0 +1,129 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+const devCharPath = "/dev/char"
+
+// devCharPaths is a discoverer that references device nodes by their
+// /dev/char/MAJOR:MINOR paths in the container.
+type devCharPaths struct {
+	Discover
+	logger      logger.Interface
+	hookCreator HookCreator
+}
+
+// WithDevCharPaths decorates the specified discoverer so that device nodes are
+// created at their /dev/char/MAJOR:MINOR path in the container. A
+// create-symlinks hook is added so that the original device node paths are
+// still available as symlinks to the /dev/char paths.
+// Device nodes whose major and minor numbers cannot be determined are returned
+// as is.
+func WithDevCharPaths(logger logger.Interface, hookCreator HookCreator, d Discover) Discover {
+	return &devCharPaths{
+		Discover:    d,
+		logger:      logger,
+		hookCreator: hookCreator,
+	}
+}
+
+// Devices returns the devices of the wrapped discoverer with their container
+// paths replaced by the equivalent /dev/char path.
+func (d *devCharPaths) Devices() ([]Device, error) {
+	devs, err := d.Discover.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	var converted []Device
+	for _, dev := range devs {
+		charPath := d.getDevCharPath(dev)
+		if charPath == "" {
+			converted = append(converted, dev)
+			continue
+		}
+		hostPath := dev.HostPath
+		if hostPath == "" {
+			hostPath = dev.Path
+		}
+		converted = append(converted, Device{
+			HostPath: hostPath,
+			Path:     charPath,
+		})
+	}
+	return converted, nil
+}
+
+// missing returns the entities that the wrapped discoverer could not locate.
+func (d *devCharPaths) missing() []string {
+	return missingFrom(d.Discover)
+}
+
+// Hooks returns the hooks of the wrapped discoverer as well as a hook to
+// create symlinks from the original device node paths to the /dev/char paths.
+func (d *devCharPaths) Hooks() ([]Hook, error) {
+	hooks, err := d.Discover.Hooks()
+	if err != nil {
+		return nil, err
+	}
+
+	devs, err := d.Discover.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	var links []string
+	for _, dev := range devs {
+		charPath := d.getDevCharPath(dev)
+		if charPath == "" {
+			continue
+		}
+		links = append(links, fmt.Sprintf("%s::%s", charPath, dev.Path))
+	}
+	if len(links) == 0 {
+		return hooks, nil
+	}
+
+	hook := d.hookCreator.Create(CreateSymlinksHook, links...)
+	if hook == nil {
+		return hooks, nil
+	}
+	return append(hooks, *hook), nil
+}
+
+// getDevCharPath returns the /dev/char/MAJOR:MINOR path for the specified
+// device. An empty path is returned if the device information cannot be
+// determined.
+func (d *devCharPaths) getDevCharPath(dev Device) string {
+	hostPath := dev.HostPath
+	if hostPath == "" {
+		hostPath = dev.Path
+	}
+	dn, err := devices.DeviceFromPath(hostPath, "rwm")
+	if err != nil {
+		d.logger.Warningf("Failed to get device information for %v; keeping original path: %v", hostPath, err)
+		return ""
+	}
+	return fmt.Sprintf("%s/%d:%d", devCharPath, dn.Major, dn.Minor)
+}
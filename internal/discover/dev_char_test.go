@@ -0,0 +1,108 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opencontainers/cgroups/devices/config"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+)
+
+func TestWithDevCharPaths(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	defer devices.SetDeviceFromPathForTest(func(path string, permissions string) (*devices.Device, error) {
+		switch path {
+		case "/host/dev/nvidia1":
+			return &devices.Device{Path: path, Rule: config.Rule{Major: 195, Minor: 1}}, nil
+		case "/host/dev/nvidiactl":
+			return &devices.Device{Path: path, Rule: config.Rule{Major: 195, Minor: 255}}, nil
+		}
+		return nil, fmt.Errorf("not a device: %v", path)
+	})()
+
+	hookCreator := NewHookCreator(WithNVIDIACDIHookPath(testNvidiaCDIHookPath))
+
+	testCases := []struct {
+		description     string
+		devices         []Device
+		expectedDevices []Device
+		expectedHooks   []Hook
+	}{
+		{
+			description: "device nodes use /dev/char paths",
+			devices: []Device{
+				{Path: "/dev/nvidia1", HostPath: "/host/dev/nvidia1"},
+				{Path: "/dev/nvidiactl", HostPath: "/host/dev/nvidiactl"},
+			},
+			expectedDevices: []Device{
+				{Path: "/dev/char/195:1", HostPath: "/host/dev/nvidia1"},
+				{Path: "/dev/char/195:255", HostPath: "/host/dev/nvidiactl"},
+			},
+			expectedHooks: []Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      testNvidiaCDIHookPath,
+					Args: []string{"nvidia-cdi-hook", "create-symlinks",
+						"--link", "/dev/char/195:1::/dev/nvidia1",
+						"--link", "/dev/char/195:255::/dev/nvidiactl",
+					},
+					Env: []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+		{
+			description: "unknown device nodes are unchanged",
+			devices: []Device{
+				{Path: "/dev/nvidia-unknown", HostPath: "/host/dev/nvidia-unknown"},
+			},
+			expectedDevices: []Device{
+				{Path: "/dev/nvidia-unknown", HostPath: "/host/dev/nvidia-unknown"},
+			},
+		},
+		{
+			description: "no devices",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			d := WithDevCharPaths(logger, hookCreator, &DiscoverMock{
+				DevicesFunc: func() ([]Device, error) {
+					return tc.devices, nil
+				},
+				HooksFunc: func() ([]Hook, error) {
+					return nil, nil
+				},
+			})
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, devices)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedHooks, hooks)
+		})
+	}
+}
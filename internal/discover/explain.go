@@ -0,0 +1,234 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// An ExplainEntry describes what a named discoverer found or, if nothing was
+// found, why it was skipped.
+type ExplainEntry struct {
+	Discoverer string
+	Found      []string
+	Skipped    bool
+	Reason     string
+}
+
+// String returns a human-readable representation of the entry.
+func (e ExplainEntry) String() string {
+	if e.Skipped {
+		return fmt.Sprintf("skipped %s: %s", e.Discoverer, e.Reason)
+	}
+	return fmt.Sprintf("found %s: %s", e.Discoverer, strings.Join(e.Found, ", "))
+}
+
+// An Explainer collects the decisions made by the discoverers that it wraps.
+// A nil Explainer is valid and records nothing.
+type Explainer struct {
+	sync.Mutex
+	names   []string
+	entries map[string]*explainEntry
+}
+
+type explainEntry struct {
+	found   []string
+	seen    map[string]bool
+	missing []string
+	reason  string
+	err     error
+}
+
+// A missingReporter reports the required entities that a discoverer looked
+// for but could not locate.
+type missingReporter interface {
+	missing() []string
+}
+
+// missingFrom returns the entities that the specified discoverer could not
+// locate. If the discoverer does not report these, nil is returned.
+func missingFrom(d Discover) []string {
+	if m, ok := d.(missingReporter); ok {
+		return m.missing()
+	}
+	return nil
+}
+
+type explained struct {
+	explainer *Explainer
+	name      string
+	d         Discover
+}
+
+var _ Discover = (*explained)(nil)
+
+// NewExplainer creates an empty Explainer.
+func NewExplainer() *Explainer {
+	return &Explainer{
+		entries: make(map[string]*explainEntry),
+	}
+}
+
+// Explain decorates the specified discoverer so that the entities that it
+// discovers are recorded under the specified name. If the discoverer finds
+// nothing, the entry is reported as skipped and the required entities that
+// could not be located are reported as the reason.
+func (e *Explainer) Explain(name string, d Discover) Discover {
+	if d == nil {
+		d = None{}
+	}
+	if e == nil {
+		return d
+	}
+	e.entry(name)
+	return &explained{
+		explainer: e,
+		name:      name,
+		d:         d,
+	}
+}
+
+// Skip records that the named discoverer was skipped for the specified reason.
+func (e *Explainer) Skip(name string, reason string) {
+	if e == nil {
+		return
+	}
+	e.entry(name).reason = reason
+}
+
+// Entries returns the recorded entries in the order in which the discoverers
+// were registered.
+func (e *Explainer) Entries() []ExplainEntry {
+	if e == nil {
+		return nil
+	}
+	e.Lock()
+	defer e.Unlock()
+
+	var entries []ExplainEntry
+	for _, name := range e.names {
+		entry := e.entries[name]
+		ee := ExplainEntry{
+			Discoverer: name,
+			Found:      append([]string{}, entry.found...),
+		}
+		switch {
+		case entry.err != nil:
+			ee.Skipped = true
+			ee.Reason = entry.err.Error()
+		case len(entry.found) > 0:
+		case entry.reason != "":
+			ee.Skipped = true
+			ee.Reason = entry.reason
+		case len(entry.missing) > 0:
+			ee.Skipped = true
+			ee.Reason = "not found: " + strings.Join(entry.missing, ", ")
+		default:
+			ee.Skipped = true
+			ee.Reason = "nothing discovered"
+		}
+		entries = append(entries, ee)
+	}
+	return entries
+}
+
+func (e *Explainer) entry(name string) *explainEntry {
+	e.Lock()
+	defer e.Unlock()
+	if entry, ok := e.entries[name]; ok {
+		return entry
+	}
+	entry := &explainEntry{seen: make(map[string]bool)}
+	e.names = append(e.names, name)
+	e.entries[name] = entry
+	return entry
+}
+
+func (e *Explainer) record(name string, err error, missing []string, found ...string) {
+	entry := e.entry(name)
+
+	e.Lock()
+	defer e.Unlock()
+	if err != nil && entry.err == nil {
+		entry.err = err
+	}
+	for _, m := range missing {
+		if slices.Contains(entry.missing, m) {
+			continue
+		}
+		entry.missing = append(entry.missing, m)
+	}
+	for _, f := range found {
+		if entry.seen[f] {
+			continue
+		}
+		entry.seen[f] = true
+		entry.found = append(entry.found, f)
+	}
+}
+
+// Devices returns the devices of the wrapped discoverer and records them.
+func (d *explained) Devices() ([]Device, error) {
+	devices, err := d.d.Devices()
+	var found []string
+	for _, device := range devices {
+		found = append(found, "device "+device.Path)
+	}
+	d.explainer.record(d.name, err, missingFrom(d.d), found...)
+	return devices, err
+}
+
+// EnvVars returns the environment variables of the wrapped discoverer and records them.
+func (d *explained) EnvVars() ([]EnvVar, error) {
+	envVars, err := d.d.EnvVars()
+	var found []string
+	for _, envVar := range envVars {
+		found = append(found, "env "+envVar.Name)
+	}
+	d.explainer.record(d.name, err, missingFrom(d.d), found...)
+	return envVars, err
+}
+
+// Hooks returns the hooks of the wrapped discoverer and records them.
+func (d *explained) Hooks() ([]Hook, error) {
+	hooks, err := d.d.Hooks()
+	var found []string
+	for _, hook := range hooks {
+		args := hook.Args
+		if len(args) == 0 {
+			args = []string{hook.Path}
+		}
+		found = append(found, "hook "+strings.Join(args, " "))
+	}
+	d.explainer.record(d.name, err, missingFrom(d.d), found...)
+	return hooks, err
+}
+
+// Mounts returns the mounts of the wrapped discoverer and records them.
+func (d *explained) Mounts() ([]Mount, error) {
+	mounts, err := d.d.Mounts()
+	var found []string
+	for _, mount := range mounts {
+		found = append(found, "mount "+mount.Path)
+	}
+	d.explainer.record(d.name, err, missingFrom(d.d), found...)
+	return mounts, err
+}
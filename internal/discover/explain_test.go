@@ -0,0 +1,132 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		discoverer      Discover
+		expectedEntries []ExplainEntry
+	}{
+		{
+			description: "found entities are recorded once",
+			discoverer: &DiscoverMock{
+				DevicesFunc: func() ([]Device, error) {
+					return []Device{{Path: "/dev/foo"}}, nil
+				},
+				MountsFunc: func() ([]Mount, error) {
+					return []Mount{{Path: "/lib/libfoo.so"}}, nil
+				},
+				EnvVarsFunc: func() ([]EnvVar, error) {
+					return nil, nil
+				},
+				HooksFunc: func() ([]Hook, error) {
+					return []Hook{{Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}}}, nil
+				},
+			},
+			expectedEntries: []ExplainEntry{
+				{
+					Discoverer: "foo",
+					Found: []string{
+						"device /dev/foo",
+						"mount /lib/libfoo.so",
+						"hook nvidia-cdi-hook update-ldcache",
+					},
+				},
+			},
+		},
+		{
+			description: "nothing found is skipped",
+			discoverer:  None{},
+			expectedEntries: []ExplainEntry{
+				{
+					Discoverer: "foo",
+					Found:      []string{},
+					Skipped:    true,
+					Reason:     "nothing discovered",
+				},
+			},
+		},
+		{
+			description: "missing entities are reported as the reason",
+			discoverer: Merge(
+				NewCharDeviceDiscoverer(logger, t.TempDir(), []string{"/dev/foo"}),
+				WithCache(NewCharDeviceDiscoverer(logger, t.TempDir(), []string{"/dev/bar*"})),
+			),
+			expectedEntries: []ExplainEntry{
+				{
+					Discoverer: "foo",
+					Found:      []string{},
+					Skipped:    true,
+					Reason:     "not found: /dev/foo, /dev/bar*",
+				},
+			},
+		},
+		{
+			description: "errors are reported as the reason",
+			discoverer: &DiscoverMock{
+				DevicesFunc: func() ([]Device, error) {
+					return nil, fmt.Errorf("failed to locate")
+				},
+			},
+			expectedEntries: []ExplainEntry{
+				{
+					Discoverer: "foo",
+					Found:      []string{},
+					Skipped:    true,
+					Reason:     "failed to locate",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			e := NewExplainer()
+			d := e.Explain("foo", tc.discoverer)
+
+			for i := 0; i < 2; i++ {
+				_, _ = d.Devices()
+				_, _ = d.Mounts()
+				_, _ = d.Hooks()
+			}
+
+			require.EqualValues(t, tc.expectedEntries, e.Entries())
+		})
+	}
+}
+
+func TestNilExplainer(t *testing.T) {
+	var e *Explainer
+
+	d := e.Explain("foo", nil)
+	require.Equal(t, None{}, d)
+
+	e.Skip("foo", "bar")
+	require.Empty(t, e.Entries())
+}
@@ -16,7 +16,11 @@
 
 package discover
 
-import "github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+import (
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
 
 // Filter defines an interface for filtering discovered entities
 type Filter interface {
@@ -54,9 +58,35 @@ func (d filtered) Devices() ([]Device, error) {
 	for _, device := range devices {
 		if d.filter.DeviceIsSelected(device) {
 			selected = append(selected, device)
+			continue
 		}
 		d.logger.Debugf("skipping device %v", device)
 	}
 
 	return selected, nil
 }
+
+// deviceNodeAllowlist selects device nodes whose container path matches one
+// of a set of glob patterns.
+type deviceNodeAllowlist []string
+
+// WithDeviceNodeAllowlist decorates the specified discoverer so that only the
+// device nodes matching one of the specified glob patterns are returned.
+// Other entities such as mounts and hooks are not affected. If no patterns
+// are specified, the discoverer is returned as is.
+func WithDeviceNodeAllowlist(logger logger.Interface, d Discover, patterns ...string) Discover {
+	if len(patterns) == 0 {
+		return d
+	}
+	return newFilteredDiscoverer(logger, d, deviceNodeAllowlist(patterns))
+}
+
+// DeviceIsSelected checks whether the device path matches an allowed pattern.
+func (l deviceNodeAllowlist) DeviceIsSelected(device Device) bool {
+	for _, pattern := range l {
+		if match, _ := filepath.Match(pattern, device.Path); match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDeviceNodeAllowlist(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	d := &DiscoverMock{
+		DevicesFunc: func() ([]Device, error) {
+			return []Device{
+				{Path: "/dev/nvidia1", HostPath: "/dev/nvidia1"},
+				{Path: "/dev/dri/card1", HostPath: "/dev/dri/card1"},
+				{Path: "/dev/dri/renderD129", HostPath: "/dev/dri/renderD129"},
+			}, nil
+		},
+		MountsFunc: func() ([]Mount, error) {
+			return []Mount{{Path: "/lib/libfoo.so"}}, nil
+		},
+	}
+
+	testCases := []struct {
+		description     string
+		patterns        []string
+		expectedDevices []Device
+	}{
+		{
+			description: "no patterns selects all devices",
+			expectedDevices: []Device{
+				{Path: "/dev/nvidia1", HostPath: "/dev/nvidia1"},
+				{Path: "/dev/dri/card1", HostPath: "/dev/dri/card1"},
+				{Path: "/dev/dri/renderD129", HostPath: "/dev/dri/renderD129"},
+			},
+		},
+		{
+			description: "only /dev/nvidia1 is selected",
+			patterns:    []string{"/dev/nvidia1"},
+			expectedDevices: []Device{
+				{Path: "/dev/nvidia1", HostPath: "/dev/nvidia1"},
+			},
+		},
+		{
+			description: "glob patterns are supported",
+			patterns:    []string{"/dev/nvidia[0-9]*", "/dev/dri/render*"},
+			expectedDevices: []Device{
+				{Path: "/dev/nvidia1", HostPath: "/dev/nvidia1"},
+				{Path: "/dev/dri/renderD129", HostPath: "/dev/dri/renderD129"},
+			},
+		},
+		{
+			description: "no matching devices",
+			patterns:    []string{"/dev/nvidia0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			filtered := WithDeviceNodeAllowlist(logger, d, tc.patterns...)
+
+			devices, err := filtered.Devices()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, devices)
+
+			mounts, err := filtered.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, []Mount{{Path: "/lib/libfoo.so"}}, mounts)
+		})
+	}
+}
@@ -79,3 +79,8 @@ func (d *gdsDeviceDiscoverer) Mounts() ([]Mount, error) {
 
 	return d.mounts.Mounts()
 }
+
+// missing returns the device nodes and mounts that could not be located.
+func (d *gdsDeviceDiscoverer) missing() []string {
+	return append(missingFrom(d.devices), missingFrom(d.mounts)...)
+}
@@ -39,7 +39,7 @@ import (
 func NewDRMNodesDiscoverer(logger logger.Interface, devices image.VisibleDevices, devRoot string, hookCreator HookCreator) (Discover, error) {
 	drmDeviceNodes, err := newDRMDeviceDiscoverer(logger, devices, devRoot)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create DRM device discoverer: %v", err)
+		return nil, fmt.Errorf("failed to create DRM device discoverer: %w", err)
 	}
 
 	drmByPathSymlinks := newCreateDRMByPathSymlinks(logger, drmDeviceNodes, devRoot, hookCreator)
@@ -144,6 +144,14 @@ func newGraphicsLibrariesDiscoverer(logger logger.Interface, driver *root.Driver
 			// but need to be handled for the legacy case too.
 			"libnvidia-allocator.so." + cudaVersionPattern,
 			"libnvidia-vulkan-producer.so." + cudaVersionPattern,
+			// The vendor-specific GLX, EGL, and GLES libraries are loaded by
+			// the GLVND dispatch libraries of the container. The dispatch
+			// libraries themselves (e.g. libGLX.so.0) are not injected since
+			// these are provided by the GLVND installation in the container.
+			"libGLX_nvidia.so." + cudaVersionPattern,
+			"libEGL_nvidia.so." + cudaVersionPattern,
+			"libGLESv1_CM_nvidia.so." + cudaVersionPattern,
+			"libGLESv2_nvidia.so." + cudaVersionPattern,
 		},
 	)
 
@@ -177,7 +185,7 @@ func newGraphicsLibrariesDiscoverer(logger logger.Interface, driver *root.Driver
 func (d graphicsDriverLibraries) Mounts() ([]Mount, error) {
 	mounts, err := d.Discover.Mounts()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get library mounts: %v", err)
+		return nil, fmt.Errorf("failed to get library mounts: %w", err)
 	}
 
 	var filtered []Mount
@@ -194,7 +202,7 @@ func (d graphicsDriverLibraries) Mounts() ([]Mount, error) {
 func (d graphicsDriverLibraries) Hooks() ([]Hook, error) {
 	mounts, err := d.Discover.Mounts()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get library mounts: %v", err)
+		return nil, fmt.Errorf("failed to get library mounts: %w", err)
 	}
 
 	var links []string
@@ -296,14 +304,14 @@ func newCreateDRMByPathSymlinks(logger logger.Interface, devices Discover, devRo
 func (d drmDevicesByPath) Hooks() ([]Hook, error) {
 	devices, err := d.devicesFrom.Devices()
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover devices for by-path symlinks: %v", err)
+		return nil, fmt.Errorf("failed to discover devices for by-path symlinks: %w", err)
 	}
 	if len(devices) == 0 {
 		return nil, nil
 	}
 	links, err := d.getSpecificLinkArgs(devices)
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine specific links: %v", err)
+		return nil, fmt.Errorf("failed to determine specific links: %w", err)
 	}
 	if len(links) == 0 {
 		return nil, nil
@@ -361,7 +369,7 @@ func newDRMDeviceDiscoverer(logger logger.Interface, devices image.VisibleDevice
 
 	filter, err := newDRMDeviceFilter(devices, devRoot)
 	if err != nil {
-		return nil, fmt.Errorf("failed to construct DRM device filter: %v", err)
+		return nil, fmt.Errorf("failed to construct DRM device filter: %w", err)
 	}
 
 	// We return a discoverer that applies the DRM device filter created above to all discovered DRM device nodes.
@@ -378,14 +386,14 @@ func newDRMDeviceDiscoverer(logger logger.Interface, devices image.VisibleDevice
 func newDRMDeviceFilter(devices image.VisibleDevices, devRoot string) (Filter, error) {
 	gpuInformationPaths, err := proc.GetInformationFilePaths(devRoot)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read GPU information: %v", err)
+		return nil, fmt.Errorf("failed to read GPU information: %w", err)
 	}
 
 	var selectedBusIds []string
 	for _, f := range gpuInformationPaths {
 		info, err := proc.ParseGPUInformationFile(f)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse %v: %v", f, err)
+			return nil, fmt.Errorf("failed to parse %v: %w", f, err)
 		}
 		uuid := info[proc.GPUInfoGPUUUID]
 		busID := info[proc.GPUInfoBusLocation]
@@ -400,7 +408,7 @@ func newDRMDeviceFilter(devices image.VisibleDevices, devRoot string) (Filter, e
 	for _, busID := range selectedBusIds {
 		drmDeviceNodes, err := drm.GetDeviceNodesByBusID(busID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to determine DRM devices for %v: %v", busID, err)
+			return nil, fmt.Errorf("failed to determine DRM devices for %v: %w", busID, err)
 		}
 		for _, drmDeviceNode := range drmDeviceNodes {
 			filter[drmDeviceNode] = true
@@ -17,6 +17,7 @@
 package discover
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -25,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
 )
 
@@ -140,6 +142,30 @@ func TestGraphicsLibrariesDiscoverer(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "libGLX_nvidia discovered",
+			libraries: &DiscoverMock{
+				MountsFunc: func() ([]Mount, error) {
+					mounts := []Mount{
+						{
+							Path: "/usr/lib64/libGLX_nvidia.so.123.45.67",
+						},
+						{
+							Path: "/usr/lib64/libEGL_nvidia.so.123.45.67",
+						},
+					}
+					return mounts, nil
+				},
+			},
+			expectedMounts: []Mount{
+				{
+					Path: "/usr/lib64/libGLX_nvidia.so.123.45.67",
+				},
+				{
+					Path: "/usr/lib64/libEGL_nvidia.so.123.45.67",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -169,6 +195,69 @@ func TestGraphicsLibrariesDiscoverer(t *testing.T) {
 	}
 }
 
+func TestGraphicsLibrariesDiscovererDriverLayout(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	libDir := filepath.Join(driverRoot, "usr/lib64")
+	require.NoError(t, os.MkdirAll(libDir, 0755))
+
+	files := []string{
+		"libcuda.so.123.45.67",
+		"libGLX_nvidia.so.123.45.67",
+		"libEGL_nvidia.so.123.45.67",
+		"libGLESv1_CM_nvidia.so.123.45.67",
+		"libGLESv2_nvidia.so.123.45.67",
+		"libGLdispatch.so.0.0.0",
+		"libGLX.so.0.0.0",
+		"libOpenGL.so.0.0.0",
+		"libEGL.so.1.1.0",
+	}
+	for _, file := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(libDir, file), nil, 0600))
+	}
+	symlinks := map[string]string{
+		"libGLdispatch.so.0": "libGLdispatch.so.0.0.0",
+		"libGLX.so.0":        "libGLX.so.0.0.0",
+		"libOpenGL.so.0":     "libOpenGL.so.0.0.0",
+		"libEGL.so.1":        "libEGL.so.1.1.0",
+	}
+	for link, target := range symlinks {
+		require.NoError(t, os.Symlink(target, filepath.Join(libDir, link)))
+	}
+
+	driver := root.New(
+		root.WithLogger(logger),
+		root.WithDriverRoot(driverRoot),
+	)
+
+	d, err := newGraphicsLibrariesDiscoverer(logger, driver, NewHookCreator())
+	require.NoError(t, err)
+
+	mounts, err := d.Mounts()
+	require.NoError(t, err)
+
+	var libraries []string
+	for _, m := range mounts {
+		libraries = append(libraries, filepath.Base(m.Path))
+	}
+	require.ElementsMatch(t,
+		[]string{
+			"libGLX_nvidia.so.123.45.67",
+			"libEGL_nvidia.so.123.45.67",
+			"libGLESv1_CM_nvidia.so.123.45.67",
+			"libGLESv2_nvidia.so.123.45.67",
+		},
+		libraries,
+	)
+
+	// The libGLX_indirect.so.0 symlink is created for the driver libraries
+	// and must not be duplicated for the graphics libraries.
+	hooks, err := d.Hooks()
+	require.NoError(t, err)
+	require.Empty(t, hooks)
+}
+
 func TestDrmDevicesByPath(t *testing.T) {
 	defer devices.SetAllForTest()()
 	moduleRoot, err := test.GetModuleRoot()
@@ -19,6 +19,7 @@ package discover
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 )
@@ -30,6 +31,9 @@ const (
 	// AllHooks is a special hook name that allows all hooks to be matched.
 	AllHooks = HookName("all")
 
+	// A CheckLibrariesHook is used to verify that the injected libraries
+	// resolve in the container.
+	CheckLibrariesHook = HookName("check-libraries")
 	// A ChmodHook is used to set the file mode of the specified paths.
 	//
 	// Deprecated: The chmod hook is deprecated and will be removed in a future release.
@@ -57,6 +61,9 @@ var defaultDisabledHooks = []HookName{
 	// ChmodHook is disabled by default as it was a workaround for older
 	// versions of crun that has since been fixed.
 	ChmodHook,
+	// CheckLibrariesHook is disabled by default since it causes container
+	// creation to fail if a required library does not resolve.
+	CheckLibrariesHook,
 }
 
 var _ Discover = (*Hook)(nil)
@@ -92,6 +99,7 @@ type hookCreatorOptions struct {
 	disabledHooks     []HookName
 	enabledHooks      []HookName
 	debugLogging      bool
+	env               []string
 }
 
 type Option func(*hookCreatorOptions)
@@ -103,6 +111,7 @@ type cdiHookCreator struct {
 
 	fixedArgs    []string
 	debugLogging bool
+	env          []string
 }
 
 // An allDisabledHookCreator is a HookCreator that does not create any hooks.
@@ -124,6 +133,16 @@ func WithDebugLogging(debugLogging bool) Option {
 	}
 }
 
+// WithEnv sets additional environment variables for the created hooks.
+// Each envvar is expected to be of the form KEY=VALUE. If NVIDIA_CTK_DEBUG is
+// specified, this overrides the value determined by the debug logging option.
+// This can be specified multiple times.
+func WithEnv(env ...string) Option {
+	return func(c *hookCreatorOptions) {
+		c.env = append(c.env, env...)
+	}
+}
+
 // WithDisabledHooks explicitly disables the specified hooks.
 // This can be specified multiple times.
 func WithDisabledHooks(hooks ...HookName) Option {
@@ -182,6 +201,7 @@ func NewHookCreator(opts ...Option) HookCreator {
 		disabledHooks:     disabledHooks,
 		fixedArgs:         getFixedArgsForCDIHookCLI(o.nvidiaCDIHookPath),
 		debugLogging:      o.debugLogging,
+		env:               o.env,
 	}
 
 	return c
@@ -198,8 +218,23 @@ func (c cdiHookCreator) Create(name HookName, args ...string) *Hook {
 		Lifecycle: cdi.CreateContainerHook,
 		Path:      c.nvidiaCDIHookPath,
 		Args:      append(c.requiredArgs(name), c.transformArgs(name, args...)...),
-		Env:       []string{fmt.Sprintf("NVIDIA_CTK_DEBUG=%v", c.debugLogging)},
+		Env:       c.getEnv(),
+	}
+}
+
+// getEnv returns the environment for a created hook. The NVIDIA_CTK_DEBUG
+// envvar is always included and is followed by any additional envvars.
+func (c cdiHookCreator) getEnv() []string {
+	debugEnv := fmt.Sprintf("NVIDIA_CTK_DEBUG=%v", c.debugLogging)
+	var env []string
+	for _, e := range c.env {
+		if strings.HasPrefix(e, "NVIDIA_CTK_DEBUG=") {
+			debugEnv = e
+			continue
+		}
+		env = append(env, e)
 	}
+	return append([]string{debugEnv}, env...)
 }
 
 func (c cdiHookCreator) isDisabled(name HookName, args ...string) bool {
@@ -213,7 +248,7 @@ func (c cdiHookCreator) isDisabled(name HookName, args ...string) bool {
 
 	// still reject hooks that require args if none were provided
 	switch name {
-	case CreateSymlinksHook, ChmodHook:
+	case CreateSymlinksHook, ChmodHook, CheckLibrariesHook:
 		return len(args) == 0
 	}
 	return false
@@ -235,6 +270,10 @@ func (c cdiHookCreator) transformArgs(name HookName, args ...string) []string {
 		for _, arg := range args {
 			transformedArgs = append(transformedArgs, "--path", arg)
 		}
+	case CheckLibrariesHook:
+		for _, arg := range args {
+			transformedArgs = append(transformedArgs, "--folder", arg)
+		}
 	case UpdateLDCacheHook:
 		if c.ldconfigPath != "" {
 			transformedArgs = append(transformedArgs, "--ldconfig-path", c.ldconfigPath)
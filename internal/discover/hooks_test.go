@@ -35,7 +35,8 @@ func TestNewHookCreator(t *testing.T) {
 				nvidiaCDIHookPath: defaultNvidiaCDIHookPath,
 				fixedArgs:         []string{"nvidia-cdi-hook"},
 				disabledHooks: map[HookName]bool{
-					ChmodHook: true, // ChmodHook is disabled by default
+					ChmodHook:          true, // ChmodHook is disabled by default
+					CheckLibrariesHook: true,
 				},
 			},
 		},
@@ -48,7 +49,8 @@ func TestNewHookCreator(t *testing.T) {
 				nvidiaCDIHookPath: "/custom/path/nvidia-cdi-hook",
 				fixedArgs:         []string{"nvidia-cdi-hook"},
 				disabledHooks: map[HookName]bool{
-					ChmodHook: true,
+					ChmodHook:          true,
+					CheckLibrariesHook: true,
 				},
 			},
 		},
@@ -69,9 +71,10 @@ func TestNewHookCreator(t *testing.T) {
 				nvidiaCDIHookPath: defaultNvidiaCDIHookPath,
 				fixedArgs:         []string{"nvidia-cdi-hook"},
 				disabledHooks: map[HookName]bool{
-					AllHooks:          true,
-					UpdateLDCacheHook: false,
-					ChmodHook:         true,
+					AllHooks:           true,
+					UpdateLDCacheHook:  false,
+					ChmodHook:          true,
+					CheckLibrariesHook: true,
 				},
 			},
 		},
@@ -90,6 +93,7 @@ func TestNewHookCreator(t *testing.T) {
 					EnableCudaCompatHook:              true,
 					ChmodHook:                         false,
 					DisableDeviceNodeModificationHook: true,
+					CheckLibrariesHook:                true,
 				},
 			},
 		},
@@ -108,6 +112,7 @@ func TestNewHookCreator(t *testing.T) {
 					CreateSymlinksHook:   true,
 					EnableCudaCompatHook: true,
 					ChmodHook:            true, // Default disabled
+					CheckLibrariesHook:   true,
 				},
 			},
 		},
@@ -120,7 +125,8 @@ func TestNewHookCreator(t *testing.T) {
 				nvidiaCDIHookPath: defaultNvidiaCDIHookPath,
 				fixedArgs:         []string{"nvidia-cdi-hook"},
 				disabledHooks: map[HookName]bool{
-					ChmodHook: false, // ChmodHook is enabled
+					ChmodHook:          false, // ChmodHook is enabled
+					CheckLibrariesHook: true,
 				},
 			},
 		},
@@ -133,7 +139,8 @@ func TestNewHookCreator(t *testing.T) {
 				nvidiaCDIHookPath: "/usr/bin/nvidia-ctk",
 				fixedArgs:         []string{"nvidia-ctk", "hook"},
 				disabledHooks: map[HookName]bool{
-					ChmodHook: true,
+					ChmodHook:          true,
+					CheckLibrariesHook: true,
 				},
 			},
 		},
@@ -146,7 +153,8 @@ func TestNewHookCreator(t *testing.T) {
 				nvidiaCDIHookPath: "/usr/local/nvidia/toolkit/nvidia-ctk",
 				fixedArgs:         []string{"nvidia-ctk", "hook"},
 				disabledHooks: map[HookName]bool{
-					ChmodHook: true,
+					ChmodHook:          true,
+					CheckLibrariesHook: true,
 				},
 			},
 		},
@@ -316,6 +324,30 @@ func TestCDIHookCreator_Create(t *testing.T) {
 				Env:       []string{"NVIDIA_CTK_DEBUG=true"},
 			},
 		},
+		{
+			name:        "additional env is appended",
+			hookCreator: NewHookCreator(WithEnv("FOO=bar", "BAZ=")),
+			hookName:    UpdateLDCacheHook,
+			args:        []string{},
+			expectedHook: &Hook{
+				Lifecycle: "createContainer",
+				Path:      defaultNvidiaCDIHookPath,
+				Args:      []string{"nvidia-cdi-hook", "update-ldcache"},
+				Env:       []string{"NVIDIA_CTK_DEBUG=false", "FOO=bar", "BAZ="},
+			},
+		},
+		{
+			name:        "env overrides debug logging",
+			hookCreator: NewHookCreator(WithEnv("FOO=bar", "NVIDIA_CTK_DEBUG=true")),
+			hookName:    UpdateLDCacheHook,
+			args:        []string{},
+			expectedHook: &Hook{
+				Lifecycle: "createContainer",
+				Path:      defaultNvidiaCDIHookPath,
+				Args:      []string{"nvidia-cdi-hook", "update-ldcache"},
+				Env:       []string{"NVIDIA_CTK_DEBUG=true", "FOO=bar"},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -46,7 +46,7 @@ type ldconfig struct {
 func (d ldconfig) Hooks() ([]Hook, error) {
 	mounts, err := d.mountsFrom.Mounts()
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover mounts for ldcache update: %v", err)
+		return nil, fmt.Errorf("failed to discover mounts for ldcache update: %w", err)
 	}
 
 	libraryFolders := uniqueFolders(getLibraryPaths(mounts))
@@ -54,6 +54,43 @@ func (d ldconfig) Hooks() ([]Hook, error) {
 	return d.hookCreator.Create(UpdateLDCacheHook, libraryFolders...).Hooks()
 }
 
+// NewLDLibraryPathEnvVar creates a discoverer that sets LD_LIBRARY_PATH to the
+// folders of the libraries in the specified mounts. This can be used instead of
+// the ldcache update hook for containers where the ldcache cannot be updated,
+// such as containers with a read-only rootfs. When the edits are applied by the
+// NVIDIA Container Runtime, these folders are prepended to an LD_LIBRARY_PATH
+// that is already set in the container.
+func NewLDLibraryPathEnvVar(mounts Discover) Discover {
+	return &ldLibraryPath{
+		mountsFrom: mounts,
+	}
+}
+
+type ldLibraryPath struct {
+	None
+	mountsFrom Discover
+}
+
+// EnvVars returns the LD_LIBRARY_PATH envvar for the library folders of the
+// discovered mounts. No envvar is returned if no libraries are discovered.
+func (d ldLibraryPath) EnvVars() ([]EnvVar, error) {
+	mounts, err := d.mountsFrom.Mounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover mounts for LD_LIBRARY_PATH: %w", err)
+	}
+
+	libraryFolders := uniqueFolders(getLibraryPaths(mounts))
+	if len(libraryFolders) == 0 {
+		return nil, nil
+	}
+
+	e := EnvVar{
+		Name:  "LD_LIBRARY_PATH",
+		Value: strings.Join(libraryFolders, ":"),
+	}
+	return []EnvVar{e}, nil
+}
+
 // getLibraryPaths extracts the library dirs from the specified mounts
 func getLibraryPaths(mounts []Mount) []string {
 	var paths []string
@@ -149,6 +149,76 @@ func TestLDCacheUpdateHook(t *testing.T) {
 	}
 }
 
+func TestLDLibraryPathEnvVar(t *testing.T) {
+	testCases := []struct {
+		description     string
+		mounts          []Mount
+		mountError      error
+		expectedError   error
+		expectedEnvVars []EnvVar
+	}{
+		{
+			description: "empty mounts",
+		},
+		{
+			description:   "mount error",
+			mountError:    fmt.Errorf("mountError"),
+			expectedError: fmt.Errorf("mountError"),
+		},
+		{
+			description: "library folders are joined",
+			mounts: []Mount{
+				{
+					HostPath: "/host/usr/local/lib/libfoo.so",
+					Path:     "/usr/local/lib/libfoo.so",
+				},
+				{
+					Path: "/usr/bin/notlib",
+				},
+				{
+					Path: "/usr/local/libother/libfoo.so",
+				},
+				{
+					Path: "/usr/local/lib/libbar.so",
+				},
+			},
+			expectedEnvVars: []EnvVar{
+				{
+					Name:  "LD_LIBRARY_PATH",
+					Value: "/usr/local/lib:/usr/local/libother",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mountMock := &DiscoverMock{
+				MountsFunc: func() ([]Mount, error) {
+					return tc.mounts, tc.mountError
+				},
+			}
+			d := NewLDLibraryPathEnvVar(mountMock)
+
+			envVars, err := d.EnvVars()
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedEnvVars, envVars)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.Empty(t, hooks)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.Empty(t, mounts)
+		})
+	}
+}
+
 func TestIsLibName(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -45,7 +45,7 @@ func (d list) Devices() ([]Device, error) {
 	for i, di := range d {
 		devices, err := di.Devices()
 		if err != nil {
-			return nil, fmt.Errorf("error discovering devices for discoverer %v: %v", i, err)
+			return nil, fmt.Errorf("error discovering devices for discoverer %v: %w", i, err)
 		}
 		allDevices = append(allDevices, devices...)
 	}
@@ -75,7 +75,7 @@ func (d list) Mounts() ([]Mount, error) {
 	for i, di := range d {
 		mounts, err := di.Mounts()
 		if err != nil {
-			return nil, fmt.Errorf("error discovering mounts for discoverer %v: %v", i, err)
+			return nil, fmt.Errorf("error discovering mounts for discoverer %v: %w", i, err)
 		}
 		allMounts = append(allMounts, mounts...)
 	}
@@ -90,10 +90,19 @@ func (d list) Hooks() ([]Hook, error) {
 	for i, di := range d {
 		hooks, err := di.Hooks()
 		if err != nil {
-			return nil, fmt.Errorf("error discovering hooks for discoverer %v: %v", i, err)
+			return nil, fmt.Errorf("error discovering hooks for discoverer %v: %w", i, err)
 		}
 		allHooks = append(allHooks, hooks...)
 	}
 
 	return allHooks, nil
 }
+
+// missing returns the entities that the included discoverers could not locate.
+func (d list) missing() []string {
+	var allMissing []string
+	for _, di := range d {
+		allMissing = append(allMissing, missingFrom(di)...)
+	}
+	return allMissing
+}
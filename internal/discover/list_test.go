@@ -0,0 +1,56 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+func TestMergePreservesErrors(t *testing.T) {
+	notFound := fmt.Errorf("libfoo.so: %w", lookup.ErrNotFound)
+	failing := &DiscoverMock{
+		DevicesFunc: func() ([]Device, error) {
+			return nil, notFound
+		},
+		EnvVarsFunc: func() ([]EnvVar, error) {
+			return nil, notFound
+		},
+		MountsFunc: func() ([]Mount, error) {
+			return nil, notFound
+		},
+		HooksFunc: func() ([]Hook, error) {
+			return nil, notFound
+		},
+	}
+
+	d := Merge(None{}, failing)
+
+	_, err := d.Devices()
+	require.ErrorIs(t, err, lookup.ErrNotFound)
+	_, err = d.EnvVars()
+	require.ErrorIs(t, err, lookup.ErrNotFound)
+	_, err = d.Mounts()
+	require.ErrorIs(t, err, lookup.ErrNotFound)
+	_, err = d.Hooks()
+	require.ErrorIs(t, err, lookup.ErrNotFound)
+}
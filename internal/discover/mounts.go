@@ -34,6 +34,9 @@ type mounts struct {
 	lookup   lookup.Locator
 	root     string
 	required []string
+	// misses records the required entities that could not be located by
+	// the last call to Mounts.
+	misses []string
 }
 
 var _ Discover = (*mounts)(nil)
@@ -60,15 +63,18 @@ func (d *mounts) Mounts() ([]Mount, error) {
 
 	var mounts []Mount
 	seen := make(map[string]bool)
+	d.misses = nil
 	for _, candidate := range d.required {
 		d.logger.Debugf("Locating %v", candidate)
 		located, err := d.lookup.Locate(candidate)
 		if err != nil {
 			d.logger.Warningf("Could not locate %v: %v", candidate, err)
+			d.misses = append(d.misses, candidate)
 			continue
 		}
 		if len(located) == 0 {
 			d.logger.Warningf("Missing %v", candidate)
+			d.misses = append(d.misses, candidate)
 			continue
 		}
 		d.logger.Debugf("Located %v as %v", candidate, located)
@@ -103,6 +109,11 @@ func (d *mounts) Mounts() ([]Mount, error) {
 	return mounts, nil
 }
 
+// missing returns the required entities that could not be located.
+func (d *mounts) missing() []string {
+	return d.misses
+}
+
 // relativeTo returns the path relative to the root for the file locator
 func (d *mounts) relativeTo(path string) string {
 	if d.root == "/" {
@@ -0,0 +1,130 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+const (
+	defaultMPSPipeDirectory = "/tmp/nvidia-mps"
+	defaultMPSLogDirectory  = "/var/log/nvidia-mps"
+
+	envVarCUDAMPSPipeDirectory = "CUDA_MPS_PIPE_DIRECTORY"
+	envVarCUDAMPSLogDirectory  = "CUDA_MPS_LOG_DIRECTORY"
+)
+
+type mps struct {
+	None
+	logger      logger.Interface
+	pipeDir     Discover
+	logDir      Discover
+	pipeDirPath string
+	logDirPath  string
+}
+
+// NewMPSDiscoverer creates a discoverer for the CUDA Multi-Process Service
+// (MPS) pipe and log directories. If the pipe directory does not exist, no
+// entities are discovered. The default paths are used if no pipe or log
+// directory is specified. The directories are resolved relative to the
+// specified MPS root, which defaults to the host root (/). Note that this is
+// independent of the driver root since the MPS control daemon is not part of
+// the driver installation.
+func NewMPSDiscoverer(logger logger.Interface, mpsRoot string, pipeDirectory string, logDirectory string) Discover {
+	if mpsRoot == "" {
+		mpsRoot = "/"
+	}
+	if pipeDirectory == "" {
+		pipeDirectory = defaultMPSPipeDirectory
+	}
+	if logDirectory == "" {
+		logDirectory = defaultMPSLogDirectory
+	}
+
+	locator := lookup.NewDirectoryLocator(
+		lookup.WithLogger(logger),
+		lookup.WithRoot(mpsRoot),
+		lookup.WithCount(1),
+	)
+
+	return &mps{
+		logger:      logger,
+		pipeDir:     NewMounts(logger, locator, mpsRoot, []string{pipeDirectory}),
+		logDir:      NewMounts(logger, locator, mpsRoot, []string{logDirectory}),
+		pipeDirPath: pipeDirectory,
+		logDirPath:  logDirectory,
+	}
+}
+
+// Mounts returns the mounts for the MPS pipe and log directories. The log
+// directory is only included if the pipe directory exists.
+func (d *mps) Mounts() ([]Mount, error) {
+	pipeDirMounts, err := d.pipeDir.Mounts()
+	if err != nil {
+		return nil, err
+	}
+	if len(pipeDirMounts) == 0 {
+		d.logger.Debugf("MPS pipe directory %v not found; skipping MPS directories", d.pipeDirPath)
+		return nil, nil
+	}
+
+	logDirMounts, err := d.logDir.Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var mounts []Mount
+	for _, m := range append(pipeDirMounts, logDirMounts...) {
+		m.Options = []string{
+			"nosuid",
+			"nodev",
+			"rbind",
+			"rprivate",
+			"noexec",
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, nil
+}
+
+// EnvVars returns the CUDA_MPS_PIPE_DIRECTORY and CUDA_MPS_LOG_DIRECTORY
+// envvars for the discovered MPS directories.
+func (d *mps) EnvVars() ([]EnvVar, error) {
+	pipeDirMounts, err := d.pipeDir.Mounts()
+	if err != nil {
+		return nil, err
+	}
+	if len(pipeDirMounts) == 0 {
+		return nil, nil
+	}
+
+	envVars := []EnvVar{
+		{Name: envVarCUDAMPSPipeDirectory, Value: pipeDirMounts[0].Path},
+	}
+
+	logDirMounts, err := d.logDir.Mounts()
+	if err != nil {
+		return nil, err
+	}
+	if len(logDirMounts) > 0 {
+		envVars = append(envVars, EnvVar{Name: envVarCUDAMPSLogDirectory, Value: logDirMounts[0].Path})
+	}
+
+	return envVars, nil
+}
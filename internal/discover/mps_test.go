@@ -0,0 +1,137 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPSDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	mpsMountOptions := []string{"nosuid", "nodev", "rbind", "rprivate", "noexec"}
+
+	testCases := []struct {
+		description     string
+		directories     []string
+		pipeDirectory   string
+		logDirectory    string
+		expectedMounts  func(string) []Mount
+		expectedEnvVars []EnvVar
+	}{
+		{
+			description: "no pipe directory is a no-op",
+			directories: []string{"/var/log/nvidia-mps"},
+		},
+		{
+			description: "default directories",
+			directories: []string{"/tmp/nvidia-mps", "/var/log/nvidia-mps"},
+			expectedMounts: func(root string) []Mount {
+				return []Mount{
+					{HostPath: filepath.Join(root, "/tmp/nvidia-mps"), Path: "/tmp/nvidia-mps", Options: mpsMountOptions},
+					{HostPath: filepath.Join(root, "/var/log/nvidia-mps"), Path: "/var/log/nvidia-mps", Options: mpsMountOptions},
+				}
+			},
+			expectedEnvVars: []EnvVar{
+				{Name: "CUDA_MPS_PIPE_DIRECTORY", Value: "/tmp/nvidia-mps"},
+				{Name: "CUDA_MPS_LOG_DIRECTORY", Value: "/var/log/nvidia-mps"},
+			},
+		},
+		{
+			description: "missing log directory is skipped",
+			directories: []string{"/tmp/nvidia-mps"},
+			expectedMounts: func(root string) []Mount {
+				return []Mount{
+					{HostPath: filepath.Join(root, "/tmp/nvidia-mps"), Path: "/tmp/nvidia-mps", Options: mpsMountOptions},
+				}
+			},
+			expectedEnvVars: []EnvVar{
+				{Name: "CUDA_MPS_PIPE_DIRECTORY", Value: "/tmp/nvidia-mps"},
+			},
+		},
+		{
+			description:   "configured directories",
+			directories:   []string{"/run/mps/pipe", "/run/mps/log"},
+			pipeDirectory: "/run/mps/pipe",
+			logDirectory:  "/run/mps/log",
+			expectedMounts: func(root string) []Mount {
+				return []Mount{
+					{HostPath: filepath.Join(root, "/run/mps/pipe"), Path: "/run/mps/pipe", Options: mpsMountOptions},
+					{HostPath: filepath.Join(root, "/run/mps/log"), Path: "/run/mps/log", Options: mpsMountOptions},
+				}
+			},
+			expectedEnvVars: []EnvVar{
+				{Name: "CUDA_MPS_PIPE_DIRECTORY", Value: "/run/mps/pipe"},
+				{Name: "CUDA_MPS_LOG_DIRECTORY", Value: "/run/mps/log"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mpsRoot := t.TempDir()
+			for _, dir := range tc.directories {
+				require.NoError(t, os.MkdirAll(filepath.Join(mpsRoot, dir), 0755))
+			}
+
+			d := NewMPSDiscoverer(logger, mpsRoot, tc.pipeDirectory, tc.logDirectory)
+
+			var expectedMounts []Mount
+			if tc.expectedMounts != nil {
+				expectedMounts = tc.expectedMounts(mpsRoot)
+			}
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, expectedMounts, mounts)
+
+			envVars, err := d.EnvVars()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedEnvVars, envVars)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.Empty(t, hooks)
+		})
+	}
+}
+
+func TestMPSDiscovererDefaultsToHostRoot(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	hostDir := t.TempDir()
+	pipeDirectory := filepath.Join(hostDir, "pipe")
+	logDirectory := filepath.Join(hostDir, "log")
+	require.NoError(t, os.MkdirAll(pipeDirectory, 0755))
+	require.NoError(t, os.MkdirAll(logDirectory, 0755))
+
+	d := NewMPSDiscoverer(logger, "", pipeDirectory, logDirectory)
+
+	mounts, err := d.Mounts()
+	require.NoError(t, err)
+	require.Len(t, mounts, 2)
+	require.Equal(t, pipeDirectory, mounts[0].HostPath)
+	require.Equal(t, pipeDirectory, mounts[0].Path)
+	require.Equal(t, logDirectory, mounts[1].HostPath)
+	require.Equal(t, logDirectory, mounts[1].Path)
+}
@@ -0,0 +1,80 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+// sysModulePath is the host path at which the loaded kernel modules are listed.
+// This is a variable to allow it to be overridden in tests.
+var sysModulePath = "/sys/module"
+
+type peermemDiscoverer struct {
+	None
+	logger    logger.Interface
+	moduleDir string
+	devices   Discover
+	// moduleNotLoaded records whether the module was found to not be loaded
+	// by the last call to Devices.
+	moduleNotLoaded bool
+}
+
+// NewPeermemDiscoverer creates a discoverer for the RDMA device nodes used for
+// GPUDirect RDMA with the nvidia-peermem kernel module. These are the MOFED
+// device nodes, which are only discovered if the nvidia-peermem module is
+// loaded on the host.
+func NewPeermemDiscoverer(logger logger.Interface, driver *root.Driver) (Discover, error) {
+	devices, err := NewMOFEDDiscoverer(logger, driver)
+	if err != nil {
+		return nil, err
+	}
+
+	d := peermemDiscoverer{
+		logger:    logger,
+		moduleDir: filepath.Join(sysModulePath, "nvidia_peermem"),
+		devices:   devices,
+	}
+
+	return &d, nil
+}
+
+// Devices discovers the RDMA device nodes if the nvidia-peermem module is
+// loaded.
+func (d *peermemDiscoverer) Devices() ([]Device, error) {
+	_, err := os.Stat(d.moduleDir)
+	d.moduleNotLoaded = err != nil
+	if d.moduleNotLoaded {
+		d.logger.Debugf("The nvidia-peermem module is not loaded; skipping detection of devices")
+		return nil, nil
+	}
+	return d.devices.Devices()
+}
+
+// missing returns the module directory if the nvidia-peermem module is not
+// loaded and the device nodes that could not be located otherwise.
+func (d *peermemDiscoverer) missing() []string {
+	if d.moduleNotLoaded {
+		return []string{d.moduleDir}
+	}
+	return missingFrom(d.devices)
+}
@@ -0,0 +1,96 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestNewPeermemDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	defer devices.SetAllForTest()()
+
+	testCases := []struct {
+		description     string
+		moduleLoaded    bool
+		expectedDevices []Device
+	}{
+		{
+			description: "module not loaded returns no devices",
+		},
+		{
+			description:  "module loaded returns devices",
+			moduleLoaded: true,
+			expectedDevices: []Device{
+				{Path: "/dev/infiniband/uverbs0", HostPath: "/dev/infiniband/uverbs0"},
+				{Path: "/dev/infiniband/rdma_cm", HostPath: "/dev/infiniband/rdma_cm"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devRoot := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev/infiniband"), 0755))
+			for _, name := range []string{"uverbs0", "rdma_cm"} {
+				require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev/infiniband", name), nil, 0600))
+			}
+			sysRoot := t.TempDir()
+			if tc.moduleLoaded {
+				require.NoError(t, os.MkdirAll(filepath.Join(sysRoot, "sys/module/nvidia_peermem"), 0755))
+			}
+			defer setSysModulePathForTest(filepath.Join(sysRoot, "sys/module"))()
+
+			driver := root.New(root.WithDevRoot(devRoot))
+			d, err := NewPeermemDiscoverer(logger, driver)
+			require.NoError(t, err)
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, test.StripRoot(devices, devRoot))
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.Empty(t, mounts)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.Empty(t, hooks)
+		})
+	}
+}
+
+// setSysModulePathForTest overrides the host path at which loaded kernel
+// modules are listed and returns a function that restores the original value.
+func setSysModulePathForTest(path string) func() {
+	original := sysModulePath
+	sysModulePath = path
+	return func() {
+		sysModulePath = original
+	}
+}
@@ -38,33 +38,51 @@ func (s *Symlink) String() string {
 type additionalSymlinks struct {
 	logger logger.Interface
 	Discover
-	version     string
-	hookCreator HookCreator
+	version              string
+	hookCreator          HookCreator
+	libcudaSonameSymlink bool
+}
+
+// A DotSoSymlinksOption configures the discoverer returned by
+// WithDriverDotSoSymlinks.
+type DotSoSymlinksOption func(*additionalSymlinks)
+
+// WithLibcudaSonameSymlink sets whether the libcuda.so.1 -> libcuda.so.RM_VERSION
+// symlink is created for the injected libcuda library even if this symlink
+// does not exist on the host.
+func WithLibcudaSonameSymlink(libcudaSonameSymlink bool) DotSoSymlinksOption {
+	return func(d *additionalSymlinks) {
+		d.libcudaSonameSymlink = libcudaSonameSymlink
+	}
 }
 
 // WithDriverDotSoSymlinks decorates the provided discoverer.
 // A hook is added that checks for specific driver symlinks that need to be created.
-func WithDriverDotSoSymlinks(logger logger.Interface, mounts Discover, version string, hookCreator HookCreator) Discover {
+func WithDriverDotSoSymlinks(logger logger.Interface, mounts Discover, version string, hookCreator HookCreator, opts ...DotSoSymlinksOption) Discover {
 	if version == "" {
 		version = "*.*"
 	}
-	return &additionalSymlinks{
+	d := &additionalSymlinks{
 		logger:      logger,
 		Discover:    mounts,
 		hookCreator: hookCreator,
 		version:     version,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 // Hooks returns a hook to create the additional symlinks based on the mounts.
 func (d *additionalSymlinks) Hooks() ([]Hook, error) {
 	mounts, err := d.Mounts()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get library mounts: %v", err)
+		return nil, fmt.Errorf("failed to get library mounts: %w", err)
 	}
 	hooks, err := d.Discover.Hooks()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get hooks: %v", err)
+		return nil, fmt.Errorf("failed to get hooks: %w", err)
 	}
 
 	var links []string
@@ -98,7 +116,7 @@ func (d *additionalSymlinks) Hooks() ([]Hook, error) {
 
 	createSymlinkHooks, err := d.hookCreator.Create("create-symlinks", links...).Hooks()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create symlink hook: %v", err)
+		return nil, fmt.Errorf("failed to create symlink hook: %w", err)
 	}
 
 	return append(hooks, createSymlinkHooks...), nil
@@ -109,6 +127,9 @@ func (d additionalSymlinks) getLinksForMount(path string) []string {
 	dir, filename := filepath.Split(path)
 	switch {
 	case d.isDriverLibrary("libcuda.so", filename):
+		if d.libcudaSonameSymlink {
+			return getLibcudaSymlinkChain(dir, filename)
+		}
 		// XXX Many applications wrongly assume that libcuda.so exists (e.g. with dlopen).
 		// create libcuda.so -> libcuda.so.1 symlink
 		link := fmt.Sprintf("%s::%s", "libcuda.so.1", filepath.Join(dir, "libcuda.so"))
@@ -127,6 +148,24 @@ func (d additionalSymlinks) getLinksForMount(path string) []string {
 	return nil
 }
 
+// getLibcudaSymlinkChain returns the symlinks required to resolve libcuda.so
+// to the specified versioned libcuda library in the container.
+// Since applications load libcuda.so.1 (the SONAME), this link is created to
+// point to the injected library, even if it does not exist on the host. This
+// ensures that the libcuda.so link is never left dangling and that a stale
+// libcuda.so.1 link in the container is replaced.
+func getLibcudaSymlinkChain(dir string, filename string) []string {
+	var links []string
+	if filename != "libcuda.so.1" {
+		// create libcuda.so.1 -> libcuda.so.RM_VERSION symlink
+		links = append(links, fmt.Sprintf("%s::%s", filename, filepath.Join(dir, "libcuda.so.1")))
+	}
+	// XXX Many applications wrongly assume that libcuda.so exists (e.g. with dlopen).
+	// create libcuda.so -> libcuda.so.1 symlink
+	links = append(links, fmt.Sprintf("%s::%s", "libcuda.so.1", filepath.Join(dir, "libcuda.so")))
+	return links
+}
+
 // isDriverLibrary checks whether the specified filename is a specific driver library.
 func (d additionalSymlinks) isDriverLibrary(libraryName string, filename string) bool {
 	pattern := libraryName + "." + d.version
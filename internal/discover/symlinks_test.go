@@ -17,6 +17,7 @@
 package discover
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -30,6 +31,7 @@ func TestWithWithDriverDotSoSymlinks(t *testing.T) {
 		description          string
 		discover             Discover
 		version              string
+		opts                 []DotSoSymlinksOption
 		expectedDevices      []Device
 		expectedDevicesError error
 		expectedHooks        []Hook
@@ -155,6 +157,40 @@ func TestWithWithDriverDotSoSymlinks(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "libcuda.so.1 symlink is created when requested",
+			discover: &DiscoverMock{
+				DevicesFunc: func() ([]Device, error) {
+					return nil, nil
+				},
+				HooksFunc: func() ([]Hook, error) {
+					return nil, nil
+				},
+				MountsFunc: func() ([]Mount, error) {
+					mounts := []Mount{
+						{
+							Path: "/usr/lib/libcuda.so.1.2.3",
+						},
+					}
+					return mounts, nil
+				},
+			},
+			version: "1.2.3",
+			opts:    []DotSoSymlinksOption{WithLibcudaSonameSymlink(true)},
+			expectedMounts: []Mount{
+				{
+					Path: "/usr/lib/libcuda.so.1.2.3",
+				},
+			},
+			expectedHooks: []Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      "/usr/bin/nvidia-cdi-hook",
+					Args:      []string{"nvidia-cdi-hook", "create-symlinks", "--link", "libcuda.so.1.2.3::/usr/lib/libcuda.so.1", "--link", "libcuda.so.1::/usr/lib/libcuda.so"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
 		{
 			description: "beta libcuda.so.RM_VERSION is matched",
 			discover: &DiscoverMock{
@@ -322,6 +358,7 @@ func TestWithWithDriverDotSoSymlinks(t *testing.T) {
 				tc.discover,
 				tc.version,
 				hookCreator,
+				tc.opts...,
 			)
 
 			devices, err := d.Devices()
@@ -443,6 +480,45 @@ func TestGetDotSoSymlinks(t *testing.T) {
 	}
 }
 
+func TestGetLibcudaSymlinkChain(t *testing.T) {
+	testCases := []struct {
+		description      string
+		path             string
+		expectedSymlinks []string
+	}{
+		{
+			description: "versioned library",
+			path:        "/usr/lib/libcuda.so.999.88.77",
+			expectedSymlinks: []string{
+				"libcuda.so.999.88.77::/usr/lib/libcuda.so.1",
+				"libcuda.so.1::/usr/lib/libcuda.so",
+			},
+		},
+		{
+			description: "tegra library",
+			path:        "/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1",
+			expectedSymlinks: []string{
+				"libcuda.so.1.1::/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1",
+				"libcuda.so.1::/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so",
+			},
+		},
+		{
+			description: "soname library",
+			path:        "/usr/lib/libcuda.so.1",
+			expectedSymlinks: []string{
+				"libcuda.so.1::/usr/lib/libcuda.so",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir, filename := filepath.Split(tc.path)
+			require.EqualValues(t, tc.expectedSymlinks, getLibcudaSymlinkChain(dir, filename))
+		})
+	}
+}
+
 func TestGetSoLink(t *testing.T) {
 	testCases := []struct {
 		description    string
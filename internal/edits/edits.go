@@ -42,6 +42,7 @@ type empty string
 type factory struct {
 	logger                         logger.Interface
 	noAdditionalGIDsForDeviceNodes bool
+	mountPropagation               string
 }
 
 var _ Factory = (*empty)(nil)
@@ -98,7 +99,7 @@ func (f *factory) FromDiscoverer(d discover.Discover) (*cdi.ContainerEdits, erro
 	}
 
 	for _, m := range mounts {
-		c.Append(mount(m).toEdits())
+		c.Append(f.mount(m).toEdits())
 	}
 
 	for _, h := range hooks {
@@ -115,6 +116,13 @@ func (f *factory) device(d discover.Device) *device {
 	}
 }
 
+func (f *factory) mount(m discover.Mount) *mount {
+	return &mount{
+		Mount:       m,
+		propagation: f.mountPropagation,
+	}
+}
+
 // New creates a set of empty CDI container edits for an empty factory.
 func (e empty) New() *cdi.ContainerEdits {
 	c := cdi.ContainerEdits{
@@ -139,3 +147,11 @@ func WithNoAdditionalGIDsForDeviceNodes(noAdditionalGIDsForDeviceNodes bool) Opt
 		f.noAdditionalGIDsForDeviceNodes = noAdditionalGIDsForDeviceNodes
 	}
 }
+
+// WithMountPropagation sets the propagation for the generated mounts. If
+// this is empty the mount options from the discoverers are used as is.
+func WithMountPropagation(mountPropagation string) Option {
+	return func(f *factory) {
+		f.mountPropagation = mountPropagation
+	}
+}
@@ -0,0 +1,62 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package edits
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestHookToSpecEnv(t *testing.T) {
+	testCases := []struct {
+		description  string
+		hook         *discover.Hook
+		expectedJSON string
+	}{
+		{
+			description: "env is serialized",
+			hook: discover.NewHookCreator(
+				discover.WithEnv("FOO=bar"),
+			).Create(discover.UpdateLDCacheHook),
+			expectedJSON: `{"hookName":"createContainer","path":"/usr/bin/nvidia-cdi-hook","args":["nvidia-cdi-hook","update-ldcache"],"env":["NVIDIA_CTK_DEBUG=false","FOO=bar"]}`,
+		},
+		{
+			description: "empty env is omitted",
+			hook: &discover.Hook{
+				Lifecycle: "createContainer",
+				Path:      "/usr/bin/nvidia-cdi-hook",
+				Args:      []string{"nvidia-cdi-hook", "update-ldcache"},
+			},
+			expectedJSON: `{"hookName":"createContainer","path":"/usr/bin/nvidia-cdi-hook","args":["nvidia-cdi-hook","update-ldcache"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s := (*hook)(tc.hook).toSpec()
+
+			contents, err := json.Marshal(s)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expectedJSON, string(contents))
+		})
+	}
+}
@@ -23,7 +23,20 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 )
 
-type mount discover.Mount
+type mount struct {
+	discover.Mount
+	propagation string
+}
+
+// propagationOptions are the mount options that define mount propagation.
+var propagationOptions = map[string]bool{
+	"rprivate": true,
+	"private":  true,
+	"rslave":   true,
+	"slave":    true,
+	"rshared":  true,
+	"shared":   true,
+}
 
 // toEdits converts a discovered mount to CDI Container Edits.
 func (d mount) toEdits() *cdi.ContainerEdits {
@@ -41,8 +54,25 @@ func (d mount) toSpec() *specs.Mount {
 	s := specs.Mount{
 		HostPath:      d.HostPath,
 		ContainerPath: d.Path,
-		Options:       d.Options,
+		Options:       d.options(),
 	}
 
 	return &s
 }
+
+// options returns the mount options with the propagation option replaced by
+// the configured propagation. If no propagation is configured, the options
+// are returned as is.
+func (d mount) options() []string {
+	if d.propagation == "" {
+		return d.Options
+	}
+	var options []string
+	for _, option := range d.Options {
+		if propagationOptions[option] {
+			continue
+		}
+		options = append(options, option)
+	}
+	return append(options, d.propagation)
+}
@@ -0,0 +1,124 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package edits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestMountPropagation(t *testing.T) {
+	library := discover.Mount{
+		HostPath: "/usr/lib64/libcuda.so.1",
+		Path:     "/usr/lib64/libcuda.so.1",
+		Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+	}
+	directory := discover.Mount{
+		HostPath: "/tmp/nvidia-mps",
+		Path:     "/tmp/nvidia-mps",
+		Options:  []string{"nosuid", "nodev", "rbind", "rprivate", "noexec"},
+	}
+
+	testCases := []struct {
+		description      string
+		mountPropagation string
+		mounts           []discover.Mount
+		expectedMounts   []*specs.Mount
+	}{
+		{
+			description: "default leaves options unchanged",
+			mounts:      []discover.Mount{library, directory},
+			expectedMounts: []*specs.Mount{
+				{
+					HostPath:      "/usr/lib64/libcuda.so.1",
+					ContainerPath: "/usr/lib64/libcuda.so.1",
+					Options:       []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+				},
+				{
+					HostPath:      "/tmp/nvidia-mps",
+					ContainerPath: "/tmp/nvidia-mps",
+					Options:       []string{"nosuid", "nodev", "rbind", "rprivate", "noexec"},
+				},
+			},
+		},
+		{
+			description:      "propagation is replaced",
+			mountPropagation: "rslave",
+			mounts:           []discover.Mount{library, directory},
+			expectedMounts: []*specs.Mount{
+				{
+					HostPath:      "/usr/lib64/libcuda.so.1",
+					ContainerPath: "/usr/lib64/libcuda.so.1",
+					Options:       []string{"ro", "nosuid", "nodev", "rbind", "rslave"},
+				},
+				{
+					HostPath:      "/tmp/nvidia-mps",
+					ContainerPath: "/tmp/nvidia-mps",
+					Options:       []string{"nosuid", "nodev", "rbind", "noexec", "rslave"},
+				},
+			},
+		},
+		{
+			description:      "propagation is added if not present",
+			mountPropagation: "rprivate",
+			mounts: []discover.Mount{
+				{
+					HostPath: "/usr/bin/nvidia-smi",
+					Path:     "/usr/bin/nvidia-smi",
+					Options:  []string{"ro", "rbind"},
+				},
+			},
+			expectedMounts: []*specs.Mount{
+				{
+					HostPath:      "/usr/bin/nvidia-smi",
+					ContainerPath: "/usr/bin/nvidia-smi",
+					Options:       []string{"ro", "rbind", "rprivate"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			f := NewFactory(WithMountPropagation(tc.mountPropagation))
+
+			d := &discover.DiscoverMock{
+				DevicesFunc: func() ([]discover.Device, error) {
+					return nil, nil
+				},
+				EnvVarsFunc: func() ([]discover.EnvVar, error) {
+					return nil, nil
+				},
+				HooksFunc: func() ([]discover.Hook, error) {
+					return nil, nil
+				},
+				MountsFunc: func() ([]discover.Mount, error) {
+					return tc.mounts, nil
+				},
+			}
+
+			edits, err := f.FromDiscoverer(d)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, edits.Mounts)
+		})
+	}
+}
@@ -56,6 +56,9 @@ type modeResolver struct {
 	image             *image.CUDA
 	propertyExtractor info.PropertyExtractor
 	defaultMode       RuntimeMode
+	// allowModeOverride indicates whether a container is allowed to select
+	// the runtime mode using the NVIDIA_CONTAINER_RUNTIME_MODE envvar.
+	allowModeOverride bool
 }
 
 type Option func(*modeResolver)
@@ -66,6 +69,14 @@ func WithDefaultMode(defaultMode RuntimeMode) Option {
 	}
 }
 
+// WithAllowModeOverride sets whether a container is allowed to override the
+// requested runtime mode by setting the NVIDIA_CONTAINER_RUNTIME_MODE envvar.
+func WithAllowModeOverride(allowModeOverride bool) Option {
+	return func(mr *modeResolver) {
+		mr.allowModeOverride = allowModeOverride
+	}
+}
+
 func WithLogger(logger logger.Interface) Option {
 	return func(mr *modeResolver) {
 		mr.logger = logger
@@ -109,6 +120,10 @@ func ResolveAutoMode(logger logger.Interface, mode string, image image.CUDA) (rm
 }
 
 func (m *modeResolver) ResolveRuntimeMode(mode string) (rmode RuntimeMode) {
+	if override := m.getModeOverride(); override != "" {
+		m.logger.Infof("Using container-requested mode '%s' instead of '%s'", override, mode)
+		return override
+	}
 	if mode != "auto" {
 		m.logger.Infof("Using requested mode '%s'", mode)
 		return RuntimeMode(mode)
@@ -134,3 +149,22 @@ func (m *modeResolver) ResolveRuntimeMode(mode string) (rmode RuntimeMode) {
 	}
 	return m.defaultMode
 }
+
+// getModeOverride returns the runtime mode requested by the container.
+// Only the legacy and cdi modes can be requested and an empty mode is returned
+// if overrides are not allowed or no valid mode was requested.
+func (m *modeResolver) getModeOverride() RuntimeMode {
+	if !m.allowModeOverride || m.image == nil {
+		return ""
+	}
+	requested := m.image.Getenv(image.EnvVarNvidiaContainerRuntimeMode)
+	switch RuntimeMode(requested) {
+	case "":
+		return ""
+	case LegacyRuntimeMode, CDIRuntimeMode:
+		return RuntimeMode(requested)
+	default:
+		m.logger.Warningf("Ignoring unsupported %v value %q", image.EnvVarNvidiaContainerRuntimeMode, requested)
+		return ""
+	}
+}
@@ -247,3 +247,70 @@ func TestResolveAutoMode(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveRuntimeModeOverride(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description       string
+		mode              string
+		allowModeOverride bool
+		envmap            map[string]string
+		expectedMode      RuntimeMode
+	}{
+		{
+			description:       "no override uses configured mode",
+			mode:              "legacy",
+			allowModeOverride: true,
+			expectedMode:      LegacyRuntimeMode,
+		},
+		{
+			description:       "cdi overrides configured legacy mode",
+			mode:              "legacy",
+			allowModeOverride: true,
+			envmap:            map[string]string{"NVIDIA_CONTAINER_RUNTIME_MODE": "cdi"},
+			expectedMode:      CDIRuntimeMode,
+		},
+		{
+			description:       "legacy overrides configured cdi mode",
+			mode:              "cdi",
+			allowModeOverride: true,
+			envmap:            map[string]string{"NVIDIA_CONTAINER_RUNTIME_MODE": "legacy"},
+			expectedMode:      LegacyRuntimeMode,
+		},
+		{
+			description:       "override takes precedence over auto",
+			mode:              "auto",
+			allowModeOverride: true,
+			envmap:            map[string]string{"NVIDIA_CONTAINER_RUNTIME_MODE": "legacy"},
+			expectedMode:      LegacyRuntimeMode,
+		},
+		{
+			description:       "unsupported override is ignored",
+			mode:              "legacy",
+			allowModeOverride: true,
+			envmap:            map[string]string{"NVIDIA_CONTAINER_RUNTIME_MODE": "csv"},
+			expectedMode:      LegacyRuntimeMode,
+		},
+		{
+			description:  "override is ignored if not allowed",
+			mode:         "legacy",
+			envmap:       map[string]string{"NVIDIA_CONTAINER_RUNTIME_MODE": "cdi"},
+			expectedMode: LegacyRuntimeMode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			image, _ := image.New(
+				image.WithEnvMap(tc.envmap),
+			)
+			mr := NewRuntimeModeResolver(
+				WithLogger(logger),
+				WithImage(&image),
+				WithAllowModeOverride(tc.allowModeOverride),
+			)
+			require.EqualValues(t, tc.expectedMode, mr.ResolveRuntimeMode(tc.mode))
+		})
+	}
+}
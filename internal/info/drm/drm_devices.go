@@ -17,22 +17,31 @@
 package drm
 
 import (
-	"fmt"
 	"path/filepath"
 )
 
 // GetDeviceNodesByBusID returns the DRM devices associated with the specified PCI bus ID
 func GetDeviceNodesByBusID(busID string) ([]string, error) {
-	drmRoot := filepath.Join("/sys/bus/pci/devices", busID, "drm")
-	matches, err := filepath.Glob(fmt.Sprintf("%s/*", drmRoot))
-	if err != nil {
-		return nil, err
-	}
+	return GetDeviceNodesByBusIDFromSysfs("/sys", busID)
+}
+
+// GetDeviceNodesByBusIDFromSysfs returns the DRM card and render nodes
+// associated with the specified PCI bus ID using the specified sysfs root. If
+// the device has no DRM nodes (e.g. if the nvidia-drm module is not loaded),
+// an empty list is returned.
+func GetDeviceNodesByBusIDFromSysfs(sysfsRoot string, busID string) ([]string, error) {
+	drmRoot := filepath.Join(sysfsRoot, "bus/pci/devices", busID, "drm")
 
 	var drmDeviceNodes []string
-	for _, m := range matches {
-		drmDeviceNode := filepath.Join("/dev/dri", filepath.Base(m))
-		drmDeviceNodes = append(drmDeviceNodes, drmDeviceNode)
+	for _, pattern := range []string{"card*", "renderD*"} {
+		matches, err := filepath.Glob(filepath.Join(drmRoot, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			drmDeviceNode := filepath.Join("/dev/dri", filepath.Base(m))
+			drmDeviceNodes = append(drmDeviceNodes, drmDeviceNode)
+		}
 	}
 
 	return drmDeviceNodes, nil
@@ -0,0 +1,59 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// defaultDriverRootCandidates are the locations that are probed for a driver
+// installation if no driver root is configured. These correspond to a driver
+// installed on the host, a driver container, and a host root mounted into a
+// container.
+var defaultDriverRootCandidates = []string{"/", "/run/nvidia/driver", "/host"}
+
+// DetectDriverRoot returns the first of the specified candidate roots that
+// contains a driver installation. A root is considered valid if the
+// libnvidia-ml.so library can be located there. If no candidates are
+// specified, the default candidates are probed. An empty string is returned if
+// no valid driver root is found.
+func DetectDriverRoot(logger logger.Interface, candidates ...string) string {
+	if len(candidates) == 0 {
+		candidates = defaultDriverRootCandidates
+	}
+	for _, candidate := range candidates {
+		driver := New(
+			WithLogger(logger),
+			WithDriverRoot(candidate),
+		)
+		if !driver.hasNVML() {
+			logger.Debugf("No driver installation found at %v", candidate)
+			continue
+		}
+		logger.Debugf("Detected driver root %v", candidate)
+		return candidate
+	}
+	return ""
+}
+
+// hasNVML checks whether the libnvidia-ml.so library can be located in the
+// driver root.
+func (r *Driver) hasNVML() bool {
+	libraries, err := r.Libraries().Locate("libnvidia-ml.so.1")
+	return err == nil && len(libraries) > 0
+}
@@ -0,0 +1,81 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDriverRoot(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		withNVML      []bool
+		expectedIndex int
+	}{
+		{
+			description:   "no valid driver root",
+			withNVML:      []bool{false, false, false},
+			expectedIndex: -1,
+		},
+		{
+			description:   "first valid driver root is selected",
+			withNVML:      []bool{true, true, true},
+			expectedIndex: 0,
+		},
+		{
+			description:   "invalid candidates are skipped",
+			withNVML:      []bool{false, true, true},
+			expectedIndex: 1,
+		},
+		{
+			description:   "last candidate is probed",
+			withNVML:      []bool{false, false, true},
+			expectedIndex: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var candidates []string
+			for _, withNVML := range tc.withNVML {
+				candidate := t.TempDir()
+				libDir := filepath.Join(candidate, "usr/lib64")
+				require.NoError(t, os.MkdirAll(libDir, 0755))
+				if withNVML {
+					require.NoError(t, os.WriteFile(filepath.Join(libDir, "libnvidia-ml.so.999.88.77"), nil, 0600))
+					require.NoError(t, os.Symlink("libnvidia-ml.so.999.88.77", filepath.Join(libDir, "libnvidia-ml.so.1")))
+				}
+				candidates = append(candidates, candidate)
+			}
+
+			var expected string
+			if tc.expectedIndex >= 0 {
+				expected = candidates[tc.expectedIndex]
+			}
+
+			require.Equal(t, expected, DetectDriverRoot(logger, candidates...))
+		})
+	}
+}
@@ -127,19 +127,85 @@ func (r *Driver) DriverLibraryLocator(additionalDirs ...string) (lookup.Locator,
 			searchPaths = append(searchPaths, filepath.Join(libcudasoParentDirPath, dir))
 		}
 	}
+	// The explicit library search paths supplement the libcuda.so parent
+	// directory. Since the locator resolves symlinks and returns unique
+	// candidates, libraries found in multiple locations are only included
+	// once.
+	for _, dir := range r.librarySearchPaths {
+		searchPaths = append(searchPaths, r.RelativeToRoot(dir))
+	}
+
+	version, err := r.Version()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver version: %w", err)
+	}
 
 	l := lookup.AsOptional(
-		lookup.NewSymlinkLocator(
-			lookup.WithRoot(r.Root),
-			lookup.WithLogger(r.logger),
-			lookup.WithSearchPaths(
-				searchPaths...,
+		&activeVersionLocator{
+			logger:  r.logger,
+			version: version,
+			Locator: lookup.NewSymlinkLocator(
+				lookup.WithRoot(r.Root),
+				lookup.WithLogger(r.logger),
+				lookup.WithSearchPaths(
+					searchPaths...,
+				),
 			),
-		),
+		},
 	)
 	return l, nil
 }
 
+// An activeVersionLocator filters out candidates that are located in the
+// directory of a driver installation other than the active one. This allows
+// multiple driver versions to be installed side-by-side in versioned
+// directories.
+type activeVersionLocator struct {
+	lookup.Locator
+	logger  logger.Interface
+	version string
+}
+
+// Locate returns the candidates from the wrapped locator that are not in the
+// library directory of a different driver version.
+func (l *activeVersionLocator) Locate(pattern string) ([]string, error) {
+	candidates, err := l.Locator.Locate(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []string
+	for _, candidate := range candidates {
+		if otherVersion := otherDriverVersionInDir(filepath.Dir(candidate), l.version); otherVersion != "" {
+			l.logger.Debugf("Ignoring %v for driver version %v; active version is %v", candidate, otherVersion, l.version)
+			continue
+		}
+		filtered = append(filtered, candidate)
+	}
+	return filtered, nil
+}
+
+// otherDriverVersionInDir returns the driver version of the libcuda.so or
+// libnvidia-ml.so libraries in the specified directory if these do not include
+// the specified version. An empty string is returned if the directory contains
+// the specified version or no versioned driver libraries.
+func otherDriverVersionInDir(dir string, version string) string {
+	var otherVersion string
+	for _, driverLib := range []string{"libcuda.so.", "libnvidia-ml.so."} {
+		if _, err := os.Lstat(filepath.Join(dir, driverLib+version)); err == nil {
+			return ""
+		}
+		if otherVersion != "" {
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(dir, driverLib+"*.*"))
+		if len(matches) > 0 {
+			otherVersion = strings.TrimPrefix(filepath.Base(matches[0]), driverLib)
+		}
+	}
+	return otherVersion
+}
+
 func (r *Driver) updateInfo() error {
 	driverLibPath, version, err := r.inferVersion()
 	if err != nil {
@@ -205,11 +271,25 @@ func (r *Driver) Files(opts ...lookup.Option) lookup.Locator {
 }
 
 // Libraries returns a Locator for driver libraries.
+// If explicit library search paths are specified, the libraries found in these
+// paths are returned in addition to the libraries found in the standard
+// library locations, including the ldcache. Libraries found in both are only
+// included once.
 func (r *Driver) Libraries() lookup.Locator {
-	return lookup.NewLibraryLocator(
+	standard := lookup.NewLibraryLocator(
 		lookup.WithLogger(r.logger),
 		lookup.WithRoot(r.Root),
-		lookup.WithSearchPaths(r.librarySearchPaths...),
+	)
+	if len(r.librarySearchPaths) == 0 {
+		return standard
+	}
+	return lookup.Merge(
+		lookup.NewLibraryLocator(
+			lookup.WithLogger(r.logger),
+			lookup.WithRoot(r.Root),
+			lookup.WithSearchPaths(r.librarySearchPaths...),
+		),
+		standard,
 	)
 }
 
@@ -17,6 +17,7 @@
 package root
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -79,3 +80,209 @@ func TestDriverLibrariesLocate(t *testing.T) {
 		}
 	}
 }
+
+func TestDriverLibraryLocatorWithLibrarySearchPaths(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	for _, lib := range []string{
+		"usr/lib64/libcuda.so.999.88.77",
+		"usr/lib64/libnvidia-ml.so.999.88.77",
+		"opt/nvidia/lib/libnvidia-custom.so.999.88.77",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, filepath.Dir(lib)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(driverRoot, lib), nil, 0644))
+	}
+	// A symlink to a library in the standard location is only included once.
+	require.NoError(t, os.Symlink(
+		"../../../usr/lib64/libcuda.so.999.88.77",
+		filepath.Join(driverRoot, "opt/nvidia/lib/libcuda.so.999.88.77"),
+	))
+
+	testCases := []struct {
+		description        string
+		librarySearchPaths []string
+		expected           []string
+	}{
+		{
+			description: "standard locations only",
+			expected: []string{
+				"/usr/lib64/libcuda.so.999.88.77",
+				"/usr/lib64/libnvidia-ml.so.999.88.77",
+			},
+		},
+		{
+			description:        "library search path supplements standard locations",
+			librarySearchPaths: []string{filepath.Join(driverRoot, "opt/nvidia/lib")},
+			expected: []string{
+				"/usr/lib64/libcuda.so.999.88.77",
+				"/usr/lib64/libnvidia-ml.so.999.88.77",
+				"/opt/nvidia/lib/libnvidia-custom.so.999.88.77",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driver := New(
+				WithLogger(logger),
+				WithDriverRoot(driverRoot),
+				WithLibrarySearchPaths(tc.librarySearchPaths...),
+			)
+
+			locator, err := driver.DriverLibraryLocator()
+			require.NoError(t, err)
+
+			candidates, err := locator.Locate("*.so.999.88.77")
+			require.NoError(t, err)
+
+			var expected []string
+			for _, path := range tc.expected {
+				expected = append(expected, filepath.Join(driverRoot, path))
+			}
+			require.ElementsMatch(t, expected, candidates)
+		})
+	}
+}
+
+type staticVersion string
+
+func (v staticVersion) Version() (string, error) {
+	return string(v), nil
+}
+
+func TestDriverLibraryLocatorWithSideBySideVersions(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	for _, lib := range []string{
+		"usr/lib/nvidia-535/libcuda.so.535.183.01",
+		"usr/lib/nvidia-535/libnvidia-ml.so.535.183.01",
+		"usr/lib/nvidia-535/libnvidia-api.so.1",
+		"usr/lib/nvidia-550/libcuda.so.550.54.15",
+		"usr/lib/nvidia-550/libnvidia-ml.so.550.54.15",
+		"usr/lib/nvidia-550/libnvidia-api.so.1",
+		"opt/nvidia/lib/libnvidia-api.so.1",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, filepath.Dir(lib)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(driverRoot, lib), nil, 0644))
+	}
+	librarySearchPaths := []string{
+		filepath.Join(driverRoot, "usr/lib/nvidia-535"),
+		filepath.Join(driverRoot, "usr/lib/nvidia-550"),
+		filepath.Join(driverRoot, "opt/nvidia/lib"),
+	}
+
+	testCases := []struct {
+		description          string
+		activeVersion        string
+		expectedLibDirectory string
+		expected             []string
+	}{
+		{
+			description:          "newer version is active",
+			activeVersion:        "550.54.15",
+			expectedLibDirectory: "/usr/lib/nvidia-550",
+			expected: []string{
+				"/usr/lib/nvidia-550/libnvidia-api.so.1",
+				"/opt/nvidia/lib/libnvidia-api.so.1",
+			},
+		},
+		{
+			description:          "older version is active",
+			activeVersion:        "535.183.01",
+			expectedLibDirectory: "/usr/lib/nvidia-535",
+			expected: []string{
+				"/usr/lib/nvidia-535/libnvidia-api.so.1",
+				"/opt/nvidia/lib/libnvidia-api.so.1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driver := New(
+				WithLogger(logger),
+				WithDriverRoot(driverRoot),
+				WithLibrarySearchPaths(librarySearchPaths...),
+				WithVersioner(staticVersion(tc.activeVersion)),
+			)
+
+			libDirectory, err := driver.GetDriverLibDirectory()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedLibDirectory, libDirectory)
+
+			locator, err := driver.DriverLibraryLocator()
+			require.NoError(t, err)
+
+			candidates, err := locator.Locate("libnvidia-api.so.1")
+			require.NoError(t, err)
+
+			var expected []string
+			for _, path := range tc.expected {
+				expected = append(expected, filepath.Join(driverRoot, path))
+			}
+			require.ElementsMatch(t, expected, candidates)
+		})
+	}
+}
+
+func TestDriverLibrariesWithLibrarySearchPaths(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	for _, lib := range []string{
+		"usr/lib64/libcuda.so.999.88.77",
+		"opt/nvidia/lib/libnvidia-custom.so.999.88.77",
+		"opt/nvidia/lib/libnvidia-ml.so.999.88.77",
+		"usr/lib64/libnvidia-ml.so.999.88.77",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, filepath.Dir(lib)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(driverRoot, lib), nil, 0644))
+	}
+	// A symlink to a library in the standard location is only included once.
+	require.NoError(t, os.Symlink(
+		"../../../usr/lib64/libcuda.so.999.88.77",
+		filepath.Join(driverRoot, "opt/nvidia/lib/libcuda.so.999.88.77"),
+	))
+
+	driver := New(
+		WithLogger(logger),
+		WithDriverRoot(driverRoot),
+		WithLibrarySearchPaths(filepath.Join(driverRoot, "opt/nvidia/lib")),
+	)
+
+	testCases := []struct {
+		library  string
+		expected []string
+	}{
+		{
+			library:  "libcuda.so.999.88.77",
+			expected: []string{"/usr/lib64/libcuda.so.999.88.77"},
+		},
+		{
+			library:  "libnvidia-custom.so.999.88.77",
+			expected: []string{"/opt/nvidia/lib/libnvidia-custom.so.999.88.77"},
+		},
+		{
+			library: "libnvidia-ml.so.999.88.77",
+			expected: []string{
+				"/opt/nvidia/lib/libnvidia-ml.so.999.88.77",
+				"/usr/lib64/libnvidia-ml.so.999.88.77",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.library, func(t *testing.T) {
+			candidates, err := driver.Libraries().Locate(tc.library)
+			require.NoError(t, err)
+
+			var expected []string
+			for _, path := range tc.expected {
+				expected = append(expected, filepath.Join(driverRoot, path))
+			}
+			require.Equal(t, expected, candidates)
+		})
+	}
+}
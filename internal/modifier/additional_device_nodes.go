@@ -0,0 +1,104 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// additionalDeviceNodes is a spec modifier that injects the configured
+// additional device nodes.
+type additionalDeviceNodes struct {
+	devices []specs.LinuxDevice
+}
+
+var _ oci.SpecModifier = (*additionalDeviceNodes)(nil)
+
+// newAdditionalDeviceNodes creates a modifier that adds the configured
+// additional device nodes to containers that request devices. The device
+// type and numbers are read from the host device nodes so that block devices
+// are also supported. Since this is only done when a container is created,
+// invalid device nodes are not detected when the config is loaded. If no
+// additional device nodes are configured, or no devices are requested, nil is
+// returned.
+func (f *Factory) newAdditionalDeviceNodes() (oci.SpecModifier, error) {
+	if len(f.cfg.NVIDIAContainerRuntimeConfig.AdditionalDeviceNodes) == 0 {
+		return nil, nil
+	}
+	if f.image == nil {
+		return nil, nil
+	}
+	// Note that a request for no devices (e.g. NVIDIA_VISIBLE_DEVICES=none)
+	// is represented as a single empty device.
+	if devices := f.image.VisibleDevices(); len(devices) == 0 || devices[0] == "" {
+		return nil, nil
+	}
+
+	var linuxDevices []specs.LinuxDevice
+	for _, path := range f.cfg.NVIDIAContainerRuntimeConfig.AdditionalDeviceNodes {
+		d, err := devices.DeviceFromPath(path, "rwm")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get additional device node %v: %w", path, err)
+		}
+		linuxDevice := specs.LinuxDevice{
+			Path:  path,
+			Type:  string(d.Type),
+			Major: d.Major,
+			Minor: d.Minor,
+		}
+		if d.FileMode != 0 {
+			fileMode := d.FileMode
+			linuxDevice.FileMode = &fileMode
+		}
+		if d.Gid != 0 {
+			gid := d.Gid
+			linuxDevice.GID = &gid
+		}
+		linuxDevices = append(linuxDevices, linuxDevice)
+	}
+
+	return &additionalDeviceNodes{devices: linuxDevices}, nil
+}
+
+// Modify adds the additional device nodes to the spec. Device nodes that are
+// already present in the spec are not added again.
+func (m *additionalDeviceNodes) Modify(spec *specs.Spec) error {
+	if spec == nil {
+		return nil
+	}
+	if spec.Linux == nil {
+		spec.Linux = &specs.Linux{}
+	}
+
+	existing := make(map[string]bool)
+	for _, device := range spec.Linux.Devices {
+		existing[device.Path] = true
+	}
+	for _, device := range m.devices {
+		if existing[device.Path] {
+			continue
+		}
+		spec.Linux.Devices = append(spec.Linux.Devices, device)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/cgroups/devices/config"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+)
+
+func TestAdditionalDeviceNodes(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	defer devices.SetDeviceFromPathForTest(func(path string, permissions string) (*devices.Device, error) {
+		switch path {
+		case "/dev/nvidia-custom":
+			return &devices.Device{
+				Path:     path,
+				Rule:     config.Rule{Type: config.CharDevice, Major: 511, Minor: 3},
+				FileMode: os.ModeCharDevice | 0666,
+			}, nil
+		case "/dev/nvme-custom":
+			return &devices.Device{
+				Path:     path,
+				Rule:     config.Rule{Type: config.BlockDevice, Major: 259, Minor: 7},
+				FileMode: os.ModeDevice | 0660,
+				Gid:      6,
+			}, nil
+		}
+		return nil, fmt.Errorf("not a device: %v", path)
+	})()
+
+	charMajor, charMinor := int64(511), int64(3)
+	blockMajor, blockMinor := int64(259), int64(7)
+	charFileMode := os.ModeCharDevice | 0666
+	blockFileMode := os.ModeDevice | 0660
+	blockGID := uint32(6)
+
+	testCases := []struct {
+		description           string
+		additionalDeviceNodes []string
+		env                   []string
+		spec                  *specs.Spec
+		expectedSpec          *specs.Spec
+		expectedError         bool
+	}{
+		{
+			description:  "no additional device nodes configured",
+			env:          []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			spec:         &specs.Spec{},
+			expectedSpec: &specs.Spec{},
+		},
+		{
+			description:           "no devices requested",
+			additionalDeviceNodes: []string{"/dev/nvidia-custom"},
+			spec:                  &specs.Spec{},
+			expectedSpec:          &specs.Spec{},
+		},
+		{
+			description:           "devices requested as none",
+			additionalDeviceNodes: []string{"/dev/nvidia-custom"},
+			env:                   []string{"NVIDIA_VISIBLE_DEVICES=none"},
+			spec:                  &specs.Spec{},
+			expectedSpec:          &specs.Spec{},
+		},
+		{
+			description:           "char and block devices are injected with cgroup rules",
+			additionalDeviceNodes: []string{"/dev/nvidia-custom", "/dev/nvme-custom"},
+			env:                   []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			spec:                  &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/nvidia-custom", Type: "c", Major: 511, Minor: 3, FileMode: &charFileMode},
+						{Path: "/dev/nvme-custom", Type: "b", Major: 259, Minor: 7, FileMode: &blockFileMode, GID: &blockGID},
+					},
+					Resources: &specs.LinuxResources{
+						Devices: []specs.LinuxDeviceCgroup{
+							{Allow: true, Type: "c", Major: &charMajor, Minor: &charMinor, Access: "rwm"},
+							{Allow: true, Type: "b", Major: &blockMajor, Minor: &blockMinor, Access: "rwm"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description:           "device node existing in the spec is not added",
+			additionalDeviceNodes: []string{"/dev/nvidia-custom"},
+			env:                   []string{"NVIDIA_VISIBLE_DEVICES=0"},
+			spec: &specs.Spec{
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/nvidia-custom", Type: "c", Major: 511, Minor: 3},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/nvidia-custom", Type: "c", Major: 511, Minor: 3},
+					},
+				},
+			},
+		},
+		{
+			description:           "path that is not a device is an error",
+			additionalDeviceNodes: []string{"/dev/missing"},
+			env:                   []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			expectedError:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &v1.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.AdditionalDeviceNodes = tc.additionalDeviceNodes
+
+			cudaImage, err := image.New(
+				image.WithEnv(tc.env),
+				image.WithAcceptEnvvarUnprivileged(true),
+			)
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+				WithImage(&cudaImage),
+			)
+
+			m, err := f.newAdditionalDeviceNodes()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NoError(t, f.withDeviceCgroupRules(list{m}).Modify(tc.spec))
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
+
+func TestAdditionalDeviceNodesWithoutImage(t *testing.T) {
+	cfg := &v1.Config{}
+	cfg.NVIDIAContainerRuntimeConfig.AdditionalDeviceNodes = []string{"/dev/nvidia-custom"}
+
+	f := createFactory(WithConfig(cfg))
+
+	m, err := f.newAdditionalDeviceNodes()
+	require.NoError(t, err)
+	require.Nil(t, m)
+}
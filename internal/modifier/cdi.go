@@ -21,7 +21,9 @@ import (
 	"strings"
 
 	"tags.cncf.io/container-device-interface/pkg/parser"
+	"tags.cncf.io/container-device-interface/specs-go"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/modifier/cdi"
@@ -142,6 +144,9 @@ func (g gatedDevices) DeviceRequests() []string {
 	if i.Getenv("NVIDIA_NVSWITCH") == "enabled" {
 		devices = append(devices, "mode=nvswitch")
 	}
+	if i.Getenv("NVIDIA_PEERMEM") == "enabled" {
+		devices = append(devices, "mode=peermem")
+	}
 
 	return devices
 }
@@ -209,6 +214,16 @@ func (f *Factory) newAutomaticCDISpecModifier(devices []string) (oci.SpecModifie
 			return nil, fmt.Errorf("failed to generate CDI spec for mode %q: %w", mode, err)
 		}
 
+		if mode == "auto" && requestsDevices(cdiModeIdentifiers.idsByMode[mode]) && !hasDeviceNodes(spec.Raw()) {
+			skip, err := f.handleNoDevices(devices)
+			if err != nil {
+				return nil, err
+			}
+			if skip {
+				continue
+			}
+		}
+
 		cdiDeviceRequestor, err := cdi.New(
 			cdi.WithLogger(f.logger),
 			cdi.WithSpec(spec.Raw()),
@@ -223,6 +238,47 @@ func (f *Factory) newAutomaticCDISpecModifier(devices []string) (oci.SpecModifie
 	return modifiers, nil
 }
 
+// handleNoDevices applies the configured behavior for a container that
+// requests GPUs on a node where no GPUs are available. If true is returned, no
+// modifications are made for the requested GPUs.
+func (f *Factory) handleNoDevices(devices []string) (bool, error) {
+	switch f.cfg.NVIDIAContainerRuntimeConfig.Modes.JitCDI.OnNoDevices {
+	case config.OnNoDevicesFail:
+		return false, fmt.Errorf("no GPUs are available for the requested devices %v", devices)
+	case config.OnNoDevicesNone:
+		f.logger.Warningf("No GPUs are available for the requested devices %v; no devices are injected", devices)
+		return true, nil
+	default:
+		f.logger.Warningf("No GPUs are available for the requested devices %v; only control devices are injected", devices)
+		return false, nil
+	}
+}
+
+// requestsDevices checks whether the specified device IDs request at least
+// one device. An empty list is equivalent to requesting all devices.
+func requestsDevices(ids []string) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	for _, id := range ids {
+		if id != "none" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDeviceNodes checks whether any of the devices in the specified CDI spec
+// inject device nodes.
+func hasDeviceNodes(spec *specs.Spec) bool {
+	for _, device := range spec.Devices {
+		if len(device.ContainerEdits.DeviceNodes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 type cdiModeIdentifiers struct {
 	modes             []string
 	idsByMode         map[string][]string
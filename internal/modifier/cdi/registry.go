@@ -19,6 +19,7 @@ package cdi
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"tags.cncf.io/container-device-interface/pkg/cdi"
@@ -59,8 +60,32 @@ func (m fromRegistry) Modify(spec *specs.Spec) error {
 			m.logger.Warningf("Refreshing the CDI registry generated errors: %v", rerr)
 		}
 
+		if len(unresolvedDevices) > 0 {
+			return unresolvedDevicesError(unresolvedDevices, m.registry.GetSpecDirectories())
+		}
 		return fmt.Errorf("failed to inject CDI devices: %v", err)
 	}
 
 	return nil
 }
+
+// unresolvedDevicesError returns an error for CDI devices that are not defined
+// in any of the CDI specs in the specified directories. The error indicates
+// whether the spec directories are missing or the devices are not defined.
+func unresolvedDevicesError(devices []string, specDirs []string) error {
+	var existingDirs []string
+	for _, dir := range specDirs {
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		existingDirs = append(existingDirs, dir)
+	}
+	if len(existingDirs) == 0 {
+		return fmt.Errorf("failed to inject CDI devices %v: none of the CDI spec directories %v exist; "+
+			"generate a CDI spec using 'nvidia-ctk cdi generate' or update nvidia-container-runtime.modes.cdi.spec-dirs",
+			devices, specDirs)
+	}
+	return fmt.Errorf("failed to inject CDI devices %v: the devices are not defined in the CDI specs in %v; "+
+		"check the available devices using 'nvidia-ctk cdi list' or regenerate the CDI spec",
+		devices, existingDirs)
+}
@@ -0,0 +1,90 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package cdi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryUnresolvedDevices(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	specDir := t.TempDir()
+	spec := `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+    - name: "0"
+      containerEdits:
+        env:
+            - GPU=0
+`
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "nvidia.yaml"), []byte(spec), 0600))
+	missingDir := filepath.Join(t.TempDir(), "missing")
+
+	testCases := []struct {
+		description   string
+		specDirs      []string
+		devices       []string
+		expectedError string
+	}{
+		{
+			description: "defined device is injected",
+			specDirs:    []string{specDir},
+			devices:     []string{"nvidia.com/gpu=0"},
+		},
+		{
+			description: "missing spec dir",
+			specDirs:    []string{missingDir},
+			devices:     []string{"nvidia.com/gpu=0"},
+			expectedError: "failed to inject CDI devices [nvidia.com/gpu=0]: none of the CDI spec directories [" + missingDir + "] exist; " +
+				"generate a CDI spec using 'nvidia-ctk cdi generate' or update nvidia-container-runtime.modes.cdi.spec-dirs",
+		},
+		{
+			description: "missing device",
+			specDirs:    []string{missingDir, specDir},
+			devices:     []string{"nvidia.com/gpu=0", "nvidia.com/gpu=1"},
+			expectedError: "failed to inject CDI devices [nvidia.com/gpu=1]: the devices are not defined in the CDI specs in [" + specDir + "]; " +
+				"check the available devices using 'nvidia-ctk cdi list' or regenerate the CDI spec",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			m, err := New(
+				WithLogger(logger),
+				WithSpecDirs(tc.specDirs...),
+				WithDevices(tc.devices...),
+			)
+			require.NoError(t, err)
+
+			err = m.Modify(&specs.Spec{})
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
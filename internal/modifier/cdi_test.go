@@ -17,13 +17,20 @@
 package modifier
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
 )
 
 func TestDeviceRequests(t *testing.T) {
@@ -306,3 +313,88 @@ func Test_cdiModeIdentfiersFromDevices(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleNoDevices(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	testCases := []struct {
+		description   string
+		onNoDevices   string
+		expectedSkip  bool
+		expectedError bool
+	}{
+		{
+			description: "default injects control devices",
+		},
+		{
+			description: "control-devices",
+			onNoDevices: config.OnNoDevicesControlDevices,
+		},
+		{
+			description:  "none skips injection",
+			onNoDevices:  config.OnNoDevicesNone,
+			expectedSkip: true,
+		},
+		{
+			description:   "fail returns an error",
+			onNoDevices:   config.OnNoDevicesFail,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// Mock a node with the driver installed but no GPUs.
+			server := dgxa100.New()
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 0, nvml.SUCCESS
+			}
+			server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+				return "999.88.77", nvml.SUCCESS
+			}
+
+			cdilib, err := nvcdi.New(
+				nvcdi.WithLogger(logger),
+				nvcdi.WithMode(nvcdi.ModeNvml),
+				nvcdi.WithDriverRoot(driverRoot),
+				nvcdi.WithNvmlLib(server),
+				nvcdi.WithVendor(automaticDeviceVendor),
+				nvcdi.WithClass(automaticDeviceClass),
+			)
+			require.NoError(t, err)
+
+			spec, err := cdilib.GetSpec("all")
+			require.NoError(t, err)
+			require.False(t, hasDeviceNodes(spec.Raw()))
+			require.NotEmpty(t, spec.Raw().ContainerEdits.DeviceNodes)
+
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.Modes.JitCDI.OnNoDevices = tc.onNoDevices
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+			)
+
+			skip, err := f.handleNoDevices([]string{automaticDevicePrefix + "all"})
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSkip, skip)
+		})
+	}
+}
+
+func TestRequestsDevices(t *testing.T) {
+	require.True(t, requestsDevices(nil))
+	require.True(t, requestsDevices([]string{"all"}))
+	require.True(t, requestsDevices([]string{"none", "0"}))
+	require.False(t, requestsDevices([]string{"none"}))
+}
@@ -0,0 +1,83 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+type cudaCompatibilityCheck struct {
+	logger logger.Interface
+	image  *image.CUDA
+	driver *root.Driver
+}
+
+// newCUDACompatibilityCheck creates a modifier that logs a warning if the CUDA
+// version of the container is newer than the version supported by the host
+// driver. This check is best-effort: the spec is never modified and no error
+// is returned. The check is only performed if it is enabled in the config.
+func (f *Factory) newCUDACompatibilityCheck() oci.SpecModifier {
+	if !f.cfg.NVIDIAContainerRuntimeConfig.CUDAForwardCompat.CheckVersion {
+		return nil
+	}
+	return &cudaCompatibilityCheck{
+		logger: f.logger,
+		image:  f.image,
+		driver: f.driver,
+	}
+}
+
+// Modify checks the CUDA compatibility of the container described by the
+// specified spec.
+func (c *cudaCompatibilityCheck) Modify(s *specs.Spec) error {
+	if c.image == nil || len(c.image.VisibleDevices()) == 0 {
+		return nil
+	}
+	if c.image.HasDisableRequire() {
+		c.logger.Debugf("Skipping CUDA compatibility check; requirement checks are disabled")
+		return nil
+	}
+
+	containerVersion := getContainerCUDAVersion(c.logger, c.image, s)
+	if containerVersion == "" {
+		c.logger.Debugf("Skipping CUDA compatibility check; container CUDA version could not be determined")
+		return nil
+	}
+
+	hostVersion, err := hostCUDAVersion(c.driver)
+	if err != nil {
+		c.logger.Debugf("Skipping CUDA compatibility check; failed to get host CUDA version: %v", err)
+		return nil
+	}
+
+	isNewer, err := discover.IsNewerCUDAVersion(containerVersion, hostVersion)
+	if err != nil {
+		c.logger.Debugf("Skipping CUDA compatibility check: %v", err)
+		return nil
+	}
+	if isNewer {
+		c.logger.Warningf("The container CUDA version %v is newer than CUDA version %v supported by the host driver; CUDA applications may fail to start", containerVersion, hostVersion)
+	}
+	return nil
+}
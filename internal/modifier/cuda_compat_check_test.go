@@ -0,0 +1,143 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestCUDACompatibilityCheck(t *testing.T) {
+	testCases := []struct {
+		description     string
+		env             []string
+		versionFile     string
+		hostVersion     string
+		hostVersionErr  error
+		expectedWarning bool
+	}{
+		{
+			description: "no devices requested",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=void", "CUDA_VERSION=12.4.1"},
+			hostVersion: "12.2",
+		},
+		{
+			description: "container version from envvar is supported",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.2.0"},
+			hostVersion: "12.2",
+		},
+		{
+			description:     "container version from envvar is newer",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.4.1"},
+			hostVersion:     "12.2",
+			expectedWarning: true,
+		},
+		{
+			description:     "container version from version file is newer",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.1.0"},
+			versionFile:     `{"cuda": {"name": "CUDA SDK", "version": "12.4.1"}}`,
+			hostVersion:     "12.2",
+			expectedWarning: true,
+		},
+		{
+			description: "container version from version file is supported",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.4.1"},
+			versionFile: `{"cuda": {"name": "CUDA SDK", "version": "11.8.0"}}`,
+			hostVersion: "12.2",
+		},
+		{
+			description: "requirements disabled",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.4.1", "NVIDIA_DISABLE_REQUIRE=true"},
+			hostVersion: "12.2",
+		},
+		{
+			description:    "host version unavailable",
+			env:            []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.4.1"},
+			hostVersionErr: fmt.Errorf("libcuda.so.1 not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, hook := testlog.NewNullLogger()
+
+			defer func(f func(*root.Driver) (string, error)) { hostCUDAVersion = f }(hostCUDAVersion)
+			hostCUDAVersion = func(*root.Driver) (string, error) {
+				return tc.hostVersion, tc.hostVersionErr
+			}
+
+			rootfs := t.TempDir()
+			if tc.versionFile != "" {
+				versionFilePath := filepath.Join(rootfs, cudaVersionFilePath)
+				require.NoError(t, os.MkdirAll(filepath.Dir(versionFilePath), 0755))
+				require.NoError(t, os.WriteFile(versionFilePath, []byte(tc.versionFile), 0600))
+			}
+
+			cudaImage, err := image.New(image.WithEnv(tc.env))
+			require.NoError(t, err)
+
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.CUDAForwardCompat.CheckVersion = true
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+				WithImage(&cudaImage),
+			)
+
+			spec := &specs.Spec{
+				Root: &specs.Root{Path: rootfs},
+			}
+			require.NoError(t, f.newCUDACompatibilityCheck().Modify(spec))
+			require.Equal(t, &specs.Spec{Root: &specs.Root{Path: rootfs}}, spec)
+
+			var warnings []*logrus.Entry
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel {
+					warnings = append(warnings, entry)
+				}
+			}
+			if tc.expectedWarning {
+				require.Len(t, warnings, 1)
+			} else {
+				require.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestCUDACompatibilityCheckDisabledByDefault(t *testing.T) {
+	cudaImage, err := image.New(image.WithEnv([]string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.4.1"}))
+	require.NoError(t, err)
+
+	f := createFactory(
+		WithConfig(&config.Config{}),
+		WithImage(&cudaImage),
+	)
+	require.Nil(t, f.newCUDACompatibilityCheck())
+}
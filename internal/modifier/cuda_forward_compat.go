@@ -0,0 +1,158 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/cuda"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+// cudaVersionFilePath is the path in the container rootfs of the file that
+// records the version of the installed CUDA toolkit.
+const cudaVersionFilePath = "/usr/local/cuda/version.json"
+
+// hostCUDAVersion returns the CUDA version supported by the driver at the
+// specified driver root. The libcuda.so.1 library is located in the driver
+// root and loaded to query the version. This is a variable so that it can be
+// overridden in tests.
+var hostCUDAVersion = func(driver *root.Driver) (string, error) {
+	candidates, err := driver.Libraries().Locate("libcuda.so.1")
+	if err != nil {
+		return "", fmt.Errorf("failed to locate libcuda.so.1: %w", err)
+	}
+	return cuda.VersionFromLibrary(candidates[0])
+}
+
+type cudaForwardCompat struct {
+	logger              logger.Interface
+	image               *image.CUDA
+	driver              *root.Driver
+	hookCreator         discover.HookCreator
+	hostCompatRoot      string
+	containerCompatRoot string
+	newModifier         func(discover.Discover) (oci.SpecModifier, error)
+}
+
+// newCUDAForwardCompatModifier creates a modifier that injects the CUDA
+// Forward Compatibility libraries provided by the host into a container that
+// requires a newer CUDA version than the one supported by the host driver.
+// If no host compat root is configured, nil is returned.
+func (f *Factory) newCUDAForwardCompatModifier() oci.SpecModifier {
+	cfg := f.cfg.NVIDIAContainerRuntimeConfig.CUDAForwardCompat
+	if cfg.HostCompatRoot == "" {
+		return nil
+	}
+	return &cudaForwardCompat{
+		logger:              f.logger,
+		image:               f.image,
+		driver:              f.driver,
+		hookCreator:         f.hookCreator,
+		hostCompatRoot:      cfg.HostCompatRoot,
+		containerCompatRoot: cfg.ContainerCompatRoot,
+		newModifier:         f.newModifierFromDiscoverer,
+	}
+}
+
+// Modify injects the CUDA Forward Compatibility libraries if these are
+// required by the container described by the specified spec.
+func (m *cudaForwardCompat) Modify(s *specs.Spec) error {
+	if m.image == nil || len(m.image.VisibleDevices()) == 0 {
+		return nil
+	}
+
+	containerVersion := getContainerCUDAVersion(m.logger, m.image, s)
+	if containerVersion == "" {
+		m.logger.Debugf("Skipping CUDA Forward Compatibility; container CUDA version could not be determined")
+		return nil
+	}
+	hostVersion, err := hostCUDAVersion(m.driver)
+	if err != nil {
+		m.logger.Warningf("Skipping CUDA Forward Compatibility; failed to get host CUDA version: %v", err)
+		return nil
+	}
+
+	locator := lookup.NewFileLocator(
+		lookup.WithLogger(m.logger),
+		lookup.WithRoot(m.driver.Root),
+	)
+	compat := discover.NewCUDAForwardCompatDiscoverer(m.logger, m.hookCreator, locator,
+		&discover.CUDAForwardCompatOptions{
+			HostCompatRoot:       m.hostCompatRoot,
+			ContainerCompatRoot:  m.containerCompatRoot,
+			HostCUDAVersion:      hostVersion,
+			ContainerCUDAVersion: containerVersion,
+		},
+	)
+
+	modifier, err := m.newModifier(compat)
+	if err != nil {
+		return err
+	}
+	return modifier.Modify(s)
+}
+
+// getContainerCUDAVersion returns the CUDA version of the container. The
+// version is read from /usr/local/cuda/version.json in the container rootfs
+// if available and from the CUDA_VERSION envvar otherwise. A relative rootfs
+// path is resolved relative to the current working directory, which is the
+// bundle directory when invoked by a container engine.
+func getContainerCUDAVersion(logger logger.Interface, i *image.CUDA, s *specs.Spec) string {
+	if s != nil && s.Root != nil && s.Root.Path != "" {
+		version, err := readCUDAVersionFile(filepath.Join(s.Root.Path, cudaVersionFilePath))
+		if err == nil {
+			return version
+		}
+		if !os.IsNotExist(err) {
+			logger.Debugf("Ignoring container CUDA version file: %v", err)
+		}
+	}
+	return i.Getenv(image.EnvVarCudaVersion)
+}
+
+// readCUDAVersionFile reads the CUDA version from the specified version.json
+// file.
+func readCUDAVersionFile(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var versions struct {
+		CUDA struct {
+			Version string `json:"version"`
+		} `json:"cuda"`
+	}
+	if err := json.Unmarshal(contents, &versions); err != nil {
+		return "", fmt.Errorf("failed to parse %v: %w", path, err)
+	}
+	if versions.CUDA.Version == "" {
+		return "", fmt.Errorf("no CUDA version in %v", path)
+	}
+	return versions.CUDA.Version, nil
+}
@@ -0,0 +1,129 @@
+/*
+*
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+*
+*/
+package modifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestCUDAForwardCompatModifier(t *testing.T) {
+	testCases := []struct {
+		description    string
+		env            []string
+		hostCompatRoot string
+		hostVersion    string
+		expectedSpec   func(driverRoot string) *specs.Spec
+	}{
+		{
+			description: "no host compat root",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.9.0"},
+			hostVersion: "12.2",
+		},
+		{
+			description:    "no devices requested",
+			env:            []string{"NVIDIA_VISIBLE_DEVICES=void", "CUDA_VERSION=12.9.0"},
+			hostCompatRoot: "/host/compat",
+			hostVersion:    "12.2",
+		},
+		{
+			description:    "container CUDA version is supported by host",
+			env:            []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.2.0"},
+			hostCompatRoot: "/host/compat",
+			hostVersion:    "12.2",
+		},
+		{
+			description:    "container CUDA version requires compat",
+			env:            []string{"NVIDIA_VISIBLE_DEVICES=all", "CUDA_VERSION=12.9.0"},
+			hostCompatRoot: "/host/compat",
+			hostVersion:    "12.2",
+			expectedSpec: func(driverRoot string) *specs.Spec {
+				return &specs.Spec{
+					Mounts: []specs.Mount{
+						{
+							Source:      filepath.Join(driverRoot, "host/compat/libcuda.so.580.65.06"),
+							Destination: "/usr/local/cuda/compat/libcuda.so.580.65.06",
+							Options:     []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+						},
+					},
+					Hooks: &specs.Hooks{
+						CreateContainer: []specs.Hook{
+							{
+								Path: "/usr/bin/nvidia-cdi-hook",
+								Args: []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/usr/local/cuda/compat"},
+								Env:  []string{"NVIDIA_CTK_DEBUG=false"},
+							},
+						},
+					},
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, _ := testlog.NewNullLogger()
+
+			driverRoot := t.TempDir()
+			compatDir := filepath.Join(driverRoot, "host", "compat")
+			require.NoError(t, os.MkdirAll(compatDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(compatDir, "libcuda.so.580.65.06"), nil, 0600))
+
+			defer func(f func(*root.Driver) (string, error)) { hostCUDAVersion = f }(hostCUDAVersion)
+			hostCUDAVersion = func(driver *root.Driver) (string, error) {
+				require.Equal(t, driverRoot, driver.Root)
+				return tc.hostVersion, nil
+			}
+
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.CUDAForwardCompat.HostCompatRoot = tc.hostCompatRoot
+
+			cudaImage, err := image.New(image.WithEnv(tc.env))
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+				WithImage(&cudaImage),
+				WithDriver(root.New(root.WithDriverRoot(driverRoot))),
+				WithHookCreator(discover.NewHookCreator(discover.WithNVIDIACDIHookPath("/usr/bin/nvidia-cdi-hook"))),
+			)
+
+			spec := &specs.Spec{}
+			err = list{f.newCUDAForwardCompatModifier()}.Modify(spec)
+			require.NoError(t, err)
+
+			expectedSpec := &specs.Spec{}
+			if tc.expectedSpec != nil {
+				expectedSpec = tc.expectedSpec(driverRoot)
+			}
+			require.Equal(t, expectedSpec, spec)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// dedupeMounts is a spec modifier that wraps another modifier and ensures
+// that the destinations of the mounts that it injects are unique.
+type dedupeMounts struct {
+	logger   logger.Interface
+	modifier oci.SpecModifier
+}
+
+var _ oci.SpecModifier = (*dedupeMounts)(nil)
+
+// withDeduplicatedMounts wraps the specified modifier so that injecting
+// mounts into a spec that already contains mounts to the same destinations
+// (e.g. from a prior hook or injection) does not produce duplicates.
+func (f *Factory) withDeduplicatedMounts(modifier oci.SpecModifier) oci.SpecModifier {
+	return &dedupeMounts{
+		logger:   f.logger,
+		modifier: modifier,
+	}
+}
+
+// Modify applies the wrapped modifier. For each destination where the mounts
+// were changed by the modifier, only the last mount is kept. Identical mounts
+// are also only included once. Other mounts are left untouched.
+func (m *dedupeMounts) Modify(spec *specs.Spec) error {
+	return modifyInjected(spec, m.modifier, func(before *specSnapshot) {
+		m.removeDuplicates(spec, mountsByDestination(before.mounts))
+	})
+}
+
+// removeDuplicates removes the duplicate mounts from the spec. The specified
+// mounts are the mounts in the spec before the wrapped modifier was applied.
+func (m *dedupeMounts) removeDuplicates(spec *specs.Spec, existing map[string][]specs.Mount) {
+	modified := mountsByDestination(spec.Mounts)
+	seenDestinations := make(map[string]bool)
+	var mounts []specs.Mount
+	for i := len(spec.Mounts) - 1; i >= 0; i-- {
+		mount := spec.Mounts[i]
+		destination := mount.Destination
+		isDuplicate := slices.ContainsFunc(mounts, func(other specs.Mount) bool {
+			return reflect.DeepEqual(mount, other)
+		})
+		if !reflect.DeepEqual(existing[destination], modified[destination]) {
+			isDuplicate = isDuplicate || seenDestinations[destination]
+			seenDestinations[destination] = true
+		}
+		if isDuplicate {
+			m.logger.Debugf("Removing duplicate mount of %v to %v", mount.Source, destination)
+			continue
+		}
+		mounts = append(mounts, mount)
+	}
+	slices.Reverse(mounts)
+	spec.Mounts = mounts
+}
+
+// mountsByDestination groups the specified mounts by their destination.
+func mountsByDestination(mounts []specs.Mount) map[string][]specs.Mount {
+	byDestination := make(map[string][]specs.Mount)
+	for _, mount := range mounts {
+		byDestination[mount.Destination] = append(byDestination[mount.Destination], mount)
+	}
+	return byDestination
+}
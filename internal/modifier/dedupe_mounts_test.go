@@ -0,0 +1,112 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	cdispecs "tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestDeduplicatedMounts(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	libcuda := specs.Mount{
+		Source:      "/host/libcuda.so.1",
+		Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1",
+		Type:        "bind",
+		Options:     []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+	}
+	data := specs.Mount{Source: "/data", Destination: "/data", Type: "bind"}
+	tmpfs := specs.Mount{Source: "tmpfs", Destination: "/data", Type: "tmpfs"}
+
+	appendLibcuda := modifierFunc(func(spec *specs.Spec) error {
+		spec.Mounts = append(spec.Mounts, libcuda)
+		return nil
+	})
+	applyLibcuda := modifierFunc(func(spec *specs.Spec) error {
+		edits := &cdi.ContainerEdits{
+			ContainerEdits: &cdispecs.ContainerEdits{
+				Mounts: []*cdispecs.Mount{
+					{
+						HostPath:      libcuda.Source,
+						ContainerPath: libcuda.Destination,
+						Type:          libcuda.Type,
+						Options:       libcuda.Options,
+					},
+				},
+			},
+		}
+		return edits.Apply(spec)
+	})
+
+	testCases := []struct {
+		description  string
+		modifier     modifierFunc
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description:  "mount is injected into empty spec",
+			modifier:     appendLibcuda,
+			spec:         &specs.Spec{},
+			expectedSpec: &specs.Spec{Mounts: []specs.Mount{libcuda}},
+		},
+		{
+			description:  "matching existing mount is not duplicated",
+			modifier:     appendLibcuda,
+			spec:         &specs.Spec{Mounts: []specs.Mount{data, libcuda}},
+			expectedSpec: &specs.Spec{Mounts: []specs.Mount{data, libcuda}},
+		},
+		{
+			description: "existing mount to same destination is replaced",
+			modifier:    appendLibcuda,
+			spec: &specs.Spec{Mounts: []specs.Mount{
+				{Source: "/other/libcuda.so.1", Destination: libcuda.Destination},
+				data,
+			}},
+			expectedSpec: &specs.Spec{Mounts: []specs.Mount{data, libcuda}},
+		},
+		{
+			description:  "repeated CDI injection is idempotent",
+			modifier:     applyLibcuda,
+			spec:         &specs.Spec{Mounts: []specs.Mount{libcuda, data, libcuda}},
+			expectedSpec: &specs.Spec{Mounts: []specs.Mount{data, libcuda}},
+		},
+		{
+			description:  "existing mounts to untouched destinations are kept",
+			modifier:     appendLibcuda,
+			spec:         &specs.Spec{Mounts: []specs.Mount{tmpfs, data}},
+			expectedSpec: &specs.Spec{Mounts: []specs.Mount{tmpfs, data, libcuda}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			f := &Factory{factoryOptions: factoryOptions{logger: logger}}
+
+			err := f.withDeduplicatedMounts(tc.modifier).Modify(tc.spec)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
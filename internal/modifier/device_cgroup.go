@@ -0,0 +1,148 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// deviceCgroupRules is a spec modifier that wraps another modifier and
+// replaces the device cgroup rules that it adds with a single allow rule for
+// each device node that it injects.
+type deviceCgroupRules struct {
+	logger   logger.Interface
+	modifier oci.SpecModifier
+	denyAll  bool
+}
+
+var _ oci.SpecModifier = (*deviceCgroupRules)(nil)
+
+// withDeviceCgroupRules wraps the specified modifier so that the device cgroup
+// rules of the container only grant access to the device nodes that were
+// injected. If the deny-all-devices-by-default feature is enabled, a deny-all
+// rule is also added if one is not already present.
+func (f *Factory) withDeviceCgroupRules(modifier oci.SpecModifier) oci.SpecModifier {
+	return &deviceCgroupRules{
+		logger:   f.logger,
+		modifier: modifier,
+		denyAll:  f.cfg.Features.DenyAllDevicesByDefault.IsEnabled(),
+	}
+}
+
+// Modify applies the wrapped modifier and updates the device cgroup rules.
+// Rules and device nodes that were already present in the spec are left
+// untouched.
+func (m *deviceCgroupRules) Modify(spec *specs.Spec) error {
+	if spec == nil {
+		return m.modifier.Modify(spec)
+	}
+
+	existingDevices := make(map[string]bool)
+	var existingRules []specs.LinuxDeviceCgroup
+	if spec.Linux != nil {
+		for _, device := range spec.Linux.Devices {
+			existingDevices[device.Path] = true
+		}
+		if spec.Linux.Resources != nil {
+			existingRules = append(existingRules, spec.Linux.Resources.Devices...)
+		}
+	}
+
+	if err := m.modifier.Modify(spec); err != nil {
+		return err
+	}
+
+	if spec.Linux == nil {
+		return nil
+	}
+
+	var injected []specs.LinuxDevice
+	for _, device := range spec.Linux.Devices {
+		if existingDevices[device.Path] {
+			continue
+		}
+		injected = append(injected, device)
+	}
+	if len(injected) == 0 {
+		return nil
+	}
+
+	var rules []specs.LinuxDeviceCgroup
+	if m.denyAll && !hasDenyAllRule(existingRules) {
+		m.logger.Debugf("Adding deny-all device cgroup rule")
+		rules = append(rules, specs.LinuxDeviceCgroup{Allow: false, Access: "rwm"})
+	}
+	rules = append(rules, existingRules...)
+
+	seen := make(map[deviceRuleID]bool)
+	for _, device := range injected {
+		major, minor := device.Major, device.Minor
+		rule := specs.LinuxDeviceCgroup{
+			Allow:  true,
+			Type:   device.Type,
+			Major:  &major,
+			Minor:  &minor,
+			Access: "rwm",
+		}
+		if rule.Type == "" {
+			rule.Type = "c"
+		}
+		id := deviceRuleID{deviceType: rule.Type, major: major, minor: minor}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		m.logger.Debugf("Allowing access to %v (%v %d:%d)", device.Path, rule.Type, major, minor)
+		rules = append(rules, rule)
+	}
+
+	if spec.Linux.Resources == nil {
+		spec.Linux.Resources = &specs.LinuxResources{}
+	}
+	spec.Linux.Resources.Devices = rules
+
+	return nil
+}
+
+// deviceRuleID identifies an allow rule by its device type and numbers.
+type deviceRuleID struct {
+	deviceType string
+	major      int64
+	minor      int64
+}
+
+// hasDenyAllRule checks whether the specified rules contain a rule that
+// denies access to all devices.
+func hasDenyAllRule(rules []specs.LinuxDeviceCgroup) bool {
+	for _, rule := range rules {
+		if rule.Allow {
+			continue
+		}
+		if rule.Type != "" && rule.Type != "a" {
+			continue
+		}
+		if rule.Major != nil || rule.Minor != nil {
+			continue
+		}
+		return true
+	}
+	return false
+}
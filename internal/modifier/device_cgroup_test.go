@@ -0,0 +1,148 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test/to"
+)
+
+func TestDeviceCgroupRules(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	// injected simulates a request for the second of four GPUs. The device
+	// node for the requested GPU as well as the shared control device nodes
+	// are injected, with a broad rule for the NVIDIA major number and a
+	// duplicate rule for one of the devices.
+	injected := modifierFunc(func(spec *specs.Spec) error {
+		if spec.Linux == nil {
+			spec.Linux = &specs.Linux{}
+		}
+		spec.Linux.Devices = append(spec.Linux.Devices,
+			specs.LinuxDevice{Path: "/dev/nvidia1", Type: "c", Major: 195, Minor: 1},
+			specs.LinuxDevice{Path: "/dev/nvidiactl", Type: "c", Major: 195, Minor: 255},
+			specs.LinuxDevice{Path: "/dev/nvidia-uvm", Type: "c", Major: 510, Minor: 0},
+			specs.LinuxDevice{Path: "/dev/nvidia-uvm-tools", Type: "c", Major: 510, Minor: 1},
+		)
+		if spec.Linux.Resources == nil {
+			spec.Linux.Resources = &specs.LinuxResources{}
+		}
+		spec.Linux.Resources.Devices = append(spec.Linux.Resources.Devices,
+			specs.LinuxDeviceCgroup{Allow: true, Type: "c", Major: to.Ptr[int64](195), Access: "rwm"},
+			specs.LinuxDeviceCgroup{Allow: true, Type: "c", Major: to.Ptr[int64](195), Minor: to.Ptr[int64](1), Access: "rwm"},
+			specs.LinuxDeviceCgroup{Allow: true, Type: "c", Major: to.Ptr[int64](195), Minor: to.Ptr[int64](1), Access: "rwm"},
+		)
+		return nil
+	})
+
+	requestedRules := []specs.LinuxDeviceCgroup{
+		{Allow: true, Type: "c", Major: to.Ptr[int64](195), Minor: to.Ptr[int64](1), Access: "rwm"},
+		{Allow: true, Type: "c", Major: to.Ptr[int64](195), Minor: to.Ptr[int64](255), Access: "rwm"},
+		{Allow: true, Type: "c", Major: to.Ptr[int64](510), Minor: to.Ptr[int64](0), Access: "rwm"},
+		{Allow: true, Type: "c", Major: to.Ptr[int64](510), Minor: to.Ptr[int64](1), Access: "rwm"},
+	}
+
+	testCases := []struct {
+		description   string
+		denyAll       bool
+		modifier      modifierFunc
+		spec          *specs.Spec
+		expectedRules []specs.LinuxDeviceCgroup
+	}{
+		{
+			description:   "only requested devices are allowed",
+			modifier:      injected,
+			spec:          &specs.Spec{},
+			expectedRules: requestedRules,
+		},
+		{
+			description: "deny-all rule is added",
+			denyAll:     true,
+			modifier:    injected,
+			spec:        &specs.Spec{},
+			expectedRules: append(
+				[]specs.LinuxDeviceCgroup{{Allow: false, Access: "rwm"}},
+				requestedRules...,
+			),
+		},
+		{
+			description: "existing rules are kept",
+			denyAll:     true,
+			modifier:    injected,
+			spec: &specs.Spec{
+				Linux: &specs.Linux{
+					Resources: &specs.LinuxResources{
+						Devices: []specs.LinuxDeviceCgroup{
+							{Allow: false, Access: "rwm"},
+							{Allow: true, Type: "c", Major: to.Ptr[int64](1), Minor: to.Ptr[int64](3), Access: "rwm"},
+						},
+					},
+				},
+			},
+			expectedRules: append(
+				[]specs.LinuxDeviceCgroup{
+					{Allow: false, Access: "rwm"},
+					{Allow: true, Type: "c", Major: to.Ptr[int64](1), Minor: to.Ptr[int64](3), Access: "rwm"},
+				},
+				requestedRules...,
+			),
+		},
+		{
+			description: "no injected devices leaves rules untouched",
+			denyAll:     true,
+			modifier: modifierFunc(func(spec *specs.Spec) error {
+				return nil
+			}),
+			spec:          &specs.Spec{Linux: &specs.Linux{}},
+			expectedRules: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfgToml, err := config.TreeFromMap(map[string]any{
+				"features": map[string]any{
+					"deny-all-devices-by-default": tc.denyAll,
+				},
+			})
+			require.NoError(t, err)
+			cfg, err := cfgToml.Config()
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+			)
+
+			err = f.withDeviceCgroupRules(tc.modifier).Modify(tc.spec)
+			require.NoError(t, err)
+
+			var rules []specs.LinuxDeviceCgroup
+			if tc.spec.Linux.Resources != nil {
+				rules = tc.spec.Linux.Resources.Devices
+			}
+			require.EqualValues(t, tc.expectedRules, rules)
+		})
+	}
+}
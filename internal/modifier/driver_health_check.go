@@ -0,0 +1,109 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+// driverHealthProbe checks whether the driver at the specified root is
+// functional. This is a variable so that it can be overridden in tests.
+var driverHealthProbe = runNvidiaSMI
+
+type driverHealthCheck struct {
+	logger  logger.Interface
+	image   *image.CUDA
+	driver  *root.Driver
+	timeout time.Duration
+}
+
+// newDriverHealthCheck creates a modifier that returns an error if the
+// NVIDIA driver is not functional for a container that requests GPUs. The
+// spec is never modified. If the driver-health-check is not enabled in the
+// config, nil is returned.
+func (f *Factory) newDriverHealthCheck() oci.SpecModifier {
+	cfg := f.cfg.NVIDIAContainerRuntimeConfig.DriverHealthCheck
+	if !cfg.Enabled {
+		return nil
+	}
+	// The timeout has been validated when the config was loaded.
+	timeout, _ := cfg.GetTimeout()
+	return &driverHealthCheck{
+		logger:  f.logger,
+		image:   f.image,
+		driver:  f.driver,
+		timeout: timeout,
+	}
+}
+
+// Modify runs the driver health probe if the container requests GPUs.
+func (c *driverHealthCheck) Modify(_ *specs.Spec) error {
+	if c.image == nil || len(c.image.VisibleDevices()) == 0 {
+		return nil
+	}
+
+	driverRoot := "/"
+	if c.driver != nil && c.driver.Root != "" {
+		driverRoot = c.driver.Root
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	c.logger.Debugf("Checking driver health at %v", driverRoot)
+	err := driverHealthProbe(ctx, c.logger, driverRoot)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("driver health check timed out after %v", c.timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("driver health check failed: %w", err)
+	}
+	return nil
+}
+
+// runNvidiaSMI runs the nvidia-smi executable from the specified driver root
+// and returns an error if this does not succeed.
+func runNvidiaSMI(ctx context.Context, logger logger.Interface, driverRoot string) error {
+	located, err := lookup.NewExecutableLocator(logger, driverRoot).Locate("nvidia-smi")
+	if err != nil || len(located) == 0 {
+		return fmt.Errorf("failed to locate nvidia-smi: %v", err)
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, located[0])
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v: %w: %v", located[0], err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+func TestDriverHealthCheck(t *testing.T) {
+	testCases := []struct {
+		description    string
+		env            []string
+		config         *config.Config
+		probe          func(context.Context) error
+		expectedCalled bool
+		expectedError  string
+	}{
+		{
+			description: "check disabled",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			config:      &config.Config{},
+			probe: func(context.Context) error {
+				return fmt.Errorf("driver not loaded")
+			},
+		},
+		{
+			description: "no devices requested",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=void"},
+			config:      withDriverHealthCheck(""),
+			probe: func(context.Context) error {
+				return fmt.Errorf("driver not loaded")
+			},
+		},
+		{
+			description:    "healthy driver",
+			env:            []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			config:         withDriverHealthCheck(""),
+			probe:          func(context.Context) error { return nil },
+			expectedCalled: true,
+		},
+		{
+			description: "unhealthy driver",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			config:      withDriverHealthCheck(""),
+			probe: func(context.Context) error {
+				return fmt.Errorf("driver not loaded")
+			},
+			expectedCalled: true,
+			expectedError:  "driver health check failed: driver not loaded",
+		},
+		{
+			description: "check times out",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			config:      withDriverHealthCheck("10ms"),
+			probe: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			expectedCalled: true,
+			expectedError:  "driver health check timed out after 10ms",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			testLogger, _ := testlog.NewNullLogger()
+
+			var called bool
+			defer func(f func(context.Context, logger.Interface, string) error) { driverHealthProbe = f }(driverHealthProbe)
+			driverHealthProbe = func(ctx context.Context, _ logger.Interface, _ string) error {
+				called = true
+				return tc.probe(ctx)
+			}
+
+			cudaImage, err := image.New(image.WithEnv(tc.env))
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(testLogger),
+				WithConfig(tc.config),
+				WithImage(&cudaImage),
+			)
+
+			spec := &specs.Spec{}
+			err = list{f.newDriverHealthCheck()}.Modify(spec)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedCalled, called)
+			require.Equal(t, &specs.Spec{}, spec)
+		})
+	}
+}
+
+func withDriverHealthCheck(timeout string) *config.Config {
+	cfg := &config.Config{}
+	cfg.NVIDIAContainerRuntimeConfig.DriverHealthCheck.Enabled = true
+	cfg.NVIDIAContainerRuntimeConfig.DriverHealthCheck.Timeout = timeout
+	return cfg
+}
@@ -0,0 +1,155 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// listEnvvars defines the envvars that hold comma-separated lists and whose
+// elements are combined for the union env merge policy.
+var listEnvvars = map[string]bool{
+	image.EnvVarNvidiaDriverCapabilities: true,
+}
+
+// searchPathEnvvars defines the envvars that hold colon-separated search paths.
+// For these the injected paths are always prepended to the paths that are
+// already set in the container, independent of the env merge policy.
+var searchPathEnvvars = map[string]bool{
+	"LD_LIBRARY_PATH": true,
+}
+
+// mergeEnv is a spec modifier that wraps another modifier and applies the
+// configured merge policy to envvars that it sets that were already set in
+// the container.
+type mergeEnv struct {
+	logger   logger.Interface
+	modifier oci.SpecModifier
+	policy   string
+}
+
+var _ oci.SpecModifier = (*mergeEnv)(nil)
+
+// withMergedEnv wraps the specified modifier so that envvars that are already
+// set in the container are merged with the injected envvars according to the
+// configured env merge policy.
+func (f *Factory) withMergedEnv(modifier oci.SpecModifier) oci.SpecModifier {
+	return &mergeEnv{
+		logger:   f.logger,
+		modifier: modifier,
+		policy:   f.cfg.NVIDIAContainerRuntimeConfig.GetEnvMergePolicy(),
+	}
+}
+
+// Modify applies the wrapped modifier and merges the envvars that it changed
+// with their original values. The NVIDIA_VISIBLE_DEVICES envvar is always
+// overwritten since this is used to signal that devices have already been
+// injected.
+func (m *mergeEnv) Modify(spec *specs.Spec) error {
+	if spec == nil || spec.Process == nil {
+		return m.modifier.Modify(spec)
+	}
+
+	existing := make(map[string]string)
+	for _, env := range spec.Process.Env {
+		key, value, _ := strings.Cut(env, "=")
+		existing[key] = value
+	}
+
+	if err := m.modifier.Modify(spec); err != nil {
+		return err
+	}
+	if spec.Process == nil {
+		return nil
+	}
+
+	for i, env := range spec.Process.Env {
+		key, value, _ := strings.Cut(env, "=")
+		original, ok := existing[key]
+		if !ok || original == value || key == image.EnvVarNvidiaVisibleDevices {
+			continue
+		}
+		merged := m.merge(key, original, value)
+		m.logger.Debugf("Merging %v=%v with injected value %q: %v", key, original, value, merged)
+		spec.Process.Env[i] = key + "=" + merged
+	}
+
+	return nil
+}
+
+// merge returns the value for an envvar that was originally set in the
+// container and was changed by the wrapped modifier.
+func (m *mergeEnv) merge(key string, original string, injected string) string {
+	switch {
+	case searchPathEnvvars[key]:
+		return prependSearchPath(injected, original)
+	case m.policy == config.EnvMergePolicyOverwrite:
+		return injected
+	case m.policy == config.EnvMergePolicyUnion && listEnvvars[key]:
+		return unionList(original, injected)
+	default:
+		return original
+	}
+}
+
+// prependSearchPath returns a colon-separated search path with the injected
+// paths followed by the original paths. Original paths that are also injected
+// are not repeated.
+func prependSearchPath(injected string, original string) string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, list := range []string{injected, original} {
+		for _, path := range strings.Split(list, ":") {
+			if path == "" || seen[path] {
+				continue
+			}
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return strings.Join(paths, ":")
+}
+
+// unionList combines the elements of two comma-separated lists. The elements
+// of the first list are included first. If either list contains "all", "all"
+// is returned.
+func unionList(first string, second string) string {
+	var elements []string
+	seen := make(map[string]bool)
+	for _, list := range []string{first, second} {
+		for _, element := range strings.Split(list, ",") {
+			element = strings.TrimSpace(element)
+			if element == "all" {
+				return "all"
+			}
+			if element == "" || seen[element] {
+				continue
+			}
+			seen[element] = true
+			elements = append(elements, element)
+		}
+	}
+	return strings.Join(elements, ",")
+}
@@ -0,0 +1,184 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+func TestMergedEnv(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	injected := oci.SpecModifier(modifierFunc(func(spec *specs.Spec) error {
+		for _, env := range []string{
+			"NVIDIA_DRIVER_CAPABILITIES=compute,utility,video",
+			"NVIDIA_VISIBLE_DEVICES=void",
+			"CUDA_MPS_PIPE_DIRECTORY=/tmp/nvidia-mps",
+			"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu",
+		} {
+			spec.Process.Env = setEnv(spec.Process.Env, env)
+		}
+		return nil
+	}))
+
+	testCases := []struct {
+		description string
+		policy      string
+		env         []string
+		expectedEnv []string
+	}{
+		{
+			description: "unset envvars are injected",
+			env:         []string{"PATH=/usr/bin"},
+			expectedEnv: []string{
+				"PATH=/usr/bin",
+				"NVIDIA_DRIVER_CAPABILITIES=compute,utility,video",
+				"NVIDIA_VISIBLE_DEVICES=void",
+				"CUDA_MPS_PIPE_DIRECTORY=/tmp/nvidia-mps",
+				"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu",
+			},
+		},
+		{
+			description: "user-set envvars win by default",
+			env: []string{
+				"NVIDIA_DRIVER_CAPABILITIES=graphics",
+				"NVIDIA_VISIBLE_DEVICES=all",
+				"CUDA_MPS_PIPE_DIRECTORY=/custom/mps",
+			},
+			expectedEnv: []string{
+				"NVIDIA_DRIVER_CAPABILITIES=graphics",
+				"NVIDIA_VISIBLE_DEVICES=void",
+				"CUDA_MPS_PIPE_DIRECTORY=/custom/mps",
+				"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu",
+			},
+		},
+		{
+			description: "user-set envvars win for preserve policy",
+			policy:      config.EnvMergePolicyPreserve,
+			env:         []string{"NVIDIA_DRIVER_CAPABILITIES=graphics"},
+			expectedEnv: []string{
+				"NVIDIA_DRIVER_CAPABILITIES=graphics",
+				"NVIDIA_VISIBLE_DEVICES=void",
+				"CUDA_MPS_PIPE_DIRECTORY=/tmp/nvidia-mps",
+				"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu",
+			},
+		},
+		{
+			description: "union policy combines list-valued envvars",
+			policy:      config.EnvMergePolicyUnion,
+			env: []string{
+				"NVIDIA_DRIVER_CAPABILITIES=graphics,utility",
+				"CUDA_MPS_PIPE_DIRECTORY=/custom/mps",
+			},
+			expectedEnv: []string{
+				"NVIDIA_DRIVER_CAPABILITIES=graphics,utility,compute,video",
+				"CUDA_MPS_PIPE_DIRECTORY=/custom/mps",
+				"NVIDIA_VISIBLE_DEVICES=void",
+				"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu",
+			},
+		},
+		{
+			description: "union policy with all",
+			policy:      config.EnvMergePolicyUnion,
+			env:         []string{"NVIDIA_DRIVER_CAPABILITIES=all"},
+			expectedEnv: []string{
+				"NVIDIA_DRIVER_CAPABILITIES=all",
+				"NVIDIA_VISIBLE_DEVICES=void",
+				"CUDA_MPS_PIPE_DIRECTORY=/tmp/nvidia-mps",
+				"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu",
+			},
+		},
+		{
+			description: "overwrite policy replaces user-set envvars",
+			policy:      config.EnvMergePolicyOverwrite,
+			env: []string{
+				"NVIDIA_DRIVER_CAPABILITIES=graphics",
+				"CUDA_MPS_PIPE_DIRECTORY=/custom/mps",
+			},
+			expectedEnv: []string{
+				"NVIDIA_DRIVER_CAPABILITIES=compute,utility,video",
+				"CUDA_MPS_PIPE_DIRECTORY=/tmp/nvidia-mps",
+				"NVIDIA_VISIBLE_DEVICES=void",
+				"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu",
+			},
+		},
+		{
+			description: "injected library paths are prepended by default",
+			env:         []string{"LD_LIBRARY_PATH=/opt/app/lib:/usr/lib/x86_64-linux-gnu"},
+			expectedEnv: []string{
+				"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu:/opt/app/lib",
+				"NVIDIA_DRIVER_CAPABILITIES=compute,utility,video",
+				"NVIDIA_VISIBLE_DEVICES=void",
+				"CUDA_MPS_PIPE_DIRECTORY=/tmp/nvidia-mps",
+			},
+		},
+		{
+			description: "injected library paths are prepended for overwrite policy",
+			policy:      config.EnvMergePolicyOverwrite,
+			env:         []string{"LD_LIBRARY_PATH=/opt/app/lib"},
+			expectedEnv: []string{
+				"LD_LIBRARY_PATH=/usr/lib/x86_64-linux-gnu:/opt/app/lib",
+				"NVIDIA_DRIVER_CAPABILITIES=compute,utility,video",
+				"NVIDIA_VISIBLE_DEVICES=void",
+				"CUDA_MPS_PIPE_DIRECTORY=/tmp/nvidia-mps",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.EnvMergePolicy = tc.policy
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+			)
+
+			spec := &specs.Spec{
+				Process: &specs.Process{
+					Env: tc.env,
+				},
+			}
+			err := f.withMergedEnv(injected).Modify(spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedEnv, spec.Process.Env)
+		})
+	}
+}
+
+// setEnv sets the specified envvar, replacing an existing value in place as
+// is done when applying CDI container edits.
+func setEnv(env []string, envvar string) []string {
+	key, _, _ := strings.Cut(envvar, "=")
+	for i, e := range env {
+		if strings.HasPrefix(e, key+"=") {
+			env[i] = envvar
+			return env
+		}
+	}
+	return append(env, envvar)
+}
@@ -41,6 +41,7 @@ type factoryOptions struct {
 	hookCreator discover.HookCreator
 	image       *image.CUDA
 	runtimeMode info.RuntimeMode
+	vmRuntime   bool
 }
 
 type Factory struct {
@@ -72,6 +73,7 @@ func createFactory(opts ...Option) *Factory {
 	f.editsFactory = edits.NewFactory(
 		edits.WithLogger(f.logger),
 		edits.WithNoAdditionalGIDsForDeviceNodes(f.cfg.Features.NoAdditionalGIDsForDeviceNodes.IsEnabled()),
+		edits.WithMountPropagation(f.cfg.NVIDIAContainerRuntimeConfig.MountPropagation),
 	)
 
 	return f
@@ -94,6 +96,9 @@ func (f *Factory) validate() error {
 // Modify creates the configured modifier and applies it to the supplied OCI
 // specification.
 func (f *Factory) Modify(s *specs.Spec) error {
+	if f.isSkippedImage() || f.isSkippedContainer(s) {
+		return nil
+	}
 	m, err := f.create()
 	if err != nil {
 		return err
@@ -104,33 +109,38 @@ func (f *Factory) Modify(s *specs.Spec) error {
 // create a modifier based on the modifier factory configuration.
 func (f *Factory) create() (oci.SpecModifier, error) {
 	var modifiers list
-	for _, modifierType := range supportedModifierTypes(f.runtimeMode) {
-		switch modifierType {
-		case "mode":
-			modeModifier, err := f.newModeModifier()
-			if err != nil {
-				return nil, err
-			}
-			modifiers = append(modifiers, modeModifier)
-		case "nvidia-hook-remover":
-			modifiers = append(modifiers, f.newNvidiaContainerRuntimeHookRemover())
-		case "graphics":
-			graphicsModifier, err := f.newGraphicsModifier()
-			if err != nil {
-				return nil, err
-			}
-			modifiers = append(modifiers, graphicsModifier)
-		case "feature-gated":
-			featureGatedModifier, err := f.newFeatureGatedModifier()
-			if err != nil {
-				return nil, err
-			}
-			modifiers = append(modifiers, featureGatedModifier)
-		default:
-			f.logger.Debugf("Ignoring unknown modifier type %q", modifierType)
+	switch f.runtimeMode {
+	case info.CSVRuntimeMode, info.CDIRuntimeMode, info.JitCDIRuntimeMode:
+		modifiers = append(modifiers, f.newNvidiaContainerRuntimeHookRemover())
+	}
+	for _, discoverer := range f.getDiscoverers() {
+		discovererModifier, err := f.newDiscovererModifier(discoverer)
+		if err != nil {
+			return nil, err
 		}
+		modifiers = append(modifiers, discovererModifier)
+	}
+	modeModifier, err := f.newModeModifier()
+	if err != nil {
+		return nil, err
+	}
+	modifiers = append(modifiers, modeModifier)
+
+	passThroughMounts, err := f.newPassThroughMounts()
+	if err != nil {
+		return nil, err
 	}
-	return modifiers, nil
+	additionalDeviceNodes, err := f.newAdditionalDeviceNodes()
+	if err != nil {
+		return nil, err
+	}
+	modifiers = append(modifiers, f.newCUDAForwardCompatModifier(), passThroughMounts, additionalDeviceNodes, f.newCUDACompatibilityCheck())
+
+	modifiers = list{f.newDriverHealthCheck(), f.withUVMDeviceCheck(modifiers)}
+
+	modifier := f.withMergedEnv(f.withProtectedMounts(f.withSkipMounts(f.withDisabledLDCacheUpdate(f.withDeduplicatedMounts(f.withDeviceCgroupRules(modifiers))))))
+
+	return f.withSpecValidation(f.withInjectedDevicesAnnotation(f.withVMPassthrough(modifier))), nil
 }
 
 type Option func(*factoryOptions)
@@ -169,3 +179,12 @@ func WithRuntimeMode(runtimeMode info.RuntimeMode) Option {
 		f.runtimeMode = runtimeMode
 	}
 }
+
+// WithVMRuntime indicates whether the low-level runtime runs containers in a
+// VM. If so, devices are passed through to the VM instead of injecting mounts
+// and hooks.
+func WithVMRuntime(vmRuntime bool) Option {
+	return func(f *factoryOptions) {
+		f.vmRuntime = vmRuntime
+	}
+}
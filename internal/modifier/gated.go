@@ -24,40 +24,75 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 )
 
-// newFeatureGatedModifier creates the modifiers for optional features.
-// These include:
+// newDiscovererModifier creates the modifier for the specified discoverer.
+// These include the graphics discoverer and the discoverers for optional
+// features:
 //
-//	NVIDIA_GDS=enabled
-//	NVIDIA_MOFED=enabled
-//	NVIDIA_NVSWITCH=enabled
-//	NVIDIA_GDRCOPY=enabled
+//	gated-devices: NVIDIA_GDS=enabled, NVIDIA_MOFED=enabled,
+//	               NVIDIA_NVSWITCH=enabled, NVIDIA_GDRCOPY=enabled,
+//	               NVIDIA_PEERMEM=enabled
+//	mps:           the CUDA MPS directories if the enable-mps feature is enabled
+//	cuda-compat:   the enable-cuda-compat hook
 //
 // If not devices are selected, no changes are made.
-func (f *Factory) newFeatureGatedModifier() (oci.SpecModifier, error) {
+func (f *Factory) newDiscovererModifier(discoverer string) (oci.SpecModifier, error) {
+	if discoverer == config.DiscovererGraphics {
+		return f.newGraphicsModifier()
+	}
+
 	if devices := f.image.VisibleDevices(); len(devices) == 0 {
-		f.logger.Infof("No modification required; no devices requested")
+		f.logger.Infof("No %v modification required; no devices requested", discoverer)
 		return nil, nil
 	}
 
-	var modifers list
-	if gatedDeviceRequests := withUniqueDevices(gatedDevices(*f.image)).DeviceRequests(); len(gatedDeviceRequests) != 0 {
-		featureGatedModifier, err := f.newAutomaticCDISpecModifier(gatedDeviceRequests)
-		if err != nil {
-			return nil, err
+	switch discoverer {
+	case config.DiscovererGatedDevices:
+		return f.newGatedDevicesModifier()
+	case config.DiscovererMPS:
+		return f.newMPSModifier()
+	case config.DiscovererCUDACompat:
+		// If the feature flag has explicitly been toggled, we don't make any modification.
+		if f.cfg.Features.DisableCUDACompatLibHook.IsEnabled() {
+			return nil, nil
 		}
-		modifers = append(modifers, featureGatedModifier)
-	}
-
-	// If the feature flag has explicitly been toggled, we don't make any modification.
-	if !f.cfg.Features.DisableCUDACompatLibHook.IsEnabled() {
 		cudaCompatModifer, err := f.getCudaCompatModeModifier()
 		if err != nil {
 			return nil, fmt.Errorf("failed to construct CUDA Compat discoverer: %w", err)
 		}
-		modifers = append(modifers, cudaCompatModifer)
+		return cudaCompatModifer, nil
+	default:
+		return nil, fmt.Errorf("unknown discoverer %q", discoverer)
 	}
+}
 
-	return modifers, nil
+// newGatedDevicesModifier creates the modifier for the optional devices that
+// are requested by the container.
+func (f *Factory) newGatedDevicesModifier() (oci.SpecModifier, error) {
+	gatedDeviceRequests := withUniqueDevices(gatedDevices(*f.image)).DeviceRequests()
+	if len(gatedDeviceRequests) == 0 {
+		return nil, nil
+	}
+	return f.newAutomaticCDISpecModifier(gatedDeviceRequests)
+}
+
+// newMPSModifier creates the modifier for the CUDA MPS directories if the
+// enable-mps feature is enabled.
+func (f *Factory) newMPSModifier() (oci.SpecModifier, error) {
+	if !f.cfg.Features.EnableMPS.IsEnabled() {
+		return nil, nil
+	}
+	mpsModifier, err := f.newModifierFromDiscoverer(
+		discover.NewMPSDiscoverer(
+			f.logger,
+			f.cfg.NVIDIAContainerRuntimeConfig.MPS.Root,
+			f.cfg.NVIDIAContainerRuntimeConfig.MPS.PipeDirectory,
+			f.cfg.NVIDIAContainerRuntimeConfig.MPS.LogDirectory,
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct MPS discoverer: %w", err)
+	}
+	return mpsModifier, nil
 }
 
 func (f *Factory) getCudaCompatModeModifier() (oci.SpecModifier, error) {
@@ -0,0 +1,160 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// specSnapshot records the mounts and hooks of a spec before a wrapped
+// modifier is applied. This allows the mounts and hooks that were injected by
+// the modifier to be distinguished from those that were already present.
+type specSnapshot struct {
+	mounts    []specs.Mount
+	mountKeys map[mountID]bool
+	hookKeys  map[string]bool
+}
+
+// modifyInjected applies the specified modifier to the spec and then calls
+// filter with a snapshot of the spec from before the modifier was applied. The
+// filter is not called for a nil spec.
+func modifyInjected(spec *specs.Spec, modifier oci.SpecModifier, filter func(*specSnapshot)) error {
+	if spec == nil {
+		return modifier.Modify(spec)
+	}
+
+	before := newSpecSnapshot(spec)
+	if err := modifier.Modify(spec); err != nil {
+		return err
+	}
+	filter(before)
+
+	return nil
+}
+
+// newSpecSnapshot records the mounts and hooks of the specified spec.
+func newSpecSnapshot(spec *specs.Spec) *specSnapshot {
+	s := &specSnapshot{
+		mounts:    slices.Clone(spec.Mounts),
+		mountKeys: make(map[mountID]bool),
+		hookKeys:  make(map[string]bool),
+	}
+	for _, mount := range spec.Mounts {
+		s.mountKeys[mountKey(mount)] = true
+	}
+	for _, hook := range allHooks(spec.Hooks) {
+		s.hookKeys[hookKey(hook)] = true
+	}
+	return s
+}
+
+// removeInjectedMounts removes the mounts that were added to the spec after
+// the snapshot was taken and for which remove returns true.
+func (s *specSnapshot) removeInjectedMounts(spec *specs.Spec, remove func(specs.Mount) bool) {
+	var mounts []specs.Mount
+	for _, mount := range spec.Mounts {
+		if !s.mountKeys[mountKey(mount)] && remove(mount) {
+			continue
+		}
+		mounts = append(mounts, mount)
+	}
+	spec.Mounts = mounts
+}
+
+// removeInjectedHooks removes the hooks that were added to the spec after the
+// snapshot was taken and for which remove returns true. If no hooks remain,
+// the hooks of the spec are set to nil.
+func (s *specSnapshot) removeInjectedHooks(spec *specs.Spec, remove func(specs.Hook) bool) {
+	s.editInjectedHooks(spec, func(hook specs.Hook) (specs.Hook, bool) {
+		return hook, !remove(hook)
+	})
+}
+
+// editInjectedHooks replaces each hook that was added to the spec after the
+// snapshot was taken by the hook returned by edit. If edit returns false, the
+// hook is removed instead. If no hooks remain, the hooks of the spec are set
+// to nil.
+func (s *specSnapshot) editInjectedHooks(spec *specs.Spec, edit func(specs.Hook) (specs.Hook, bool)) {
+	if spec.Hooks == nil {
+		return
+	}
+	filter := func(hooks []specs.Hook) []specs.Hook {
+		var filtered []specs.Hook
+		for _, hook := range hooks {
+			if !s.hookKeys[hookKey(hook)] {
+				edited, keep := edit(hook)
+				if !keep {
+					continue
+				}
+				hook = edited
+			}
+			filtered = append(filtered, hook)
+		}
+		return filtered
+	}
+	spec.Hooks.Prestart = filter(spec.Hooks.Prestart)
+	spec.Hooks.CreateRuntime = filter(spec.Hooks.CreateRuntime)
+	spec.Hooks.CreateContainer = filter(spec.Hooks.CreateContainer)
+	spec.Hooks.StartContainer = filter(spec.Hooks.StartContainer)
+	spec.Hooks.Poststart = filter(spec.Hooks.Poststart)
+	spec.Hooks.Poststop = filter(spec.Hooks.Poststop)
+	if len(allHooks(spec.Hooks)) == 0 {
+		spec.Hooks = nil
+	}
+}
+
+// mountID identifies a mount by its source, destination, and type.
+type mountID struct {
+	source      string
+	destination string
+	mountType   string
+}
+
+// mountKey returns the mountID for the specified mount.
+func mountKey(mount specs.Mount) mountID {
+	return mountID{
+		source:      mount.Source,
+		destination: mount.Destination,
+		mountType:   mount.Type,
+	}
+}
+
+// allHooks returns the hooks for all lifecycle stages.
+func allHooks(hooks *specs.Hooks) []specs.Hook {
+	if hooks == nil {
+		return nil
+	}
+	var all []specs.Hook
+	all = append(all, hooks.Prestart...)
+	all = append(all, hooks.CreateRuntime...)
+	all = append(all, hooks.CreateContainer...)
+	all = append(all, hooks.StartContainer...)
+	all = append(all, hooks.Poststart...)
+	all = append(all, hooks.Poststop...)
+	return all
+}
+
+// hookKey returns a key that identifies a hook by its path and arguments.
+func hookKey(hook specs.Hook) string {
+	return strings.Join(append([]string{hook.Path}, hook.Args...), "\x00")
+}
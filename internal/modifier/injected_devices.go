@@ -0,0 +1,84 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	// injectedDevicesAnnotation is the annotation used to record the devices
+	// that were injected into a container.
+	injectedDevicesAnnotation = "nvidia.com/injected-devices"
+)
+
+// annotateInjectedDevices is a spec modifier that wraps another modifier
+// and records the requested devices as an annotation on the spec.
+type annotateInjectedDevices struct {
+	modifier oci.SpecModifier
+	devices  []string
+}
+
+var _ oci.SpecModifier = (*annotateInjectedDevices)(nil)
+
+// withInjectedDevicesAnnotation wraps the specified modifier so that the
+// devices requested by the container are recorded in the
+// nvidia.com/injected-devices annotation. This is only done if the
+// annotate-injected-devices option is set and devices are requested.
+func (f *Factory) withInjectedDevicesAnnotation(modifier oci.SpecModifier) oci.SpecModifier {
+	if !f.cfg.NVIDIAContainerRuntimeConfig.AnnotateInjectedDevices || f.image == nil {
+		return modifier
+	}
+
+	var devices []string
+	for _, device := range f.image.VisibleDevices() {
+		if device == "" || slices.Contains(devices, device) {
+			continue
+		}
+		devices = append(devices, device)
+	}
+	if len(devices) == 0 {
+		return modifier
+	}
+
+	return &annotateInjectedDevices{
+		modifier: modifier,
+		devices:  devices,
+	}
+}
+
+// Modify applies the wrapped modifier and sets the annotation once the
+// devices have been injected successfully.
+func (m *annotateInjectedDevices) Modify(spec *specs.Spec) error {
+	if err := m.modifier.Modify(spec); err != nil {
+		return err
+	}
+	if spec == nil {
+		return nil
+	}
+	if spec.Annotations == nil {
+		spec.Annotations = make(map[string]string)
+	}
+	spec.Annotations[injectedDevicesAnnotation] = strings.Join(m.devices, ",")
+	return nil
+}
@@ -0,0 +1,116 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestInjectedDevicesAnnotation(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	errTest := errors.New("test error")
+
+	testCases := []struct {
+		description         string
+		annotate            bool
+		env                 []string
+		modifierError       error
+		spec                *specs.Spec
+		expectedAnnotations map[string]string
+		expectedError       error
+	}{
+		{
+			description: "annotation is not added by default",
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=GPU-1,GPU-2"},
+			spec:        &specs.Spec{},
+		},
+		{
+			description: "requested devices are recorded",
+			annotate:    true,
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=GPU-1,GPU-2"},
+			spec:        &specs.Spec{},
+			expectedAnnotations: map[string]string{
+				"nvidia.com/injected-devices": "GPU-1,GPU-2",
+			},
+		},
+		{
+			description: "existing annotations are preserved",
+			annotate:    true,
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			spec: &specs.Spec{
+				Annotations: map[string]string{
+					"example.com/annotation": "value",
+				},
+			},
+			expectedAnnotations: map[string]string{
+				"example.com/annotation":      "value",
+				"nvidia.com/injected-devices": "all",
+			},
+		},
+		{
+			description: "no annotation for no requested devices",
+			annotate:    true,
+			env:         []string{"NVIDIA_VISIBLE_DEVICES=none"},
+			spec:        &specs.Spec{},
+		},
+		{
+			description:   "no annotation if modification fails",
+			annotate:      true,
+			env:           []string{"NVIDIA_VISIBLE_DEVICES=GPU-1"},
+			modifierError: errTest,
+			spec:          &specs.Spec{},
+			expectedError: errTest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cudaImage, err := image.New(
+				image.WithEnv(tc.env),
+				image.WithAcceptEnvvarUnprivileged(true),
+			)
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(&config.Config{
+					NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+						AnnotateInjectedDevices: tc.annotate,
+					},
+				}),
+				WithImage(&cudaImage),
+			)
+
+			m := f.withInjectedDevicesAnnotation(modifierFunc(func(spec *specs.Spec) error {
+				return tc.modifierError
+			}))
+
+			err = m.Modify(tc.spec)
+			require.ErrorIs(t, err, tc.expectedError)
+			require.EqualValues(t, tc.expectedAnnotations, tc.spec.Annotations)
+		})
+	}
+}
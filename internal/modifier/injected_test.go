@@ -0,0 +1,96 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyInjected(t *testing.T) {
+	existingMount := specs.Mount{Source: "/host/existing", Destination: "/existing"}
+	injectedMount := specs.Mount{Source: "/host/injected", Destination: "/injected"}
+	existingHook := specs.Hook{Path: "/bin/existing"}
+	injectedHook := specs.Hook{Path: "/bin/injected"}
+
+	inject := modifierFunc(func(spec *specs.Spec) error {
+		spec.Mounts = append(spec.Mounts, existingMount, injectedMount)
+		if spec.Hooks == nil {
+			spec.Hooks = &specs.Hooks{}
+		}
+		spec.Hooks.Prestart = append(spec.Hooks.Prestart, existingHook, injectedHook)
+		return nil
+	})
+
+	testCases := []struct {
+		description  string
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description: "only injected mounts and hooks are removed",
+			spec: &specs.Spec{
+				Mounts: []specs.Mount{existingMount},
+				Hooks: &specs.Hooks{
+					Prestart: []specs.Hook{existingHook},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{existingMount, existingMount},
+				Hooks: &specs.Hooks{
+					Prestart: []specs.Hook{existingHook, existingHook},
+				},
+			},
+		},
+		{
+			description: "hooks are removed if none remain",
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{existingMount},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := modifyInjected(tc.spec, inject, func(before *specSnapshot) {
+				before.removeInjectedMounts(tc.spec, func(mount specs.Mount) bool {
+					return mount.Destination == injectedMount.Destination
+				})
+				before.removeInjectedHooks(tc.spec, func(hook specs.Hook) bool {
+					return true
+				})
+			})
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+
+	t.Run("errors are returned without filtering", func(t *testing.T) {
+		failing := modifierFunc(func(*specs.Spec) error {
+			return fmt.Errorf("failed")
+		})
+		err := modifyInjected(&specs.Spec{}, failing, func(*specSnapshot) {
+			t.Error("filter called after error")
+		})
+		require.ErrorContains(t, err, "failed")
+	})
+}
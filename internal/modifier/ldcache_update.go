@@ -0,0 +1,83 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// disabledLDCacheUpdate is a spec modifier that wraps another modifier and
+// removes any update-ldcache hooks that it injects.
+type disabledLDCacheUpdate struct {
+	logger   logger.Interface
+	modifier oci.SpecModifier
+}
+
+var _ oci.SpecModifier = (*disabledLDCacheUpdate)(nil)
+
+// withDisabledLDCacheUpdate wraps the specified modifier so that the
+// update-ldcache hooks that it adds are removed if the NVIDIA_DISABLE_LDCACHE_UPDATE
+// envvar of the container image is set to true.
+func (f *Factory) withDisabledLDCacheUpdate(modifier oci.SpecModifier) oci.SpecModifier {
+	if f.image == nil || !f.image.HasDisableLDCacheUpdate() {
+		return modifier
+	}
+	return &disabledLDCacheUpdate{
+		logger:   f.logger,
+		modifier: modifier,
+	}
+}
+
+// Modify applies the wrapped modifier and removes any update-ldcache hooks
+// that it added. Hooks that were already present in the spec are left
+// untouched.
+func (m *disabledLDCacheUpdate) Modify(spec *specs.Spec) error {
+	return modifyInjected(spec, m.modifier, func(before *specSnapshot) {
+		before.removeInjectedHooks(spec, func(hook specs.Hook) bool {
+			if !isUpdateLDCacheHook(hook) {
+				return false
+			}
+			m.logger.Infof("Skipping ldcache update hook %v", hook.Args)
+			return true
+		})
+	})
+}
+
+// isUpdateLDCacheHook checks whether the specified hook invokes the
+// update-ldcache subcommand of either the nvidia-cdi-hook or the nvidia-ctk
+// CLI.
+func isUpdateLDCacheHook(hook specs.Hook) bool {
+	return cdiHookName(hook) == discover.UpdateLDCacheHook
+}
+
+// cdiHookName returns the name of the hook that the specified hook invokes
+// using either the nvidia-cdi-hook or the nvidia-ctk CLI.
+func cdiHookName(hook specs.Hook) discover.HookName {
+	args := hook.Args
+	if len(args) > 1 && args[1] == "hook" {
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		return ""
+	}
+	return discover.HookName(args[1])
+}
@@ -0,0 +1,128 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+func TestDisabledLDCacheUpdate(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	updateLDCache := specs.Hook{
+		Path: "/usr/bin/nvidia-cdi-hook",
+		Args: []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/usr/lib/x86_64-linux-gnu"},
+	}
+	ctkUpdateLDCache := specs.Hook{
+		Path: "/usr/bin/nvidia-ctk",
+		Args: []string{"nvidia-ctk", "hook", "update-ldcache", "--folder", "/usr/lib64"},
+	}
+	createSymlinks := specs.Hook{
+		Path: "/usr/bin/nvidia-cdi-hook",
+		Args: []string{"nvidia-cdi-hook", "create-symlinks", "--link", "libcuda.so.1::/usr/lib/x86_64-linux-gnu/libcuda.so"},
+	}
+
+	injected := oci.SpecModifier(list{
+		modifierFunc(func(spec *specs.Spec) error {
+			if spec.Hooks == nil {
+				spec.Hooks = &specs.Hooks{}
+			}
+			spec.Hooks.CreateContainer = append(spec.Hooks.CreateContainer, createSymlinks, updateLDCache)
+			spec.Hooks.Prestart = append(spec.Hooks.Prestart, ctkUpdateLDCache)
+			return nil
+		}),
+	})
+
+	testCases := []struct {
+		description  string
+		env          []string
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description: "envvar not set keeps all hooks",
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					Prestart:        []specs.Hook{ctkUpdateLDCache},
+					CreateContainer: []specs.Hook{createSymlinks, updateLDCache},
+				},
+			},
+		},
+		{
+			description: "envvar set to false keeps all hooks",
+			env:         []string{"NVIDIA_DISABLE_LDCACHE_UPDATE=false"},
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					Prestart:        []specs.Hook{ctkUpdateLDCache},
+					CreateContainer: []specs.Hook{createSymlinks, updateLDCache},
+				},
+			},
+		},
+		{
+			description: "envvar set removes update-ldcache hooks",
+			env:         []string{"NVIDIA_DISABLE_LDCACHE_UPDATE=1"},
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					CreateContainer: []specs.Hook{createSymlinks},
+				},
+			},
+		},
+		{
+			description: "update-ldcache hook existing in the spec is not removed",
+			env:         []string{"NVIDIA_DISABLE_LDCACHE_UPDATE=true"},
+			spec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					CreateContainer: []specs.Hook{updateLDCache},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Hooks: &specs.Hooks{
+					CreateContainer: []specs.Hook{updateLDCache, createSymlinks, updateLDCache},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cudaImage, err := image.New(image.WithEnv(tc.env))
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(&config.Config{}),
+				WithImage(&cudaImage),
+			)
+
+			err = f.withDisabledLDCacheUpdate(injected).Modify(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package modifier
 
 import (
 	"fmt"
+	"slices"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
 )
@@ -20,16 +22,42 @@ func (f *Factory) newModeModifier() (oci.SpecModifier, error) {
 	return nil, fmt.Errorf("invalid runtime mode: %v", f.runtimeMode)
 }
 
-// supportedModifierTypes returns the modifiers supported for a specific runtime mode.
-func supportedModifierTypes(mode info.RuntimeMode) []string {
+// getDiscoverers returns the discoverers to apply in the order in which they
+// are applied. If discoverers are specified in the config, these are used
+// instead of the defaults for the runtime mode with discoverers that are not
+// supported for the runtime mode being ignored.
+func (f *Factory) getDiscoverers() []string {
+	supported := supportedDiscoverers(f.runtimeMode)
+
+	configured := f.cfg.NVIDIAContainerRuntimeConfig.Discoverers
+	if len(configured) == 0 {
+		return supported
+	}
+
+	var discoverers []string
+	for _, discoverer := range configured {
+		if !slices.Contains(supported, discoverer) {
+			f.logger.Warningf("Ignoring discoverer %q; not supported in %v mode", discoverer, f.runtimeMode)
+			continue
+		}
+		if slices.Contains(discoverers, discoverer) {
+			continue
+		}
+		discoverers = append(discoverers, discoverer)
+	}
+	return discoverers
+}
+
+// supportedDiscoverers returns the discoverers supported for a specific runtime mode.
+func supportedDiscoverers(mode info.RuntimeMode) []string {
 	switch mode {
 	case info.CDIRuntimeMode, info.JitCDIRuntimeMode:
 		// For CDI mode we make no additional modifications.
-		return []string{"nvidia-hook-remover", "mode"}
+		return nil
 	case info.CSVRuntimeMode:
-		// For CSV mode we support mode and feature-gated modification.
-		return []string{"nvidia-hook-remover", "feature-gated", "mode"}
+		// For CSV mode we support the feature-gated discoverers.
+		return []string{config.DiscovererGatedDevices, config.DiscovererMPS, config.DiscovererCUDACompat}
 	default:
-		return []string{"feature-gated", "graphics", "mode"}
+		return []string{config.DiscovererGatedDevices, config.DiscovererMPS, config.DiscovererCUDACompat, config.DiscovererGraphics}
 	}
 }
@@ -0,0 +1,113 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
+)
+
+func TestGetDiscoverers(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description         string
+		runtimeMode         info.RuntimeMode
+		discoverers         []string
+		expectedDiscoverers []string
+	}{
+		{
+			description:         "legacy mode defaults",
+			runtimeMode:         info.LegacyRuntimeMode,
+			expectedDiscoverers: []string{"gated-devices", "mps", "cuda-compat", "graphics"},
+		},
+		{
+			description:         "csv mode defaults",
+			runtimeMode:         info.CSVRuntimeMode,
+			expectedDiscoverers: []string{"gated-devices", "mps", "cuda-compat"},
+		},
+		{
+			description: "cdi mode defaults",
+			runtimeMode: info.CDIRuntimeMode,
+		},
+		{
+			description:         "configured discoverers are reordered",
+			runtimeMode:         info.LegacyRuntimeMode,
+			discoverers:         []string{"graphics", "mps", "gated-devices"},
+			expectedDiscoverers: []string{"graphics", "mps", "gated-devices"},
+		},
+		{
+			description:         "omitted discoverers are disabled",
+			runtimeMode:         info.LegacyRuntimeMode,
+			discoverers:         []string{"gated-devices"},
+			expectedDiscoverers: []string{"gated-devices"},
+		},
+		{
+			description: "unsupported discoverers for the mode are ignored",
+			runtimeMode: info.CDIRuntimeMode,
+			discoverers: []string{"graphics"},
+		},
+		{
+			description:         "duplicate discoverers are applied once",
+			runtimeMode:         info.CSVRuntimeMode,
+			discoverers:         []string{"mps", "gated-devices", "mps"},
+			expectedDiscoverers: []string{"mps", "gated-devices"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.Discoverers = tc.discoverers
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+				WithRuntimeMode(tc.runtimeMode),
+			)
+
+			require.EqualValues(t, tc.expectedDiscoverers, f.getDiscoverers())
+		})
+	}
+}
+
+func TestModeModifierIsAlwaysApplied(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	cfg := &config.Config{}
+	cfg.NVIDIAContainerRuntimeConfig.Discoverers = []string{"gated-devices"}
+
+	f := createFactory(
+		WithLogger(logger),
+		WithConfig(cfg),
+		WithRuntimeMode(info.LegacyRuntimeMode),
+		WithImage(&image.CUDA{}),
+	)
+
+	spec := &specs.Spec{}
+	require.NoError(t, f.Modify(spec))
+	require.NotNil(t, spec.Hooks)
+	require.Len(t, spec.Hooks.Prestart, 1)
+}
@@ -0,0 +1,62 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// passThroughMounts is a discoverer for the configured pass-through mounts.
+type passThroughMounts struct {
+	discover.None
+	mounts []discover.Mount
+}
+
+// newPassThroughMounts creates a modifier that adds the configured
+// pass-through mounts to containers that request devices. The mounts are added
+// regardless of the devices that are requested. If no pass-through mounts are
+// configured, nil is returned.
+func (f *Factory) newPassThroughMounts() (oci.SpecModifier, error) {
+	if len(f.cfg.NVIDIAContainerRuntimeConfig.PassThroughMounts) == 0 {
+		return nil, nil
+	}
+	if devices := f.image.VisibleDevices(); len(devices) == 0 {
+		return nil, nil
+	}
+
+	var mounts []discover.Mount
+	for _, m := range f.cfg.NVIDIAContainerRuntimeConfig.PassThroughMounts {
+		options := []string{"nosuid", "nodev", "rbind", "rprivate"}
+		if m.ReadOnly {
+			options = append([]string{"ro"}, options...)
+		}
+		mounts = append(mounts, discover.Mount{
+			HostPath: m.HostPath,
+			Path:     m.GetContainerPath(),
+			Options:  options,
+		})
+	}
+
+	return f.newModifierFromDiscoverer(&passThroughMounts{mounts: mounts})
+}
+
+// Mounts returns the configured pass-through mounts.
+func (d *passThroughMounts) Mounts() ([]discover.Mount, error) {
+	return d.mounts, nil
+}
@@ -0,0 +1,111 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestPassThroughMounts(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	passThroughMounts := []config.PassThroughMountConfig{
+		{HostPath: "/etc/pki/ca-trust", ReadOnly: true},
+		{HostPath: "/run/telemetry", ContainerPath: "/var/run/telemetry"},
+	}
+	expectedMounts := []specs.Mount{
+		{
+			Source:      "/etc/pki/ca-trust",
+			Destination: "/etc/pki/ca-trust",
+			Options:     []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+		},
+		{
+			Source:      "/run/telemetry",
+			Destination: "/var/run/telemetry",
+			Options:     []string{"nosuid", "nodev", "rbind", "rprivate"},
+		},
+	}
+
+	testCases := []struct {
+		description       string
+		passThroughMounts []config.PassThroughMountConfig
+		env               []string
+		expectedMounts    []specs.Mount
+	}{
+		{
+			description:       "no pass-through mounts configured",
+			passThroughMounts: nil,
+			env:               []string{"NVIDIA_VISIBLE_DEVICES=all"},
+		},
+		{
+			description:       "no devices requested",
+			passThroughMounts: passThroughMounts,
+		},
+		{
+			description:       "all devices requested",
+			passThroughMounts: passThroughMounts,
+			env:               []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			expectedMounts:    expectedMounts,
+		},
+		{
+			description:       "single device requested",
+			passThroughMounts: passThroughMounts,
+			env:               []string{"NVIDIA_VISIBLE_DEVICES=0"},
+			expectedMounts:    expectedMounts,
+		},
+		{
+			description:       "none requested",
+			passThroughMounts: passThroughMounts,
+			env:               []string{"NVIDIA_VISIBLE_DEVICES=none"},
+			expectedMounts:    expectedMounts,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.PassThroughMounts = tc.passThroughMounts
+
+			cudaImage, err := image.New(
+				image.WithEnv(tc.env),
+				image.WithAcceptEnvvarUnprivileged(true),
+			)
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+				WithImage(&cudaImage),
+			)
+
+			m, err := f.newPassThroughMounts()
+			require.NoError(t, err)
+
+			spec := &specs.Spec{}
+			require.NoError(t, list{m}.Modify(spec))
+			require.EqualValues(t, tc.expectedMounts, spec.Mounts)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// protectedMounts is a spec modifier that wraps another modifier and removes
+// any mounts that it injects at or below one of the protected container
+// paths.
+type protectedMounts struct {
+	logger   logger.Interface
+	modifier oci.SpecModifier
+	prefixes []string
+}
+
+var _ oci.SpecModifier = (*protectedMounts)(nil)
+
+// withProtectedMounts wraps the specified modifier so that mounts that it
+// adds at or below one of the configured protected mount prefixes are
+// removed.
+func (f *Factory) withProtectedMounts(modifier oci.SpecModifier) oci.SpecModifier {
+	var prefixes []string
+	for _, prefix := range f.cfg.NVIDIAContainerRuntimeConfig.ProtectedMountPrefixes {
+		prefixes = append(prefixes, filepath.Clean(prefix))
+	}
+	if len(prefixes) == 0 {
+		return modifier
+	}
+	return &protectedMounts{
+		logger:   f.logger,
+		modifier: modifier,
+		prefixes: prefixes,
+	}
+}
+
+// Modify applies the wrapped modifier and removes any mounts that it added
+// to a protected container path. Mounts that were already present in the
+// spec are left untouched.
+func (m *protectedMounts) Modify(spec *specs.Spec) error {
+	return modifyInjected(spec, m.modifier, func(before *specSnapshot) {
+		before.removeInjectedMounts(spec, func(mount specs.Mount) bool {
+			prefix, ok := m.protectedPrefix(mount.Destination)
+			if !ok {
+				return false
+			}
+			m.logger.Warningf("Skipping mount of %v to %v: destination is protected by %v", mount.Source, mount.Destination, prefix)
+			return true
+		})
+	})
+}
+
+// protectedPrefix returns the protected prefix that the specified container
+// path is equal to or below, if any.
+func (m *protectedMounts) protectedPrefix(destination string) (string, bool) {
+	destination = filepath.Clean(destination)
+	for _, prefix := range m.prefixes {
+		if destination == prefix || prefix == "/" || strings.HasPrefix(destination, prefix+"/") {
+			return prefix, true
+		}
+	}
+	return "", false
+}
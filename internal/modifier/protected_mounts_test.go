@@ -0,0 +1,130 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+func TestProtectedMounts(t *testing.T) {
+	logger, hook := testlog.NewNullLogger()
+
+	injected := oci.SpecModifier(list{
+		modifierFunc(func(spec *specs.Spec) error {
+			spec.Mounts = append(spec.Mounts,
+				specs.Mount{Source: "/host/nvidia-smi", Destination: "/usr/bin/nvidia-smi"},
+				specs.Mount{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+			)
+			return nil
+		}),
+	})
+
+	testCases := []struct {
+		description      string
+		prefixes         []string
+		spec             *specs.Spec
+		expectedSpec     *specs.Spec
+		expectedWarnings int
+	}{
+		{
+			description: "no protected prefixes keeps all mounts",
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/host/nvidia-smi", Destination: "/usr/bin/nvidia-smi"},
+					{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				},
+			},
+		},
+		{
+			description: "mount below protected prefix is skipped",
+			prefixes:    []string{"/usr/bin"},
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				},
+			},
+			expectedWarnings: 1,
+		},
+		{
+			description: "mount at protected prefix is skipped",
+			prefixes:    []string{"/usr/bin/nvidia-smi/"},
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				},
+			},
+			expectedWarnings: 1,
+		},
+		{
+			description: "prefix match requires a path component boundary",
+			prefixes:    []string{"/usr/b", "/usr/lib/x86_64"},
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/host/nvidia-smi", Destination: "/usr/bin/nvidia-smi"},
+					{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				},
+			},
+		},
+		{
+			description: "mount existing in the spec is not removed",
+			prefixes:    []string{"/usr/bin"},
+			spec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/bundled/tool", Destination: "/usr/bin/tool"},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/bundled/tool", Destination: "/usr/bin/tool"},
+					{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				},
+			},
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hook.Reset()
+
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.ProtectedMountPrefixes = tc.prefixes
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+			)
+
+			err := f.withProtectedMounts(injected).Modify(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+			require.Len(t, hook.AllEntries(), tc.expectedWarnings)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// isSkippedContainer checks whether the container matches one of the
+// predicates in the skip-containers config option. A container matches if its
+// cgroups path starts with one of the configured cgroup path prefixes or if
+// it belongs to one of the configured Kubernetes namespaces. If it does, the
+// OCI spec is not modified.
+func (f *Factory) isSkippedContainer(spec *specs.Spec) bool {
+	skip := f.cfg.NVIDIAContainerRuntimeConfig.SkipContainers
+
+	if spec != nil && spec.Linux != nil && spec.Linux.CgroupsPath != "" {
+		for _, prefix := range skip.CgroupPaths {
+			if prefix != "" && strings.HasPrefix(spec.Linux.CgroupsPath, prefix) {
+				f.logger.Infof("Skipping modifications for container in cgroup %q matching %q", spec.Linux.CgroupsPath, prefix)
+				return true
+			}
+		}
+	}
+
+	if f.image == nil || len(skip.Namespaces) == 0 {
+		return false
+	}
+	namespace := f.image.Namespace()
+	if namespace == "" {
+		return false
+	}
+	if slices.Contains(skip.Namespaces, namespace) {
+		f.logger.Infof("Skipping modifications for container in namespace %q", namespace)
+		return true
+	}
+	return false
+}
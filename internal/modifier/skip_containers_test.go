@@ -0,0 +1,145 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestSkipContainers(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		cgroupPaths     []string
+		namespaces      []string
+		cgroupsPath     string
+		annotations     map[string]string
+		expectedSkipped bool
+	}{
+		{
+			description: "no skip predicates",
+			cgroupsPath: "/kubepods/besteffort/pod1234/abcd",
+			annotations: map[string]string{
+				"io.kubernetes.cri.sandbox-namespace": "kube-system",
+			},
+			expectedSkipped: false,
+		},
+		{
+			description:     "cgroupfs path prefix matches",
+			cgroupPaths:     []string{"/system.slice/", "/kubepods/besteffort/"},
+			cgroupsPath:     "/kubepods/besteffort/pod1234/abcd",
+			expectedSkipped: true,
+		},
+		{
+			description:     "systemd cgroups path prefix matches",
+			cgroupPaths:     []string{"system.slice:"},
+			cgroupsPath:     "system.slice:docker:abcd",
+			expectedSkipped: true,
+		},
+		{
+			description:     "cgroups path prefix does not match",
+			cgroupPaths:     []string{"/system.slice/"},
+			cgroupsPath:     "/kubepods/besteffort/pod1234/abcd",
+			expectedSkipped: false,
+		},
+		{
+			description:     "empty cgroup path prefix is ignored",
+			cgroupPaths:     []string{""},
+			cgroupsPath:     "/kubepods/besteffort/pod1234/abcd",
+			expectedSkipped: false,
+		},
+		{
+			description:     "no cgroups path in spec",
+			cgroupPaths:     []string{"/kubepods/"},
+			expectedSkipped: false,
+		},
+		{
+			description: "containerd namespace annotation matches",
+			namespaces:  []string{"kube-system", "monitoring"},
+			annotations: map[string]string{
+				"io.kubernetes.cri.sandbox-namespace": "monitoring",
+			},
+			expectedSkipped: true,
+		},
+		{
+			description: "cri-o namespace annotation matches",
+			namespaces:  []string{"kube-system"},
+			annotations: map[string]string{
+				"io.kubernetes.pod.namespace": "kube-system",
+			},
+			expectedSkipped: true,
+		},
+		{
+			description: "namespace does not match",
+			namespaces:  []string{"kube-system"},
+			annotations: map[string]string{
+				"io.kubernetes.cri.sandbox-namespace": "default",
+			},
+			expectedSkipped: false,
+		},
+		{
+			description:     "no namespace annotation",
+			namespaces:      []string{"kube-system"},
+			expectedSkipped: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.SkipContainers.CgroupPaths = tc.cgroupPaths
+			cfg.NVIDIAContainerRuntimeConfig.SkipContainers.Namespaces = tc.namespaces
+
+			cudaImage, err := image.New(image.WithAnnotations(tc.annotations))
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+				WithImage(&cudaImage),
+			)
+
+			spec := &specs.Spec{
+				Annotations: tc.annotations,
+				Process:     &specs.Process{Env: []string{"NVIDIA_VISIBLE_DEVICES=all"}},
+				Linux:       &specs.Linux{CgroupsPath: tc.cgroupsPath},
+			}
+
+			require.Equal(t, tc.expectedSkipped, f.isSkippedContainer(spec))
+			if !tc.expectedSkipped {
+				return
+			}
+
+			expectedSpec := &specs.Spec{
+				Annotations: tc.annotations,
+				Process:     &specs.Process{Env: []string{"NVIDIA_VISIBLE_DEVICES=all"}},
+				Linux:       &specs.Linux{CgroupsPath: tc.cgroupsPath},
+			}
+			require.NoError(t, f.Modify(spec))
+			require.EqualValues(t, expectedSpec, spec)
+		})
+	}
+}
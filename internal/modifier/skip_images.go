@@ -0,0 +1,50 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import "strings"
+
+// isSkippedImage checks whether the image of the container matches one of the
+// image references in the skip-images config option. If it does, the OCI spec
+// is not modified.
+func (f *Factory) isSkippedImage() bool {
+	if f.image == nil || len(f.cfg.NVIDIAContainerRuntimeConfig.SkipImages) == 0 {
+		return false
+	}
+	imageName := f.image.ImageName()
+	if imageName == "" {
+		return false
+	}
+	for _, reference := range f.cfg.NVIDIAContainerRuntimeConfig.SkipImages {
+		if matchesImageReference(reference, imageName) {
+			f.logger.Infof("Skipping modifications for image %q matching %q", imageName, reference)
+			return true
+		}
+	}
+	return false
+}
+
+// matchesImageReference checks whether the specified image name matches the
+// reference. A reference ending in * matches any image name with the preceding
+// prefix. Other references must match the image name exactly.
+func matchesImageReference(reference string, imageName string) bool {
+	if prefix, ok := strings.CutSuffix(reference, "*"); ok {
+		return strings.HasPrefix(imageName, prefix)
+	}
+	return reference == imageName
+}
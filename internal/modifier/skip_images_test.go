@@ -0,0 +1,125 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+)
+
+func TestSkipImages(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description     string
+		skipImages      []string
+		annotations     map[string]string
+		expectedSkipped bool
+	}{
+		{
+			description: "no skip images",
+			annotations: map[string]string{
+				"io.kubernetes.cri.image-name": "ghcr.io/foo/bar:latest",
+			},
+			expectedSkipped: false,
+		},
+		{
+			description:     "no image name annotation",
+			skipImages:      []string{"*"},
+			expectedSkipped: false,
+		},
+		{
+			description: "prefix matches containerd image name",
+			skipImages:  []string{"ghcr.io/foo/*"},
+			annotations: map[string]string{
+				"io.kubernetes.cri.image-name": "ghcr.io/foo/bar:latest",
+			},
+			expectedSkipped: true,
+		},
+		{
+			description: "prefix matches cri-o image name",
+			skipImages:  []string{"ghcr.io/foo/*"},
+			annotations: map[string]string{
+				"io.kubernetes.cri-o.ImageName": "ghcr.io/foo/bar@sha256:0123",
+			},
+			expectedSkipped: true,
+		},
+		{
+			description: "exact reference matches",
+			skipImages:  []string{"nvcr.io/nvidia/cuda:12.4.0-base-ubuntu22.04"},
+			annotations: map[string]string{
+				"io.kubernetes.cri.image-name": "nvcr.io/nvidia/cuda:12.4.0-base-ubuntu22.04",
+			},
+			expectedSkipped: true,
+		},
+		{
+			description: "prefix does not match",
+			skipImages:  []string{"ghcr.io/foo/*"},
+			annotations: map[string]string{
+				"io.kubernetes.cri.image-name": "ghcr.io/foobar/bar:latest",
+			},
+			expectedSkipped: false,
+		},
+		{
+			description: "exact reference requires full match",
+			skipImages:  []string{"nvcr.io/nvidia/cuda"},
+			annotations: map[string]string{
+				"io.kubernetes.cri.image-name": "nvcr.io/nvidia/cuda:12.4.0-base-ubuntu22.04",
+			},
+			expectedSkipped: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.SkipImages = tc.skipImages
+
+			cudaImage, err := image.New(image.WithAnnotations(tc.annotations))
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+				WithImage(&cudaImage),
+			)
+
+			require.Equal(t, tc.expectedSkipped, f.isSkippedImage())
+			if !tc.expectedSkipped {
+				return
+			}
+
+			spec := &specs.Spec{
+				Annotations: tc.annotations,
+				Process:     &specs.Process{Env: []string{"NVIDIA_VISIBLE_DEVICES=all"}},
+			}
+			expectedSpec := &specs.Spec{
+				Annotations: tc.annotations,
+				Process:     &specs.Process{Env: []string{"NVIDIA_VISIBLE_DEVICES=all"}},
+			}
+			require.NoError(t, f.Modify(spec))
+			require.EqualValues(t, expectedSpec, spec)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// skipMounts is a spec modifier that wraps another modifier and removes any
+// mounts that it injects to the specified container paths.
+type skipMounts struct {
+	logger       logger.Interface
+	modifier     oci.SpecModifier
+	destinations map[string]bool
+}
+
+var _ oci.SpecModifier = (*skipMounts)(nil)
+
+// withSkipMounts wraps the specified modifier so that mounts that it adds
+// with one of the configured destinations are removed. The destinations are
+// taken from the runtime config as well as the NVIDIA_SKIP_MOUNTS envvar of
+// the container image.
+func (f *Factory) withSkipMounts(modifier oci.SpecModifier) oci.SpecModifier {
+	destinations := make(map[string]bool)
+	for _, destination := range f.cfg.NVIDIAContainerRuntimeConfig.SkipMounts {
+		destinations[destination] = true
+	}
+	if f.image != nil {
+		for _, destination := range f.image.SkipMounts() {
+			destinations[destination] = true
+		}
+	}
+	if len(destinations) == 0 {
+		return modifier
+	}
+	return &skipMounts{
+		logger:       f.logger,
+		modifier:     modifier,
+		destinations: destinations,
+	}
+}
+
+// Modify applies the wrapped modifier and removes any mounts that it added
+// to one of the skipped destinations. The arguments of the injected
+// create-symlinks, update-ldcache, and check-libraries hooks that refer to the
+// skipped mounts are also removed. Mounts and hooks that were already present
+// in the spec are left untouched.
+func (m *skipMounts) Modify(spec *specs.Spec) error {
+	return modifyInjected(spec, m.modifier, func(before *specSnapshot) {
+		skipped := make(map[string]bool)
+		before.removeInjectedMounts(spec, func(mount specs.Mount) bool {
+			if !m.destinations[mount.Destination] {
+				return false
+			}
+			m.logger.Infof("Skipping mount of %v to %v", mount.Source, mount.Destination)
+			skipped[mount.Destination] = true
+			return true
+		})
+		if len(skipped) == 0 {
+			return
+		}
+
+		mountedDirs := make(map[string]bool)
+		for _, mount := range spec.Mounts {
+			mountedDirs[filepath.Dir(mount.Destination)] = true
+		}
+		before.editInjectedHooks(spec, func(hook specs.Hook) (specs.Hook, bool) {
+			switch cdiHookName(hook) {
+			case discover.CreateSymlinksHook:
+				hook.Args = m.withoutArgs(hook.Args, "--link", func(link string) bool {
+					return isSkippedLink(link, skipped)
+				})
+				// A create-symlinks hook without links is not required.
+				return hook, slices.Contains(hook.Args, "--link")
+			case discover.UpdateLDCacheHook:
+				hook.Args = m.withoutArgs(hook.Args, "--folder", func(folder string) bool {
+					return !mountedDirs[filepath.Clean(folder)] && isSkippedFolder(folder, skipped)
+				})
+			case discover.CheckLibrariesHook:
+				hook.Args = m.withoutArgs(hook.Args, "--folder", func(folder string) bool {
+					return isSkippedFolder(folder, skipped)
+				})
+				// The libraries in a skipped folder are not injected and are
+				// therefore not checked.
+				return hook, slices.Contains(hook.Args, "--folder")
+			}
+			return hook, true
+		})
+	})
+}
+
+// withoutArgs returns a copy of the specified hook arguments where the
+// specified flag and its value are removed if skip returns true for the value.
+func (m *skipMounts) withoutArgs(args []string, flag string, skip func(string) bool) []string {
+	var filtered []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == flag && i+1 < len(args) && skip(args[i+1]) {
+			m.logger.Infof("Skipping hook argument %v %v", flag, args[i+1])
+			i++
+			continue
+		}
+		filtered = append(filtered, args[i])
+	}
+	return filtered
+}
+
+// isSkippedLink checks whether the specified target::link pair of a
+// create-symlinks hook refers to a skipped mount. This is the case if either
+// the link or the target that it resolves to is a skipped mount.
+func isSkippedLink(link string, skipped map[string]bool) bool {
+	target, linkPath, ok := strings.Cut(link, "::")
+	if !ok {
+		return false
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(linkPath), target)
+	}
+	return skipped[filepath.Clean(target)] || skipped[filepath.Clean(linkPath)]
+}
+
+// isSkippedFolder checks whether the specified folder of an update-ldcache or
+// check-libraries hook contains a skipped mount.
+func isSkippedFolder(folder string, skipped map[string]bool) bool {
+	for destination := range skipped {
+		if filepath.Dir(destination) == filepath.Clean(folder) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+func TestSkipMounts(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	injected := oci.SpecModifier(list{
+		modifierFunc(func(spec *specs.Spec) error {
+			spec.Mounts = append(spec.Mounts,
+				specs.Mount{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				specs.Mount{Source: "/host/libnvidia-ml.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.1"},
+			)
+			return nil
+		}),
+	})
+
+	testCases := []struct {
+		description  string
+		skipMounts   []string
+		env          []string
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description: "no skipped mounts keeps all mounts",
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+					{Source: "/host/libnvidia-ml.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.1"},
+				},
+			},
+		},
+		{
+			description: "skipped mount from config is removed",
+			skipMounts:  []string{"/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/host/libnvidia-ml.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.1"},
+				},
+			},
+		},
+		{
+			description: "skipped mount from image envvar is removed",
+			env:         []string{"NVIDIA_SKIP_MOUNTS=/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.1, /not/mounted"},
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				},
+			},
+		},
+		{
+			description: "mount existing in the spec is not removed",
+			skipMounts:  []string{"/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+			spec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/bundled/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/bundled/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+					{Source: "/host/libnvidia-ml.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.1"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.SkipMounts = tc.skipMounts
+
+			cudaImage, err := image.New(image.WithEnv(tc.env))
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+				WithImage(&cudaImage),
+			)
+
+			err = f.withSkipMounts(injected).Modify(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
+
+func TestSkipMountsRemovesDependentHookArgs(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	injected := modifierFunc(func(spec *specs.Spec) error {
+		spec.Mounts = append(spec.Mounts,
+			specs.Mount{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+			specs.Mount{Source: "/host/libnvidia-ml.so.1", Destination: "/usr/lib64/libnvidia-ml.so.1"},
+		)
+		spec.Hooks = &specs.Hooks{
+			CreateContainer: []specs.Hook{
+				{
+					Path: "/usr/bin/nvidia-cdi-hook",
+					Args: []string{"nvidia-cdi-hook", "create-symlinks",
+						"--link", "libcuda.so.1::/usr/lib/x86_64-linux-gnu/libcuda.so",
+						"--link", "/usr/lib64/libnvidia-ml.so.1::/usr/lib64/libnvidia-ml.so",
+					},
+				},
+				{
+					Path: "/usr/bin/nvidia-cdi-hook",
+					Args: []string{"nvidia-cdi-hook", "create-symlinks",
+						"--link", "libcuda.so.1::/usr/lib/x86_64-linux-gnu/libcuda.so.99",
+					},
+				},
+				{
+					Path: "/usr/bin/nvidia-cdi-hook",
+					Args: []string{"nvidia-cdi-hook", "update-ldcache",
+						"--folder", "/usr/lib/x86_64-linux-gnu",
+						"--folder", "/usr/lib64",
+					},
+				},
+				{
+					Path: "/usr/bin/nvidia-cdi-hook",
+					Args: []string{"nvidia-cdi-hook", "check-libraries",
+						"--folder", "/usr/lib/x86_64-linux-gnu",
+					},
+				},
+			},
+		}
+		return nil
+	})
+
+	cfg := &config.Config{}
+	cfg.NVIDIAContainerRuntimeConfig.SkipMounts = []string{"/usr/lib/x86_64-linux-gnu/libcuda.so.1"}
+
+	f := createFactory(
+		WithLogger(logger),
+		WithConfig(cfg),
+	)
+
+	spec := &specs.Spec{}
+	err := f.withSkipMounts(injected).Modify(spec)
+	require.NoError(t, err)
+
+	expectedSpec := &specs.Spec{
+		Mounts: []specs.Mount{
+			{Source: "/host/libnvidia-ml.so.1", Destination: "/usr/lib64/libnvidia-ml.so.1"},
+		},
+		Hooks: &specs.Hooks{
+			CreateContainer: []specs.Hook{
+				{
+					Path: "/usr/bin/nvidia-cdi-hook",
+					Args: []string{"nvidia-cdi-hook", "create-symlinks",
+						"--link", "/usr/lib64/libnvidia-ml.so.1::/usr/lib64/libnvidia-ml.so",
+					},
+				},
+				{
+					Path: "/usr/bin/nvidia-cdi-hook",
+					Args: []string{"nvidia-cdi-hook", "update-ldcache",
+						"--folder", "/usr/lib64",
+					},
+				},
+			},
+		},
+	}
+	require.EqualValues(t, expectedSpec, spec)
+}
+
+type modifierFunc func(*specs.Spec) error
+
+func (m modifierFunc) Modify(spec *specs.Spec) error {
+	return m(spec)
+}
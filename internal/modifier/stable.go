@@ -28,9 +28,14 @@ import (
 // newStableRuntimeModifier creates an OCI spec modifier that inserts the NVIDIA Container Runtime Hook into an OCI
 // spec. The specified logger is used to capture log output.
 func (f *Factory) newStableRuntimeModifier() oci.SpecModifier {
+	hookPath := f.cfg.NVIDIAContainerRuntimeConfig.HookPath
+	if hookPath == "" {
+		hookPath = f.cfg.NVIDIAContainerRuntimeHookConfig.Path
+	}
 	m := stableRuntimeModifier{
 		logger:                         f.logger,
-		nvidiaContainerRuntimeHookPath: f.cfg.NVIDIAContainerRuntimeHookConfig.Path,
+		nvidiaContainerRuntimeHookPath: hookPath,
+		driverRoot:                     f.cfg.NVIDIAContainerCLIConfig.Root,
 	}
 
 	return &m
@@ -41,6 +46,9 @@ func (f *Factory) newStableRuntimeModifier() oci.SpecModifier {
 type stableRuntimeModifier struct {
 	logger                         logger.Interface
 	nvidiaContainerRuntimeHookPath string
+	// driverRoot is passed to the hook so that a driver root detected by the
+	// runtime is also used by the hook.
+	driverRoot string
 }
 
 // Modify applies the required modification to the incoming OCI spec, inserting the nvidia-container-runtime-hook
@@ -60,6 +68,9 @@ func (m stableRuntimeModifier) Modify(spec *specs.Spec) error {
 	path := m.nvidiaContainerRuntimeHookPath
 	m.logger.Infof("Using prestart hook path: %v", path)
 	args := []string{filepath.Base(path)}
+	if m.driverRoot != "" && m.driverRoot != "/" {
+		args = append(args, "-driver-root="+m.driverRoot)
+	}
 	if spec.Hooks == nil {
 		spec.Hooks = &specs.Hooks{}
 	}
@@ -173,3 +173,96 @@ func TestAddHookModifier(t *testing.T) {
 	}
 
 }
+
+func TestStableRuntimeModifierHookPath(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description      string
+		hookConfigPath   string
+		runtimeHookPath  string
+		expectedHookPath string
+	}{
+		{
+			description:      "hook config path is used by default",
+			hookConfigPath:   "/usr/bin/nvidia-container-runtime-hook",
+			expectedHookPath: "/usr/bin/nvidia-container-runtime-hook",
+		},
+		{
+			description:      "runtime hook path overrides hook config path",
+			hookConfigPath:   "/usr/bin/nvidia-container-runtime-hook",
+			runtimeHookPath:  "/run/nvidia/driver/usr/bin/nvidia-container-runtime-hook",
+			expectedHookPath: "/run/nvidia/driver/usr/bin/nvidia-container-runtime-hook",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeHookConfig.Path = tc.hookConfigPath
+			cfg.NVIDIAContainerRuntimeConfig.HookPath = tc.runtimeHookPath
+
+			factory := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+			)
+
+			spec := specs.Spec{}
+			err := factory.newStableRuntimeModifier().Modify(&spec)
+			require.NoError(t, err)
+
+			require.EqualValues(t,
+				[]specs.Hook{
+					{
+						Path: tc.expectedHookPath,
+						Args: []string{"nvidia-container-runtime-hook", "prestart"},
+					},
+				},
+				spec.Hooks.Prestart,
+			)
+		})
+	}
+}
+
+func TestStableRuntimeModifierDriverRoot(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description  string
+		driverRoot   string
+		expectedArgs []string
+	}{
+		{
+			description:  "no driver root",
+			expectedArgs: []string{"nvidia-container-runtime-hook", "prestart"},
+		},
+		{
+			description:  "host driver root",
+			driverRoot:   "/",
+			expectedArgs: []string{"nvidia-container-runtime-hook", "prestart"},
+		},
+		{
+			description:  "driver root is passed to the hook",
+			driverRoot:   "/run/nvidia/driver",
+			expectedArgs: []string{"nvidia-container-runtime-hook", "-driver-root=/run/nvidia/driver", "prestart"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeHookConfig.Path = "/usr/bin/nvidia-container-runtime-hook"
+			cfg.NVIDIAContainerCLIConfig.Root = tc.driverRoot
+
+			factory := createFactory(
+				WithLogger(logger),
+				WithConfig(cfg),
+			)
+
+			spec := specs.Spec{}
+			require.NoError(t, factory.newStableRuntimeModifier().Modify(&spec))
+			require.Len(t, spec.Hooks.Prestart, 1)
+			require.Equal(t, tc.expectedArgs, spec.Hooks.Prestart[0].Args)
+		})
+	}
+}
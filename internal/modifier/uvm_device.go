@@ -0,0 +1,200 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/system/nvdevices"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/system/nvmodules"
+)
+
+// uvmDevicePollInterval is the interval at which the existence of the
+// /dev/nvidia-uvm device node is checked after loading the kernel module.
+const uvmDevicePollInterval = 10 * time.Millisecond
+
+// loadUVMModule loads the nvidia-uvm kernel module for the specified driver
+// root. This is a variable so that it can be overridden in tests.
+var loadUVMModule = func(logger logger.Interface, driverRoot string) error {
+	modules := nvmodules.New(
+		nvmodules.WithLogger(logger),
+		nvmodules.WithRoot(driverRoot),
+	)
+	return modules.Load("nvidia-uvm")
+}
+
+// createUVMDeviceNodes creates the nvidia-uvm device nodes at the specified
+// dev root. This is a variable so that it can be overridden in tests.
+var createUVMDeviceNodes = func(logger logger.Interface, devRoot string) error {
+	devices, err := nvdevices.New(
+		nvdevices.WithLogger(logger),
+		nvdevices.WithDevRoot(devRoot),
+	)
+	if err != nil {
+		return err
+	}
+	for _, node := range []string{"nvidia-uvm", "nvidia-uvm-tools"} {
+		if err := devices.CreateNVIDIADevice(node); err != nil {
+			return fmt.Errorf("failed to create device node %s: %w", node, err)
+		}
+	}
+	return nil
+}
+
+// uvmDeviceNodes are the container paths of the nvidia-uvm device nodes.
+var uvmDeviceNodes = []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"}
+
+type uvmDeviceCheck struct {
+	logger     logger.Interface
+	image      *image.CUDA
+	modifier   oci.SpecModifier
+	driverRoot string
+	devRoot    string
+	onMissing  string
+	timeout    time.Duration
+}
+
+// withUVMDeviceCheck wraps the specified modifier so that a missing
+// /dev/nvidia-uvm device node is handled for a container that requests GPUs.
+// If no uvm-device.on-missing action is configured, the modifier is returned
+// as is.
+func (f *Factory) withUVMDeviceCheck(modifier oci.SpecModifier) oci.SpecModifier {
+	cfg := f.cfg.NVIDIAContainerRuntimeConfig.UVMDevice
+	if cfg.OnMissing == "" {
+		return modifier
+	}
+	// The timeout has been validated when the config was loaded.
+	timeout, _ := cfg.GetTimeout()
+
+	driverRoot := "/"
+	devRoot := "/"
+	if f.driver != nil {
+		if f.driver.Root != "" {
+			driverRoot = f.driver.Root
+		}
+		if f.driver.DevRoot != "" {
+			devRoot = f.driver.DevRoot
+		}
+	}
+
+	return &uvmDeviceCheck{
+		logger:     f.logger,
+		image:      f.image,
+		modifier:   modifier,
+		driverRoot: driverRoot,
+		devRoot:    devRoot,
+		onMissing:  cfg.OnMissing,
+		timeout:    timeout,
+	}
+}
+
+// Modify checks whether the /dev/nvidia-uvm device node exists for a
+// container that requests GPUs and applies the configured action if it does
+// not. For the skip action, the nvidia-uvm device nodes injected by the
+// wrapped modifier are removed from the spec. For the load action, the
+// device nodes are created before the wrapped modifier is applied.
+func (c *uvmDeviceCheck) Modify(spec *specs.Spec) error {
+	if c.image == nil || len(c.image.VisibleDevices()) == 0 {
+		return c.modifier.Modify(spec)
+	}
+
+	uvmDevicePath := filepath.Join(c.devRoot, "dev", "nvidia-uvm")
+	if deviceNodeExists(uvmDevicePath) {
+		return c.modifier.Modify(spec)
+	}
+
+	switch c.onMissing {
+	case config.UVMDeviceOnMissingSkip:
+		c.logger.Warningf("Device node %v does not exist; the nvidia-uvm device will not be injected", uvmDevicePath)
+		return c.withoutInjectedUVMDeviceNodes(spec)
+	case config.UVMDeviceOnMissingLoad:
+		c.logger.Infof("Device node %v does not exist; loading the nvidia-uvm kernel module", uvmDevicePath)
+		if err := loadUVMModule(c.logger, c.driverRoot); err != nil {
+			return fmt.Errorf("failed to load the nvidia-uvm kernel module: %w", err)
+		}
+		if err := createUVMDeviceNodes(c.logger, c.devRoot); err != nil {
+			return fmt.Errorf("failed to create the nvidia-uvm device nodes: %w", err)
+		}
+		if err := c.waitForDeviceNode(uvmDevicePath); err != nil {
+			return err
+		}
+	}
+	return c.modifier.Modify(spec)
+}
+
+// withoutInjectedUVMDeviceNodes applies the wrapped modifier and removes the
+// nvidia-uvm device nodes that it injected. Device nodes that were already
+// present in the spec are left untouched.
+func (c *uvmDeviceCheck) withoutInjectedUVMDeviceNodes(spec *specs.Spec) error {
+	if spec == nil {
+		return c.modifier.Modify(spec)
+	}
+
+	existingDevices := make(map[string]bool)
+	if spec.Linux != nil {
+		for _, device := range spec.Linux.Devices {
+			existingDevices[device.Path] = true
+		}
+	}
+
+	if err := c.modifier.Modify(spec); err != nil {
+		return err
+	}
+
+	if spec.Linux == nil {
+		return nil
+	}
+	var devices []specs.LinuxDevice
+	for _, device := range spec.Linux.Devices {
+		if !existingDevices[device.Path] && slices.Contains(uvmDeviceNodes, device.Path) {
+			c.logger.Debugf("Removing device node %v", device.Path)
+			continue
+		}
+		devices = append(devices, device)
+	}
+	spec.Linux.Devices = devices
+	return nil
+}
+
+// waitForDeviceNode waits until the specified device node exists or the
+// configured timeout has elapsed.
+func (c *uvmDeviceCheck) waitForDeviceNode(path string) error {
+	deadline := time.Now().Add(c.timeout)
+	for !deviceNodeExists(path) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v waiting for device node %v", c.timeout, path)
+		}
+		time.Sleep(uvmDevicePollInterval)
+	}
+	return nil
+}
+
+func deviceNodeExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
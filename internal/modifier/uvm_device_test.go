@@ -0,0 +1,211 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestUVMDeviceCheck(t *testing.T) {
+	testCases := []struct {
+		description          string
+		env                  []string
+		onMissing            string
+		timeout              string
+		uvmDeviceExists      bool
+		loadError            error
+		createDeviceNode     bool
+		expectedLoadCalled   bool
+		expectedCreateCalled bool
+		expectedError        string
+		expectedDevices      []string
+	}{
+		{
+			description:     "check disabled",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			uvmDeviceExists: false,
+			expectedDevices: []string{"/dev/nvidia0", "/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+		},
+		{
+			description:     "no devices requested",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=void"},
+			onMissing:       config.UVMDeviceOnMissingSkip,
+			expectedDevices: []string{"/dev/nvidia0", "/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+		},
+		{
+			description:     "device node present",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			onMissing:       config.UVMDeviceOnMissingSkip,
+			uvmDeviceExists: true,
+			expectedDevices: []string{"/dev/nvidia0", "/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+		},
+		{
+			description:     "device node present is not loaded",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			onMissing:       config.UVMDeviceOnMissingLoad,
+			uvmDeviceExists: true,
+			expectedDevices: []string{"/dev/nvidia0", "/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+		},
+		{
+			description:     "device node absent is skipped",
+			env:             []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			onMissing:       config.UVMDeviceOnMissingSkip,
+			expectedDevices: []string{"/dev/nvidia0"},
+		},
+		{
+			description:          "device node absent is loaded",
+			env:                  []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			onMissing:            config.UVMDeviceOnMissingLoad,
+			createDeviceNode:     true,
+			expectedLoadCalled:   true,
+			expectedCreateCalled: true,
+			expectedDevices:      []string{"/dev/nvidia0", "/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+		},
+		{
+			description:        "module load fails",
+			env:                []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			onMissing:          config.UVMDeviceOnMissingLoad,
+			loadError:          fmt.Errorf("modprobe failed"),
+			expectedLoadCalled: true,
+			expectedError:      "failed to load the nvidia-uvm kernel module: modprobe failed",
+		},
+		{
+			description:          "device node does not appear",
+			env:                  []string{"NVIDIA_VISIBLE_DEVICES=all"},
+			onMissing:            config.UVMDeviceOnMissingLoad,
+			timeout:              "10ms",
+			expectedLoadCalled:   true,
+			expectedCreateCalled: true,
+			expectedError:        "timed out after 10ms waiting for device node",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			testLogger, _ := testlog.NewNullLogger()
+
+			devRoot := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev"), 0755))
+			uvmDevicePath := filepath.Join(devRoot, "dev", "nvidia-uvm")
+			if tc.uvmDeviceExists {
+				require.NoError(t, os.WriteFile(uvmDevicePath, nil, 0600))
+			}
+
+			var loadCalled, createCalled bool
+			defer func(f func(logger.Interface, string) error) { loadUVMModule = f }(loadUVMModule)
+			loadUVMModule = func(_ logger.Interface, _ string) error {
+				loadCalled = true
+				return tc.loadError
+			}
+			defer func(f func(logger.Interface, string) error) { createUVMDeviceNodes = f }(createUVMDeviceNodes)
+			createUVMDeviceNodes = func(_ logger.Interface, root string) error {
+				createCalled = true
+				require.Equal(t, devRoot, root)
+				if tc.createDeviceNode {
+					return os.WriteFile(uvmDevicePath, nil, 0600)
+				}
+				return nil
+			}
+
+			cfg := &config.Config{}
+			cfg.NVIDIAContainerRuntimeConfig.UVMDevice.OnMissing = tc.onMissing
+			cfg.NVIDIAContainerRuntimeConfig.UVMDevice.Timeout = tc.timeout
+
+			cudaImage, err := image.New(image.WithEnv(tc.env))
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithLogger(testLogger),
+				WithConfig(cfg),
+				WithImage(&cudaImage),
+				WithDriver(root.New(root.WithDevRoot(devRoot))),
+			)
+
+			injectDevices := modifierFunc(func(spec *specs.Spec) error {
+				spec.Linux = &specs.Linux{}
+				for _, path := range []string{"/dev/nvidia0", "/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"} {
+					spec.Linux.Devices = append(spec.Linux.Devices, specs.LinuxDevice{Path: path})
+				}
+				return nil
+			})
+
+			spec := &specs.Spec{}
+			err = f.withUVMDeviceCheck(injectDevices).Modify(spec)
+			require.Equal(t, tc.expectedLoadCalled, loadCalled)
+			require.Equal(t, tc.expectedCreateCalled, createCalled)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				require.Equal(t, &specs.Spec{}, spec)
+				return
+			}
+			require.NoError(t, err)
+
+			var devices []string
+			for _, device := range spec.Linux.Devices {
+				devices = append(devices, device.Path)
+			}
+			require.Equal(t, tc.expectedDevices, devices)
+		})
+	}
+}
+
+func TestUVMDeviceCheckSkipRemovesCgroupRules(t *testing.T) {
+	testLogger, _ := testlog.NewNullLogger()
+
+	cfg := &config.Config{}
+	cfg.NVIDIAContainerRuntimeConfig.UVMDevice.OnMissing = config.UVMDeviceOnMissingSkip
+
+	cudaImage, err := image.New(image.WithEnv([]string{"NVIDIA_VISIBLE_DEVICES=all"}))
+	require.NoError(t, err)
+
+	f := createFactory(
+		WithLogger(testLogger),
+		WithConfig(cfg),
+		WithImage(&cudaImage),
+		WithDriver(root.New(root.WithDevRoot(t.TempDir()))),
+	)
+
+	injectDevices := modifierFunc(func(spec *specs.Spec) error {
+		spec.Linux = &specs.Linux{
+			Devices: []specs.LinuxDevice{
+				{Path: "/dev/nvidia0", Type: "c", Major: 195, Minor: 0},
+				{Path: "/dev/nvidia-uvm", Type: "c", Major: 510, Minor: 0},
+			},
+		}
+		return nil
+	})
+
+	spec := &specs.Spec{}
+	require.NoError(t, f.withDeviceCgroupRules(f.withUVMDeviceCheck(injectDevices)).Modify(spec))
+
+	major, minor := int64(195), int64(0)
+	require.Equal(t, []specs.LinuxDevice{{Path: "/dev/nvidia0", Type: "c", Major: 195, Minor: 0}}, spec.Linux.Devices)
+	require.Equal(t, []specs.LinuxDeviceCgroup{{Allow: true, Type: "c", Major: &major, Minor: &minor, Access: "rwm"}}, spec.Linux.Resources.Devices)
+}
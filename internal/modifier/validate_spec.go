@@ -0,0 +1,94 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// validateSpec is a spec modifier that wraps another modifier and checks the
+// modified spec for conditions that are invalid for the low-level runtime.
+type validateSpec struct {
+	modifier oci.SpecModifier
+}
+
+var _ oci.SpecModifier = (*validateSpec)(nil)
+
+// withSpecValidation wraps the specified modifier so that the modified spec is
+// validated before it is handed off to the low-level runtime. The validation
+// is only performed if the validate-modified-spec feature is enabled.
+func (f *Factory) withSpecValidation(modifier oci.SpecModifier) oci.SpecModifier {
+	if !f.cfg.Features.ValidateModifiedSpec.IsEnabled() {
+		return modifier
+	}
+	return &validateSpec{
+		modifier: modifier,
+	}
+}
+
+// Modify applies the wrapped modifier and validates the resultant spec.
+func (m *validateSpec) Modify(spec *specs.Spec) error {
+	if err := m.modifier.Modify(spec); err != nil {
+		return err
+	}
+	if spec == nil {
+		return nil
+	}
+	if err := validateMounts(spec.Mounts); err != nil {
+		return fmt.Errorf("invalid modified OCI spec: %w", err)
+	}
+	return nil
+}
+
+// validateMounts checks that the destinations of the specified mounts are
+// unique and that the sources of bind mounts exist.
+func validateMounts(mounts []specs.Mount) error {
+	var errs []error
+	seenDestinations := make(map[string]bool)
+	for _, mount := range mounts {
+		destination := filepath.Clean(mount.Destination)
+		if seenDestinations[destination] {
+			errs = append(errs, fmt.Errorf("duplicate mount destination %v", destination))
+		}
+		seenDestinations[destination] = true
+
+		if !isBindMount(mount) {
+			continue
+		}
+		if _, err := os.Lstat(mount.Source); err != nil {
+			errs = append(errs, fmt.Errorf("missing source for mount to %v: %w", destination, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isBindMount checks whether the specified mount is a bind mount.
+func isBindMount(mount specs.Mount) bool {
+	if mount.Type == "bind" {
+		return true
+	}
+	return slices.Contains(mount.Options, "bind") || slices.Contains(mount.Options, "rbind")
+}
@@ -0,0 +1,105 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+)
+
+func TestValidateSpec(t *testing.T) {
+	source := t.TempDir()
+	data := specs.Mount{Source: source, Destination: "/data", Type: "bind"}
+	missing := specs.Mount{Source: filepath.Join(source, "missing"), Destination: "/missing", Options: []string{"rbind"}}
+	tmpfs := specs.Mount{Source: "tmpfs", Destination: "/tmp", Type: "tmpfs"}
+
+	testCases := []struct {
+		description   string
+		validate      bool
+		spec          *specs.Spec
+		mounts        []specs.Mount
+		expectedError string
+	}{
+		{
+			description: "valid mounts are accepted",
+			validate:    true,
+			spec:        &specs.Spec{Mounts: []specs.Mount{tmpfs}},
+			mounts:      []specs.Mount{data},
+		},
+		{
+			description:   "duplicate destination is rejected",
+			validate:      true,
+			spec:          &specs.Spec{Mounts: []specs.Mount{data}},
+			mounts:        []specs.Mount{{Source: source, Destination: "/data/", Type: "bind"}},
+			expectedError: "invalid modified OCI spec: duplicate mount destination /data",
+		},
+		{
+			description:   "missing bind mount source is rejected",
+			validate:      true,
+			spec:          &specs.Spec{},
+			mounts:        []specs.Mount{missing},
+			expectedError: "invalid modified OCI spec: missing source for mount to /missing",
+		},
+		{
+			description: "missing source for non-bind mount is accepted",
+			validate:    true,
+			spec:        &specs.Spec{},
+			mounts:      []specs.Mount{tmpfs},
+		},
+		{
+			description: "duplicate destination is accepted if validation is disabled",
+			validate:    false,
+			spec:        &specs.Spec{Mounts: []specs.Mount{data}},
+			mounts:      []specs.Mount{data},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfgToml, err := config.TreeFromMap(map[string]any{
+				"features": map[string]any{
+					"validate-modified-spec": tc.validate,
+				},
+			})
+			require.NoError(t, err)
+			cfg, err := cfgToml.Config()
+			require.NoError(t, err)
+
+			f := createFactory(
+				WithConfig(cfg),
+			)
+
+			appendMounts := modifierFunc(func(spec *specs.Spec) error {
+				spec.Mounts = append(spec.Mounts, tc.mounts...)
+				return nil
+			})
+
+			err = f.withSpecValidation(appendMounts).Modify(tc.spec)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// vmPassthrough is a spec modifier that wraps another modifier and adapts
+// its edits for VM-based low-level runtimes such as Kata Containers.
+type vmPassthrough struct {
+	logger   logger.Interface
+	modifier oci.SpecModifier
+}
+
+var _ oci.SpecModifier = (*vmPassthrough)(nil)
+
+// withVMPassthrough wraps the specified modifier if the low-level runtime is
+// VM-based. For such runtimes host paths cannot be bind-mounted into the
+// container and hooks run outside of the VM, so the mounts and CDI hooks that
+// the wrapped modifier adds are removed. The NVIDIA Container Runtime Hook
+// injected in legacy mode is kept. The device nodes that the wrapped modifier
+// adds are kept in the spec so that the runtime can pass the devices through
+// to the VM.
+func (f *Factory) withVMPassthrough(modifier oci.SpecModifier) oci.SpecModifier {
+	if !f.vmRuntime {
+		return modifier
+	}
+	return &vmPassthrough{
+		logger:   f.logger,
+		modifier: modifier,
+	}
+}
+
+// Modify applies the wrapped modifier and removes the mounts and CDI hooks
+// that it added. Mounts, hooks, and device nodes that were already present in
+// the spec are left untouched.
+func (m *vmPassthrough) Modify(spec *specs.Spec) error {
+	return modifyInjected(spec, m.modifier, func(before *specSnapshot) {
+		before.removeInjectedMounts(spec, func(mount specs.Mount) bool {
+			m.logger.Debugf("Removing mount of %v to %v for VM-based runtime", mount.Source, mount.Destination)
+			return true
+		})
+		before.removeInjectedHooks(spec, func(hook specs.Hook) bool {
+			if isNVIDIAContainerRuntimeHook(&hook) {
+				return false
+			}
+			m.logger.Debugf("Removing hook %v for VM-based runtime", hook.Path)
+			return true
+		})
+	})
+}
@@ -0,0 +1,172 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+func TestVMPassthrough(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	injected := oci.SpecModifier(modifierFunc(func(spec *specs.Spec) error {
+		spec.Mounts = append(spec.Mounts,
+			specs.Mount{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+		)
+		if spec.Hooks == nil {
+			spec.Hooks = &specs.Hooks{}
+		}
+		spec.Hooks.CreateContainer = append(spec.Hooks.CreateContainer,
+			specs.Hook{Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}},
+		)
+		if spec.Linux == nil {
+			spec.Linux = &specs.Linux{}
+		}
+		spec.Linux.Devices = append(spec.Linux.Devices,
+			specs.LinuxDevice{Path: "/dev/nvidiactl", Type: "c", Major: 195, Minor: 255},
+			specs.LinuxDevice{Path: "/dev/nvidia0", Type: "c", Major: 195, Minor: 0},
+		)
+		return nil
+	}))
+
+	testCases := []struct {
+		description  string
+		vmRuntime    bool
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description: "non-VM runtime keeps mounts and hooks",
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/host/libcuda.so.1", Destination: "/usr/lib/x86_64-linux-gnu/libcuda.so.1"},
+				},
+				Hooks: &specs.Hooks{
+					CreateContainer: []specs.Hook{
+						{Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}},
+					},
+				},
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/nvidiactl", Type: "c", Major: 195, Minor: 255},
+						{Path: "/dev/nvidia0", Type: "c", Major: 195, Minor: 0},
+					},
+				},
+			},
+		},
+		{
+			description: "VM runtime passes devices through instead of mounts",
+			vmRuntime:   true,
+			spec:        &specs.Spec{},
+			expectedSpec: &specs.Spec{
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/nvidiactl", Type: "c", Major: 195, Minor: 255},
+						{Path: "/dev/nvidia0", Type: "c", Major: 195, Minor: 0},
+					},
+				},
+			},
+		},
+		{
+			description: "VM runtime keeps existing mounts, hooks, and devices",
+			vmRuntime:   true,
+			spec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/data", Destination: "/data"},
+				},
+				Hooks: &specs.Hooks{
+					Poststop: []specs.Hook{
+						{Path: "/usr/bin/cleanup"},
+					},
+				},
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/fuse", Type: "c", Major: 10, Minor: 229},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{Source: "/data", Destination: "/data"},
+				},
+				Hooks: &specs.Hooks{
+					Poststop: []specs.Hook{
+						{Path: "/usr/bin/cleanup"},
+					},
+				},
+				Linux: &specs.Linux{
+					Devices: []specs.LinuxDevice{
+						{Path: "/dev/fuse", Type: "c", Major: 10, Minor: 229},
+						{Path: "/dev/nvidiactl", Type: "c", Major: 195, Minor: 255},
+						{Path: "/dev/nvidia0", Type: "c", Major: 195, Minor: 0},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			f := createFactory(
+				WithLogger(logger),
+				WithConfig(&config.Config{}),
+				WithVMRuntime(tc.vmRuntime),
+			)
+
+			err := f.withVMPassthrough(injected).Modify(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
+
+func TestVMPassthroughKeepsLegacyHook(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	cfg := &config.Config{}
+	cfg.NVIDIAContainerRuntimeHookConfig.Path = "/usr/bin/nvidia-container-runtime-hook"
+	f := createFactory(
+		WithLogger(logger),
+		WithConfig(cfg),
+		WithVMRuntime(true),
+	)
+
+	spec := &specs.Spec{}
+	require.NoError(t, f.withVMPassthrough(f.newStableRuntimeModifier()).Modify(spec))
+	require.EqualValues(t,
+		&specs.Spec{
+			Hooks: &specs.Hooks{
+				Prestart: []specs.Hook{
+					{
+						Path: "/usr/bin/nvidia-container-runtime-hook",
+						Args: []string{"nvidia-container-runtime-hook", "prestart"},
+					},
+				},
+			},
+		},
+		spec,
+	)
+}
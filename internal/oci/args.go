@@ -81,6 +81,17 @@ func GetSpecFilePath(bundleDir string) string {
 	return specFilePath
 }
 
+// ResolveSpecFilePath returns the path to the OCI specification file for the
+// given bundle directory and spec path override. If the override is empty, the
+// default spec file in the bundle directory is used. The override is expected
+// to be relative and is resolved relative to the bundle directory.
+func ResolveSpecFilePath(bundleDir string, specPath string) string {
+	if specPath == "" {
+		return GetSpecFilePath(bundleDir)
+	}
+	return filepath.Join(bundleDir, specPath)
+}
+
 // IsBundleFlag is a helper function that checks wither the specified argument represents
 // a bundle flag (--bundle or -b)
 func IsBundleFlag(arg string) bool {
@@ -152,6 +152,53 @@ func TestGetSpecFilePathAppendsFilename(t *testing.T) {
 	}
 }
 
+func TestResolveSpecFilePath(t *testing.T) {
+	testCases := []struct {
+		description string
+		argv        []string
+		specPath    string
+		expected    string
+	}{
+		{
+			description: "no bundle and no override",
+			argv:        []string{"create"},
+			expected:    "config.json",
+		},
+		{
+			description: "bundle and no override",
+			argv:        []string{"--bundle", "/run/bundle", "create"},
+			expected:    "/run/bundle/config.json",
+		},
+		{
+			description: "bundle with custom filename",
+			argv:        []string{"-b=/run/bundle", "create"},
+			specPath:    "spec.json",
+			expected:    "/run/bundle/spec.json",
+		},
+		{
+			description: "no bundle with custom filename",
+			argv:        []string{"create"},
+			specPath:    "spec.json",
+			expected:    "spec.json",
+		},
+		{
+			description: "bundle with override in subdirectory",
+			argv:        []string{"--bundle=/run/bundle", "create"},
+			specPath:    "specs/container.json",
+			expected:    "/run/bundle/specs/container.json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			bundleDir, err := GetBundleDir(tc.argv)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, ResolveSpecFilePath(bundleDir, tc.specPath))
+		})
+	}
+}
+
 func TestHasCreateSubcommand(t *testing.T) {
 	testCases := []struct {
 		args         []string
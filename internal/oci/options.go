@@ -22,6 +22,7 @@ import "github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 type options struct {
 	logger            logger.Interface
 	allowUnkownFields bool
+	specPath          string
 }
 
 type Option func(*options)
@@ -32,6 +33,15 @@ func WithLogger(logger logger.Interface) Option {
 	}
 }
 
+// WithSpecPath sets the path to the OCI specification file that is loaded. The
+// path is resolved relative to the bundle directory. The modified spec is
+// written to config.json in the bundle directory.
+func WithSpecPath(specPath string) Option {
+	return func(o *options) {
+		o.specPath = specPath
+	}
+}
+
 func WithAllowUnknownFields(allowUnknownFields bool) Option {
 	return func(o *options) {
 		o.allowUnkownFields = allowUnknownFields
@@ -17,7 +17,10 @@
 package oci
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
@@ -31,9 +34,37 @@ func NewLowLevelRuntime(logger logger.Interface, candidates []string) (Runtime,
 	if err != nil {
 		return nil, fmt.Errorf("error locating runtime: %v", err)
 	}
+	if err := assertIsNotSelf(runtimePath); err != nil {
+		return nil, err
+	}
 	return NewRuntimeForPath(logger, runtimePath)
 }
 
+var errRuntimeIsSelf = errors.New("low-level runtime refers to the current executable")
+
+// assertIsNotSelf checks whether the specified runtime path refers to the
+// executable of the current process. This would be the case if a wrapper such
+// as the nvidia-container-runtime is configured as its own low-level runtime
+// and would result in the runtime invoking itself indefinitely.
+func assertIsNotSelf(runtimePath string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+	selfInfo, err := os.Stat(self)
+	if err != nil {
+		return nil
+	}
+	runtimeInfo, err := os.Stat(runtimePath)
+	if err != nil {
+		return nil
+	}
+	if os.SameFile(selfInfo, runtimeInfo) {
+		return fmt.Errorf("%w: %v is the same file as %v; check the configured runtimes", errRuntimeIsSelf, runtimePath, self)
+	}
+	return nil
+}
+
 // findRuntime checks elements in a list of supplied candidates for a matching executable in the PATH.
 // The absolute path to the first match is returned.
 func findRuntime(logger logger.Interface, candidates []string) (string, error) {
@@ -53,3 +84,18 @@ func findRuntime(logger logger.Interface, candidates []string) (string, error) {
 
 	return "", fmt.Errorf("no runtime binary found from candidate list: %v", candidates)
 }
+
+// IsVMRuntime checks whether the low-level runtime at the specified path is
+// one of the specified VM-based runtimes (e.g. Kata Containers). A VM-based
+// runtime matches if either its path or the name of the executable is
+// specified. For such runtimes host paths cannot be bind-mounted into the
+// container and devices must be passed through to the VM instead.
+func IsVMRuntime(runtimePath string, vmRuntimes []string) bool {
+	name := filepath.Base(runtimePath)
+	for _, vmRuntime := range vmRuntimes {
+		if vmRuntime == runtimePath || vmRuntime == name {
+			return true
+		}
+	}
+	return false
+}
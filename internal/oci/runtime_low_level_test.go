@@ -0,0 +1,93 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package oci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLowLevelRuntime(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	self, err := os.Executable()
+	require.NoError(t, err)
+
+	selfSymlink := filepath.Join(t.TempDir(), "runc")
+	require.NoError(t, os.Symlink(self, selfSymlink))
+
+	testCases := []struct {
+		description   string
+		candidates    []string
+		expectedError error
+	}{
+		{
+			description: "other executable is accepted",
+			candidates:  []string{"/bin/sh"},
+		},
+		{
+			description:   "current executable is rejected",
+			candidates:    []string{self},
+			expectedError: errRuntimeIsSelf,
+		},
+		{
+			description:   "symlink to the current executable is rejected",
+			candidates:    []string{selfSymlink},
+			expectedError: errRuntimeIsSelf,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r, err := NewLowLevelRuntime(logger, tc.candidates)
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				require.Nil(t, r)
+				return
+			}
+			require.NotNil(t, r)
+		})
+	}
+}
+
+func TestIsVMRuntime(t *testing.T) {
+	vmRuntimes := []string{"kata-runtime", "/opt/kata/bin/kata-qemu"}
+
+	testCases := []struct {
+		runtimePath string
+		vmRuntimes  []string
+		expected    bool
+	}{
+		{runtimePath: "/usr/bin/runc", vmRuntimes: vmRuntimes},
+		{runtimePath: "/opt/kata/bin/kata-runtime", vmRuntimes: vmRuntimes, expected: true},
+		{runtimePath: "/opt/kata/bin/kata-qemu", vmRuntimes: vmRuntimes, expected: true},
+		{runtimePath: "/usr/local/bin/kata-qemu", vmRuntimes: vmRuntimes},
+		{runtimePath: "/usr/local/bin/containerd-shim-kata-v2", vmRuntimes: vmRuntimes},
+		{runtimePath: "/opt/kata/bin/kata-runtime"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.runtimePath, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsVMRuntime(tc.runtimePath, tc.vmRuntimes))
+		})
+	}
+}
@@ -61,10 +61,17 @@ func NewSpec(args []string, opts ...Option) (Spec, error) {
 	}
 	o.logger.Debugf("Using bundle directory: %v", bundleDir)
 
-	ociSpecPath := GetSpecFilePath(bundleDir)
+	ociSpecPath := ResolveSpecFilePath(bundleDir, o.specPath)
 	o.logger.Infof("Using OCI specification file path: %v", ociSpecPath)
 
-	ociSpec := NewFileSpec(ociSpecPath, !o.allowUnkownFields)
+	ociSpec := NewFileSpec(ociSpecPath, !o.allowUnkownFields).(*fileSpec)
+	// The modified spec is always written to the spec file in the bundle
+	// directory since this is the file that is read by the low-level runtime
+	// and the container lifecycle hooks.
+	if bundleSpecPath := GetSpecFilePath(bundleDir); bundleSpecPath != ociSpecPath {
+		o.logger.Infof("Writing modified OCI specification to: %v", bundleSpecPath)
+		ociSpec.flushPath = bundleSpecPath
+	}
 
 	return ociSpec, nil
 }
@@ -28,6 +28,9 @@ import (
 type fileSpec struct {
 	memorySpec
 	path string
+	// flushPath is the path to which the spec is written. If this is empty,
+	// the spec is written to the path from which it was loaded.
+	flushPath string
 	loader
 }
 
@@ -94,14 +97,19 @@ func (s *fileSpec) Modify(m SpecModifier) error {
 	return s.memorySpec.Modify(m)
 }
 
-// Flush writes the stored OCI specification to the filepath specified by the path member.
+// Flush writes the stored OCI specification to the filepath specified by the
+// flushPath member or, if this is not set, the path member.
 // The file is truncated upon opening, overwriting any existing contents.
 func (s fileSpec) Flush() error {
 	if s.Spec == nil {
 		return fmt.Errorf("no OCI specification loaded")
 	}
 
-	specFile, err := os.Create(s.path)
+	path := s.path
+	if s.flushPath != "" {
+		path = s.flushPath
+	}
+	specFile, err := os.Create(path)
 	if err != nil {
 		return fmt.Errorf("error opening OCI specification file: %v", err)
 	}
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
@@ -39,3 +41,48 @@ func TestMaintainSpec(t *testing.T) {
 		require.JSONEq(t, string(inputContents), string(outputContents))
 	}
 }
+
+func TestNewSpecWithSpecPath(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	bundleDir := t.TempDir()
+	specPath := filepath.Join(bundleDir, "container.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"ociVersion": "1.0.0", "root": {"path": "rootfs"}}`), 0600))
+
+	spec, err := NewSpec(
+		[]string{"--bundle", bundleDir, "create"},
+		WithLogger(logger),
+		WithSpecPath("container.json"),
+	)
+	require.NoError(t, err)
+
+	_, err = spec.Load()
+	require.NoError(t, err)
+	require.NoError(t, spec.Modify(rootModifier("modified-rootfs")))
+	require.NoError(t, spec.Flush())
+
+	// The spec that was loaded is not modified.
+	contents, err := os.ReadFile(specPath)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"ociVersion": "1.0.0", "root": {"path": "rootfs"}}`, string(contents))
+
+	// The modified spec is written to the config.json file in the bundle
+	// directory that is read by the low-level runtime.
+	contents, err = os.ReadFile(filepath.Join(bundleDir, "config.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"ociVersion": "1.0.0", "root": {"path": "modified-rootfs"}}`, string(contents))
+
+	// The container lifecycle hooks also see the modified spec.
+	state := &State{Bundle: bundleDir}
+	containerRoot, err := state.GetContainerRoot()
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(bundleDir, "modified-rootfs"), containerRoot)
+}
+
+// rootModifier sets the root path of a spec.
+type rootModifier string
+
+func (m rootModifier) Modify(spec *specs.Spec) error {
+	spec.Root.Path = string(m)
+	return nil
+}
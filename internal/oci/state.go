@@ -59,7 +59,16 @@ func ReadContainerState(reader io.Reader) (*State, error) {
 // GetContainerRoot returns the root for the container from the associated spec. If the spec is not yet loaded, it is
 // loaded and cached.
 func (s *State) GetContainerRoot() (string, error) {
-	spec, err := s.loadMinimalSpec()
+	return s.GetContainerRootInHostRoot("")
+}
+
+// GetContainerRootInHostRoot returns the root for the container for a process
+// that has the host root filesystem mounted at the specified host root. Since
+// the bundle and root paths in the container state are host paths, these are
+// resolved relative to the host root. This is required when a hook is run in a
+// container instead of in the host mount namespace.
+func (s *State) GetContainerRootInHostRoot(hostRoot string) (string, error) {
+	spec, err := s.loadMinimalSpec(hostRoot)
 	if err != nil {
 		return "", err
 	}
@@ -70,15 +79,23 @@ func (s *State) GetContainerRoot() (string, error) {
 	}
 
 	if filepath.IsAbs(containerRoot) {
-		return containerRoot, nil
+		return inHostRoot(hostRoot, containerRoot), nil
 	}
 
-	return filepath.Join(s.Bundle, containerRoot), nil
+	return inHostRoot(hostRoot, filepath.Join(s.Bundle, containerRoot)), nil
+}
+
+// inHostRoot returns the specified host path in the host root.
+func inHostRoot(hostRoot string, path string) string {
+	if hostRoot == "" || hostRoot == "/" {
+		return path
+	}
+	return filepath.Join(hostRoot, path)
 }
 
 // loadMinimalSpec loads a reduced OCI spec associated with the container state.
-func (s *State) loadMinimalSpec() (*minimalSpec, error) {
-	specFilePath := GetSpecFilePath(s.Bundle)
+func (s *State) loadMinimalSpec(hostRoot string) (*minimalSpec, error) {
+	specFilePath := inHostRoot(hostRoot, GetSpecFilePath(s.Bundle))
 	specFile, err := os.Open(specFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open OCI spec file: %v", err)
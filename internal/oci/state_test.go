@@ -0,0 +1,92 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package oci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetContainerRootInHostRoot(t *testing.T) {
+	testCases := []struct {
+		description           string
+		hostRoot              string
+		rootPath              string
+		expectedContainerRoot string
+		expectedError         string
+	}{
+		{
+			description:           "relative root without host root",
+			rootPath:              "rootfs",
+			expectedContainerRoot: "{{ .hostRoot }}/run/bundle/rootfs",
+		},
+		{
+			description:           "relative root in host root",
+			hostRoot:              "{{ .hostRoot }}",
+			rootPath:              "rootfs",
+			expectedContainerRoot: "{{ .hostRoot }}/run/bundle/rootfs",
+		},
+		{
+			description:           "absolute root in host root",
+			hostRoot:              "{{ .hostRoot }}",
+			rootPath:              "/var/lib/containers/rootfs",
+			expectedContainerRoot: "{{ .hostRoot }}/var/lib/containers/rootfs",
+		},
+		{
+			description:   "spec outside host root",
+			hostRoot:      "{{ .hostRoot }}/missing",
+			rootPath:      "rootfs",
+			expectedError: "failed to open OCI spec file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hostRoot := t.TempDir()
+			replaceHostRoot := func(s string) string {
+				return strings.ReplaceAll(s, "{{ .hostRoot }}", hostRoot)
+			}
+
+			// The bundle path in the container state is a path on the host.
+			bundle := "/run/bundle"
+			if tc.hostRoot == "" {
+				bundle = filepath.Join(hostRoot, bundle)
+			}
+			bundleInHostRoot := filepath.Join(hostRoot, "run/bundle")
+			require.NoError(t, os.MkdirAll(bundleInHostRoot, 0755))
+			require.NoError(t, os.WriteFile(
+				filepath.Join(bundleInHostRoot, "config.json"),
+				[]byte(`{"root": {"path": "`+tc.rootPath+`"}}`),
+				0600,
+			))
+
+			s := &State{Bundle: bundle}
+			containerRoot, err := s.GetContainerRootInHostRoot(replaceHostRoot(tc.hostRoot))
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, replaceHostRoot(tc.expectedContainerRoot), containerRoot)
+		})
+	}
+}
@@ -120,6 +120,9 @@ func new(opts ...Option) (*options, error) {
 	if o.logger == nil {
 		o.logger = logger.New()
 	}
+	if o.sysfsRoot == "" {
+		o.sysfsRoot = "/sys"
+	}
 
 	if o.migCaps == nil {
 		migCaps, err := nvcaps.NewMigCaps()
@@ -44,7 +44,9 @@ func (o *options) newNvmlDGPUDiscoverer(d requiredInfo) (discover.Discover, erro
 		return nil, fmt.Errorf("error getting PCI info for device: %w", err)
 	}
 
-	drmDeviceNodes, err := drm.GetDeviceNodesByBusID(pciBusID)
+	// The DRM card and render nodes are required for graphics workloads such
+	// as headless Vulkan or EGL rendering.
+	drmDeviceNodes, err := drm.GetDeviceNodesByBusIDFromSysfs(o.sysfsRoot, pciBusID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine DRM devices for %v: %v", pciBusID, err)
 	}
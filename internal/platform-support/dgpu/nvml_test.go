@@ -17,6 +17,8 @@
 package dgpu
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
@@ -25,9 +27,11 @@ import (
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
 )
 
 // TODO: In order to properly test this, we need a mechanism to inject /
@@ -89,6 +93,78 @@ func TestNewNvmlDGPUDiscoverer(t *testing.T) {
 	}
 }
 
+func TestNewNvmlDGPUDiscovererDRMNodes(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	defer devices.SetAllForTest()()
+
+	testCases := []struct {
+		description     string
+		sysfsDRMNodes   []string
+		expectedDevices []discover.Device
+	}{
+		{
+			description:   "GPU is mapped to its card and render nodes",
+			sysfsDRMNodes: []string{"card1", "renderD129", "controlD65"},
+			expectedDevices: []discover.Device{
+				{Path: "/dev/nvidia3", HostPath: "/dev/nvidia3"},
+				{Path: "/dev/dri/card1", HostPath: "/dev/dri/card1"},
+				{Path: "/dev/dri/renderD129", HostPath: "/dev/dri/renderD129"},
+			},
+		},
+		{
+			description: "GPU without DRM nodes",
+			expectedDevices: []discover.Device{
+				{Path: "/dev/nvidia3", HostPath: "/dev/nvidia3"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devRoot := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev/dri"), 0755))
+			for _, node := range []string{"dev/nvidia3", "dev/dri/card0", "dev/dri/card1", "dev/dri/renderD128", "dev/dri/renderD129"} {
+				require.NoError(t, os.WriteFile(filepath.Join(devRoot, node), nil, 0600))
+			}
+
+			sysfsRoot := t.TempDir()
+			drmDir := filepath.Join(sysfsRoot, "bus/pci/devices/0000:45:00.0/drm")
+			require.NoError(t, os.MkdirAll(drmDir, 0755))
+			for _, node := range tc.sysfsDRMNodes {
+				require.NoError(t, os.Mkdir(filepath.Join(drmDir, node), 0755))
+			}
+
+			o, err := new(
+				WithLogger(logger),
+				WithDriver(root.New(root.WithDriverRoot(devRoot))),
+				WithHookCreator(discover.NewHookCreator()),
+			)
+			require.NoError(t, err)
+			o.sysfsRoot = sysfsRoot
+
+			device, err := device.New(&mock.Interface{}).NewDevice(&mock.Device{
+				GetMinorNumberFunc: func() (int, nvml.Return) {
+					return 3, nvml.SUCCESS
+				},
+				GetPciInfoFunc: func() (nvml.PciInfo, nvml.Return) {
+					var busID [32]uint8
+					copy(busID[:], "00000000:45:00.0")
+					return nvml.PciInfo{BusId: busID}, nvml.SUCCESS
+				},
+			})
+			require.NoError(t, err)
+
+			d, err := o.newNvmlDGPUDiscoverer(&toRequiredInfo{device})
+			require.NoError(t, err)
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, test.StripRoot(devices, devRoot))
+		})
+	}
+}
+
 func TestNewNvmlMIGDiscoverer(t *testing.T) {
 	logger, _ := testlog.NewNullLogger()
 
@@ -36,6 +36,10 @@ type options struct {
 	migCapsError error
 
 	nvsandboxutilslib nvsandboxutils.Interface
+
+	// sysfsRoot is the root used to determine the DRM device nodes
+	// associated with a GPU. This is set to /sys by default.
+	sysfsRoot string
 }
 
 type Option func(*options)
@@ -57,6 +57,7 @@ func (o options) newDiscovererFromMountSpecs(targetsByType MountSpecPathsByType)
 		),
 		"",
 		o.hookCreator,
+		discover.WithLibcudaSonameSymlink(o.libcudaSonameSymlink),
 	)
 
 	// We process the explicitly requested symlinks.
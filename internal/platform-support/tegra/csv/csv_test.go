@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
@@ -82,3 +83,61 @@ func TestGetFileList(t *testing.T) {
 		})
 	}
 }
+
+func TestParse(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	moduleRoot, _ := test.GetModuleRoot()
+
+	testCases := []struct {
+		description        string
+		filename           string
+		expectedError      bool
+		expectedMountSpecs []*MountSpec
+	}{
+		{
+			description: "all entry types are parsed",
+			filename:    "tests/input/csv_samples/simple.csv",
+			expectedMountSpecs: []*MountSpec{
+				{Type: MountSpecLib, Path: "/lib/target"},
+				{Type: MountSpecDir, Path: "/lib/target"},
+				{Type: MountSpecDev, Path: "/dev/null"},
+				{Type: MountSpecDev, Path: "full"},
+				{Type: MountSpecDev, Path: "/dev/target"},
+				{Type: MountSpecSym, Path: "/source"},
+			},
+		},
+		{
+			description: "whitespace and empty lines are ignored",
+			filename:    "tests/input/csv_samples/spaced.csv",
+			expectedMountSpecs: []*MountSpec{
+				{Type: MountSpecDev, Path: "/dev/target"},
+				{Type: MountSpecLib, Path: "/lib/target"},
+				{Type: MountSpecDir, Path: "/lib/target"},
+				{Type: MountSpecSym, Path: "/source"},
+			},
+		},
+		{
+			description: "invalid lines are skipped",
+			filename:    "tests/input/csv_samples/simple_wrong.csv",
+		},
+		{
+			description:   "non-existent file returns error",
+			filename:      "tests/input/csv_samples/NONEXISTENT.csv",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			parser := NewCSVFileParser(logger, filepath.Join(moduleRoot, tc.filename))
+
+			mountSpecs, err := parser.Parse()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMountSpecs, mountSpecs)
+		})
+	}
+}
@@ -30,6 +30,8 @@ type options struct {
 	hookCreator        discover.HookCreator
 	librarySearchPaths []string
 
+	libcudaSonameSymlink bool
+
 	// The following can be overridden for testing
 	symlinkLocator      lookup.Locator
 	symlinkChainLocator lookup.Locator
@@ -70,6 +72,14 @@ func WithLibrarySearchPaths(librarySearchPaths ...string) Option {
 	}
 }
 
+// WithLibcudaSonameSymlink sets whether the libcuda.so.1 symlink is created
+// for the injected libcuda library even if it does not exist on the host.
+func WithLibcudaSonameSymlink(libcudaSonameSymlink bool) Option {
+	return func(o *options) {
+		o.libcudaSonameSymlink = libcudaSonameSymlink
+	}
+}
+
 func WithMountSpecs(mountSpecs ...MountSpecPathsByTyper) Option {
 	return func(o *options) {
 		o.mountSpecs = mountSpecPathsByTypers(mountSpecs)
@@ -26,7 +26,6 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
-	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
 )
 
 // Run is an entry point that allows for idiomatic handling of errors
@@ -43,7 +42,8 @@ func (r rt) Run(argv []string) (rerr error) {
 		fmt.Printf("%v version %v\n", "NVIDIA Container Runtime", info.GetVersionString(fmt.Sprintf("spec: %v", specs.Version)))
 	}
 
-	cfg, err := config.GetConfig()
+	configFilePath := config.GetConfigFilePath()
+	cfg, err := config.GetConfigFrom(configFilePath)
 	if err != nil {
 		return fmt.Errorf("error loading config: %v", err)
 	}
@@ -52,6 +52,7 @@ func (r rt) Run(argv []string) (rerr error) {
 		cfg.NVIDIAContainerRuntimeConfig.LogLevel,
 		argv,
 	)
+	r.logger.Debugf("Using config file %v", configFilePath)
 	defer func() {
 		if rerr != nil {
 			r.logger.Errorf("%v", rerr)
@@ -69,18 +70,15 @@ func (r rt) Run(argv []string) (rerr error) {
 	//nolint:staticcheck  // TODO(elezar): We should swith the nvidia-container-runtime from using nvidia-ctk to using nvidia-cdi-hook.
 	cfg.NVIDIACTKConfig.Path = config.ResolveNVIDIACTKPath(&logger.NullLogger{}, cfg.NVIDIACTKConfig.Path)
 	cfg.NVIDIAContainerRuntimeHookConfig.Path = config.ResolveNVIDIAContainerRuntimeHookPath(&logger.NullLogger{}, cfg.NVIDIAContainerRuntimeHookConfig.Path)
+	if cfg.NVIDIAContainerRuntimeConfig.HookPath != "" {
+		cfg.NVIDIAContainerRuntimeConfig.HookPath = config.ResolveNVIDIAContainerRuntimeHookPath(&logger.NullLogger{}, cfg.NVIDIAContainerRuntimeConfig.HookPath)
+	}
 
 	// Log the config at Trace to allow for debugging if required.
 	r.logger.Tracef("Running with config: %+v", cfg)
 
-	driver := root.New(
-		root.WithLogger(r.logger),
-		root.WithDriverRoot(cfg.NVIDIAContainerCLIConfig.Root),
-		root.WithDevRoot(cfg.NVIDIAContainerCLIConfig.Root),
-	)
-
 	r.logger.Tracef("Command line arguments: %v", argv)
-	runtime, err := newNVIDIAContainerRuntime(r.logger, driver, cfg, argv)
+	runtime, err := newNVIDIAContainerRuntime(r.logger, cfg, argv)
 	if err != nil {
 		return fmt.Errorf("failed to create NVIDIA Container Runtime: %v", err)
 	}
@@ -30,7 +30,7 @@ import (
 )
 
 // newNVIDIAContainerRuntime is a factory method that constructs a runtime based on the selected configuration and specified logger
-func newNVIDIAContainerRuntime(logger logger.Interface, driver *root.Driver, cfg *config.Config, argv []string) (oci.Runtime, error) {
+func newNVIDIAContainerRuntime(logger logger.Interface, cfg *config.Config, argv []string) (oci.Runtime, error) {
 	lowLevelRuntime, err := oci.NewLowLevelRuntime(logger, cfg.NVIDIAContainerRuntimeConfig.Runtimes)
 	if err != nil {
 		return nil, fmt.Errorf("error constructing low-level runtime: %v", err)
@@ -42,15 +42,23 @@ func newNVIDIAContainerRuntime(logger logger.Interface, driver *root.Driver, cfg
 		return lowLevelRuntime, nil
 	}
 
+	driver := newDriver(logger, cfg)
+
 	ociSpec, err := oci.NewSpec(argv,
 		oci.WithLogger(logger),
 		oci.WithAllowUnknownFields(cfg.Features.AllowUnknownOCISpecFields.IsEnabled()),
+		oci.WithSpecPath(cfg.NVIDIAContainerRuntimeConfig.SpecPath),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error constructing OCI specification: %v", err)
 	}
 
-	specModifier, err := newSpecModifier(logger, driver, cfg, ociSpec)
+	vmRuntime := oci.IsVMRuntime(lowLevelRuntime.String(), cfg.NVIDIAContainerRuntimeConfig.VMRuntimes)
+	if vmRuntime {
+		logger.Debugf("Low-level runtime %v is VM-based; devices will be passed through", lowLevelRuntime.String())
+	}
+
+	specModifier, err := newSpecModifier(logger, driver, cfg, ociSpec, vmRuntime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct OCI spec modifier: %v", err)
 	}
@@ -66,8 +74,27 @@ func newNVIDIAContainerRuntime(logger logger.Interface, driver *root.Driver, cfg
 	return r, nil
 }
 
+// newDriver constructs the driver for the configured driver root. If no driver
+// root is configured, we probe common locations such as a driver container
+// root. Since this is only required when modifying the OCI spec, this is only
+// done for the create subcommand. A detected driver root is also set in the
+// config so that it is passed to the NVIDIA Container Runtime Hook.
+func newDriver(logger logger.Interface, cfg *config.Config) *root.Driver {
+	driverRoot := cfg.NVIDIAContainerCLIConfig.Root
+	if driverRoot == "" {
+		driverRoot = root.DetectDriverRoot(logger)
+		cfg.NVIDIAContainerCLIConfig.Root = driverRoot
+	}
+
+	return root.New(
+		root.WithLogger(logger),
+		root.WithDriverRoot(driverRoot),
+		root.WithDevRoot(driverRoot),
+	)
+}
+
 // newSpecModifier is a factory method that creates constructs an OCI spec modifer based on the provided config.
-func newSpecModifier(logger logger.Interface, driver *root.Driver, cfg *config.Config, ociSpec oci.Spec) (oci.SpecModifier, error) {
+func newSpecModifier(logger logger.Interface, driver *root.Driver, cfg *config.Config, ociSpec oci.Spec, vmRuntime bool) (oci.SpecModifier, error) {
 	mode, image, err := initRuntimeModeAndImage(logger, cfg, ociSpec)
 	if err != nil {
 		return nil, err
@@ -81,6 +108,7 @@ func newSpecModifier(logger logger.Interface, driver *root.Driver, cfg *config.C
 		modifier.WithDriver(driver),
 		modifier.WithHookCreator(hookCreator),
 		modifier.WithRuntimeMode(mode),
+		modifier.WithVMRuntime(vmRuntime),
 	)
 }
 
@@ -100,6 +128,8 @@ func initRuntimeModeAndImage(logger logger.Interface, cfg *config.Config, ociSpe
 		image.WithLogger(logger),
 		image.WithAcceptDeviceListAsVolumeMounts(cfg.AcceptDeviceListAsVolumeMounts),
 		image.WithAcceptEnvvarUnprivileged(cfg.AcceptEnvvarUnprivileged),
+		image.WithDefaultVisibleDevices(cfg.DefaultVisibleDevices),
+		image.WithVisibleDevicesFile(cfg.VisibleDevicesFile),
 		image.WithAnnotationsPrefixes(cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.AnnotationPrefixes...),
 		image.WithPreferredVisibleDevicesEnvVars(cfg.SwarmResource),
 		image.WithIgnoreImexChannelRequests(cfg.Features.IgnoreImexChannelRequests.IsEnabled()),
@@ -111,6 +141,7 @@ func initRuntimeModeAndImage(logger logger.Interface, cfg *config.Config, ociSpe
 	modeResolver := info.NewRuntimeModeResolver(
 		info.WithLogger(logger),
 		info.WithImage(&image),
+		info.WithAllowModeOverride(cfg.NVIDIAContainerRuntimeConfig.AllowModeOverride),
 	)
 	mode := modeResolver.ResolveRuntimeMode(cfg.NVIDIAContainerRuntimeConfig.Mode)
 	// We update the mode here so that we can continue passing just the config to other functions.
@@ -67,9 +67,6 @@ func TestMain(m *testing.M) {
 
 func TestFactoryMethod(t *testing.T) {
 	logger, _ := testlog.NewNullLogger()
-	driver := root.New(
-		root.WithDriverRoot("/nvidia/driver/root"),
-	)
 
 	testCases := []struct {
 		description   string
@@ -159,7 +156,7 @@ func TestFactoryMethod(t *testing.T) {
 
 			argv := []string{"--bundle", bundleDir, "create"}
 
-			_, err = newNVIDIAContainerRuntime(logger, driver, tc.cfg, argv)
+			_, err = newNVIDIAContainerRuntime(logger, tc.cfg, argv)
 			if tc.expectedError {
 				require.Error(t, err)
 			} else {
@@ -337,7 +334,7 @@ func TestNewSpecModifier(t *testing.T) {
 					return tc.spec, nil
 				},
 			}
-			m, err := newSpecModifier(logger, driver, tc.config, spec)
+			m, err := newSpecModifier(logger, driver, tc.config, spec, false)
 			require.NoError(t, err)
 
 			err = m.Modify(tc.spec)
@@ -429,6 +426,47 @@ func TestInitRuntimeModeAndImage(t *testing.T) {
 				require.EqualValues(t, []string{"GPU1", "GPU2"}, c.VisibleDevices())
 			},
 		},
+		{
+			decription: "container mode overrides configured mode",
+			config: config.Config{
+				AcceptEnvvarUnprivileged: true,
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					Mode:              "legacy",
+					AllowModeOverride: true,
+				},
+			},
+			ociSpec: &oci.SpecMock{
+				LoadFunc: func() (*specs.Spec, error) {
+					s := &specs.Spec{
+						Process: &specs.Process{
+							Env: []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_CONTAINER_RUNTIME_MODE=cdi"},
+						},
+					}
+					return s, nil
+				},
+			},
+			expectedMode: "cdi",
+		},
+		{
+			decription: "container mode is ignored by default",
+			config: config.Config{
+				AcceptEnvvarUnprivileged: true,
+				NVIDIAContainerRuntimeConfig: config.RuntimeConfig{
+					Mode: "legacy",
+				},
+			},
+			ociSpec: &oci.SpecMock{
+				LoadFunc: func() (*specs.Spec, error) {
+					s := &specs.Spec{
+						Process: &specs.Process{
+							Env: []string{"NVIDIA_VISIBLE_DEVICES=all", "NVIDIA_CONTAINER_RUNTIME_MODE=cdi"},
+						},
+					}
+					return s, nil
+				},
+			},
+			expectedMode: "legacy",
+		},
 	}
 
 	for _, tc := range testCases {
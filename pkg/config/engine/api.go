@@ -16,6 +16,8 @@
 
 package engine
 
+import "io"
+
 const (
 	// SaveToSTDOUT is used to write the specified config to stdout instead of
 	// to a file on disk.
@@ -33,14 +35,20 @@ type Interface interface {
 	AddRuntime(string, string, bool) error
 	DefaultRuntime() string
 	EnableCDI()
+	DisableCDI()
+	IsCDIEnabled() bool
 	GetRuntimeConfig(string) (RuntimeConfig, error)
+	GetRuntimeNames() []string
 	RemoveRuntime(string) error
+	SetSystemdCgroup(string, bool) error
 	UpdateDefaultRuntime(string, string) error
 	Save(string) (int64, error)
 	String() string
+	io.WriterTo
 }
 
 // RuntimeConfig defines the interface to query container runtime handler configuration
 type RuntimeConfig interface {
 	GetBinaryPath() string
+	GetRuntimeType() string
 }
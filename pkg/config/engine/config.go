@@ -17,6 +17,8 @@
 
 package engine
 
+import "io"
+
 // A Config represents a config for a container engine.
 // These include container, cri-o, and docker.
 // The config is logically split into a Source and Destination. This allows an
@@ -33,7 +35,9 @@ type Config struct {
 type RuntimeConfigSource interface {
 	DefaultRuntime() string
 	GetRuntimeConfig(string) (RuntimeConfig, error)
+	GetRuntimeNames() []string
 	GetDefaultRuntimeOptions() interface{}
+	IsCDIEnabled() bool
 	String() string
 }
 
@@ -42,10 +46,13 @@ type RuntimeConfigSource interface {
 type RuntimeConfigDestination interface {
 	AddRuntimeWithOptions(string, string, bool, interface{}) error
 	EnableCDI()
+	DisableCDI()
 	RemoveRuntime(string) error
+	SetSystemdCgroup(string, bool) error
 	UpdateDefaultRuntime(string, string) error
 	Save(string) (int64, error)
 	String() string
+	io.WriterTo
 }
 
 // AddRuntime adds a runtime to the destination config and optionally sets it as the default.
@@ -61,6 +68,12 @@ func (c *Config) RemoveRuntime(runtime string) error {
 	return c.Destination.RemoveRuntime(runtime)
 }
 
+// SetSystemdCgroup sets whether the specified runtime uses the systemd cgroup
+// driver in the destination config.
+func (c *Config) SetSystemdCgroup(runtime string, enabled bool) error {
+	return c.Destination.SetSystemdCgroup(runtime, enabled)
+}
+
 // UpdateDefaultRuntime updates the default runtime setting in the destination config.
 // When action is 'set' the provided runtime name is set as the default.
 // When action is 'unset' we make sure the provided runtime name is not
@@ -74,6 +87,16 @@ func (c *Config) EnableCDI() {
 	c.Destination.EnableCDI()
 }
 
+// DisableCDI disables CDI in the destination config.
+func (c *Config) DisableCDI() {
+	c.Destination.DisableCDI()
+}
+
+// IsCDIEnabled checks whether CDI is enabled in the source config.
+func (c *Config) IsCDIEnabled() bool {
+	return c.Source.IsCDIEnabled()
+}
+
 // DefaultRuntime returns the default runtime for the source config.
 func (c *Config) DefaultRuntime() string {
 	return c.Source.DefaultRuntime()
@@ -84,11 +107,21 @@ func (c *Config) GetRuntimeConfig(runtime string) (RuntimeConfig, error) {
 	return c.Source.GetRuntimeConfig(runtime)
 }
 
+// GetRuntimeNames returns the names of the runtimes in the source config.
+func (c *Config) GetRuntimeNames() []string {
+	return c.Source.GetRuntimeNames()
+}
+
 // Save saves the destination runtime to the specified path.
 func (c *Config) Save(path string) (int64, error) {
 	return c.Destination.Save(path)
 }
 
+// WriteTo writes the destination config to the specified writer.
+func (c *Config) WriteTo(w io.Writer) (int64, error) {
+	return c.Destination.WriteTo(w)
+}
+
 func (c *Config) String() string {
 	return c.Destination.String()
 }
@@ -18,6 +18,7 @@ package containerd
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
@@ -59,13 +60,20 @@ func (c *Config) AddRuntimeWithOptions(name string, path string, setAsDefault bo
 	if options != nil {
 		config.SetPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", name}, options)
 	}
-	if len(c.ContainerAnnotations) > 0 {
-		annotations, err := c.getStringArrayValue([]string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", name, "container_annotations"})
-		if err != nil {
-			return err
+	if err := c.addRuntimeAnnotations(&config, name, "pod_annotations", c.PodAnnotations); err != nil {
+		return err
+	}
+	if err := c.addRuntimeAnnotations(&config, name, "container_annotations", c.ContainerAnnotations); err != nil {
+		return err
+	}
+
+	if c.Sandboxer != "" {
+		// A sandboxed runtime requires that the runtime_type is set. If this
+		// was not set in the runtime options, we use the configured type.
+		if _, ok := config.GetPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", name, "runtime_type"}).(string); !ok {
+			config.SetPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", name, "runtime_type"}, c.RuntimeType)
 		}
-		annotations = append(c.ContainerAnnotations, annotations...)
-		config.SetPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", name, "container_annotations"}, annotations)
+		config.SetPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", name, "sandboxer"}, c.Sandboxer)
 	}
 
 	config.SetPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", name, "options", "BinaryName"}, path)
@@ -82,6 +90,30 @@ func (c *Config) AddRuntimeWithOptions(name string, path string, setAsDefault bo
 	return nil
 }
 
+// addRuntimeAnnotations adds the specified annotations to the annotations list
+// with the specified key for the named runtime. The specified annotations are
+// placed before any existing annotations and duplicates are removed.
+func (c *Config) addRuntimeAnnotations(config *toml.Tree, name string, key string, annotations []string) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+	path := []string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", name, key}
+	existing, err := c.getStringArrayValue(path)
+	if err != nil {
+		return err
+	}
+
+	var merged []string
+	for _, annotation := range append(slices.Clone(annotations), existing...) {
+		if slices.Contains(merged, annotation) {
+			continue
+		}
+		merged = append(merged, annotation)
+	}
+	config.SetPath(path, merged)
+	return nil
+}
+
 func (c *Config) getStringArrayValue(path []string) ([]string, error) {
 	if c == nil || c.Tree == nil {
 		return nil, nil
@@ -91,9 +123,13 @@ func (c *Config) getStringArrayValue(path []string) ([]string, error) {
 	if !config.HasPath(path) {
 		return nil, nil
 	}
-	annotationsI, ok := config.GetPath(path).([]interface{})
+	value := config.GetPath(path)
+	if annotations, ok := value.([]string); ok {
+		return annotations, nil
+	}
+	annotationsI, ok := value.([]interface{})
 	if !ok {
-		return nil, fmt.Errorf("invalid annotations: %v", annotationsI)
+		return nil, fmt.Errorf("invalid annotations: %v", value)
 	}
 
 	var annotations []string
@@ -108,6 +144,25 @@ func (c *Config) getStringArrayValue(path []string) ([]string, error) {
 	return annotations, nil
 }
 
+// SetSystemdCgroup sets the SystemdCgroup option for the specified runtime.
+// This should match the cgroup driver used by the node.
+func (c *Config) SetSystemdCgroup(runtime string, enabled bool) error {
+	if c == nil || c.Tree == nil {
+		return fmt.Errorf("config is nil")
+	}
+	return setSystemdCgroup(c.Tree, []string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes", runtime}, enabled)
+}
+
+// setSystemdCgroup sets the SystemdCgroup option for the runtime at the
+// specified path in the config.
+func setSystemdCgroup(config *toml.Tree, runtimePath []string, enabled bool) error {
+	if _, ok := config.GetPath(runtimePath).(*toml.Tree); !ok {
+		return fmt.Errorf("%q: %w", runtimePath[len(runtimePath)-1], engine.ErrRuntimeNotFound)
+	}
+	config.SetPath(append(slices.Clone(runtimePath), "options", "SystemdCgroup"), enabled)
+	return nil
+}
+
 // DefaultRuntime returns the default runtime for the containerd config.
 func (c Config) DefaultRuntime() string {
 	if runtime, ok := c.GetPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "default_runtime_name"}).(string); ok {
@@ -123,6 +178,28 @@ func (c *Config) EnableCDI() {
 	*c.Tree = config
 }
 
+// DisableCDI removes the enable_cdi field from the containerd config. The CRI
+// plugin config is also removed if it is empty.
+func (c *Config) DisableCDI() {
+	if c == nil || c.Tree == nil {
+		return
+	}
+	config := *c.Tree
+	config.DeletePath([]string{"plugins", c.CRIRuntimePluginName, "enable_cdi"})
+	deleteEmptyPaths(&config, []string{"plugins", c.CRIRuntimePluginName})
+	*c.Tree = config
+}
+
+// IsCDIEnabled checks whether the enable_cdi field in the containerd config is
+// set to true. If the field is not set, false is returned.
+func (c *Config) IsCDIEnabled() bool {
+	if c == nil || c.Tree == nil {
+		return false
+	}
+	enabled, _ := c.GetPath([]string{"plugins", c.CRIRuntimePluginName, "enable_cdi"}).(bool)
+	return enabled
+}
+
 // RemoveRuntime removes a runtime from the containerd config
 func (c *Config) RemoveRuntime(name string) error {
 	if c == nil || c.Tree == nil {
@@ -19,6 +19,7 @@ package containerd
 
 import (
 	"fmt"
+	"io"
 	"path/filepath"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
@@ -88,6 +89,12 @@ func (c *ConfigWithDropIn) Save(dropInPath string) (int64, error) {
 	return bytesWritten, nil
 }
 
+// WriteTo writes the drop-in config to the specified writer.
+// Since no drop-in path is known, the top-level config is not modified.
+func (c *ConfigWithDropIn) WriteTo(w io.Writer) (int64, error) {
+	return c.Interface.WriteTo(w)
+}
+
 // RemoveRuntime removes the runtime from both configs.
 func (c *ConfigWithDropIn) RemoveRuntime(name string) error {
 	if err := c.topLevelConfig.RemoveRuntime(name); err != nil {
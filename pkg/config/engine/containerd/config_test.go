@@ -17,11 +17,13 @@
 package containerd
 
 import (
+	"bytes"
 	"testing"
 
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
 )
 
@@ -367,6 +369,145 @@ func TestAddRuntime(t *testing.T) {
 	}
 }
 
+func TestAddRuntimeWithSandboxer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description    string
+		config         string
+		sandboxer      string
+		expectedConfig string
+	}{
+		{
+			description: "empty config sets sandboxer and runtime_type",
+			sandboxer:   "shim",
+			expectedConfig: `
+			version = 3
+			[plugins]
+			[plugins."io.containerd.cri.v1.runtime"]
+				[plugins."io.containerd.cri.v1.runtime".containerd]
+				[plugins."io.containerd.cri.v1.runtime".containerd.runtimes]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.test]
+					privileged_without_host_devices = false
+					runtime_engine = ""
+					runtime_root = ""
+					runtime_type = "io.containerd.runc.v2"
+					sandboxer = "shim"
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.test.options]
+						BinaryName = "/usr/bin/test"
+			`,
+		},
+		{
+			description: "sandboxer overrides the imported runc options",
+			sandboxer:   "shim",
+			config: `
+			version = 3
+			[plugins]
+			[plugins."io.containerd.cri.v1.runtime"]
+				[plugins."io.containerd.cri.v1.runtime".containerd]
+				[plugins."io.containerd.cri.v1.runtime".containerd.runtimes]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc]
+					runtime_type = "io.containerd.runc.v2"
+					sandboxer = "podsandbox"
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc.options]
+						BinaryName = "/usr/bin/runc"
+			`,
+			expectedConfig: `
+			version = 3
+			[plugins]
+			[plugins."io.containerd.cri.v1.runtime"]
+				[plugins."io.containerd.cri.v1.runtime".containerd]
+				[plugins."io.containerd.cri.v1.runtime".containerd.runtimes]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc]
+					runtime_type = "io.containerd.runc.v2"
+					sandboxer = "podsandbox"
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc.options]
+						BinaryName = "/usr/bin/runc"
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.test]
+					runtime_type = "io.containerd.runc.v2"
+					sandboxer = "shim"
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.test.options]
+						BinaryName = "/usr/bin/test"
+			`,
+		},
+		{
+			description: "runtime_type is added for runtime options without one",
+			sandboxer:   "podsandbox",
+			config: `
+			version = 3
+			[plugins]
+			[plugins."io.containerd.cri.v1.runtime"]
+				[plugins."io.containerd.cri.v1.runtime".containerd]
+				[plugins."io.containerd.cri.v1.runtime".containerd.runtimes]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc.options]
+						BinaryName = "/usr/bin/runc"
+			`,
+			expectedConfig: `
+			version = 3
+			[plugins]
+			[plugins."io.containerd.cri.v1.runtime"]
+				[plugins."io.containerd.cri.v1.runtime".containerd]
+				[plugins."io.containerd.cri.v1.runtime".containerd.runtimes]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc.options]
+						BinaryName = "/usr/bin/runc"
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.test]
+					runtime_type = "io.containerd.runc.v2"
+					sandboxer = "podsandbox"
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.test.options]
+						BinaryName = "/usr/bin/test"
+			`,
+		},
+		{
+			description: "no sandboxer leaves the runtime options untouched",
+			config: `
+			version = 3
+			[plugins]
+			[plugins."io.containerd.cri.v1.runtime"]
+				[plugins."io.containerd.cri.v1.runtime".containerd]
+				[plugins."io.containerd.cri.v1.runtime".containerd.runtimes]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc.options]
+						BinaryName = "/usr/bin/runc"
+			`,
+			expectedConfig: `
+			version = 3
+			[plugins]
+			[plugins."io.containerd.cri.v1.runtime"]
+				[plugins."io.containerd.cri.v1.runtime".containerd]
+				[plugins."io.containerd.cri.v1.runtime".containerd.runtimes]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.runc.options]
+						BinaryName = "/usr/bin/runc"
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.test]
+					[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.test.options]
+						BinaryName = "/usr/bin/test"
+			`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			expectedConfig, err := toml.Load(tc.expectedConfig)
+			require.NoError(t, err)
+
+			c, err := New(
+				WithLogger(logger),
+				WithConfigSource(toml.FromString(tc.config)),
+				WithConfigVersion(3),
+				WithSandboxer(tc.sandboxer),
+				WithDisableDropIn(true),
+			)
+			require.NoError(t, err)
+
+			err = c.AddRuntime("test", "/usr/bin/test", false)
+			require.NoError(t, err)
+
+			require.EqualValues(t, expectedConfig.String(), c.(*Config).String())
+		})
+	}
+}
+
 func TestGetRuntimeConfig(t *testing.T) {
 	logger, _ := testlog.NewNullLogger()
 	config := `
@@ -462,3 +603,354 @@ func TestGetRuntimeConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteTo(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description string
+		config      string
+	}{
+		{
+			description: "empty config",
+		},
+		{
+			description: "version 1 config",
+			config: `
+			[plugins]
+			[plugins.cri.containerd.runtimes.runc]
+			runtime_type = "io.containerd.runc.v2"
+			`,
+		},
+		{
+			description: "version 2 config",
+			config: `
+			version = 2
+			[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+			runtime_type = "io.containerd.runc.v2"
+			`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c, err := New(
+				WithLogger(logger),
+				WithConfigSource(toml.FromString(tc.config)),
+			)
+			require.NoError(t, err)
+
+			require.NoError(t, c.AddRuntime("nvidia", "/usr/bin/nvidia-container-runtime", true))
+
+			buffer := &bytes.Buffer{}
+			n, err := c.WriteTo(buffer)
+			require.NoError(t, err)
+			require.EqualValues(t, buffer.Len(), n)
+
+			written, err := toml.LoadBytes(buffer.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, c.String(), written.String())
+
+			rc, err := New(
+				WithLogger(logger),
+				WithConfigSource(toml.FromString(buffer.String())),
+			)
+			require.NoError(t, err)
+			require.Equal(t, "nvidia", rc.DefaultRuntime())
+		})
+	}
+}
+
+func TestIsCDIEnabled(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description string
+		config      string
+		expected    bool
+	}{
+		{
+			description: "v1 config enable_cdi absent",
+			config: `
+			version = 1
+			[plugins.cri.containerd]
+			snapshotter = "overlayfs"
+			`,
+		},
+		{
+			description: "v1 config enable_cdi false",
+			config: `
+			version = 1
+			[plugins.cri.containerd]
+			enable_cdi = false
+			`,
+		},
+		{
+			description: "v1 config enable_cdi true",
+			config: `
+			version = 1
+			[plugins.cri.containerd]
+			enable_cdi = true
+			`,
+			expected: true,
+		},
+		{
+			description: "v2 config enable_cdi absent",
+			config: `
+			version = 2
+			[plugins."io.containerd.grpc.v1.cri"]
+			sandbox_image = "pause"
+			`,
+		},
+		{
+			description: "v2 config enable_cdi false",
+			config: `
+			version = 2
+			[plugins."io.containerd.grpc.v1.cri"]
+			enable_cdi = false
+			`,
+		},
+		{
+			description: "v2 config enable_cdi true",
+			config: `
+			version = 2
+			[plugins."io.containerd.grpc.v1.cri"]
+			enable_cdi = true
+			`,
+			expected: true,
+		},
+		{
+			description: "v3 config enable_cdi absent",
+			config: `
+			version = 3
+			[plugins."io.containerd.cri.v1.runtime"]
+			snapshotter = "overlayfs"
+			`,
+		},
+		{
+			description: "v3 config enable_cdi false",
+			config: `
+			version = 3
+			[plugins."io.containerd.cri.v1.runtime"]
+			enable_cdi = false
+			`,
+		},
+		{
+			description: "v3 config enable_cdi true",
+			config: `
+			version = 3
+			[plugins."io.containerd.cri.v1.runtime"]
+			enable_cdi = true
+			`,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c, err := New(
+				WithLogger(logger),
+				WithConfigSource(toml.FromString(tc.config)),
+			)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, c.IsCDIEnabled())
+		})
+	}
+}
+
+func TestAddRuntimeWithAnnotations(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description    string
+		config         string
+		expectedConfig string
+	}{
+		{
+			description: "empty config",
+			expectedConfig: `
+			version = 2
+			[plugins]
+			[plugins."io.containerd.grpc.v1.cri"]
+				[plugins."io.containerd.grpc.v1.cri".containerd]
+				[plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+					[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.test]
+					container_annotations = ["cdi.k8s.io/*"]
+					pod_annotations = ["cdi.k8s.io/*"]
+					privileged_without_host_devices = false
+					runtime_engine = ""
+					runtime_root = ""
+					runtime_type = "io.containerd.runc.v2"
+					[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.test.options]
+						BinaryName = "/usr/bin/test"
+			`,
+		},
+		{
+			description: "annotations from runc are merged without duplicates",
+			config: `
+			version = 2
+			[plugins]
+			[plugins."io.containerd.grpc.v1.cri"]
+				[plugins."io.containerd.grpc.v1.cri".containerd]
+				[plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+					[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+					container_annotations = ["example.com/container", "cdi.k8s.io/*"]
+					pod_annotations = ["cdi.k8s.io/*", "example.com/pod"]
+					runtime_type = "io.containerd.runc.v2"
+					[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+						BinaryName = "/usr/bin/runc"
+			`,
+			expectedConfig: `
+			version = 2
+			[plugins]
+			[plugins."io.containerd.grpc.v1.cri"]
+				[plugins."io.containerd.grpc.v1.cri".containerd]
+				[plugins."io.containerd.grpc.v1.cri".containerd.runtimes]
+					[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+					container_annotations = ["example.com/container", "cdi.k8s.io/*"]
+					pod_annotations = ["cdi.k8s.io/*", "example.com/pod"]
+					runtime_type = "io.containerd.runc.v2"
+					[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+						BinaryName = "/usr/bin/runc"
+					[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.test]
+					container_annotations = ["cdi.k8s.io/*", "example.com/container"]
+					pod_annotations = ["cdi.k8s.io/*", "example.com/pod"]
+					runtime_type = "io.containerd.runc.v2"
+					[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.test.options]
+						BinaryName = "/usr/bin/test"
+			`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			expectedConfig, err := toml.Load(tc.expectedConfig)
+			require.NoError(t, err)
+
+			c, err := New(
+				WithLogger(logger),
+				WithConfigSource(toml.FromString(tc.config)),
+				WithConfigVersion(2),
+				WithDisableDropIn(true),
+				WithContainerAnnotations("cdi.k8s.io/*"),
+				WithPodAnnotations("cdi.k8s.io/*"),
+			)
+			require.NoError(t, err)
+
+			err = c.AddRuntime("test", "/usr/bin/test", false)
+			require.NoError(t, err)
+
+			require.EqualValues(t, expectedConfig.String(), c.(*Config).String())
+		})
+	}
+}
+
+func TestSetSystemdCgroup(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description    string
+		config         string
+		runtime        string
+		enabled        bool
+		expectedError  error
+		expectedConfig string
+	}{
+		{
+			description: "v2 config systemd cgroup enabled",
+			config: `
+			version = 2
+			[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+			runtime_type = "io.containerd.runc.v2"
+			[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+			BinaryName = "/usr/bin/nvidia-container-runtime"
+			`,
+			runtime: "nvidia",
+			enabled: true,
+			expectedConfig: `
+			version = 2
+			[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+			runtime_type = "io.containerd.runc.v2"
+			[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+			BinaryName = "/usr/bin/nvidia-container-runtime"
+			SystemdCgroup = true
+			`,
+		},
+		{
+			description: "v3 config systemd cgroup disabled",
+			config: `
+			version = 3
+			[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.nvidia]
+			runtime_type = "io.containerd.runc.v2"
+			[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.nvidia.options]
+			BinaryName = "/usr/bin/nvidia-container-runtime"
+			SystemdCgroup = true
+			`,
+			runtime: "nvidia",
+			enabled: false,
+			expectedConfig: `
+			version = 3
+			[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.nvidia]
+			runtime_type = "io.containerd.runc.v2"
+			[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.nvidia.options]
+			BinaryName = "/usr/bin/nvidia-container-runtime"
+			SystemdCgroup = false
+			`,
+		},
+		{
+			description: "missing runtime returns error",
+			config: `
+			version = 2
+			[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+			runtime_type = "io.containerd.runc.v2"
+			`,
+			runtime:       "nvidia",
+			enabled:       true,
+			expectedError: engine.ErrRuntimeNotFound,
+			expectedConfig: `
+			version = 2
+			[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+			runtime_type = "io.containerd.runc.v2"
+			`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			expectedConfig, err := toml.Load(tc.expectedConfig)
+			require.NoError(t, err)
+
+			c, err := New(
+				WithLogger(logger),
+				WithConfigSource(toml.FromString(tc.config)),
+				WithDisableDropIn(true),
+			)
+			require.NoError(t, err)
+
+			err = c.SetSystemdCgroup(tc.runtime, tc.enabled)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			require.EqualValues(t, expectedConfig.String(), c.String())
+		})
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	_, err := New(
+		WithLogger(logger),
+		WithConfigSource(toml.FromString(`version = `)),
+		WithDisableDropIn(true),
+	)
+	require.ErrorIs(t, err, engine.ErrConfigParse)
+}
+
+func TestNewUnreadableConfig(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	_, err := New(
+		WithLogger(logger),
+		WithConfigSource(toml.FromFile(t.TempDir())),
+		WithDisableDropIn(true),
+	)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, engine.ErrConfigParse)
+}
@@ -75,6 +75,15 @@ func (c ConfigV1) DefaultRuntime() string {
 	return ""
 }
 
+// SetSystemdCgroup sets the SystemdCgroup option for the specified runtime.
+// This should match the cgroup driver used by the node.
+func (c *ConfigV1) SetSystemdCgroup(runtime string, enabled bool) error {
+	if c == nil || c.Tree == nil {
+		return fmt.Errorf("config is nil")
+	}
+	return setSystemdCgroup(c.Tree, []string{"plugins", "cri", "containerd", "runtimes", runtime}, enabled)
+}
+
 // RemoveRuntime removes a runtime from the containerd config.
 func (c *ConfigV1) RemoveRuntime(name string) error {
 	if c == nil || c.Tree == nil {
@@ -140,8 +149,39 @@ func (c *ConfigV1) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
 	}, nil
 }
 
+// GetRuntimeNames returns the sorted names of the runtimes defined in the
+// config.
+func (c *ConfigV1) GetRuntimeNames() []string {
+	if c == nil || c.Tree == nil {
+		return nil
+	}
+	return getRuntimeNames(c.GetSubtreeByPath([]string{"plugins", "cri", "containerd", "runtimes"}))
+}
+
 func (c *ConfigV1) EnableCDI() {
 	config := *c.Tree
 	config.SetPath([]string{"plugins", "cri", "containerd", "enable_cdi"}, true)
 	*c.Tree = config
 }
+
+// DisableCDI removes the enable_cdi field from the containerd config. The CRI
+// plugin config is also removed if it is empty.
+func (c *ConfigV1) DisableCDI() {
+	if c == nil || c.Tree == nil {
+		return
+	}
+	config := *c.Tree
+	config.DeletePath([]string{"plugins", "cri", "containerd", "enable_cdi"})
+	deleteEmptyPaths(&config, []string{"plugins", "cri", "containerd"})
+	*c.Tree = config
+}
+
+// IsCDIEnabled checks whether the enable_cdi field in the containerd config is
+// set to true. If the field is not set, false is returned.
+func (c *ConfigV1) IsCDIEnabled() bool {
+	if c == nil || c.Tree == nil {
+		return false
+	}
+	enabled, _ := c.GetPath([]string{"plugins", "cri", "containerd", "enable_cdi"}).(bool)
+	return enabled
+}
@@ -22,6 +22,7 @@ import (
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
 )
 
@@ -218,3 +219,99 @@ func TestAddRuntimeV1(t *testing.T) {
 		})
 	}
 }
+
+func TestAddRuntimeWithAnnotationsV1(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	config := `
+	[plugins]
+	[plugins.cri]
+		[plugins.cri.containerd]
+		[plugins.cri.containerd.runtimes]
+			[plugins.cri.containerd.runtimes.runc]
+			container_annotations = ["cdi.k8s.io/*", "example.com/container"]
+			runtime_type = "type"
+			[plugins.cri.containerd.runtimes.runc.options]
+				BinaryName = "/usr/bin/runc"
+	`
+	expectedConfig, err := toml.Load(`
+	version = 1
+	[plugins]
+	[plugins.cri]
+		[plugins.cri.containerd]
+		[plugins.cri.containerd.runtimes]
+			[plugins.cri.containerd.runtimes.runc]
+			container_annotations = ["cdi.k8s.io/*", "example.com/container"]
+			runtime_type = "type"
+			[plugins.cri.containerd.runtimes.runc.options]
+				BinaryName = "/usr/bin/runc"
+			[plugins.cri.containerd.runtimes.test]
+			container_annotations = ["cdi.k8s.io/*", "example.com/container"]
+			pod_annotations = ["cdi.k8s.io/*"]
+			runtime_type = "type"
+			[plugins.cri.containerd.runtimes.test.options]
+				BinaryName = "/usr/bin/test"
+				Runtime = "/usr/bin/test"
+	`)
+	require.NoError(t, err)
+
+	c, err := New(
+		WithLogger(logger),
+		WithConfigSource(toml.FromString(config)),
+		WithUseLegacyConfig(true),
+		WithRuntimeType(""),
+		WithContainerAnnotations("cdi.k8s.io/*"),
+		WithPodAnnotations("cdi.k8s.io/*"),
+	)
+	require.NoError(t, err)
+
+	err = c.AddRuntime("test", "/usr/bin/test", false)
+	require.NoError(t, err)
+
+	require.EqualValues(t, expectedConfig.String(), c.String())
+}
+
+func TestSetSystemdCgroupV1(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	config := `
+	version = 1
+	[plugins]
+	[plugins.cri]
+		[plugins.cri.containerd]
+		[plugins.cri.containerd.runtimes]
+			[plugins.cri.containerd.runtimes.nvidia]
+			runtime_type = "io.containerd.runc.v2"
+			[plugins.cri.containerd.runtimes.nvidia.options]
+				BinaryName = "/usr/bin/nvidia-container-runtime"
+				Runtime = "/usr/bin/nvidia-container-runtime"
+	`
+	expectedConfig, err := toml.Load(`
+	version = 1
+	[plugins]
+	[plugins.cri]
+		[plugins.cri.containerd]
+		[plugins.cri.containerd.runtimes]
+			[plugins.cri.containerd.runtimes.nvidia]
+			runtime_type = "io.containerd.runc.v2"
+			[plugins.cri.containerd.runtimes.nvidia.options]
+				BinaryName = "/usr/bin/nvidia-container-runtime"
+				Runtime = "/usr/bin/nvidia-container-runtime"
+				SystemdCgroup = true
+	`)
+	require.NoError(t, err)
+
+	c, err := New(
+		WithLogger(logger),
+		WithConfigSource(toml.FromString(config)),
+	)
+	require.NoError(t, err)
+
+	err = c.SetSystemdCgroup("nvidia", true)
+	require.NoError(t, err)
+
+	require.EqualValues(t, expectedConfig.String(), c.String())
+
+	err = c.SetSystemdCgroup("runc", true)
+	require.ErrorIs(t, err, engine.ErrRuntimeNotFound)
+}
@@ -18,6 +18,7 @@ package containerd
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
@@ -39,7 +40,9 @@ type configOptions struct {
 	Version              int64
 	Logger               logger.Interface
 	RuntimeType          string
+	Sandboxer            string
 	ContainerAnnotations []string
+	PodAnnotations       []string
 	// UseLegacyConfig indicates whether a config file pre v1.3 should be generated.
 	// For version 1 config prior to containerd v1.4 the default runtime was
 	// specified in a containerd.runtimes.default_runtime section.
@@ -71,6 +74,17 @@ func (c *containerdCfgRuntime) GetBinaryPath() string {
 	return binPath
 }
 
+// GetRuntimeType retrieves the runtime_type for a runtime.
+// If no type is available, the empty string is returned.
+func (c *containerdCfgRuntime) GetRuntimeType() string {
+	if c == nil || c.tree == nil {
+		return ""
+	}
+
+	runtimeType, _ := c.tree.GetPath([]string{"runtime_type"}).(string)
+	return runtimeType
+}
+
 // New creates a containerd config with the specified options
 func New(opts ...Option) (engine.Interface, error) {
 	b := &builder{
@@ -89,7 +103,7 @@ func New(opts ...Option) (engine.Interface, error) {
 
 	sourceConfigTree, err := b.configSource.Load()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %v", err)
+		return nil, engine.WrapLoadError(err)
 	}
 
 	configVersion, err := b.parseVersion(sourceConfigTree)
@@ -109,8 +123,10 @@ func New(opts ...Option) (engine.Interface, error) {
 		CRIRuntimePluginName: criRuntimePluginName,
 		Logger:               b.logger,
 		RuntimeType:          b.runtimeType,
+		Sandboxer:            b.sandboxer,
 		UseLegacyConfig:      b.useLegacyConfig,
 		ContainerAnnotations: b.containerAnnotations,
+		PodAnnotations:       b.podAnnotations,
 	}
 	sourceConfig := &Config{
 		Tree:          sourceConfigTree,
@@ -122,6 +138,11 @@ func New(opts ...Option) (engine.Interface, error) {
 		// We return the sourceConfig as is.
 		return (*ConfigV1)(sourceConfig), nil
 	default:
+		if b.disableDropIn {
+			// If drop-in files are disabled, modifications are made to the
+			// source config directly.
+			return sourceConfig, nil
+		}
 		// For other versions, we create a DropInConfig with a reference to the
 		// top-level config if present.
 		topLevelConfig := &Config{
@@ -194,6 +215,37 @@ func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
 	}, nil
 }
 
+// GetRuntimeNames returns the sorted names of the runtimes defined in the
+// config.
+func (c *Config) GetRuntimeNames() []string {
+	if c == nil || c.Tree == nil {
+		return nil
+	}
+	return getRuntimeNames(c.GetSubtreeByPath([]string{"plugins", c.CRIRuntimePluginName, "containerd", "runtimes"}))
+}
+
+// getRuntimeNames returns the sorted keys of the specified runtimes tree.
+func getRuntimeNames(runtimes *toml.Tree) []string {
+	if runtimes == nil {
+		return nil
+	}
+	names := runtimes.Keys()
+	slices.Sort(names)
+	return names
+}
+
+// deleteEmptyPaths deletes the tree at the specified path and each of its
+// parents if they are empty.
+func deleteEmptyPaths(config *toml.Tree, path []string) {
+	for i := len(path); i > 0; i-- {
+		tree, ok := config.GetPath(path[:i]).(*toml.Tree)
+		if !ok || len(tree.Keys()) != 0 {
+			return
+		}
+		config.DeletePath(path[:i])
+	}
+}
+
 // CommandLineSource returns the CLI-based containerd config loader
 func CommandLineSource(hostRoot string, executablePath string) toml.Loader {
 	if executablePath == "" {
@@ -26,9 +26,12 @@ type builder struct {
 	configSource         toml.Loader
 	configVersion        int
 	useLegacyConfig      bool
+	disableDropIn        bool
 	topLevelConfigPath   string
 	runtimeType          string
+	sandboxer            string
 	containerAnnotations []string
+	podAnnotations       []string
 
 	containerToHostPathMap map[string]string
 }
@@ -77,6 +80,16 @@ func WithRuntimeType(runtimeType string) Option {
 	}
 }
 
+// WithSandboxer sets the sandboxer to use for the configured runtimes.
+// Sandboxers were introduced in containerd 2.x with supported values of
+// "podsandbox" and "shim". If this is not set, the sandboxer of the runtime
+// that is used as a template is used.
+func WithSandboxer(sandboxer string) Option {
+	return func(b *builder) {
+		b.sandboxer = sandboxer
+	}
+}
+
 // WithUseLegacyConfig sets the useLegacyConfig flag for the config builder.
 func WithUseLegacyConfig(useLegacyConfig bool) Option {
 	return func(b *builder) {
@@ -97,3 +110,18 @@ func WithContainerAnnotations(containerAnnotations ...string) Option {
 		b.containerAnnotations = containerAnnotations
 	}
 }
+
+// WithPodAnnotations sets the pod annotations for the config builder
+func WithPodAnnotations(podAnnotations ...string) Option {
+	return func(b *builder) {
+		b.podAnnotations = podAnnotations
+	}
+}
+
+// WithDisableDropIn configures the builder to apply modifications directly to
+// the source config instead of to a separate drop-in config.
+func WithDisableDropIn(disableDropIn bool) Option {
+	return func(b *builder) {
+		b.disableDropIn = disableDropIn
+	}
+}
@@ -18,6 +18,7 @@ package crio
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
@@ -28,6 +29,9 @@ import (
 type Config struct {
 	*toml.Tree
 	Logger logger.Interface
+	// AllowedAnnotations are merged into the allowed_annotations of added
+	// runtimes.
+	AllowedAnnotations []string
 }
 
 type crioRuntime struct {
@@ -47,6 +51,17 @@ func (c *crioRuntime) GetBinaryPath() string {
 	return ""
 }
 
+// GetRuntimeType retrieves the runtime_type for a runtime.
+// If no type is available, the empty string is returned.
+func (c *crioRuntime) GetRuntimeType() string {
+	if c.tree != nil {
+		if runtimeType, ok := c.tree.GetPath([]string{"runtime_type"}).(string); ok {
+			return runtimeType
+		}
+	}
+	return ""
+}
+
 var _ engine.Interface = (*Config)(nil)
 
 // New creates a cri-o config with the specified options
@@ -64,14 +79,14 @@ func New(opts ...Option) (engine.Interface, error) {
 
 	sourceConfig, err := b.configSource.Load()
 	if err != nil {
-		return nil, err
+		return nil, engine.WrapLoadError(err)
 	}
 
 	var destinationConfig *toml.Tree
 	if b.configDestination != nil {
 		destinationConfig, err = b.configDestination.Load()
 		if err != nil {
-			return nil, err
+			return nil, engine.WrapLoadError(err)
 		}
 	} else {
 		destinationConfig = toml.NewEmpty()
@@ -79,12 +94,14 @@ func New(opts ...Option) (engine.Interface, error) {
 
 	cfg := &engine.Config{
 		Source: &Config{
-			Tree:   sourceConfig,
-			Logger: b.logger,
+			Tree:               sourceConfig,
+			Logger:             b.logger,
+			AllowedAnnotations: b.allowedAnnotations,
 		},
 		Destination: &Config{
-			Tree:   destinationConfig,
-			Logger: b.logger,
+			Tree:               destinationConfig,
+			Logger:             b.logger,
+			AllowedAnnotations: b.allowedAnnotations,
 		},
 	}
 
@@ -124,6 +141,27 @@ func (c *Config) AddRuntimeWithOptions(name string, path string, setAsDefault bo
 	config.SetPath([]string{"crio", "runtime", "runtimes", name, "runtime_path"}, path)
 	config.SetPath([]string{"crio", "runtime", "runtimes", name, "runtime_type"}, "oci")
 
+	if len(c.AllowedAnnotations) > 0 {
+		annotationsPath := []string{"crio", "runtime", "runtimes", name, "allowed_annotations"}
+		var annotations []string
+		switch existing := config.GetPath(annotationsPath).(type) {
+		case []string:
+			annotations = append(annotations, existing...)
+		case []interface{}:
+			for _, annotation := range existing {
+				if a, ok := annotation.(string); ok {
+					annotations = append(annotations, a)
+				}
+			}
+		}
+		for _, annotation := range c.AllowedAnnotations {
+			if !slices.Contains(annotations, annotation) {
+				annotations = append(annotations, annotation)
+			}
+		}
+		config.SetPath(annotationsPath, annotations)
+	}
+
 	if setAsDefault {
 		config.SetPath([]string{"crio", "runtime", "default_runtime"}, name)
 	} else {
@@ -177,6 +215,27 @@ func (c *Config) RemoveRuntime(name string) error {
 	return nil
 }
 
+// SetSystemdCgroup sets the cgroup in which the container monitor (conmon) for
+// the specified runtime is placed. CRI-O passes its cgroup manager to the
+// runtime itself, so this is the only per-runtime setting that depends on the
+// cgroup driver: system.slice is used for the systemd cgroup manager and the pod
+// cgroup is required for the cgroupfs cgroup manager.
+func (c *Config) SetSystemdCgroup(runtime string, enabled bool) error {
+	if c == nil || c.Tree == nil {
+		return fmt.Errorf("config is nil")
+	}
+	runtimePath := []string{"crio", "runtime", "runtimes", runtime}
+	if _, ok := c.GetPath(runtimePath).(*toml.Tree); !ok {
+		return fmt.Errorf("%q: %w", runtime, engine.ErrRuntimeNotFound)
+	}
+	monitorCgroup := "pod"
+	if enabled {
+		monitorCgroup = "system.slice"
+	}
+	c.SetPath(append(runtimePath, "monitor_cgroup"), monitorCgroup)
+	return nil
+}
+
 // UpdateDefaultRuntime updates the default runtime setting in the config.
 // When action is 'set' the provided runtime name is set as the default.
 // When action is 'unset' we make sure the provided runtime name is not
@@ -219,9 +278,40 @@ func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
 	}, nil
 }
 
+// GetRuntimeNames returns the sorted names of the runtimes defined in the
+// config.
+func (c *Config) GetRuntimeNames() []string {
+	if c == nil || c.Tree == nil {
+		return nil
+	}
+	runtimes := c.GetSubtreeByPath([]string{"crio", "runtime", "runtimes"})
+	if runtimes == nil {
+		return nil
+	}
+	names := runtimes.Keys()
+	slices.Sort(names)
+	return names
+}
+
 // EnableCDI is a no-op for CRI-O since it always enabled where supported.
 func (c *Config) EnableCDI() {}
 
+// DisableCDI is a no-op for CRI-O since CDI cannot be disabled in the config.
+func (c *Config) DisableCDI() {}
+
+// IsCDIEnabled checks whether CDI is supported by the CRI-O instance
+// described by the config. CRI-O cannot disable CDI in its config, but the
+// cdi_spec_dirs option is only available (and included in the output of
+// `crio status config`) for CRI-O versions that support CDI (v1.23.0 and
+// later). We use the presence of this option to determine whether CDI is
+// enabled.
+func (c *Config) IsCDIEnabled() bool {
+	if c == nil || c.Tree == nil {
+		return false
+	}
+	return c.GetPath([]string{"crio", "runtime", "cdi_spec_dirs"}) != nil
+}
+
 // CommandLineSource returns the CLI-based crio config loader
 func CommandLineSource(hostRoot string, executablePath string) toml.Loader {
 	if executablePath == "" {
@@ -17,22 +17,26 @@
 package crio
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
 )
 
 func TestAddRuntime(t *testing.T) {
 	logger, _ := testlog.NewNullLogger()
 	testCases := []struct {
-		description    string
-		config         string
-		setAsDefault   bool
-		expectedConfig string
-		expectedError  error
+		description        string
+		config             string
+		setAsDefault       bool
+		allowedAnnotations []string
+		expectedConfig     string
+		expectedError      error
 	}{
 		{
 			description: "empty config not default runtime",
@@ -159,6 +163,34 @@ func TestAddRuntime(t *testing.T) {
 			`,
 			expectedError: nil,
 		},
+		{
+			description:        "allowed annotations are added",
+			allowedAnnotations: []string{"cdi.k8s.io/*"},
+			expectedConfig: `
+			[crio]
+			[crio.runtime.runtimes.test]
+			allowed_annotations = ["cdi.k8s.io/*"]
+			runtime_path = "/usr/bin/test"
+			runtime_type = "oci"
+			`,
+		},
+		{
+			description:        "allowed annotations are merged with imported options",
+			allowedAnnotations: []string{"cdi.k8s.io/*"},
+			config: `
+			[crio]
+			[crio.runtime.runtimes.runc]
+			runtime_path = "/usr/bin/runc"
+			allowed_annotations = ["io.kubernetes.cri-o.Devices", "cdi.k8s.io/*"]
+			`,
+			expectedConfig: `
+			[crio]
+			[crio.runtime.runtimes.test]
+			allowed_annotations = ["io.kubernetes.cri-o.Devices", "cdi.k8s.io/*"]
+			runtime_path = "/usr/bin/test"
+			runtime_type = "oci"
+			`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -169,6 +201,7 @@ func TestAddRuntime(t *testing.T) {
 			c, err := New(
 				WithLogger(logger),
 				WithConfigSource(toml.FromString(tc.config)),
+				WithAllowedAnnotations(tc.allowedAnnotations...),
 			)
 			require.NoError(t, err)
 
@@ -235,3 +268,138 @@ monitor_path = "/usr/libexec/crio/conmon"
 		})
 	}
 }
+
+func TestWriteTo(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	config := `
+[crio.runtime]
+default_runtime = "runc"
+
+[crio.runtime.runtimes.runc]
+runtime_path = "/usr/libexec/crio/runc"
+`
+	c, err := New(
+		WithLogger(logger),
+		WithConfigSource(toml.FromString(config)),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddRuntime("nvidia", "/usr/bin/nvidia-container-runtime", true))
+
+	buffer := &bytes.Buffer{}
+	n, err := c.WriteTo(buffer)
+	require.NoError(t, err)
+	require.EqualValues(t, buffer.Len(), n)
+
+	written, err := toml.LoadBytes(buffer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, c.String(), written.String())
+
+	rc, err := (&Config{Tree: written, Logger: logger}).GetRuntimeConfig("nvidia")
+	require.NoError(t, err)
+	require.Equal(t, "/usr/bin/nvidia-container-runtime", rc.GetBinaryPath())
+}
+
+func TestSetSystemdCgroup(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description           string
+		enabled               bool
+		expectedMonitorCgroup string
+	}{
+		{
+			description:           "systemd cgroup driver",
+			enabled:               true,
+			expectedMonitorCgroup: "system.slice",
+		},
+		{
+			description:           "cgroupfs cgroup driver",
+			expectedMonitorCgroup: "pod",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c, err := New(
+				WithLogger(logger),
+				WithConfigSource(toml.Empty),
+			)
+			require.NoError(t, err)
+			require.NoError(t, c.AddRuntime("nvidia", "/usr/bin/nvidia-container-runtime", false))
+
+			require.NoError(t, c.SetSystemdCgroup("nvidia", tc.enabled))
+
+			require.Contains(t, c.String(), fmt.Sprintf("monitor_cgroup = %q", tc.expectedMonitorCgroup))
+
+			err = c.SetSystemdCgroup("runc", tc.enabled)
+			require.ErrorIs(t, err, engine.ErrRuntimeNotFound)
+		})
+	}
+}
+
+func TestIsCDIEnabled(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description string
+		config      string
+		expected    bool
+	}{
+		{
+			description: "empty config",
+		},
+		{
+			description: "config without cdi_spec_dirs",
+			config: `
+[crio.runtime]
+default_runtime = "crun"
+
+[crio.runtime.runtimes.crun]
+runtime_path = "/usr/libexec/crio/crun"
+`,
+		},
+		{
+			description: "config with cdi_spec_dirs",
+			config: `
+[crio.runtime]
+default_runtime = "crun"
+cdi_spec_dirs = ["/etc/cdi", "/var/run/cdi"]
+
+[crio.runtime.runtimes.crun]
+runtime_path = "/usr/libexec/crio/crun"
+`,
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c, err := New(
+				WithLogger(logger),
+				WithConfigSource(toml.FromString(tc.config)),
+			)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, c.IsCDIEnabled())
+		})
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	_, err := New(
+		WithLogger(logger),
+		WithConfigSource(toml.FromString(`[crio.runtime`)),
+	)
+	require.ErrorIs(t, err, engine.ErrConfigParse)
+}
+
+func TestNewUnreadableConfig(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	_, err := New(
+		WithLogger(logger),
+		WithConfigSource(toml.FromFile(t.TempDir())),
+	)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, engine.ErrConfigParse)
+}
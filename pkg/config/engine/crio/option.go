@@ -26,6 +26,7 @@ type builder struct {
 	configSource       toml.Loader
 	configDestination  toml.Loader
 	topLevelConfigPath string
+	allowedAnnotations []string
 }
 
 // Option defines a function that can be used to configure the config builder
@@ -58,3 +59,11 @@ func WithConfigDestination(configDestination toml.Loader) Option {
 		b.configDestination = configDestination
 	}
 }
+
+// WithAllowedAnnotations sets the annotations that are allowed for the added
+// runtimes.
+func WithAllowedAnnotations(allowedAnnotations ...string) Option {
+	return func(b *builder) {
+		b.allowedAnnotations = allowedAnnotations
+	}
+}
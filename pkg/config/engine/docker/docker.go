@@ -19,6 +19,8 @@ package docker
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config"
@@ -29,6 +31,11 @@ const (
 	defaultDockerRuntime = "runc"
 )
 
+// defaultCDISpecDirs are the directories that Docker searches for CDI specs
+// by default. These are set as cdi-spec-dirs when CDI is enabled and no
+// cdi-spec-dirs are configured.
+var defaultCDISpecDirs = []string{"/etc/cdi", "/var/run/cdi"}
+
 // Config defines a docker config file.
 // TODO: This should not be public, but we need to access it from the tests in tools/container/docker
 type Config map[string]interface{}
@@ -50,6 +57,17 @@ func (d dockerRuntime) GetBinaryPath() string {
 	return path
 }
 
+// GetRuntimeType retrieves the runtimeType for a runtime.
+// If no type is available, the empty string is returned.
+func (d dockerRuntime) GetRuntimeType() string {
+	if d == nil {
+		return ""
+	}
+
+	runtimeType, _ := d["runtimeType"].(string)
+	return runtimeType
+}
+
 // New creates a docker config with the specified options
 func New(opts ...Option) (engine.Interface, error) {
 	b := &builder{}
@@ -103,24 +121,90 @@ func (c Config) DefaultRuntime() string {
 	return r
 }
 
-// EnableCDI sets features.cdi to true in the docker config.
+// EnableCDI sets features.cdi to true in the docker config and sets
+// cdi-spec-dirs to the default CDI spec directories if these are not already
+// set. Existing entries in features and cdi-spec-dirs are preserved.
 func (c *Config) EnableCDI() {
 	if c == nil {
 		return
 	}
 	config := *c
 
-	features, ok := config["features"].(map[string]bool)
-	if !ok {
-		features = make(map[string]bool)
+	features := make(map[string]interface{})
+	switch existing := config["features"].(type) {
+	case map[string]interface{}:
+		features = existing
+	case map[string]bool:
+		for k, v := range existing {
+			features[k] = v
+		}
 	}
 	features["cdi"] = true
-
 	config["features"] = features
 
+	if _, ok := config["cdi-spec-dirs"]; !ok {
+		config["cdi-spec-dirs"] = slices.Clone(defaultCDISpecDirs)
+	}
+
 	*c = config
 }
 
+// DisableCDI removes features.cdi from the docker config. The features entry is
+// removed if it is empty. Since EnableCDI only sets cdi-spec-dirs if these are
+// not set, cdi-spec-dirs is only removed if it matches the default CDI spec
+// directories. Other cdi-spec-dirs are set by the user and are preserved.
+func (c *Config) DisableCDI() {
+	if c == nil {
+		return
+	}
+	config := *c
+
+	switch features := config["features"].(type) {
+	case map[string]interface{}:
+		delete(features, "cdi")
+		if len(features) == 0 {
+			delete(config, "features")
+		}
+	case map[string]bool:
+		delete(features, "cdi")
+		if len(features) == 0 {
+			delete(config, "features")
+		}
+	}
+
+	var specDirs []string
+	switch existing := config["cdi-spec-dirs"].(type) {
+	case []interface{}:
+		for _, dir := range existing {
+			dir, _ := dir.(string)
+			specDirs = append(specDirs, dir)
+		}
+	case []string:
+		specDirs = existing
+	}
+	if slices.Equal(specDirs, defaultCDISpecDirs) {
+		delete(config, "cdi-spec-dirs")
+	}
+
+	*c = config
+}
+
+// IsCDIEnabled checks whether features.cdi is set to true in the docker config.
+// If the feature is not set, false is returned.
+func (c *Config) IsCDIEnabled() bool {
+	if c == nil {
+		return false
+	}
+	switch features := (*c)["features"].(type) {
+	case map[string]interface{}:
+		enabled, _ := features["cdi"].(bool)
+		return enabled
+	case map[string]bool:
+		return features["cdi"]
+	}
+	return false
+}
+
 // RemoveRuntime removes a runtime from the docker config
 func (c *Config) RemoveRuntime(name string) error {
 	if c == nil {
@@ -150,6 +234,21 @@ func (c *Config) RemoveRuntime(name string) error {
 	return nil
 }
 
+// SetSystemdCgroup checks that the specified runtime exists in the config. The
+// cgroup driver for docker is configured for the daemon (using the
+// native.cgroupdriver exec-opt) and is passed to all runtimes, so there is no
+// per-runtime setting to update.
+func (c *Config) SetSystemdCgroup(runtime string, _ bool) error {
+	if c == nil {
+		return fmt.Errorf("config is nil")
+	}
+	runtimes, _ := (*c)["runtimes"].(map[string]interface{})
+	if _, ok := runtimes[runtime]; !ok {
+		return fmt.Errorf("%q: %w", runtime, engine.ErrRuntimeNotFound)
+	}
+	return nil
+}
+
 // UpdateDefaultRuntime updates the default runtime setting in the config.
 // When action is 'set' the provided runtime name is set as the default.
 // When action is 'unset' we make sure the provided runtime name is not
@@ -194,6 +293,17 @@ func (c Config) Save(path string) (int64, error) {
 	return int64(n), err
 }
 
+// WriteTo writes the JSON representation of the config to the specified writer.
+func (c Config) WriteTo(w io.Writer) (int64, error) {
+	output, err := json.MarshalIndent(c, "", "    ")
+	if err != nil {
+		return 0, fmt.Errorf("unable to convert to JSON: %v", err)
+	}
+
+	n, err := w.Write(output)
+	return int64(n), err
+}
+
 // GetRuntimeConfig returns the runtime info of the runtime passed as input
 func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
 	if c == nil {
@@ -213,6 +323,24 @@ func (c *Config) GetRuntimeConfig(name string) (engine.RuntimeConfig, error) {
 	return &dockerRuntime{}, nil
 }
 
+// GetRuntimeNames returns the sorted names of the runtimes defined in the
+// config.
+func (c *Config) GetRuntimeNames() []string {
+	if c == nil {
+		return nil
+	}
+	runtimes, ok := (*c)["runtimes"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var names []string
+	for name := range runtimes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
 // String returns the string representation of the JSON config.
 func (c Config) String() string {
 	output, err := json.MarshalIndent(c, "", "    ")
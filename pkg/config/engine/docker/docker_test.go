@@ -17,11 +17,16 @@
 package docker
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
 )
 
 func TestUpdateConfigDefaultRuntime(t *testing.T) {
@@ -247,3 +252,205 @@ func TestGetRuntimeConfig(t *testing.T) {
 		require.Equal(t, tc.expected, rc.GetBinaryPath())
 	}
 }
+
+func TestWriteTo(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddRuntime("nvidia", "/usr/bin/nvidia-container-runtime", true))
+	c.EnableCDI()
+
+	buffer := &bytes.Buffer{}
+	n, err := c.WriteTo(buffer)
+	require.NoError(t, err)
+	require.EqualValues(t, buffer.Len(), n)
+
+	var written map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &written))
+
+	var expected map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(c.String()), &expected))
+
+	require.Equal(t, expected, written)
+	require.Equal(t, "nvidia", written["default-runtime"])
+}
+
+func TestEnableCDI(t *testing.T) {
+	testCases := []struct {
+		description    string
+		input          string
+		expectedConfig string
+	}{
+		{
+			description: "empty config",
+			expectedConfig: `{
+				"cdi-spec-dirs": ["/etc/cdi", "/var/run/cdi"],
+				"features": {"cdi": true}
+			}`,
+		},
+		{
+			description: "existing features are preserved",
+			input: `{
+				"features": {"buildkit": true, "containerd-snapshotter": false},
+				"log-level": "debug"
+			}`,
+			expectedConfig: `{
+				"cdi-spec-dirs": ["/etc/cdi", "/var/run/cdi"],
+				"features": {"buildkit": true, "cdi": true, "containerd-snapshotter": false},
+				"log-level": "debug"
+			}`,
+		},
+		{
+			description: "existing spec dirs are preserved",
+			input: `{
+				"cdi-spec-dirs": ["/opt/cdi", "/etc/cdi"],
+				"features": {"cdi": false}
+			}`,
+			expectedConfig: `{
+				"cdi-spec-dirs": ["/opt/cdi", "/etc/cdi"],
+				"features": {"cdi": true}
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c, err := New(WithConfigSource(bytes.NewReader([]byte(tc.input))))
+			require.NoError(t, err)
+
+			c.EnableCDI()
+
+			buffer := &bytes.Buffer{}
+			_, err = c.WriteTo(buffer)
+			require.NoError(t, err)
+
+			require.JSONEq(t, tc.expectedConfig, buffer.String())
+		})
+	}
+}
+
+func TestDisableCDI(t *testing.T) {
+	testCases := []struct {
+		description    string
+		input          string
+		expectedConfig string
+	}{
+		{
+			description: "enabled CDI is removed",
+			input: `{
+				"cdi-spec-dirs": ["/etc/cdi", "/var/run/cdi"],
+				"features": {"cdi": true},
+				"log-level": "debug"
+			}`,
+			expectedConfig: `{
+				"log-level": "debug"
+			}`,
+		},
+		{
+			description: "other features and spec dirs are preserved",
+			input: `{
+				"cdi-spec-dirs": ["/opt/cdi", "/etc/cdi", "/var/run/cdi"],
+				"features": {"buildkit": true, "cdi": true}
+			}`,
+			expectedConfig: `{
+				"cdi-spec-dirs": ["/opt/cdi", "/etc/cdi", "/var/run/cdi"],
+				"features": {"buildkit": true}
+			}`,
+		},
+		{
+			description: "user-set default spec dirs in a different order are preserved",
+			input: `{
+				"cdi-spec-dirs": ["/var/run/cdi", "/etc/cdi"],
+				"features": {"cdi": true}
+			}`,
+			expectedConfig: `{
+				"cdi-spec-dirs": ["/var/run/cdi", "/etc/cdi"]
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c, err := New(WithConfigSource(bytes.NewReader([]byte(tc.input))))
+			require.NoError(t, err)
+
+			c.DisableCDI()
+
+			buffer := &bytes.Buffer{}
+			_, err = c.WriteTo(buffer)
+			require.NoError(t, err)
+
+			require.JSONEq(t, tc.expectedConfig, buffer.String())
+		})
+	}
+}
+
+func TestIsCDIEnabled(t *testing.T) {
+	testCases := []struct {
+		description string
+		input       string
+		expected    bool
+	}{
+		{
+			description: "empty config",
+		},
+		{
+			description: "features without cdi",
+			input:       `{"features": {"buildkit": true}}`,
+		},
+		{
+			description: "cdi disabled",
+			input:       `{"features": {"cdi": false}}`,
+		},
+		{
+			description: "cdi enabled",
+			input:       `{"features": {"cdi": true}}`,
+			expected:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c, err := New(WithConfigSource(bytes.NewReader([]byte(tc.input))))
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, c.IsCDIEnabled())
+		})
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	_, err := New(WithConfigSource(bytes.NewReader([]byte(`{"runtimes": `))))
+	require.ErrorIs(t, err, engine.ErrConfigParse)
+}
+
+func TestNewUnreadableConfig(t *testing.T) {
+	_, err := New(WithPath(t.TempDir()))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, engine.ErrConfigParse)
+}
+
+func TestSetSystemdCgroup(t *testing.T) {
+	c, err := New(WithConfigSource(bytes.NewReader([]byte(`{"runtimes": {"nvidia": {"path": "nvidia-container-runtime"}}}`))))
+	require.NoError(t, err)
+
+	original := c.String()
+	require.NoError(t, c.SetSystemdCgroup("nvidia", true))
+	require.Equal(t, original, c.String())
+
+	err = c.SetSystemdCgroup("runc", true)
+	require.ErrorIs(t, err, engine.ErrRuntimeNotFound)
+}
+
+func TestSaveEmptyConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"runtimes": {}}`), 0600))
+
+	c := Config{}
+	_, err := c.Save(path)
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.JSONEq(t, `{}`, string(contents))
+}
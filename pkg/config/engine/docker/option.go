@@ -20,14 +20,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/engine"
 )
 
 type builder struct {
-	logger logger.Interface
-	path   string
+	logger       logger.Interface
+	path         string
+	configSource io.Reader
 }
 
 // Option defines a function that can be used to configure the config builder
@@ -47,7 +50,18 @@ func WithPath(path string) Option {
 	}
 }
 
+// WithConfigSource sets the source to read the config from.
+// If this is set, the config path is not read.
+func WithConfigSource(configSource io.Reader) Option {
+	return func(b *builder) {
+		b.configSource = configSource
+	}
+}
+
 func (b *builder) build() (*Config, error) {
+	if b.configSource != nil {
+		return b.readConfig(b.configSource)
+	}
 	if b.path == "" {
 		empty := make(Config)
 		return &empty, nil
@@ -59,7 +73,7 @@ func (b *builder) build() (*Config, error) {
 // loadConfig loads the docker config from disk
 func (b *builder) loadConfig(config string) (*Config, error) {
 	info, err := os.Stat(config)
-	if os.IsExist(err) && info.IsDir() {
+	if err == nil && info.IsDir() {
 		return nil, fmt.Errorf("config file is a directory")
 	}
 
@@ -76,9 +90,24 @@ func (b *builder) loadConfig(config string) (*Config, error) {
 		return nil, fmt.Errorf("unable to read config: %v", err)
 	}
 
-	reader := bytes.NewReader(readBytes)
-	if err := json.NewDecoder(reader).Decode(&cfg); err != nil {
-		return nil, err
+	return b.readConfig(bytes.NewReader(readBytes))
+}
+
+// readConfig reads the docker config from the specified reader.
+// If the reader has no contents, an empty config is returned.
+func (b *builder) readConfig(reader io.Reader) (*Config, error) {
+	readBytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config: %v", err)
+	}
+
+	cfg := make(Config)
+	if len(bytes.TrimSpace(readBytes)) == 0 {
+		return &cfg, nil
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(readBytes)).Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%w: %w", engine.ErrConfigParse, err)
 	}
 	return &cfg, nil
 }
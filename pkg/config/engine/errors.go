@@ -0,0 +1,45 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config/toml"
+)
+
+var (
+	// ErrRuntimeNotFound is returned if an operation references a runtime
+	// that is not defined in the config.
+	ErrRuntimeNotFound = errors.New("runtime not found")
+	// ErrConfigParse is returned if the config of a container engine cannot
+	// be parsed.
+	ErrConfigParse = errors.New("failed to parse config")
+)
+
+// WrapLoadError wraps an error that was returned when loading a TOML config.
+// Only errors that occur when parsing the config are reported as
+// ErrConfigParse. Other errors, such as failures to read a config file, are
+// returned as is.
+func WrapLoadError(err error) error {
+	if errors.Is(err, toml.ErrParse) {
+		return fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	return fmt.Errorf("failed to load config: %w", err)
+}
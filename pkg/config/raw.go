@@ -38,7 +38,7 @@ func (c Raw) Write(output []byte) (int, error) {
 
 	if len(output) == 0 {
 		err := os.Remove(path)
-		if err != nil {
+		if err != nil && !os.IsNotExist(err) {
 			return 0, fmt.Errorf("unable to remove empty file: %v", err)
 		}
 		return 0, nil
@@ -51,11 +51,42 @@ func (c Raw) Write(output []byte) (int, error) {
 		}
 	}
 
-	f, err := os.Create(path)
+	return writeFileAtomic(path, output)
+}
+
+// writeFileAtomic writes the specified contents to a temporary file in the
+// same directory as path and renames it into place. This ensures that readers
+// of the config never observe a partially-written file. The permissions of an
+// existing file are preserved.
+func writeFileAtomic(path string, output []byte) (int, error) {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
 	if err != nil {
-		return 0, fmt.Errorf("unable to open %v for writing: %v", path, err)
+		return 0, fmt.Errorf("unable to create temporary file for %v: %v", path, err)
 	}
-	defer f.Close()
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
 
-	return f.Write(output)
+	n, err := f.Write(output)
+	if err != nil {
+		f.Close()
+		return 0, fmt.Errorf("unable to write %v: %v", f.Name(), err)
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		return 0, fmt.Errorf("unable to set permissions on %v: %v", f.Name(), err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, fmt.Errorf("unable to close %v: %v", f.Name(), err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return 0, fmt.Errorf("unable to rename %v to %v: %v", f.Name(), path, err)
+	}
+	return n, nil
 }
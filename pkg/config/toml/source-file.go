@@ -26,9 +26,11 @@ type tomlFile string
 var _ Loader = (*tomlFile)(nil)
 
 // Load loads the contents of the specified TOML file as a map.
+// If the file does not exist, an empty config is returned so that a config
+// can be created from scratch.
 func (f tomlFile) Load() (*Tree, error) {
 	info, err := os.Stat(string(f))
-	if os.IsExist(err) && info.IsDir() {
+	if err == nil && info.IsDir() {
 		return nil, fmt.Errorf("config file %s is a directory", string(f))
 	}
 
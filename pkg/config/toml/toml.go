@@ -17,13 +17,19 @@
 package toml
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/pelletier/go-toml"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/config"
 )
 
+// ErrParse is returned if the contents of a TOML config cannot be parsed.
+var ErrParse = errors.New("failed to parse TOML")
+
 type Tree toml.Tree
 
 // Copy produces a copy of the contents of the Tree.
@@ -114,20 +120,30 @@ func TreeFromMap(m map[string]interface{}) (*Tree, error) {
 
 func Load(content string) (*Tree, error) {
 	return new(func() (*toml.Tree, error) {
-		return toml.Load(content)
+		tree, err := toml.Load(content)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParse, err)
+		}
+		return tree, nil
 	})
 }
 
 func LoadBytes(b []byte) (*Tree, error) {
 	return new(func() (*toml.Tree, error) {
-		return toml.LoadBytes(b)
+		tree, err := toml.LoadBytes(b)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParse, err)
+		}
+		return tree, nil
 	})
 }
 
 func LoadFile(path string) (*Tree, error) {
-	return new(func() (*toml.Tree, error) {
-		return toml.LoadFile(path)
-	})
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadBytes(contents)
 }
 
 func LoadMap(m map[string]interface{}) (*Tree, error) {
@@ -157,3 +173,14 @@ func (t *Tree) Save(path string) (int64, error) {
 	n, err := config.Raw(path).Write(output)
 	return int64(n), err
 }
+
+// WriteTo writes the TOML representation of the config to the specified writer.
+func (t *Tree) WriteTo(w io.Writer) (int64, error) {
+	output, err := (*toml.Tree)(t).Marshal()
+	if err != nil {
+		return 0, fmt.Errorf("unable to convert to TOML: %v", err)
+	}
+
+	n, err := w.Write(output)
+	return int64(n), err
+}
@@ -18,10 +18,13 @@ package lookup
 
 import (
 	"errors"
+	"path/filepath"
 )
 
 type first []Locator
 
+type merge []Locator
+
 type unique struct {
 	locator Locator
 }
@@ -58,6 +61,51 @@ func (f first) Locate(pattern string) ([]string, error) {
 	return nil, errors.Join(allErrors...)
 }
 
+// Merge returns a locator that returns the candidates from all the specified
+// locators. Candidates that resolve to the same path are only included once
+// with the first candidate being returned.
+func Merge(locators ...Locator) Locator {
+	var m merge
+	for _, l := range locators {
+		if l == nil {
+			continue
+		}
+		m = append(m, l)
+	}
+	return m
+}
+
+// Locate returns the unique candidates from all locators. An error is only
+// returned if none of the locators return a candidate.
+func (m merge) Locate(pattern string) ([]string, error) {
+	var allErrors []error
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, l := range m {
+		located, err := l.Locate(pattern)
+		if err != nil {
+			allErrors = append(allErrors, err)
+			continue
+		}
+		for _, candidate := range located {
+			resolved, err := filepath.EvalSymlinks(candidate)
+			if err != nil {
+				resolved = candidate
+			}
+			if seen[resolved] {
+				continue
+			}
+			seen[resolved] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates, nil
+	}
+
+	return nil, errors.Join(allErrors...)
+}
+
 func AsUnique(locator Locator) Locator {
 	return &unique{
 		locator: locator,
@@ -27,6 +27,7 @@ import (
 // Interface defines the API for the nvcdi package
 type Interface interface {
 	SpecGenerator
+	Discoverer
 	GetCommonEdits() (*cdi.ContainerEdits, error)
 	GetDeviceSpecsByID(...string) ([]specs.Device, error)
 	// Deprecated: GetAllDeviceSpecs is deprecated. Use GetDeviceSpecsByID("all") instead.
@@ -39,6 +40,12 @@ type SpecGenerator interface {
 	GetSpec(...string) (spec.Interface, error)
 }
 
+// A Discoverer is used to discover devices and their container edits without
+// generating a CDI spec.
+type Discoverer interface {
+	Discover(...string) (*Discovery, error)
+}
+
 // A DeviceSpecGenerator is used to generate the specs for one or more devices.
 type DeviceSpecGenerator interface {
 	GetDeviceSpecs() ([]specs.Device, error)
@@ -47,10 +54,24 @@ type DeviceSpecGenerator interface {
 // A HookName represents one of the predefined NVIDIA CDI hooks.
 type HookName = discover.HookName
 
+// An Explainer records what each discoverer found or why it was skipped.
+type Explainer = discover.Explainer
+
+// An ExplainEntry describes the decision made by a single discoverer.
+type ExplainEntry = discover.ExplainEntry
+
+// NewExplainer creates an Explainer that can be passed to WithExplainer.
+func NewExplainer() *Explainer {
+	return discover.NewExplainer()
+}
+
 const (
 	// AllHooks is a special hook name that allows all hooks to be matched.
 	AllHooks = discover.AllHooks
 
+	// A CheckLibrariesHook is used to verify that the injected libraries
+	// resolve in the container. This hook is disabled by default.
+	CheckLibrariesHook = discover.CheckLibrariesHook
 	// A CreateSymlinksHook is used to create symlinks in the container.
 	CreateSymlinksHook = discover.CreateSymlinksHook
 	// DisableDeviceNodeModificationHook refers to the hook used to ensure that
@@ -72,6 +93,20 @@ const (
 	HookUpdateLDCache = UpdateLDCacheHook
 )
 
+const (
+	// DeviceTypeAnnotation is the annotation added to devices when the
+	// FeatureEnableDeviceTypeAnnotations feature flag is set.
+	DeviceTypeAnnotation = "gpu.nvidia.com/device-type"
+	// DeviceTypeGPU is the device type annotation value for full GPUs.
+	DeviceTypeGPU = "gpu"
+	// DeviceTypeMIG is the device type annotation value for MIG devices.
+	DeviceTypeMIG = "mig"
+	// MIGProfileAnnotation is the annotation added to MIG devices when the
+	// FeatureEnableMIGProfileAnnotations feature flag is set. The value is
+	// the MIG profile of the device (e.g. 1g.5gb).
+	MIGProfileAnnotation = "gpu.nvidia.com/mig-profile"
+)
+
 // A FeatureFlag refers to a specific feature that can be toggled in the CDI api.
 // All features are off by default.
 type FeatureFlag string
@@ -89,6 +124,19 @@ const (
 	// coherent or non-coherent devices.
 	FeatureEnableCoherentAnnotations = FeatureFlag("enable-coherent-annotations")
 
+	// FeatureEnableDeviceTypeAnnotations enables the addition of annotations
+	// indicating whether a device is a full GPU or a MIG device.
+	FeatureEnableDeviceTypeAnnotations = FeatureFlag("enable-device-type-annotations")
+
+	// FeatureEnableMIGProfileAnnotations enables the addition of annotations
+	// indicating the MIG profile of a MIG device.
+	FeatureEnableMIGProfileAnnotations = FeatureFlag("enable-mig-profile-annotations")
+
+	// FeatureEnableConfidentialComputing enables the inclusion of the
+	// nvidia-caps device nodes required by GPUs in confidential computing (CC)
+	// mode. This only has an effect if CC is enabled on the system.
+	FeatureEnableConfidentialComputing = FeatureFlag("cc")
+
 	// FeatureDisableMultipleCSVDevices disables the handling of multiple devices
 	// in CSV mode.
 	FeatureDisableMultipleCSVDevices = FeatureFlag("disable-multiple-csv-devices")
@@ -96,4 +144,32 @@ const (
 	// FeatureNoAdditionalGIDsForDeviceNodes disables the injection of additional GIDs
 	// for a device node when the node is not readable and writable by the user.
 	FeatureNoAdditionalGIDsForDeviceNodes = FeatureFlag("no-additional-gids-for-device-nodes")
+
+	// FeatureEnableDeveloperTools enables the inclusion of the debugging and
+	// profiling tools that are shipped with the driver (e.g. the cuda-gdb
+	// debugger backend) if these are present.
+	FeatureEnableDeveloperTools = FeatureFlag("enable-developer-tools")
+
+	// FeatureUseLDLibraryPath sets LD_LIBRARY_PATH to the folders of the
+	// injected driver libraries instead of adding a hook that updates the
+	// ldcache in the container. This is intended for containers with a
+	// read-only rootfs where ldconfig cannot be run.
+	FeatureUseLDLibraryPath = FeatureFlag("use-ld-library-path")
+
+	// FeatureEnableNestedContainers enables the inclusion of the
+	// nvidia-container-cli and libnvidia-container libraries (if these are
+	// present) so that GPU containers can be started from within a container
+	// (e.g. Docker-in-Docker).
+	FeatureEnableNestedContainers = FeatureFlag("enable-nested-containers")
+
+	// FeatureEnableDriverManifest enables the use of the manifest written by
+	// the driver installer (if present) to determine the driver libraries and
+	// binaries that are included instead of searching the ldcache for
+	// libraries with the driver version suffix.
+	FeatureEnableDriverManifest = FeatureFlag("enable-driver-manifest")
+
+	// FeatureEnableLibcudaSonameSymlink enables the creation of the
+	// libcuda.so.1 -> libcuda.so.RM_VERSION symlink in the container even if
+	// this symlink does not exist on the host.
+	FeatureEnableLibcudaSonameSymlink = FeatureFlag("enable-libcuda-soname-symlink")
 )
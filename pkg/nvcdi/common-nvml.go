@@ -19,18 +19,16 @@ package nvcdi
 import (
 	"fmt"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 )
 
 // newCommonNVMLDiscoverer returns a discoverer for entities that are not associated with a specific CDI device.
 // This includes driver libraries and meta devices, for example.
 func (l *nvmllib) newCommonNVMLDiscoverer() (discover.Discover, error) {
-	metaDevices := l.controlDeviceNodeDiscoverer()
+	metaDevices := l.explainer.Explain("control devices", l.controlDeviceNodeDiscoverer())
 
-	graphicsMounts, err := discover.NewGraphicsMountsDiscoverer(l.logger, l.driver, l.hookCreator)
-	if err != nil {
-		l.logger.Warningf("failed to create discoverer for graphics mounts: %v", err)
-	}
+	graphicsMounts := l.graphicsMountsDiscoverer()
 
 	driverFiles, err := l.NewDriverDiscoverer()
 	if err != nil {
@@ -40,14 +38,33 @@ func (l *nvmllib) newCommonNVMLDiscoverer() (discover.Discover, error) {
 	d := discover.Merge(
 		metaDevices,
 		graphicsMounts,
-		driverFiles,
+		l.explainer.Explain("driver files", driverFiles),
+		l.confidentialComputingDiscoverer(),
 	)
 
 	return d, nil
 }
 
+// graphicsMountsDiscoverer returns a discoverer for the graphics libraries and
+// configuration files if the graphics or display driver capabilities are
+// requested.
+func (l *nvmllib) graphicsMountsDiscoverer() discover.Discover {
+	const name = "graphics mounts"
+	if !l.driverCapabilities.Any(image.DriverCapabilityGraphics, image.DriverCapabilityDisplay) {
+		l.explainer.Skip(name, fmt.Sprintf("driver capabilities %q and %q not requested", image.DriverCapabilityGraphics, image.DriverCapabilityDisplay))
+		return nil
+	}
+	graphicsMounts, err := discover.NewGraphicsMountsDiscoverer(l.logger, l.driver, l.hookCreator)
+	if err != nil {
+		l.logger.Warningf("failed to create discoverer for graphics mounts: %v", err)
+		l.explainer.Skip(name, err.Error())
+		return nil
+	}
+	return l.explainer.Explain(name, graphicsMounts)
+}
+
 func (l *nvmllib) controlDeviceNodeDiscoverer() discover.Discover {
-	return discover.NewCharDeviceDiscoverer(
+	controlDeviceNodes := discover.NewCharDeviceDiscoverer(
 		l.logger,
 		l.driver.DevRoot,
 		[]string{
@@ -57,4 +74,5 @@ func (l *nvmllib) controlDeviceNodeDiscoverer() discover.Discover {
 			"/dev/nvidiactl",
 		},
 	)
+	return (*nvcdilib)(l).withDevCharPaths(controlDeviceNodes)
 }
@@ -0,0 +1,81 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+// confidentialComputingDiscoverer returns a discoverer for the device nodes
+// required by GPUs in confidential computing (CC) mode. If the
+// FeatureEnableConfidentialComputing feature flag is not set, or CC is not
+// enabled on the system, no entities are discovered.
+func (l *nvmllib) confidentialComputingDiscoverer() discover.Discover {
+	const name = "confidential computing devices"
+	if !l.featureFlags[FeatureEnableConfidentialComputing] {
+		l.explainer.Skip(name, fmt.Sprintf("feature flag %q not set", FeatureEnableConfidentialComputing))
+		return discover.None{}
+	}
+
+	if (*nvcdilib)(l).dryRun() {
+		l.explainer.Skip(name, dryRunReason)
+		return discover.None{}
+	}
+
+	isEnabled, err := l.isConfidentialComputingEnabled()
+	if err != nil {
+		l.logger.Warningf("Ignoring confidential computing devices: %v", err)
+		l.explainer.Skip(name, err.Error())
+		return discover.None{}
+	}
+	if !isEnabled {
+		l.logger.Debugf("Confidential computing is not enabled; skipping confidential computing devices")
+		l.explainer.Skip(name, "confidential computing is not enabled")
+		return discover.None{}
+	}
+
+	devices := discover.NewCharDeviceDiscoverer(
+		l.logger,
+		l.driver.DevRoot,
+		[]string{
+			"/dev/nvidia-caps/nvidia-cap*",
+		},
+	)
+	return l.explainer.Explain(name, devices)
+}
+
+// isConfidentialComputingEnabled queries NVML to check whether the
+// confidential computing feature is enabled on the system.
+func (l *nvmllib) isConfidentialComputingEnabled() (bool, error) {
+	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
+		return false, fmt.Errorf("failed to initialize NVML: %v", r)
+	}
+	defer func() {
+		_ = l.nvmllib.Shutdown()
+	}()
+
+	state, r := l.nvmllib.SystemGetConfComputeState()
+	if r != nvml.SUCCESS {
+		return false, fmt.Errorf("failed to get confidential computing state: %v", r)
+	}
+	return state.CcFeature == nvml.CC_SYSTEM_FEATURE_ENABLED, nil
+}
@@ -0,0 +1,109 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestConfidentialComputingDiscoverer(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	testCases := []struct {
+		description     string
+		featureFlags    []string
+		ccState         nvml.ConfComputeSystemState
+		ccStateReturn   nvml.Return
+		expectedDevices []discover.Device
+	}{
+		{
+			description: "feature flag not set",
+			ccState: nvml.ConfComputeSystemState{
+				CcFeature: nvml.CC_SYSTEM_FEATURE_ENABLED,
+			},
+		},
+		{
+			description:  "cc not enabled",
+			featureFlags: []string{"cc"},
+			ccState: nvml.ConfComputeSystemState{
+				CcFeature: nvml.CC_SYSTEM_FEATURE_DISABLED,
+			},
+		},
+		{
+			description:   "cc state not supported",
+			featureFlags:  []string{"cc"},
+			ccStateReturn: nvml.ERROR_NOT_SUPPORTED,
+		},
+		{
+			description:  "cc enabled",
+			featureFlags: []string{"cc"},
+			ccState: nvml.ConfComputeSystemState{
+				CcFeature: nvml.CC_SYSTEM_FEATURE_ENABLED,
+			},
+			expectedDevices: []discover.Device{
+				{
+					HostPath: filepath.Join(driverRoot, "/dev/nvidia-caps/nvidia-cap1"),
+					Path:     "/dev/nvidia-caps/nvidia-cap1",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := dgxa100.New()
+			server.SystemGetConfComputeStateFunc = func() (nvml.ConfComputeSystemState, nvml.Return) {
+				if tc.ccStateReturn != nvml.SUCCESS {
+					return nvml.ConfComputeSystemState{}, tc.ccStateReturn
+				}
+				return tc.ccState, nvml.SUCCESS
+			}
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithNvmlLib(server),
+				WithFeatureFlags(tc.featureFlags...),
+			)
+			require.NoError(t, err)
+
+			l := lib.(*wrapper).factory.(*nvmllib)
+
+			devices, err := l.confidentialComputingDiscoverer().Devices()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, devices)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+const (
+	// DeviceClassCommon is the device class used to set the permissions of
+	// the device nodes included in the common edits of a spec. These are
+	// device nodes such as /dev/nvidiactl that are required by all devices.
+	DeviceClassCommon = "common"
+)
+
+// A deviceClasser returns the device class of the device specs that it
+// generates. Generators that do not implement this interface generate devices
+// with the class of the spec.
+type deviceClasser interface {
+	deviceClass() string
+}
+
+func (l *fullGPUDeviceSpecGenerator) deviceClass() string {
+	return DeviceTypeGPU
+}
+
+func (l *migDeviceSpecGenerator) deviceClass() string {
+	return DeviceTypeMIG
+}
+
+func (l *imexChannel) deviceClass() string {
+	return classImexChannel
+}
+
+// A deviceSpecGeneratorWrapper wraps a device spec generator that requires
+// additional setup such as initializing NVML. The wrapped generator can be
+// accessed to determine the device class of each of the generators that it
+// contains.
+type deviceSpecGeneratorWrapper interface {
+	withWrapped(func(DeviceSpecGenerator) ([]specs.Device, error)) ([]specs.Device, error)
+}
+
+// withWrapped calls the specified function for the wrapped device spec
+// generator with NVML initialized.
+func (d *deviceSpecGeneratorsWithAndShutdown) withWrapped(f func(DeviceSpecGenerator) ([]specs.Device, error)) ([]specs.Device, error) {
+	if err := d.init(); err != nil {
+		return nil, err
+	}
+	defer d.tryShutdown()
+
+	return f(d.DeviceSpecGenerator)
+}
+
+// ValidateDeviceNodePermissions checks that the specified per-class
+// permissions are valid cgroup device permissions; a combination of r, w, and
+// m.
+func ValidateDeviceNodePermissions(permissions map[string]string) error {
+	for class, p := range permissions {
+		if p == "" || strings.Trim(p, "rwm") != "" {
+			return fmt.Errorf("invalid device node permissions %q for device class %q", p, class)
+		}
+	}
+	return nil
+}
+
+// getDeviceSpecs returns the device specs for the specified generator with
+// the configured device node permissions and replicas applied.
+func (l *wrapper) getDeviceSpecs(generator DeviceSpecGenerator) ([]specs.Device, error) {
+	if len(l.deviceNodePermissions) == 0 && l.replicas <= 1 {
+		return generator.GetDeviceSpecs()
+	}
+	if w, ok := generator.(deviceSpecGeneratorWrapper); ok {
+		return w.withWrapped(l.getDeviceSpecs)
+	}
+	if generators, ok := generator.(DeviceSpecGenerators); ok {
+		var allDeviceSpecs []specs.Device
+		for _, g := range generators {
+			if g == nil {
+				continue
+			}
+			deviceSpecs, err := l.getDeviceSpecs(g)
+			if err != nil {
+				return nil, err
+			}
+			allDeviceSpecs = append(allDeviceSpecs, deviceSpecs...)
+		}
+		return allDeviceSpecs, nil
+	}
+
+	deviceSpecs, err := generator.GetDeviceSpecs()
+	if err != nil {
+		return nil, err
+	}
+
+	class := l.class
+	if c, ok := generator.(deviceClasser); ok {
+		class = c.deviceClass()
+	}
+	for i := range deviceSpecs {
+		deviceSpecs[i].ContainerEdits = l.withDeviceNodePermissions(class, deviceSpecs[i].ContainerEdits)
+	}
+	if class == DeviceTypeGPU {
+		return l.withReplicas(deviceSpecs)
+	}
+	return deviceSpecs, nil
+}
+
+// withDeviceNodePermissions returns a copy of the specified edits where the
+// permissions of the device nodes are set to those configured for the
+// specified device class. The edits are returned unmodified if no permissions
+// are configured for the class.
+func (l *wrapper) withDeviceNodePermissions(class string, edits specs.ContainerEdits) specs.ContainerEdits {
+	permissions, ok := l.deviceNodePermissions[class]
+	if !ok {
+		return edits
+	}
+	var deviceNodes []*specs.DeviceNode
+	for _, dn := range edits.DeviceNodes {
+		if dn == nil {
+			continue
+		}
+		withPermissions := *dn
+		withPermissions.Permissions = permissions
+		deviceNodes = append(deviceNodes, &withPermissions)
+	}
+	edits.DeviceNodes = deviceNodes
+	return edits
+}
@@ -0,0 +1,226 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	mocknvml "github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestDeviceNodePermissions(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	hostRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	expectedSpec := `---
+cdiVersion: 0.5.0
+kind: nvidia.com/imex-channel
+devices:
+    - name: "0"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia-caps-imex-channels/channel0
+              hostPath: {{ .hostRoot }}/dev/nvidia-caps-imex-channels/channel0
+              permissions: r
+containerEdits:
+    env:
+        - NVIDIA_VISIBLE_DEVICES=void
+`
+	expectedSpec = strings.ReplaceAll(expectedSpec, "{{ .hostRoot }}", hostRoot)
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeImex),
+		WithDriverRoot(hostRoot),
+		WithDeviceNodePermissions(map[string]string{"imex-channel": "r", "gpu": "rw"}),
+	)
+	require.NoError(t, err)
+
+	spec, err := lib.GetSpec("0")
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	_, err = spec.WriteTo(&b)
+	require.NoError(t, err)
+	require.Equal(t, expectedSpec, b.String())
+}
+
+func TestInvalidDeviceNodePermissions(t *testing.T) {
+	_, err := New(
+		WithMode(ModeImex),
+		WithDeviceNodePermissions(map[string]string{"imex-channel": "rwx"}),
+	)
+	require.EqualError(t, err, `invalid device node permissions "rwx" for device class "imex-channel"`)
+}
+
+func TestDeviceNodePermissionsNvml(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	testCases := []struct {
+		description string
+		class       string
+	}{
+		{
+			description: "default class",
+		},
+		{
+			description: "class differs from device classes",
+			class:       "custom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithDevRoot(newMIGEnabledDevRoot(t)),
+				WithNvmlLib(newMIGEnabledServer()),
+				WithClass(tc.class),
+				WithDeviceNodePermissions(map[string]string{"gpu": "rw", "mig": "r"}),
+			)
+			require.NoError(t, err)
+			withTestMIGCaps(lib)
+
+			deviceSpecs, err := lib.GetDeviceSpecsByID("all")
+			require.NoError(t, err)
+
+			permissions := make(map[string][]string)
+			for _, deviceSpec := range deviceSpecs {
+				require.NotEmpty(t, deviceSpec.ContainerEdits.DeviceNodes)
+				for _, dn := range deviceSpec.ContainerEdits.DeviceNodes {
+					permissions[deviceSpec.Name] = append(permissions[deviceSpec.Name], dn.Permissions)
+				}
+			}
+			require.Equal(t, map[string][]string{
+				"1":   {"rw"},
+				"0:0": {"r", "r", "r"},
+			}, permissions)
+		})
+	}
+}
+
+// newMIGEnabledServer returns a mock DGX A100 with two GPUs where MIG is
+// enabled on GPU 0 with a single MIG device (0:0).
+func newMIGEnabledServer() *dgxa100.Server {
+	const migUUID = "MIG-12345678-1234-1234-1234-123456789abc"
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 2, nvml.SUCCESS
+	}
+
+	parent := server.Devices[0].(*dgxa100.Device)
+	mig := &mocknvml.Device{
+		IsMigDeviceHandleFunc: func() (bool, nvml.Return) {
+			return true, nvml.SUCCESS
+		},
+		GetDeviceHandleFromMigDeviceHandleFunc: func() (nvml.Device, nvml.Return) {
+			return parent, nvml.SUCCESS
+		},
+		GetIndexFunc: func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		},
+		GetUUIDFunc: func() (string, nvml.Return) {
+			return migUUID, nvml.SUCCESS
+		},
+		GetGpuInstanceIdFunc: func() (int, nvml.Return) {
+			return 1, nvml.SUCCESS
+		},
+		GetComputeInstanceIdFunc: func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		},
+	}
+
+	for _, d := range server.Devices {
+		d := d.(*dgxa100.Device)
+		d.IsMigDeviceHandleFunc = func() (bool, nvml.Return) {
+			return false, nvml.SUCCESS
+		}
+		d.GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			if d == parent {
+				return 1, nvml.SUCCESS
+			}
+			return 0, nvml.SUCCESS
+		}
+	}
+	parent.MigMode = nvml.DEVICE_MIG_ENABLE
+	parent.GetMigDeviceHandleByIndexFunc = func(n int) (nvml.Device, nvml.Return) {
+		if n != 0 {
+			return nil, nvml.ERROR_INVALID_ARGUMENT
+		}
+		return mig, nvml.SUCCESS
+	}
+
+	getHandleByUUID := server.DeviceGetHandleByUUIDFunc
+	server.DeviceGetHandleByUUIDFunc = func(uuid string) (nvml.Device, nvml.Return) {
+		if uuid == migUUID {
+			return mig, nvml.SUCCESS
+		}
+		return getHandleByUUID(uuid)
+	}
+	return server
+}
+
+// newMIGEnabledDevRoot creates a dev root containing the device nodes for the
+// server returned by newMIGEnabledServer.
+func newMIGEnabledDevRoot(t *testing.T) string {
+	devRoot := t.TempDir()
+	for _, deviceNode := range []string{"nvidia0", "nvidia1", "nvidia-caps/nvidia-cap1", "nvidia-caps/nvidia-cap2"} {
+		path := filepath.Join(devRoot, "dev", deviceNode)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, nil, 0600))
+	}
+	return devRoot
+}
+
+// withTestMIGCaps sets the MIG capabilities for the MIG device of the server
+// returned by newMIGEnabledServer.
+func withTestMIGCaps(lib Interface) {
+	lib.(*wrapper).factory.(*nvmllib).migCaps = nvcaps.MigCaps{
+		nvcaps.NewGPUInstanceCap(0, 1):        1,
+		nvcaps.NewComputeInstanceCap(0, 1, 0): 2,
+	}
+}
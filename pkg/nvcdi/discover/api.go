@@ -0,0 +1,41 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Package discover defines the interface implemented by the discoverers that
+// are used to generate CDI specifications. This allows custom discoverers to
+// be added to the discovery performed by the nvcdi package.
+package discover
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+// A Discoverer discovers the devices, envvars, mounts, and hooks that are
+// required in a container. The built-in discoverers implement this interface.
+type Discoverer = discover.Discover
+
+// A Device is a device node that is discovered on the host.
+type Device = discover.Device
+
+// An EnvVar is an environment variable that is set in a container.
+type EnvVar = discover.EnvVar
+
+// A Mount is a host path that is mounted into a container.
+type Mount = discover.Mount
+
+// A Hook is an OCI hook that is run for a container.
+type Hook = discover.Hook
@@ -0,0 +1,175 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+// A Discovery holds the devices and container edits discovered for a set of
+// requested device IDs. These are independent of the CDI spec format and can
+// be used to construct specs for other consumers.
+type Discovery struct {
+	// Devices lists the discovered devices.
+	Devices []DiscoveredDevice
+	// CommonEdits are the edits required by all discovered devices.
+	CommonEdits ContainerEdits
+}
+
+// A DiscoveredDevice is a single device and the edits required to make it
+// available in a container.
+type DiscoveredDevice struct {
+	Name        string
+	Annotations map[string]string
+	Edits       ContainerEdits
+}
+
+// ContainerEdits are the modifications required to make a device available in
+// a container.
+type ContainerEdits struct {
+	Env            []string
+	DeviceNodes    []DeviceNode
+	Mounts         []Mount
+	Hooks          []Hook
+	AdditionalGIDs []uint32
+}
+
+// A DeviceNode is a device node that is injected into a container.
+type DeviceNode struct {
+	Path        string
+	HostPath    string
+	Type        string
+	Major       int64
+	Minor       int64
+	FileMode    *os.FileMode
+	Permissions string
+	UID         *uint32
+	GID         *uint32
+}
+
+// A Mount is a host path that is mounted into a container.
+type Mount struct {
+	HostPath      string
+	ContainerPath string
+	Type          string
+	Options       []string
+}
+
+// A Hook is an OCI hook that is run for a container.
+type Hook struct {
+	HookName string
+	Path     string
+	Args     []string
+	Env      []string
+	Timeout  *int
+}
+
+// Discover returns the devices and common edits for the specified device IDs.
+// If no device IDs are specified, all devices are discovered. The edits are
+// deduplicated and sorted as they would be in a generated spec.
+func (l *wrapper) Discover(devices ...string) (*Discovery, error) {
+	if len(devices) == 0 {
+		devices = append(devices, "all")
+	}
+	deviceSpecs, err := l.GetDeviceSpecsByID(devices...)
+	if err != nil {
+		return nil, err
+	}
+
+	edits, err := l.GetCommonEdits()
+	if err != nil {
+		return nil, err
+	}
+
+	raw := &specs.Spec{
+		Devices:        deviceSpecs,
+		ContainerEdits: *edits.ContainerEdits,
+	}
+	if err := transform.NewSimplifier().Transform(raw); err != nil {
+		return nil, fmt.Errorf("failed to simplify discovered edits: %w", err)
+	}
+
+	d := &Discovery{
+		CommonEdits: newContainerEdits(&raw.ContainerEdits),
+	}
+	for _, deviceSpec := range raw.Devices {
+		d.Devices = append(d.Devices, DiscoveredDevice{
+			Name:        deviceSpec.Name,
+			Annotations: maps.Clone(deviceSpec.Annotations),
+			Edits:       newContainerEdits(&deviceSpec.ContainerEdits),
+		})
+	}
+	return d, nil
+}
+
+// newContainerEdits converts the specified CDI container edits.
+func newContainerEdits(edits *specs.ContainerEdits) ContainerEdits {
+	var e ContainerEdits
+	if edits == nil {
+		return e
+	}
+	e.Env = slices.Clone(edits.Env)
+	e.AdditionalGIDs = slices.Clone(edits.AdditionalGIDs)
+	for _, dn := range edits.DeviceNodes {
+		if dn == nil {
+			continue
+		}
+		e.DeviceNodes = append(e.DeviceNodes, DeviceNode{
+			Path:        dn.Path,
+			HostPath:    dn.HostPath,
+			Type:        dn.Type,
+			Major:       dn.Major,
+			Minor:       dn.Minor,
+			FileMode:    dn.FileMode,
+			Permissions: dn.Permissions,
+			UID:         dn.UID,
+			GID:         dn.GID,
+		})
+	}
+	for _, m := range edits.Mounts {
+		if m == nil {
+			continue
+		}
+		e.Mounts = append(e.Mounts, Mount{
+			HostPath:      m.HostPath,
+			ContainerPath: m.ContainerPath,
+			Type:          m.Type,
+			Options:       slices.Clone(m.Options),
+		})
+	}
+	for _, h := range edits.Hooks {
+		if h == nil {
+			continue
+		}
+		e.Hooks = append(e.Hooks, Hook{
+			HookName: h.HookName,
+			Path:     h.Path,
+			Args:     slices.Clone(h.Args),
+			Env:      slices.Clone(h.Env),
+			Timeout:  h.Timeout,
+		})
+	}
+	return e
+}
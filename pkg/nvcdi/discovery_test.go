@@ -0,0 +1,153 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestDiscover(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	lookupRoot := filepath.Join(moduleRoot, "testdata", "lookup")
+
+	commonEdits := ContainerEdits{
+		Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+	}
+
+	testCases := []struct {
+		description       string
+		mode              Mode
+		driverRootfs      string
+		devices           []string
+		expectedDiscovery *Discovery
+	}{
+		{
+			description:  "all imex channels",
+			mode:         ModeImex,
+			driverRootfs: "rootfs-1",
+			expectedDiscovery: &Discovery{
+				Devices: []DiscoveredDevice{
+					{
+						Name: "0",
+						Edits: ContainerEdits{
+							DeviceNodes: []DeviceNode{
+								{Path: "/dev/nvidia-caps-imex-channels/channel0", HostPath: "/dev/nvidia-caps-imex-channels/channel0"},
+							},
+						},
+					},
+					{
+						Name: "1",
+						Edits: ContainerEdits{
+							DeviceNodes: []DeviceNode{
+								{Path: "/dev/nvidia-caps-imex-channels/channel1", HostPath: "/dev/nvidia-caps-imex-channels/channel1"},
+							},
+						},
+					},
+					{
+						Name: "2047",
+						Edits: ContainerEdits{
+							DeviceNodes: []DeviceNode{
+								{Path: "/dev/nvidia-caps-imex-channels/channel2047", HostPath: "/dev/nvidia-caps-imex-channels/channel2047"},
+							},
+						},
+					},
+				},
+				CommonEdits: commonEdits,
+			},
+		},
+		{
+			description:  "selected imex channel",
+			mode:         ModeImex,
+			driverRootfs: "rootfs-1",
+			devices:      []string{"1"},
+			expectedDiscovery: &Discovery{
+				Devices: []DiscoveredDevice{
+					{
+						Name: "1",
+						Edits: ContainerEdits{
+							DeviceNodes: []DeviceNode{
+								{Path: "/dev/nvidia-caps-imex-channels/channel1", HostPath: "/dev/nvidia-caps-imex-channels/channel1"},
+							},
+						},
+					},
+				},
+				CommonEdits: commonEdits,
+			},
+		},
+		{
+			description:  "nvswitch devices",
+			mode:         ModeNvswitch,
+			driverRootfs: "rootfs-with-nvswitch",
+			expectedDiscovery: &Discovery{
+				Devices: []DiscoveredDevice{
+					{
+						Name: "all",
+						Edits: ContainerEdits{
+							DeviceNodes: []DeviceNode{
+								{Path: "/dev/nvidia-nvswitch0", HostPath: "/dev/nvidia-nvswitch0"},
+								{Path: "/dev/nvidia-nvswitch1", HostPath: "/dev/nvidia-nvswitch1"},
+								{Path: "/dev/nvidia-nvswitchctl", HostPath: "/dev/nvidia-nvswitchctl"},
+							},
+						},
+					},
+				},
+				CommonEdits: commonEdits,
+			},
+		},
+		{
+			description:  "peermem module not loaded",
+			mode:         ModePeermem,
+			driverRootfs: "rootfs-1",
+			expectedDiscovery: &Discovery{
+				Devices: []DiscoveredDevice{
+					{Name: "all"},
+				},
+				CommonEdits: commonEdits,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := filepath.Join(lookupRoot, tc.driverRootfs)
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(tc.mode),
+				WithDriverRoot(driverRoot),
+			)
+			require.NoError(t, err)
+
+			discovery, err := lib.Discover(tc.devices...)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDiscovery, test.StripRoot(discovery, driverRoot))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+// driverLibraryCapabilities associates the driver libraries with the driver
+// capabilities that require them. This follows the libraries that
+// libnvidia-container injects for each capability. Libraries that are
+// required by more than one capability list all of these.
+var driverLibraryCapabilities = []struct {
+	pattern      string
+	capabilities []image.DriverCapability
+}{
+	{"libnvidia-ml.so.*", []image.DriverCapability{image.DriverCapabilityUtility}},
+	{"libnvidia-cfg.so.*", []image.DriverCapability{image.DriverCapabilityUtility}},
+	{"libnvidia-nscq.so.*", []image.DriverCapability{image.DriverCapabilityUtility}},
+
+	{"libcuda.so.*", []image.DriverCapability{image.DriverCapabilityCompute}},
+	{"libcudadebugger.so.*", []image.DriverCapability{image.DriverCapabilityCompute}},
+	{"libnvidia-opencl.so.*", []image.DriverCapability{image.DriverCapabilityCompute}},
+	{"libnvidia-compiler.so.*", []image.DriverCapability{image.DriverCapabilityCompute}},
+	{"libnvidia-nvvm.so.*", []image.DriverCapability{image.DriverCapabilityCompute}},
+	{"libnvidia-pkcs11*.so.*", []image.DriverCapability{image.DriverCapabilityCompute}},
+	{"libnvidia-fatbinaryloader.so.*", []image.DriverCapability{image.DriverCapabilityCompute}},
+	{"libnvidia-allocator.so.*", []image.DriverCapability{image.DriverCapabilityCompute, image.DriverCapabilityGraphics}},
+	{"libnvidia-ptxjitcompiler.so.*", []image.DriverCapability{image.DriverCapabilityCompute, image.DriverCapabilityGraphics}},
+	{"libnvidia-gpucomp.so.*", []image.DriverCapability{image.DriverCapabilityCompute, image.DriverCapabilityGraphics}},
+
+	{"libvdpau_nvidia.so.*", []image.DriverCapability{image.DriverCapabilityVideo}},
+	{"libnvidia-encode.so.*", []image.DriverCapability{image.DriverCapabilityVideo}},
+	{"libnvidia-opticalflow.so.*", []image.DriverCapability{image.DriverCapabilityVideo}},
+	{"libnvcuvid.so.*", []image.DriverCapability{image.DriverCapabilityVideo}},
+
+	{"libnvidia-eglcore.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvidia-glcore.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvidia-tls.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvidia-glsi.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvidia-fbc.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvidia-ifr.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvidia-rtcore.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvoptix.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libGLX_nvidia.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libEGL_nvidia.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libGLESv2_nvidia.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libGLESv1_CM_nvidia.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvidia-glvkspirv.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+	{"libnvidia-cbl.so.*", []image.DriverCapability{image.DriverCapabilityGraphics}},
+
+	{"libnvidia-ngx.so.*", []image.DriverCapability{image.DriverCapabilityNgx}},
+}
+
+// IsValidDriverCapability checks whether the specified driver capability is
+// supported.
+func IsValidDriverCapability(capability string) bool {
+	if image.DriverCapability(capability) == image.DriverCapabilityAll {
+		return true
+	}
+	return image.SupportedDriverCapabilities.Has(image.DriverCapability(capability))
+}
+
+// withDriverCapabilitiesFilter removes the driver libraries that are only
+// required for driver capabilities that are not selected from the mounts of
+// the specified discoverer. Libraries that are not associated with a driver
+// capability are always included.
+func (l *nvcdilib) withDriverCapabilitiesFilter(d discover.Discover) discover.Discover {
+	if l.driverCapabilities.IsAll() {
+		return d
+	}
+	var denied []string
+	for _, library := range driverLibraryCapabilities {
+		if l.driverCapabilities.Any(library.capabilities...) {
+			continue
+		}
+		denied = append(denied, library.pattern)
+	}
+	return discover.WithMountsDenylist(l.logger, d, denied...)
+}
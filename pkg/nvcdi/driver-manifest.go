@@ -0,0 +1,118 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// driverManifestPath is the path at which the driver installer records the
+// manifest of the installed driver files.
+const driverManifestPath = "/var/lib/nvidia/.manifest"
+
+// A driverManifest lists the files that were installed by the driver
+// installer.
+type driverManifest struct {
+	path        string
+	libraries   []string
+	executables []string
+}
+
+// getDriverManifest returns the driver manifest at the driver root. If the
+// FeatureEnableDriverManifest feature flag is not set or no manifest is
+// present, nil is returned.
+func (l *nvcdilib) getDriverManifest() (*driverManifest, error) {
+	if !l.featureFlags[FeatureEnableDriverManifest] {
+		return nil, nil
+	}
+
+	path := filepath.Join(l.driver.Root, driverManifestPath)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		l.logger.Debugf("No driver manifest found at %v", path)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open driver manifest: %w", err)
+	}
+	defer f.Close()
+
+	manifest, err := parseDriverManifest(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse driver manifest %v: %w", path, err)
+	}
+	manifest.path = path
+	return manifest, nil
+}
+
+// parseDriverManifest parses the entries of a driver manifest. Each file entry
+// consists of the file name, its octal permissions, and its type followed by
+// type-specific fields. Lines that do not match this format, such as the
+// header lines of the manifest, are skipped. Native libraries (of type *_LIB)
+// and binaries (of type *_BINARY) are included. Symlinks and 32-bit
+// compatibility libraries are ignored.
+func parseDriverManifest(r io.Reader) (*driverManifest, error) {
+	manifest := &driverManifest{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		if _, err := strconv.ParseUint(fields[1], 8, 32); err != nil {
+			continue
+		}
+		if slices.Contains(fields[3:], "COMPAT32") {
+			continue
+		}
+
+		name := filepath.Base(fields[0])
+		switch fileType := fields[2]; {
+		case strings.HasSuffix(fileType, "_LIB"):
+			manifest.libraries = append(manifest.libraries, name)
+		case strings.HasSuffix(fileType, "_BINARY"):
+			manifest.executables = append(manifest.executables, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// filterExecutables returns the specified executables that are also listed in
+// the manifest.
+func (m *driverManifest) filterExecutables(executables []string) []string {
+	var filtered []string
+	for _, executable := range executables {
+		if slices.Contains(m.executables, executable) {
+			filtered = append(filtered, executable)
+		}
+	}
+	return filtered
+}
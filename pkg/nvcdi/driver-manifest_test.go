@@ -0,0 +1,181 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestParseDriverManifest(t *testing.T) {
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	f, err := os.Open(filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-manifest", driverManifestPath))
+	require.NoError(t, err)
+	defer f.Close()
+
+	manifest, err := parseDriverManifest(f)
+	require.NoError(t, err)
+
+	require.EqualValues(t,
+		[]string{
+			"libcuda.so.550.54.15",
+			"libnvidia-ml.so.550.54.15",
+			"libnvidia-ptxjitcompiler.so.550.54.15",
+			"libvdpau_nvidia.so.550.54.15",
+		},
+		manifest.libraries,
+	)
+	require.EqualValues(t,
+		[]string{
+			"nvidia-smi",
+			"nvidia-debugdump",
+			"nvidia-bug-report.sh",
+		},
+		manifest.executables,
+	)
+}
+
+func TestDriverManifestDiscoverers(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-manifest")
+
+	mount := func(path string) discover.Mount {
+		return discover.Mount{
+			HostPath: filepath.Join(driverRoot, path),
+			Path:     path,
+			Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+		}
+	}
+
+	testCases := []struct {
+		description       string
+		featureFlags      []string
+		expectedLibraries []discover.Mount
+		expectedBinaries  []discover.Mount
+	}{
+		{
+			description: "feature flag not set uses version suffix",
+			expectedLibraries: []discover.Mount{
+				mount("/usr/lib/x86_64-linux-gnu/libcuda.so.550.54.15"),
+				mount("/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.550.54.15"),
+				mount("/usr/lib/x86_64-linux-gnu/libnvidia-ptxjitcompiler.so.550.54.15"),
+				mount("/usr/lib/x86_64-linux-gnu/libnvidia-unlisted.so.550.54.15"),
+				mount("/usr/lib/x86_64-linux-gnu/vdpau/libvdpau_nvidia.so.550.54.15"),
+			},
+			expectedBinaries: []discover.Mount{
+				mount("/usr/bin/nvidia-smi"),
+				mount("/usr/bin/nvidia-debugdump"),
+				mount("/usr/bin/nvidia-persistenced"),
+			},
+		},
+		{
+			description:  "feature flag set uses manifest",
+			featureFlags: []string{string(FeatureEnableDriverManifest)},
+			expectedLibraries: []discover.Mount{
+				mount("/usr/lib/x86_64-linux-gnu/libcuda.so.550.54.15"),
+				mount("/usr/lib/x86_64-linux-gnu/libnvidia-ml.so.550.54.15"),
+				mount("/usr/lib/x86_64-linux-gnu/libnvidia-ptxjitcompiler.so.550.54.15"),
+				mount("/usr/lib/x86_64-linux-gnu/vdpau/libvdpau_nvidia.so.550.54.15"),
+			},
+			expectedBinaries: []discover.Mount{
+				mount("/usr/bin/nvidia-smi"),
+				mount("/usr/bin/nvidia-debugdump"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithNvmlLib(dgxa100.New()),
+				WithFeatureFlags(tc.featureFlags...),
+			)
+			require.NoError(t, err)
+
+			l := (*nvcdilib)(lib.(*wrapper).factory.(*nvmllib))
+
+			manifest, err := l.getDriverManifest()
+			require.NoError(t, err)
+
+			libraries, err := l.getDriverLibraryMounts("550.54.15", manifest)
+			require.NoError(t, err)
+			libraryMounts, err := libraries.Mounts()
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.expectedLibraries, libraryMounts)
+
+			binaryMounts, err := l.newDriverBinariesDiscoverer(manifest).Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedBinaries, binaryMounts)
+		})
+	}
+}
+
+func TestDriverManifestNotPresent(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeNvml),
+		WithDriverRoot(t.TempDir()),
+		WithNvmlLib(dgxa100.New()),
+		WithFeatureFlags(string(FeatureEnableDriverManifest)),
+	)
+	require.NoError(t, err)
+
+	manifest, err := (*nvcdilib)(lib.(*wrapper).factory.(*nvmllib)).getDriverManifest()
+	require.NoError(t, err)
+	require.Nil(t, manifest)
+}
+
+func TestDriverManifestParseErrorIsFatal(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	// A directory cannot be read as a manifest.
+	require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, driverManifestPath), 0755))
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeNvml),
+		WithDriverRoot(driverRoot),
+		WithNvmlLib(dgxa100.New()),
+		WithFeatureFlags(string(FeatureEnableDriverManifest)),
+	)
+	require.NoError(t, err)
+
+	l := (*nvcdilib)(lib.(*wrapper).factory.(*nvmllib))
+
+	_, err = l.NewDriverLibraryDiscoverer("550.54.15", "/usr/lib/x86_64-linux-gnu")
+	require.ErrorContains(t, err, "failed to parse driver manifest")
+}
@@ -46,7 +46,14 @@ func (l *nvcdilib) newDriverVersionDiscoverer() (discover.Discover, error) {
 		return nil, fmt.Errorf("failed to get libcuda.so parent path: %w", err)
 	}
 
-	libraries, err := l.NewDriverLibraryDiscoverer(version, libcudasoParentDirPath)
+	// The driver manifest is loaded once and used for both the driver
+	// libraries and binaries.
+	manifest, err := l.getDriverManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	libraries, err := l.newDriverLibraryDiscoverer(version, libcudasoParentDirPath, manifest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discoverer for driver libraries: %v", err)
 	}
@@ -61,13 +68,25 @@ func (l *nvcdilib) newDriverVersionDiscoverer() (discover.Discover, error) {
 		return nil, fmt.Errorf("failed to create discoverer for GSP firmware: %v", err)
 	}
 
-	binaries := l.newDriverBinariesDiscoverer()
+	binaries := l.newDriverBinariesDiscoverer(manifest)
+
+	developerTools, err := l.newDeveloperToolsDiscoverer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discoverer for developer tools: %v", err)
+	}
+
+	nestedContainers, err := l.newNestedContainersDiscoverer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discoverer for nested containers: %v", err)
+	}
 
 	d := discover.Merge(
 		libraries,
 		ipcs,
 		firmwares,
 		binaries,
+		developerTools,
+		nestedContainers,
 	)
 
 	return d, nil
@@ -75,7 +94,20 @@ func (l *nvcdilib) newDriverVersionDiscoverer() (discover.Discover, error) {
 
 // NewDriverLibraryDiscoverer creates a discoverer for the libraries associated with the specified driver version.
 func (l *nvcdilib) NewDriverLibraryDiscoverer(version string, libcudaSoParentDirPath string) (discover.Discover, error) {
-	versionSuffixLibraryMounts, err := l.getVersionSuffixDriverLibraryMounts(version)
+	manifest, err := l.getDriverManifest()
+	if err != nil {
+		return nil, err
+	}
+	return l.newDriverLibraryDiscoverer(version, libcudaSoParentDirPath, manifest)
+}
+
+// newDriverLibraryDiscoverer creates a discoverer for the libraries associated
+// with the specified driver version. If a driver manifest is specified, the
+// libraries listed in the manifest are used.
+func (l *nvcdilib) newDriverLibraryDiscoverer(version string, libcudaSoParentDirPath string, manifest *driverManifest) (discover.Discover, error) {
+	l.checkLdcache()
+
+	driverLibraryMounts, err := l.getDriverLibraryMounts(version, manifest)
 	if err != nil {
 		return nil, err
 	}
@@ -84,10 +116,18 @@ func (l *nvcdilib) NewDriverLibraryDiscoverer(version string, libcudaSoParentDir
 		return nil, err
 	}
 
-	libraries := discover.Merge(
-		versionSuffixLibraryMounts,
-		explicitLibraryMounts,
+	// The library denylist is applied to all edits by the edits factory. It
+	// is also applied here so that the hooks created for the driver libraries
+	// do not refer to denied libraries.
+	libraries := discover.WithMountsDenylist(
+		l.logger,
+		discover.Merge(
+			driverLibraryMounts,
+			explicitLibraryMounts,
+		),
+		l.libraryDenylist...,
 	)
+	libraries = l.withDriverCapabilitiesFilter(libraries)
 
 	var discoverers []discover.Discover
 
@@ -97,15 +137,19 @@ func (l *nvcdilib) NewDriverLibraryDiscoverer(version string, libcudaSoParentDir
 		// Since we don't only match version suffixes, we now need to match on wildcards.
 		"",
 		l.hookCreator,
+		discover.WithLibcudaSonameSymlink(l.featureFlags[FeatureEnableLibcudaSonameSymlink]),
 	)
 	discoverers = append(discoverers, driverDotSoSymlinksDiscoverer)
 
 	cudaCompatLibHookDiscoverer := discover.NewCUDACompatHookDiscoverer(l.logger, l.hookCreator, &discover.EnableCUDACompatHookOptions{HostDriverVersion: version})
 	discoverers = append(discoverers, cudaCompatLibHookDiscoverer)
 
-	updateLDCache, _ := discover.NewLDCacheUpdateHook(l.logger, libraries, l.hookCreator)
+	updateLDCache, _ := l.newLDCacheUpdateDiscoverer(libraries)
 	discoverers = append(discoverers, updateLDCache)
 
+	checkLibraries := discover.NewCheckLibrariesHook(l.logger, libraries, l.hookCreator)
+	discoverers = append(discoverers, checkLibraries)
+
 	disableDeviceNodeModification := l.hookCreator.Create(DisableDeviceNodeModificationHook)
 	discoverers = append(discoverers, disableDeviceNodeModification)
 
@@ -124,6 +168,31 @@ func (l *nvcdilib) NewDriverLibraryDiscoverer(version string, libcudaSoParentDir
 	return d, nil
 }
 
+// getDriverLibraryMounts returns a discoverer for the driver libraries. If a
+// driver manifest is specified, the libraries listed in the manifest are
+// used. Otherwise the libraries with the driver version suffix are located
+// in the ldcache.
+func (l *nvcdilib) getDriverLibraryMounts(version string, manifest *driverManifest) (discover.Discover, error) {
+	if manifest == nil {
+		return l.getVersionSuffixDriverLibraryMounts(version)
+	}
+
+	l.logger.Infof("Using driver libraries from manifest %v", manifest.path)
+	libraries, err := l.driver.DriverLibraryLocator("vdpau")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver library locator: %w", err)
+	}
+
+	mounts := discover.NewMounts(
+		l.logger,
+		libraries,
+		l.driver.Root,
+		manifest.libraries,
+	)
+
+	return mounts, nil
+}
+
 func (l *nvcdilib) getVersionSuffixDriverLibraryMounts(version string) (discover.Discover, error) {
 	versionSuffixLibraryPaths, err := l.getVersionLibs(version)
 	if err != nil {
@@ -242,24 +311,108 @@ func (l *nvcdilib) newDriverFirmwareDiscoverer(version string) (discover.Discove
 	), nil
 }
 
-// newDriverBinariesDiscoverer creates a discoverer for GSP firmware associated with the GPU driver.
-func (l *nvcdilib) newDriverBinariesDiscoverer() discover.Discover {
+// newDriverBinariesDiscoverer creates a discoverer for the binaries associated with the GPU driver.
+// Since a CDI spec is not specific to a container, the binaries for all
+// selected driver capabilities are included.
+// The libraries that these depend on are discovered separately.
+// If a driver manifest is specified, only the binaries that are also listed
+// in the manifest are included.
+func (l *nvcdilib) newDriverBinariesDiscoverer(manifest *driverManifest) discover.Discover {
+	if manifest == nil {
+		return discover.NewDriverExecutablesDiscoverer(
+			l.logger,
+			l.driver.Root,
+			l.driverCapabilities,
+		)
+	}
+
 	return discover.NewMounts(
 		l.logger,
 		lookup.NewExecutableLocator(l.logger, l.driver.Root),
 		l.driver.Root,
-		[]string{
-			"nvidia-smi",              /* System management interface */
-			"nvidia-debugdump",        /* GPU coredump utility */
-			"nvidia-persistenced",     /* Persistence mode utility */
-			"nvidia-cuda-mps-control", /* Multi process service CLI */
-			"nvidia-cuda-mps-server",  /* Multi process service server */
-			"nvidia-imex",             /* NVIDIA IMEX Daemon */
-			"nvidia-imex-ctl",         /* NVIDIA IMEX control */
-		},
+		manifest.filterExecutables(discover.DriverExecutables(l.driverCapabilities)),
 	)
 }
 
+// newDeveloperToolsDiscoverer creates a discoverer for the debugging and
+// profiling tools associated with the GPU driver. Tools that are not installed
+// are ignored.
+func (l *nvcdilib) newDeveloperToolsDiscoverer() (discover.Discover, error) {
+	if !l.featureFlags[FeatureEnableDeveloperTools] {
+		return nil, nil
+	}
+
+	executables := []string{
+		"nvidia-bug-report.sh", /* Bug report and diagnostics collector */
+		"cuda-gdbserver",       /* CUDA debugger remote server */
+	}
+	libraries := []string{
+		"libcudadebugger.so.1", /* CUDA debugger backend used by cuda-gdb and Nsight */
+	}
+
+	driverLibraryLocator, err := l.driver.DriverLibraryLocator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver library locator: %w", err)
+	}
+
+	d := discover.Merge(
+		discover.NewMounts(
+			l.logger,
+			lookup.NewExecutableLocator(l.logger, l.driver.Root),
+			l.driver.Root,
+			executables,
+		),
+		discover.NewMounts(
+			l.logger,
+			driverLibraryLocator,
+			l.driver.Root,
+			libraries,
+		),
+	)
+
+	return d, nil
+}
+
+// newNestedContainersDiscoverer creates a discoverer for the
+// nvidia-container-cli and the libraries that it depends on. These are
+// required to start GPU containers from within a container. Components that
+// are not installed are ignored.
+func (l *nvcdilib) newNestedContainersDiscoverer() (discover.Discover, error) {
+	if !l.featureFlags[FeatureEnableNestedContainers] {
+		return nil, nil
+	}
+
+	executables := []string{
+		"nvidia-container-cli",
+	}
+	libraries := []string{
+		"libnvidia-container.so.1",
+		"libnvidia-container-go.so.1",
+	}
+
+	driverLibraryLocator, err := l.driver.DriverLibraryLocator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver library locator: %w", err)
+	}
+
+	d := discover.Merge(
+		discover.NewMounts(
+			l.logger,
+			lookup.NewExecutableLocator(l.logger, l.driver.Root),
+			l.driver.Root,
+			executables,
+		),
+		discover.NewMounts(
+			l.logger,
+			driverLibraryLocator,
+			l.driver.Root,
+			libraries,
+		),
+	)
+
+	return d, nil
+}
+
 // getVersionLibs checks the LDCache for libraries ending in the specified driver version.
 // Although the ldcache at the specified driverRoot is queried, the paths are returned relative to this driverRoot.
 // This allows the standard mount location logic to be used for resolving the mounts.
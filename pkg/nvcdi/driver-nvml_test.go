@@ -0,0 +1,382 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestDeveloperToolsDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		featureFlags   []string
+		withTools      bool
+		expectedMounts func(string) []discover.Mount
+	}{
+		{
+			description: "feature flag not set",
+			withTools:   true,
+		},
+		{
+			description:  "tools not installed",
+			featureFlags: []string{string(FeatureEnableDeveloperTools)},
+		},
+		{
+			description:  "tools installed",
+			featureFlags: []string{string(FeatureEnableDeveloperTools)},
+			withTools:    true,
+			expectedMounts: func(driverRoot string) []discover.Mount {
+				return []discover.Mount{
+					{
+						HostPath: filepath.Join(driverRoot, "/usr/bin/nvidia-bug-report.sh"),
+						Path:     "/usr/bin/nvidia-bug-report.sh",
+						Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+					},
+					{
+						HostPath: filepath.Join(driverRoot, "/usr/bin/cuda-gdbserver"),
+						Path:     "/usr/bin/cuda-gdbserver",
+						Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+					},
+					{
+						HostPath: filepath.Join(driverRoot, "/usr/lib/x86_64-linux-gnu/libcudadebugger.so.550.54.15"),
+						Path:     "/usr/lib/x86_64-linux-gnu/libcudadebugger.so.550.54.15",
+						Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+					},
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			libDir := filepath.Join(driverRoot, "usr/lib/x86_64-linux-gnu")
+			require.NoError(t, os.MkdirAll(libDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcuda.so.550.54.15"), nil, 0600))
+			if tc.withTools {
+				require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcudadebugger.so.550.54.15"), nil, 0600))
+				require.NoError(t, os.Symlink("libcudadebugger.so.550.54.15", filepath.Join(libDir, "libcudadebugger.so.1")))
+
+				binDir := filepath.Join(driverRoot, "usr/bin")
+				require.NoError(t, os.MkdirAll(binDir, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(binDir, "nvidia-bug-report.sh"), nil, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(binDir, "cuda-gdbserver"), nil, 0755))
+			}
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithNvmlLib(dgxa100.New()),
+				WithFeatureFlags(tc.featureFlags...),
+			)
+			require.NoError(t, err)
+
+			l := lib.(*wrapper).factory.(*nvmllib)
+
+			d, err := (*nvcdilib)(l).newDeveloperToolsDiscoverer()
+			require.NoError(t, err)
+			if tc.expectedMounts == nil {
+				if d == nil {
+					return
+				}
+				mounts, err := d.Mounts()
+				require.NoError(t, err)
+				require.Empty(t, mounts)
+				return
+			}
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts(driverRoot), mounts)
+		})
+	}
+}
+
+func TestDriverLDCacheUpdate(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description           string
+		featureFlags          []string
+		expectLDCacheHook     bool
+		expectedLDLibraryPath string
+	}{
+		{
+			description:       "ldcache is updated by default",
+			expectLDCacheHook: true,
+		},
+		{
+			description:           "use-ld-library-path sets envvar instead",
+			featureFlags:          []string{string(FeatureUseLDLibraryPath)},
+			expectedLDLibraryPath: "/usr/lib/x86_64-linux-gnu",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			libDir := filepath.Join(driverRoot, "usr/lib/x86_64-linux-gnu")
+			require.NoError(t, os.MkdirAll(libDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcuda.so.550.54.15"), nil, 0600))
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithNVIDIACDIHookPath("/usr/bin/nvidia-cdi-hook"),
+				WithNvmlLib(dgxa100.New()),
+				WithFeatureFlags(tc.featureFlags...),
+			)
+			require.NoError(t, err)
+
+			l := lib.(*wrapper).factory.(*nvmllib)
+
+			d, err := (*nvcdilib)(l).newDriverVersionDiscoverer()
+			require.NoError(t, err)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			var hasLDCacheHook bool
+			for _, hook := range hooks {
+				if slices.Contains(hook.Args, "update-ldcache") {
+					hasLDCacheHook = true
+				}
+			}
+			require.Equal(t, tc.expectLDCacheHook, hasLDCacheHook)
+
+			envVars, err := d.EnvVars()
+			require.NoError(t, err)
+			var ldLibraryPath string
+			for _, e := range envVars {
+				if e.Name == "LD_LIBRARY_PATH" {
+					ldLibraryPath = e.Value
+				}
+			}
+			require.Equal(t, tc.expectedLDLibraryPath, ldLibraryPath)
+		})
+	}
+}
+
+func TestNestedContainersDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		featureFlags   []string
+		withCLI        bool
+		expectedMounts func(string) []discover.Mount
+	}{
+		{
+			description: "feature flag not set",
+			withCLI:     true,
+		},
+		{
+			description:  "nvidia-container-cli not installed",
+			featureFlags: []string{string(FeatureEnableNestedContainers)},
+		},
+		{
+			description:  "nvidia-container-cli installed",
+			featureFlags: []string{string(FeatureEnableNestedContainers)},
+			withCLI:      true,
+			expectedMounts: func(driverRoot string) []discover.Mount {
+				return []discover.Mount{
+					{
+						HostPath: filepath.Join(driverRoot, "/usr/bin/nvidia-container-cli"),
+						Path:     "/usr/bin/nvidia-container-cli",
+						Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+					},
+					{
+						HostPath: filepath.Join(driverRoot, "/usr/lib/x86_64-linux-gnu/libnvidia-container.so.1.19.0"),
+						Path:     "/usr/lib/x86_64-linux-gnu/libnvidia-container.so.1.19.0",
+						Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+					},
+					{
+						HostPath: filepath.Join(driverRoot, "/usr/lib/x86_64-linux-gnu/libnvidia-container-go.so.1.19.0"),
+						Path:     "/usr/lib/x86_64-linux-gnu/libnvidia-container-go.so.1.19.0",
+						Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+					},
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			libDir := filepath.Join(driverRoot, "usr/lib/x86_64-linux-gnu")
+			require.NoError(t, os.MkdirAll(libDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcuda.so.550.54.15"), nil, 0600))
+			if tc.withCLI {
+				for _, lib := range []string{"libnvidia-container", "libnvidia-container-go"} {
+					require.NoError(t, os.WriteFile(filepath.Join(libDir, lib+".so.1.19.0"), nil, 0600))
+					require.NoError(t, os.Symlink(lib+".so.1.19.0", filepath.Join(libDir, lib+".so.1")))
+				}
+
+				binDir := filepath.Join(driverRoot, "usr/bin")
+				require.NoError(t, os.MkdirAll(binDir, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(binDir, "nvidia-container-cli"), nil, 0755))
+			}
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithNvmlLib(dgxa100.New()),
+				WithFeatureFlags(tc.featureFlags...),
+			)
+			require.NoError(t, err)
+
+			l := lib.(*wrapper).factory.(*nvmllib)
+
+			d, err := (*nvcdilib)(l).newNestedContainersDiscoverer()
+			require.NoError(t, err)
+			if tc.expectedMounts == nil {
+				if d == nil {
+					return
+				}
+				mounts, err := d.Mounts()
+				require.NoError(t, err)
+				require.Empty(t, mounts)
+				return
+			}
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts(driverRoot), mounts)
+		})
+	}
+}
+
+func TestDriverLibrariesForCapabilities(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description       string
+		capabilities      []string
+		expectedLibraries []string
+	}{
+		{
+			description: "all capabilities by default",
+			expectedLibraries: []string{
+				"libGLX_nvidia.so.550.54.15",
+				"libcuda.so.550.54.15",
+				"libnvidia-encode.so.550.54.15",
+				"libnvidia-ml.so.550.54.15",
+				"libnvidia-other.so.550.54.15",
+				"libnvidia-ptxjitcompiler.so.550.54.15",
+			},
+		},
+		{
+			description:  "compute",
+			capabilities: []string{"compute"},
+			expectedLibraries: []string{
+				"libcuda.so.550.54.15",
+				"libnvidia-other.so.550.54.15",
+				"libnvidia-ptxjitcompiler.so.550.54.15",
+			},
+		},
+		{
+			description:  "graphics",
+			capabilities: []string{"graphics"},
+			expectedLibraries: []string{
+				"libGLX_nvidia.so.550.54.15",
+				"libnvidia-other.so.550.54.15",
+				"libnvidia-ptxjitcompiler.so.550.54.15",
+			},
+		},
+		{
+			description:  "video",
+			capabilities: []string{"video"},
+			expectedLibraries: []string{
+				"libnvidia-encode.so.550.54.15",
+				"libnvidia-other.so.550.54.15",
+			},
+		},
+		{
+			description:  "utility and video",
+			capabilities: []string{"utility", "video"},
+			expectedLibraries: []string{
+				"libnvidia-encode.so.550.54.15",
+				"libnvidia-ml.so.550.54.15",
+				"libnvidia-other.so.550.54.15",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			libDir := filepath.Join(driverRoot, "usr/lib/x86_64-linux-gnu")
+			require.NoError(t, os.MkdirAll(libDir, 0755))
+			for _, library := range []string{
+				"libcuda.so.550.54.15",
+				"libGLX_nvidia.so.550.54.15",
+				"libnvidia-encode.so.550.54.15",
+				"libnvidia-ml.so.550.54.15",
+				"libnvidia-other.so.550.54.15",
+				"libnvidia-ptxjitcompiler.so.550.54.15",
+			} {
+				require.NoError(t, os.WriteFile(filepath.Join(libDir, library), nil, 0600))
+			}
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithNvmlLib(dgxa100.New()),
+				WithDriverCapabilities(tc.capabilities...),
+			)
+			require.NoError(t, err)
+
+			l := lib.(*wrapper).factory.(*nvmllib)
+
+			d, err := (*nvcdilib)(l).NewDriverLibraryDiscoverer("550.54.15", "/usr/lib/x86_64-linux-gnu")
+			require.NoError(t, err)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+
+			var libraries []string
+			for _, m := range mounts {
+				libraries = append(libraries, filepath.Base(m.Path))
+			}
+			require.ElementsMatch(t, tc.expectedLibraries, libraries)
+		})
+	}
+}
+
+func TestInvalidDriverCapability(t *testing.T) {
+	_, err := New(
+		WithMode(ModeNvml),
+		WithNvmlLib(dgxa100.New()),
+		WithDriverCapabilities("compute", "invalid"),
+	)
+	require.EqualError(t, err, `invalid driver capability "invalid"`)
+}
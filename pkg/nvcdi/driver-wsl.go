@@ -38,32 +38,32 @@ var requiredDriverStoreFiles = []string{
 	"nvidia-smi",                    /* nvidia-smi binary*/
 }
 
-// newWSLDriverDiscoverer returns a Discoverer for WSL2 drivers.
-func (l *wsllib) newWSLDriverDiscoverer() (discover.Discover, error) {
+// wslLibPath is the path at which WSL2 makes the driver libraries
+// available in the Linux distribution.
+const wslLibPath = "/usr/lib/wsl/lib"
+
+// getDXCoreDriverStorePaths returns the driver store paths reported by
+// dxcore. This is a variable to allow it to be overridden in tests.
+var getDXCoreDriverStorePaths = func() ([]string, error) {
 	if err := dxcore.Init(); err != nil {
 		return nil, fmt.Errorf("failed to initialize dxcore: %w", err)
 	}
 	defer func() {
-		if err := dxcore.Shutdown(); err != nil {
-			l.logger.Warningf("failed to shutdown dxcore: %w", err)
-		}
+		_ = dxcore.Shutdown()
 	}()
 
-	driverStorePaths := dxcore.GetDriverStorePaths()
-	if len(driverStorePaths) == 0 {
-		return nil, fmt.Errorf("no driver store paths found")
-	}
-	if len(driverStorePaths) > 1 {
-		l.logger.Warningf("Found multiple driver store paths: %v", driverStorePaths)
-	}
-	l.logger.Infof("Using WSL driver store paths: %v", driverStorePaths)
+	return dxcore.GetDriverStorePaths(), nil
+}
 
-	driverStorePaths = append(driverStorePaths, "/usr/lib/wsl/lib")
+// newWSLDriverDiscoverer returns a Discoverer for WSL2 drivers.
+func (l *wsllib) newWSLDriverDiscoverer() (discover.Discover, error) {
+	driverStorePaths := l.getDriverStorePaths()
 
 	driverStoreMounts := discover.NewMounts(
 		l.logger,
 		lookup.NewFileLocator(
 			lookup.WithLogger(l.logger),
+			lookup.WithRoot(l.driver.Root),
 			lookup.WithSearchPaths(
 				driverStorePaths...,
 			),
@@ -79,7 +79,7 @@ func (l *wsllib) newWSLDriverDiscoverer() (discover.Discover, error) {
 		hookCreator: l.hookCreator,
 	}
 
-	ldcacheHook, _ := discover.NewLDCacheUpdateHook(l.logger, driverStoreMounts, l.hookCreator)
+	ldcacheHook, _ := (*nvcdilib)(l).newLDCacheUpdateDiscoverer(driverStoreMounts)
 
 	d := discover.Merge(
 		driverStoreMounts,
@@ -90,6 +90,25 @@ func (l *wsllib) newWSLDriverDiscoverer() (discover.Discover, error) {
 	return d, nil
 }
 
+// getDriverStorePaths returns the paths that are searched for the driver
+// store files. The driver store paths reported by dxcore take precedence over
+// the /usr/lib/wsl/lib folder. If dxcore is not available, only
+// /usr/lib/wsl/lib is searched.
+func (l *wsllib) getDriverStorePaths() []string {
+	driverStorePaths, err := getDXCoreDriverStorePaths()
+	if err != nil {
+		l.logger.Warningf("Failed to get driver store paths from dxcore; using %v: %v", wslLibPath, err)
+	}
+	if len(driverStorePaths) > 1 {
+		l.logger.Warningf("Found multiple driver store paths: %v", driverStorePaths)
+	}
+	if len(driverStorePaths) > 0 {
+		l.logger.Infof("Using WSL driver store paths: %v", driverStorePaths)
+	}
+
+	return append(driverStorePaths, wslLibPath)
+}
+
 type nvidiaSMISimlinkHook struct {
 	discover.None
 	logger      logger.Interface
@@ -0,0 +1,40 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// ErrDeviceNotFound is returned if a requested device does not exist.
+var ErrDeviceNotFound = errors.New("device not found")
+
+// deviceLookupError returns the error for a failed NVML device lookup. If
+// NVML indicates that the requested device does not exist, the returned error
+// wraps ErrDeviceNotFound.
+func deviceLookupError(ret nvml.Return) error {
+	switch ret {
+	case nvml.ERROR_NOT_FOUND, nvml.ERROR_INVALID_ARGUMENT:
+		return fmt.Errorf("%w: %w", ErrDeviceNotFound, ret)
+	default:
+		return ret
+	}
+}
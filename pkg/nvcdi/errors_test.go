@@ -0,0 +1,86 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceNotFound(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		id            string
+		ret           nvml.Return
+		expectedError error
+	}{
+		{
+			description:   "index out of range",
+			id:            "99",
+			expectedError: ErrDeviceNotFound,
+		},
+		{
+			description:   "unknown UUID",
+			id:            "GPU-00000000-0000-0000-0000-000000000000",
+			expectedError: ErrDeviceNotFound,
+		},
+		{
+			description:   "unknown MIG index",
+			id:            "99:0",
+			expectedError: ErrDeviceNotFound,
+		},
+		{
+			description: "other NVML errors are not reported as not found",
+			id:          "0",
+			ret:         nvml.ERROR_UNKNOWN,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := dgxa100.New()
+			if tc.ret != nvml.SUCCESS {
+				server.DeviceGetHandleByIndexFunc = func(int) (nvml.Device, nvml.Return) {
+					return nil, tc.ret
+				}
+			}
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithNvmlLib(server),
+			)
+			require.NoError(t, err)
+
+			_, err = lib.GetDeviceSpecsByID(tc.id)
+			require.Error(t, err)
+			if tc.expectedError == nil {
+				require.NotErrorIs(t, err, ErrDeviceNotFound)
+				require.ErrorIs(t, err, tc.ret)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestExplainGatedModes(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	testCases := []struct {
+		description     string
+		mode            Mode
+		driverRoot      string
+		expectedEntries []ExplainEntry
+	}{
+		{
+			description: "mofed devices present",
+			mode:        ModeMofed,
+			driverRoot:  driverRoot,
+			expectedEntries: []ExplainEntry{
+				{
+					Discoverer: "MOFED",
+					Found: []string{
+						"device /dev/infiniband/uverbs0",
+						"device /dev/infiniband/rdma_cm",
+					},
+				},
+			},
+		},
+		{
+			description: "mofed devices not present",
+			mode:        ModeMofed,
+			driverRoot:  t.TempDir(),
+			expectedEntries: []ExplainEntry{
+				{
+					Discoverer: "MOFED",
+					Found:      []string{},
+					Skipped:    true,
+					Reason:     "not found: /dev/infiniband/uverbs*, /dev/infiniband/rdma_cm",
+				},
+			},
+		},
+		{
+			description: "gdrcopy device present",
+			mode:        ModeGdrcopy,
+			driverRoot:  driverRoot,
+			expectedEntries: []ExplainEntry{
+				{
+					Discoverer: "GDRCopy",
+					Found:      []string{"device /dev/gdrdrv"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			explainer := NewExplainer()
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(tc.mode),
+				WithDriverRoot(tc.driverRoot),
+				WithExplainer(explainer),
+			)
+			require.NoError(t, err)
+
+			_, err = lib.GetDeviceSpecsByID("all")
+			require.NoError(t, err)
+
+			require.EqualValues(t, tc.expectedEntries, explainer.Entries())
+		})
+	}
+}
+
+func TestExplainConfidentialComputing(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	testCases := []struct {
+		description   string
+		featureFlags  []string
+		expectedEntry string
+	}{
+		{
+			description:   "feature flag not set",
+			expectedEntry: `skipped confidential computing devices: feature flag "cc" not set`,
+		},
+		{
+			description:   "nvml is not queried",
+			featureFlags:  []string{"cc"},
+			expectedEntry: "skipped confidential computing devices: NVML is not queried in a dry run",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := dgxa100.New()
+			server.InitFunc = func() nvml.Return {
+				t.Error("NVML must not be initialized in a dry run")
+				return nvml.ERROR_UNKNOWN
+			}
+
+			explainer := NewExplainer()
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithNvmlLib(server),
+				WithFeatureFlags(tc.featureFlags...),
+				WithExplainer(explainer),
+			)
+			require.NoError(t, err)
+
+			l := lib.(*wrapper).factory.(*nvmllib)
+
+			_, err = l.confidentialComputingDiscoverer().Devices()
+			require.NoError(t, err)
+
+			entries := explainer.Entries()
+			require.Len(t, entries, 1)
+			require.Equal(t, tc.expectedEntry, entries[0].String())
+		})
+	}
+}
@@ -46,6 +46,15 @@ func (l *fullGPUDeviceSpecGenerator) GetUUID() (string, error) {
 	return l.uuid, nil
 }
 
+// GetPCIBusID returns the PCI bus ID of the full GPU.
+func (l *fullGPUDeviceSpecGenerator) GetPCIBusID() (string, error) {
+	device, err := l.device()
+	if err != nil {
+		return "", err
+	}
+	return device.GetPCIBusID()
+}
+
 func (l *nvmllib) newFullGPUDeviceSpecGeneratorFromDevice(index int, d device.Device, featureFlags map[FeatureFlag]bool) (*fullGPUDeviceSpecGenerator, error) {
 	uuid, ret := d.GetUUID()
 	if ret != nvml.SUCCESS {
@@ -112,25 +121,28 @@ func (l *fullGPUDeviceSpecGenerator) device() (device.Device, error) {
 }
 
 func (l *fullGPUDeviceSpecGenerator) getDeviceAnnotations() (map[string]string, error) {
-	if !l.featureFlags[FeatureEnableCoherentAnnotations] {
-		return nil, nil
+	annotations := make(map[string]string)
+	if l.featureFlags[FeatureEnableDeviceTypeAnnotations] {
+		annotations[DeviceTypeAnnotation] = DeviceTypeGPU
 	}
 
-	device, err := l.device()
-	if err != nil {
-		return nil, err
-	}
+	if l.featureFlags[FeatureEnableCoherentAnnotations] {
+		device, err := l.device()
+		if err != nil {
+			return nil, err
+		}
 
-	// TODO: Should we distinguish between not-supported and disabled?
-	isCoherent, err := device.IsCoherent()
-	if err != nil {
-		return nil, fmt.Errorf("failed to check device coherence: %w", err)
+		// TODO: Should we distinguish between not-supported and disabled?
+		isCoherent, err := device.IsCoherent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check device coherence: %w", err)
+		}
+		annotations["gpu.nvidia.com/coherent"] = fmt.Sprintf("%v", isCoherent)
 	}
 
-	annotations := map[string]string{
-		"gpu.nvidia.com/coherent": fmt.Sprintf("%v", isCoherent),
+	if len(annotations) == 0 {
+		return nil, nil
 	}
-
 	return annotations, nil
 }
 
@@ -168,6 +180,8 @@ func (l *fullGPUDeviceSpecGenerator) newFullGPUDiscoverer(d device.Device) (disc
 	if err != nil {
 		return nil, fmt.Errorf("failed to create device discoverer: %v", err)
 	}
+	deviceNodes = discover.WithDeviceNodeAllowlist(l.logger, deviceNodes, l.deviceNodeAllowlist...)
+	deviceNodes = (*nvcdilib)(l.nvmllib).withDevCharPaths(deviceNodes)
 
 	deviceFolderPermissionHooks := (*nvcdilib)(l.nvmllib).newDeviceFolderPermissionHookDiscoverer(
 		deviceNodes,
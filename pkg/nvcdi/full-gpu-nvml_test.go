@@ -0,0 +1,156 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestDeviceNodeAllowlist(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	devRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev"), 0755))
+	for _, name := range []string{"nvidia0", "nvidia1", "nvidiactl", "nvidia-uvm"} {
+		require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev", name), nil, 0600))
+	}
+
+	testCases := []struct {
+		description         string
+		allowlist           []string
+		expectedDeviceNodes []string
+	}{
+		{
+			description:         "no allowlist",
+			expectedDeviceNodes: []string{"/dev/nvidia1"},
+		},
+		{
+			description:         "device includes only /dev/nvidia1",
+			allowlist:           []string{"/dev/nvidia1"},
+			expectedDeviceNodes: []string{"/dev/nvidia1"},
+		},
+		{
+			description: "device node excluded by allowlist",
+			allowlist:   []string{"/dev/nvidia0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := dgxa100.New()
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 2, nvml.SUCCESS
+			}
+			for _, d := range server.Devices {
+				d.(*dgxa100.Device).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				}
+			}
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithDevRoot(devRoot),
+				WithNvmlLib(server),
+				WithDeviceNodeAllowlist(tc.allowlist),
+			)
+			require.NoError(t, err)
+
+			deviceSpecs, err := lib.GetAllDeviceSpecs()
+			require.NoError(t, err)
+			require.Len(t, deviceSpecs, 2)
+
+			var deviceNodes []string
+			for _, deviceNode := range deviceSpecs[1].ContainerEdits.DeviceNodes {
+				deviceNodes = append(deviceNodes, deviceNode.Path)
+			}
+			require.EqualValues(t, tc.expectedDeviceNodes, deviceNodes)
+		})
+	}
+}
+
+func TestDevCharPaths(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	devRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(devRoot, "dev"), 0755))
+	for minor, name := range []string{"nvidia0", "nvidia1"} {
+		content := fmt.Sprintf(`{"major": 195, "minor": %d}`, minor)
+		require.NoError(t, os.WriteFile(filepath.Join(devRoot, "dev", name), []byte(content), 0600))
+	}
+
+	server := dgxa100.New()
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 2, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		d.(*dgxa100.Device).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+	}
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeNvml),
+		WithDriverRoot(driverRoot),
+		WithDevRoot(devRoot),
+		WithNvmlLib(server),
+		WithNVIDIACDIHookPath("/usr/bin/nvidia-cdi-hook"),
+		WithDevCharPaths(true),
+	)
+	require.NoError(t, err)
+
+	deviceSpecs, err := lib.GetAllDeviceSpecs()
+	require.NoError(t, err)
+	require.Len(t, deviceSpecs, 2)
+
+	edits := deviceSpecs[1].ContainerEdits
+	require.Len(t, edits.DeviceNodes, 1)
+	require.Equal(t, "/dev/char/195:1", edits.DeviceNodes[0].Path)
+	require.Equal(t, filepath.Join(devRoot, "dev/nvidia1"), edits.DeviceNodes[0].HostPath)
+
+	require.Len(t, edits.Hooks, 1)
+	require.Equal(t,
+		[]string{"nvidia-cdi-hook", "create-symlinks", "--link", "/dev/char/195:1::/dev/nvidia1"},
+		edits.Hooks[0].Args,
+	)
+}
@@ -42,6 +42,7 @@ func (l *gatedlib) GetDeviceSpecs() ([]specs.Device, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discoverer for mode %q: %w", l.mode, err)
 	}
+	discoverer = l.explainer.Explain(l.mode.explainName(), discoverer)
 	edits, err := l.editsFactory.FromDiscoverer(discoverer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container edits: %w", err)
@@ -65,6 +66,8 @@ func (l *gatedlib) getModeDiscoverer() (discover.Discover, error) {
 		return discover.NewMOFEDDiscoverer(l.logger, l.driver)
 	case ModeNvswitch:
 		return discover.NewNvSwitchDiscoverer(l.logger, l.driver)
+	case ModePeermem:
+		return discover.NewPeermemDiscoverer(l.logger, l.driver)
 	default:
 		return nil, fmt.Errorf("unrecognized mode")
 	}
@@ -74,3 +77,22 @@ func (l *gatedlib) getModeDiscoverer() (discover.Discover, error) {
 func (l *gatedlib) GetCommonEdits() (*cdi.ContainerEdits, error) {
 	return l.editsFactory.FromDiscoverer(discover.None{})
 }
+
+// explainName returns the name used to identify the discoverer for a gated
+// mode in explain entries.
+func (m Mode) explainName() string {
+	switch m {
+	case ModeGdrcopy:
+		return "GDRCopy"
+	case ModeGds:
+		return "GDS"
+	case ModeMofed:
+		return "MOFED"
+	case ModeNvswitch:
+		return "NVSwitch"
+	case ModePeermem:
+		return "nvidia-peermem"
+	default:
+		return string(m)
+	}
+}
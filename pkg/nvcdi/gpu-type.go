@@ -0,0 +1,100 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// A GPUType is used to select the GPUs that are included when generating
+// specs for all devices.
+type GPUType string
+
+const (
+	// GPUTypeAll selects all GPUs.
+	GPUTypeAll = GPUType("all")
+	// GPUTypeCompute selects compute-only GPUs. These are GPUs that do not
+	// have a display attached and that allow compute applications.
+	GPUTypeCompute = GPUType("compute")
+	// GPUTypeDisplay selects display GPUs. These are GPUs that have a display
+	// attached or that prohibit compute applications.
+	GPUTypeDisplay = GPUType("display")
+)
+
+// IsValidGPUType checks whether the specified GPU type is valid.
+func IsValidGPUType[T string | GPUType](gpuType T) bool {
+	switch GPUType(gpuType) {
+	case GPUTypeAll, GPUTypeCompute, GPUTypeDisplay:
+		return true
+	}
+	return false
+}
+
+// matchesGPUType checks whether the specified GPU matches the configured GPU
+// type. The GPU attributes are only queried if a GPU type other than all is
+// configured.
+func (l *nvmllib) matchesGPUType(d device.Device) (bool, error) {
+	switch l.gpuType {
+	case "", GPUTypeAll:
+		return true, nil
+	}
+
+	isDisplay, err := isDisplayGPU(d)
+	if err != nil {
+		return false, err
+	}
+
+	switch l.gpuType {
+	case GPUTypeDisplay:
+		return isDisplay, nil
+	case GPUTypeCompute:
+		return !isDisplay, nil
+	default:
+		return false, fmt.Errorf("unsupported GPU type %q", l.gpuType)
+	}
+}
+
+// isDisplayGPU checks whether the specified GPU is a display GPU. This is the
+// case if NVML reports that a display is connected to the GPU or if compute
+// applications are prohibited. Attributes that are not supported by a GPU are
+// ignored.
+func isDisplayGPU(d device.Device) (bool, error) {
+	displayMode, ret := d.GetDisplayMode()
+	switch ret {
+	case nvml.SUCCESS:
+		if displayMode == nvml.FEATURE_ENABLED {
+			return true, nil
+		}
+	case nvml.ERROR_NOT_SUPPORTED:
+	default:
+		return false, fmt.Errorf("failed to get display mode: %v", ret)
+	}
+
+	computeMode, ret := d.GetComputeMode()
+	switch ret {
+	case nvml.SUCCESS:
+		return computeMode == nvml.COMPUTEMODE_PROHIBITED, nil
+	case nvml.ERROR_NOT_SUPPORTED:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to get compute mode: %v", ret)
+	}
+}
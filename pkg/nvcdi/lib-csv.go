@@ -66,7 +66,7 @@ func (l *csvlib) DeviceSpecGenerators(ids ...string) (DeviceSpecGenerator, error
 }
 
 func (l *csvlib) usePureCSVDeviceSpecGenerator() bool {
-	if l.featureFlags[FeatureDisableMultipleCSVDevices] {
+	if l.featureFlags[FeatureDisableMultipleCSVDevices] || (*nvcdilib)(l).dryRun() {
 		return true
 	}
 	hasNVML, _ := l.infolib.HasNvml()
@@ -273,7 +273,7 @@ func (l *mixedcsvlib) deviceSpecGeneratorForId(id device.Identifier) (DeviceSpec
 		uuid := string(id)
 		device, ret := l.nvmllib.DeviceGetHandleByUUID(uuid)
 		if ret != nvml.SUCCESS {
-			return nil, fmt.Errorf("failed to get device handle from UUID %q: %v", uuid, ret)
+			return nil, fmt.Errorf("failed to get device handle from UUID %q: %w", uuid, deviceLookupError(ret))
 		}
 		index, ret := device.GetIndex()
 		if ret != nvml.SUCCESS {
@@ -287,7 +287,7 @@ func (l *mixedcsvlib) deviceSpecGeneratorForId(id device.Identifier) (DeviceSpec
 		}
 		device, ret := l.nvmllib.DeviceGetHandleByIndex(index)
 		if ret != nvml.SUCCESS {
-			return nil, fmt.Errorf("failed to get device handle from index: %v", ret)
+			return nil, fmt.Errorf("failed to get device handle from index: %w", deviceLookupError(ret))
 		}
 		uuid, ret := device.GetUUID()
 		if ret != nvml.SUCCESS {
@@ -415,6 +415,7 @@ func (l *csvlib) driverDiscoverer() (discover.Discover, error) {
 		tegra.WithHookCreator(l.hookCreator),
 		tegra.WithLibrarySearchPaths(l.librarySearchPaths...),
 		tegra.WithMountSpecs(mountSpecs),
+		tegra.WithLibcudaSonameSymlink(l.featureFlags[FeatureEnableLibcudaSonameSymlink]),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discoverer from CSV files: %w", err)
@@ -422,7 +423,7 @@ func (l *csvlib) driverDiscoverer() (discover.Discover, error) {
 
 	cudaCompatDiscoverer := l.cudaCompatDiscoverer()
 
-	ldcacheUpdateHook, err := discover.NewLDCacheUpdateHook(l.logger, driverDiscoverer, l.hookCreator)
+	ldcacheUpdateHook, err := (*nvcdilib)(l).newLDCacheUpdateDiscoverer(driverDiscoverer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ldcache update hook discoverer: %w", err)
 	}
@@ -442,6 +443,10 @@ func (l *csvlib) driverDiscoverer() (discover.Discover, error) {
 // version to be passed to the hook.
 // On Orin-based systems, the compat library root in the container is also set.
 func (l *csvlib) cudaCompatDiscoverer() discover.Discover {
+	if (*nvcdilib)(l).dryRun() {
+		l.explainer.Skip("CUDA compat hook", dryRunReason)
+		return nil
+	}
 	c, err := l.getEnableCUDACompatHookOptions()
 	if err != nil {
 		l.logger.Warningf("Skipping CUDA Forward Compat hook creation: %v", err)
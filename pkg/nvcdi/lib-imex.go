@@ -84,10 +84,13 @@ func (l *imexlib) getChannelIDs(ids ...string) ([]string, error) {
 
 // getAllChannelIDs returns the device IDs for all available IMEX channels.
 func (l *imexlib) getAllChannelIDs() ([]string, error) {
-	channelsDiscoverer := discover.NewCharDeviceDiscoverer(
-		l.logger,
-		l.driver.DevRoot,
-		[]string{"/dev/nvidia-caps-imex-channels/channel*"},
+	channelsDiscoverer := l.explainer.Explain(
+		"IMEX channels",
+		discover.NewCharDeviceDiscoverer(
+			l.logger,
+			l.driver.DevRoot,
+			[]string{"/dev/nvidia-caps-imex-channels/channel*"},
+		),
 	)
 
 	channels, err := channelsDiscoverer.Devices()
@@ -49,7 +49,13 @@ func (l *nvmllib) GetCommonEdits() (*cdi.ContainerEdits, error) {
 // * an index of a GPU or MIG device
 // * a UUID of a GPU or MIG device
 // * the special ID 'all'
+// Different ID types can be mixed in a single request. IDs that refer to the
+// same device are only included once.
 func (l *nvmllib) DeviceSpecGenerators(ids ...string) (DeviceSpecGenerator, error) {
+	if (*nvcdilib)(l).dryRun() {
+		l.explainer.Skip("GPU devices", dryRunReason)
+		return DeviceSpecGenerators{}, nil
+	}
 	if err := l.init(); err != nil {
 		return nil, err
 	}
@@ -65,6 +71,10 @@ func (l *nvmllib) DeviceSpecGenerators(ids ...string) (DeviceSpecGenerator, erro
 func (l *nvmllib) getDeviceSpecGeneratorsForIDs(ids ...string) (DeviceSpecGenerator, error) {
 	var identifiers []device.Identifier
 	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
 		if id == "none" {
 			return emptyDeviceSpecGenerator("none"), nil
 		}
@@ -83,7 +93,7 @@ func (l *nvmllib) getDeviceSpecGeneratorsForIDs(ids ...string) (DeviceSpecGenera
 	for _, uuid := range uuids {
 		device, ret := l.nvmllib.DeviceGetHandleByUUID(string(uuid))
 		if ret != nvml.SUCCESS {
-			return nil, fmt.Errorf("failed to get device handle from UUID: %v", ret)
+			return nil, fmt.Errorf("failed to get device handle from UUID %q: %w", uuid, deviceLookupError(ret))
 		}
 		generator, err := l.newDeviceSpecGeneratorFromNVMLDevice(string(uuid), device)
 		if err != nil {
@@ -109,15 +119,28 @@ func (l *nvmllib) newDeviceSpecGeneratorFromNVMLDevice(id string, nvmlDevice nvm
 
 // getDeviceSpecGeneratorsForAllDevices returns the CDI device spec generators
 // for all NVML devices detected on the system.
-// This includes full GPUs as well as MIG devices.
+// This includes full GPUs as well as MIG devices. Since the capacity of a
+// MIG-enabled GPU is exposed through its MIG devices, no full GPU device is
+// generated for such a GPU.
+// If a GPU type is configured, only GPUs of this type and their MIG devices
+// are included.
 func (l *nvmllib) getDeviceSpecGeneratorsForAllDevices() (DeviceSpecGenerator, error) {
 	var DeviceSpecGenerators DeviceSpecGenerators
 	err := l.devicelib.VisitDevices(func(i int, d device.Device) error {
+		matches, err := l.matchesGPUType(d)
+		if err != nil {
+			return fmt.Errorf("failed to determine type of GPU %d: %w", i, err)
+		}
+		if !matches {
+			l.logger.Debugf("Skipping GPU %d that is not of type %v", i, l.gpuType)
+			return nil
+		}
 		isMigEnabled, err := d.IsMigEnabled()
 		if err != nil {
 			return err
 		}
 		if isMigEnabled {
+			l.logger.Debugf("Skipping full GPU device for MIG-enabled GPU %d", i)
 			return nil
 		}
 		fullGPU, err := l.newFullGPUDeviceSpecGeneratorFromDevice(i, d, l.featureFlags)
@@ -132,6 +155,13 @@ func (l *nvmllib) getDeviceSpecGeneratorsForAllDevices() (DeviceSpecGenerator, e
 	}
 
 	err = l.devicelib.VisitMigDevices(func(i int, d device.Device, j int, mig device.MigDevice) error {
+		matches, err := l.matchesGPUType(d)
+		if err != nil {
+			return fmt.Errorf("failed to determine type of GPU %d: %w", i, err)
+		}
+		if !matches {
+			return nil
+		}
 		migDevice, err := l.newMIGDeviceSpecGeneratorFromDevice(i, d, j, mig)
 		if err != nil {
 			return err
@@ -147,14 +177,22 @@ func (l *nvmllib) getDeviceSpecGeneratorsForAllDevices() (DeviceSpecGenerator, e
 }
 
 // TODO: move this to go-nvlib?
-// normalizeDeviceID returns the UUIDs of the devices specified by the identifier.
+// normalizeDeviceIDs returns the UUIDs of the devices specified by the
+// identifiers. Since an index and a UUID may refer to the same device, each
+// UUID is only returned once.
 func (l *nvmllib) normalizeDeviceIDs(identifiers ...device.Identifier) ([]device.Identifier, error) {
 	var uuids []device.Identifier
+	seen := make(map[device.Identifier]bool)
 	for _, id := range identifiers {
 		uuid, err := l.normalizeDeviceID(id)
 		if err != nil {
 			return nil, err
 		}
+		if seen[uuid] {
+			l.logger.Debugf("Ignoring device %q that resolves to already requested device %v", id, uuid)
+			continue
+		}
+		seen[uuid] = true
 		uuids = append(uuids, uuid)
 	}
 	return uuids, nil
@@ -174,7 +212,7 @@ func (l *nvmllib) normalizeDeviceID(id device.Identifier) (device.Identifier, er
 		}
 		dev, ret := l.nvmllib.DeviceGetHandleByIndex(idx)
 		if ret != nvml.SUCCESS {
-			return "", fmt.Errorf("failed to get device handle from index: %v", ret)
+			return "", fmt.Errorf("failed to get device handle from index: %w", deviceLookupError(ret))
 		}
 		uuid, ret := dev.GetUUID()
 		if ret != nvml.SUCCESS {
@@ -195,11 +233,11 @@ func (l *nvmllib) normalizeDeviceID(id device.Identifier) (device.Identifier, er
 		}
 		parent, ret := l.nvmllib.DeviceGetHandleByIndex(gpuIdx)
 		if ret != nvml.SUCCESS {
-			return "", fmt.Errorf("failed to get parent device handle: %v", ret)
+			return "", fmt.Errorf("failed to get parent device handle: %w", deviceLookupError(ret))
 		}
 		mig, ret := parent.GetMigDeviceHandleByIndex(migIdx)
 		if ret != nvml.SUCCESS {
-			return "", fmt.Errorf("failed to get MIG handle by index: %v", ret)
+			return "", fmt.Errorf("failed to get MIG handle by index: %w", deviceLookupError(ret))
 		}
 		uuid, ret := mig.GetUUID()
 		if ret != nvml.SUCCESS {
@@ -221,6 +259,7 @@ func (l *nvmllib) init() error {
 	}
 	if r := l.nvsandboxutilslib.Init(l.driver.Root); r != nvsandboxutils.SUCCESS {
 		l.logger.Warningf("Failed to init nvsandboxutils: %v; ignoring", r)
+		l.partialDiscoveries.record("nvsandboxutils", fmt.Sprintf("failed to initialize nvsandboxutils (%v); devices were discovered using NVML only", r))
 		l.nvsandboxutilslib = nil
 	}
 	return nil
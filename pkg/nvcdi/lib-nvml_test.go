@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 	mocknvml "github.com/NVIDIA/go-nvml/pkg/nvml/mock"
 	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
@@ -30,12 +31,13 @@ import (
 
 func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 	testCases := []struct {
-		name               string
-		ids                []string
-		setupMock          func(*dgxa100.Server)
-		expectedError      error
-		expectedLength     int
-		expectedGenerators DeviceSpecGenerators
+		name                string
+		ids                 []string
+		setupMock           func(*dgxa100.Server)
+		expectedError       error
+		expectedLength      int
+		expectedGenerators  DeviceSpecGenerators
+		expectedMissingGPUs []int
 	}{
 		{
 			name:           "all devices",
@@ -43,6 +45,18 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 			expectedError:  nil,
 			expectedLength: 8,
 		},
+		{
+			name: "all devices with MIG-enabled GPU",
+			ids:  []string{"all"},
+			setupMock: func(server *dgxa100.Server) {
+				server.Devices[0].(*dgxa100.Device).GetMigModeFunc = func() (int, int, nvml.Return) {
+					return nvml.DEVICE_MIG_ENABLE, nvml.DEVICE_MIG_ENABLE, nvml.SUCCESS
+				}
+			},
+			expectedError:       nil,
+			expectedLength:      7,
+			expectedMissingGPUs: []int{0},
+		},
 		{
 			name: "single GPU index",
 			ids:  []string{"0"},
@@ -116,6 +130,7 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 		},
 	}
 
+	logger, _ := testlog.NewNullLogger()
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Set up a mock server, using the DGX A100 mock.
@@ -128,6 +143,7 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 			mockDev := device.New(mockNvml)
 
 			l := &nvmllib{
+				logger: logger,
 				platformlibs: platformlibs{
 					nvmllib:   mockNvml,
 					devicelib: mockDev,
@@ -138,6 +154,113 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 
 			require.EqualValues(t, tc.expectedError, err)
 			require.Len(t, generators, tc.expectedLength)
+
+			if len(tc.expectedMissingGPUs) == 0 {
+				return
+			}
+			require.IsType(t, DeviceSpecGenerators{}, generators)
+			for _, g := range generators.(DeviceSpecGenerators) {
+				fullGPU, ok := g.(*fullGPUDeviceSpecGenerator)
+				if !ok {
+					continue
+				}
+				require.NotContains(t, tc.expectedMissingGPUs, fullGPU.index)
+			}
+		})
+	}
+}
+
+func TestNvmllibGetDeviceSpecGeneratorsForMixedIDs(t *testing.T) {
+	const migUUID = "MIG-12345678-1234-1234-1234-123456789abc"
+
+	mockNvml := dgxa100.New()
+	mockOverrides(mockNvml)
+	for _, d := range mockNvml.Devices {
+		// TODO: This is not implemented in the mock.
+		(d.(*dgxa100.Device)).IsMigDeviceHandleFunc = func() (bool, nvml.Return) {
+			return false, nvml.SUCCESS
+		}
+	}
+
+	mig := &mocknvml.Device{
+		IsMigDeviceHandleFunc: func() (bool, nvml.Return) {
+			return true, nvml.SUCCESS
+		},
+		GetDeviceHandleFromMigDeviceHandleFunc: func() (nvml.Device, nvml.Return) {
+			return mockNvml.Devices[1], nvml.SUCCESS
+		},
+		GetIndexFunc: func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		},
+		GetUUIDFunc: func() (string, nvml.Return) {
+			return migUUID, nvml.SUCCESS
+		},
+	}
+	mockNvml.Devices[1].(*dgxa100.Device).GetMigDeviceHandleByIndexFunc = func(n int) (nvml.Device, nvml.Return) {
+		if n != 0 {
+			return nil, nvml.ERROR_INVALID_ARGUMENT
+		}
+		return mig, nvml.SUCCESS
+	}
+	getHandleByUUID := mockNvml.DeviceGetHandleByUUIDFunc
+	mockNvml.DeviceGetHandleByUUIDFunc = func(s string) (nvml.Device, nvml.Return) {
+		if s == migUUID {
+			return mig, nvml.SUCCESS
+		}
+		return getHandleByUUID(s)
+	}
+
+	gpu0 := mockNvml.Devices[0].(*dgxa100.Device).UUID
+	gpu2 := mockNvml.Devices[2].(*dgxa100.Device).UUID
+
+	logger, _ := testlog.NewNullLogger()
+	l := &nvmllib{
+		logger: logger,
+		platformlibs: platformlibs{
+			nvmllib:   mockNvml,
+			devicelib: device.New(mockNvml),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		ids           []string
+		expectedError string
+		expectedUUIDs []string
+	}{
+		{
+			name:          "mixed indices and UUIDs",
+			ids:           []string{"0", gpu2, migUUID, "1:0"},
+			expectedUUIDs: []string{gpu0, gpu2, migUUID},
+		},
+		{
+			name:          "index and UUID of the same GPU",
+			ids:           []string{"0", gpu0, " 0 ", ""},
+			expectedUUIDs: []string{gpu0},
+		},
+		{
+			name:          "invalid identifier",
+			ids:           []string{"0", "foo"},
+			expectedError: `identifier is not a valid UUID or index: "foo"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			generators, err := l.getDeviceSpecGeneratorsForIDs(tc.ids...)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			var uuids []string
+			for _, g := range generators.(DeviceSpecGenerators) {
+				uuid, err := g.(interface{ GetUUID() (string, error) }).GetUUID()
+				require.NoError(t, err)
+				uuids = append(uuids, uuid)
+			}
+			require.Equal(t, tc.expectedUUIDs, uuids)
 		})
 	}
 }
@@ -157,3 +280,78 @@ func mockOverrides(server *dgxa100.Server) {
 		}
 	}
 }
+
+func TestGetDeviceSpecGeneratorsForGPUType(t *testing.T) {
+	testCases := []struct {
+		description     string
+		gpuType         GPUType
+		expectedIndices []int
+	}{
+		{
+			description:     "all GPUs",
+			gpuType:         GPUTypeAll,
+			expectedIndices: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			description:     "no GPU type selects all GPUs",
+			expectedIndices: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			description:     "compute GPUs",
+			gpuType:         GPUTypeCompute,
+			expectedIndices: []int{3, 4, 5, 6, 7},
+		},
+		{
+			description:     "display GPUs",
+			gpuType:         GPUTypeDisplay,
+			expectedIndices: []int{0, 1, 2},
+		},
+	}
+
+	logger, _ := testlog.NewNullLogger()
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mockNvml := dgxa100.New()
+			mockOverrides(mockNvml)
+			// GPU 0 has a display attached and compute is prohibited on
+			// GPUs 1 and 2. GPU 2 does not support querying the display mode.
+			for i, d := range mockNvml.Devices {
+				(d.(*dgxa100.Device)).GetDisplayModeFunc = func() (nvml.EnableState, nvml.Return) {
+					switch i {
+					case 0:
+						return nvml.FEATURE_ENABLED, nvml.SUCCESS
+					case 2:
+						return nvml.FEATURE_DISABLED, nvml.ERROR_NOT_SUPPORTED
+					}
+					return nvml.FEATURE_DISABLED, nvml.SUCCESS
+				}
+				(d.(*dgxa100.Device)).GetComputeModeFunc = func() (nvml.ComputeMode, nvml.Return) {
+					if i == 1 || i == 2 {
+						return nvml.COMPUTEMODE_PROHIBITED, nvml.SUCCESS
+					}
+					return nvml.COMPUTEMODE_DEFAULT, nvml.SUCCESS
+				}
+			}
+
+			l := &nvmllib{
+				logger:  logger,
+				gpuType: tc.gpuType,
+				platformlibs: platformlibs{
+					nvmllib:   mockNvml,
+					devicelib: device.New(mockNvml),
+				},
+			}
+
+			generators, err := l.getDeviceSpecGeneratorsForIDs("all")
+			require.NoError(t, err)
+
+			var indices []int
+			for _, g := range generators.(DeviceSpecGenerators) {
+				fullGPU, ok := g.(*fullGPUDeviceSpecGenerator)
+				require.True(t, ok)
+				indices = append(indices, fullGPU.index)
+			}
+			require.Equal(t, tc.expectedIndices, indices)
+		})
+	}
+}
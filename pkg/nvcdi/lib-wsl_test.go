@@ -0,0 +1,135 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+)
+
+func TestWslMode(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	driverStore := "/usr/lib/wsl/drivers/nv_dispi.inf_amd64_1234"
+
+	hostRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(hostRoot, "dev"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(hostRoot, dxgDeviceNode), nil, 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(hostRoot, wslLibPath), 0755))
+	for _, file := range requiredDriverStoreFiles {
+		require.NoError(t, os.WriteFile(filepath.Join(hostRoot, wslLibPath, file), nil, 0600))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(hostRoot, driverStore), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(hostRoot, driverStore, "nvidia-smi"), nil, 0600))
+
+	testCases := []struct {
+		description       string
+		driverStorePaths  []string
+		driverStoreError  error
+		expectedNvidiaSMI string
+	}{
+		{
+			description:       "dxcore unavailable uses /usr/lib/wsl/lib",
+			driverStoreError:  errors.New("dxcore not found"),
+			expectedNvidiaSMI: "/usr/lib/wsl/lib/nvidia-smi",
+		},
+		{
+			description:       "driver store takes precedence",
+			driverStorePaths:  []string{driverStore},
+			expectedNvidiaSMI: driverStore + "/nvidia-smi",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			defer setGetDXCoreDriverStorePathsForTest(tc.driverStorePaths, tc.driverStoreError)()
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeWsl),
+				WithDriverRoot(hostRoot),
+			)
+			require.NoError(t, err)
+
+			spec, err := lib.GetSpec()
+			require.NoError(t, err)
+			raw := spec.Raw()
+
+			require.Len(t, raw.Devices, 1)
+			require.Equal(t, "all", raw.Devices[0].Name)
+			require.Len(t, raw.Devices[0].ContainerEdits.DeviceNodes, 1)
+			require.Equal(t, dxgDeviceNode, raw.Devices[0].ContainerEdits.DeviceNodes[0].Path)
+			require.Equal(t, filepath.Join(hostRoot, dxgDeviceNode), raw.Devices[0].ContainerEdits.DeviceNodes[0].HostPath)
+
+			var mounts []string
+			for _, m := range raw.ContainerEdits.Mounts {
+				require.Equal(t, filepath.Join(hostRoot, m.ContainerPath), m.HostPath)
+				mounts = append(mounts, m.ContainerPath)
+			}
+			require.Len(t, mounts, len(requiredDriverStoreFiles))
+			require.Contains(t, mounts, tc.expectedNvidiaSMI)
+			require.Contains(t, mounts, "/usr/lib/wsl/lib/libcuda.so.1.1")
+
+			require.Len(t, raw.ContainerEdits.Hooks, 2)
+			require.Equal(t, []string{"nvidia-cdi-hook", "create-symlinks", "--link", tc.expectedNvidiaSMI + "::/usr/bin/nvidia-smi"}, raw.ContainerEdits.Hooks[0].Args)
+			require.Equal(t, []string{"nvidia-cdi-hook", "update-ldcache", "--folder", wslLibPath}, raw.ContainerEdits.Hooks[1].Args)
+		})
+	}
+
+	t.Run("library denylist is applied", func(t *testing.T) {
+		defer setGetDXCoreDriverStorePathsForTest(nil, errors.New("dxcore not found"))()
+
+		lib, err := New(
+			WithLogger(logger),
+			WithMode(ModeWsl),
+			WithDriverRoot(hostRoot),
+			WithLibraryDenylist([]string{"libcuda.so.*"}),
+		)
+		require.NoError(t, err)
+
+		spec, err := lib.GetSpec()
+		require.NoError(t, err)
+
+		var mounts []string
+		for _, m := range spec.Raw().ContainerEdits.Mounts {
+			mounts = append(mounts, m.ContainerPath)
+		}
+		require.Len(t, mounts, len(requiredDriverStoreFiles)-1)
+		require.NotContains(t, mounts, "/usr/lib/wsl/lib/libcuda.so.1.1")
+		require.Contains(t, mounts, "/usr/lib/wsl/lib/nvidia-smi")
+	})
+}
+
+func setGetDXCoreDriverStorePathsForTest(paths []string, err error) func() {
+	original := getDXCoreDriverStorePaths
+	getDXCoreDriverStorePaths = func() ([]string, error) {
+		return paths, err
+	}
+	return func() {
+		getDXCoreDriverStorePaths = original
+	}
+}
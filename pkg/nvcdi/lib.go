@@ -18,14 +18,17 @@ package nvcdi
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvcaps"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/nvsandboxutils"
 )
 
@@ -36,20 +39,58 @@ type nvcdilib struct {
 	// TODO: We should use the devRoot associated with the driver.
 	devRoot            string
 	librarySearchPaths []string
+	libraryDenylist    []string
+	// deviceNodeAllowlist restricts the device nodes included for each
+	// generated device.
+	deviceNodeAllowlist []string
+	// devCharPaths indicates that device nodes are referenced by their
+	// /dev/char paths.
+	devCharPaths bool
+	// gpuType selects the GPUs that are included for the 'all' device ID.
+	gpuType GPUType
+	// driverCapabilities selects the driver files that are included.
+	driverCapabilities image.DriverCapabilities
 
 	csv csvOptions
 
 	driver *root.Driver
+	// migCaps overrides the MIG capabilities that are otherwise read from
+	// /proc/driver/nvidia-caps/mig-minors. This allows for dependency
+	// injection in tests.
+	migCaps nvcaps.MigCaps
 
 	featureFlags map[FeatureFlag]bool
 
 	hookCreator  discover.HookCreator
 	editsFactory edits.Factory
+
+	explainer *Explainer
+	// partialDiscoveries records the discoverers that fell back to a partial
+	// discovery.
+	partialDiscoveries *partialDiscoveries
 }
 
 // New creates a new nvcdi library
 func New(opts ...Option) (Interface, error) {
 	o := populateOptions(opts...)
+	if o.gpuType != "" && !IsValidGPUType(o.gpuType) {
+		return nil, fmt.Errorf("invalid GPU type %q", o.gpuType)
+	}
+	for _, capability := range o.driverCapabilities {
+		if !IsValidDriverCapability(capability) {
+			return nil, fmt.Errorf("invalid driver capability %q", capability)
+		}
+	}
+	if err := ValidateDeviceNodePermissions(o.deviceNodePermissions); err != nil {
+		return nil, err
+	}
+	if o.replicas < 0 {
+		return nil, fmt.Errorf("invalid number of replicas %d", o.replicas)
+	}
+	driverCapabilities := image.NewDriverCapabilities(o.driverCapabilities...)
+	if len(driverCapabilities) == 0 {
+		driverCapabilities = image.NewDriverCapabilities(string(image.DriverCapabilityAll))
+	}
 
 	l := &nvcdilib{
 		logger:       o.logger,
@@ -60,8 +101,13 @@ func New(opts ...Option) (Interface, error) {
 		devRoot:      o.devRoot,
 		deviceNamers: o.deviceNamers,
 
-		librarySearchPaths: slices.Clone(o.librarySearchPaths),
-		featureFlags:       o.featureFlags,
+		librarySearchPaths:  slices.Clone(o.librarySearchPaths),
+		libraryDenylist:     slices.Clone(o.libraryDenylist),
+		deviceNodeAllowlist: slices.Clone(o.deviceNodeAllowlist),
+		devCharPaths:        o.devCharPaths,
+		gpuType:             o.gpuType,
+		driverCapabilities:  driverCapabilities,
+		featureFlags:        o.featureFlags,
 
 		csv: o.csv,
 
@@ -70,8 +116,12 @@ func New(opts ...Option) (Interface, error) {
 			discover.WithEnabledHooks(o.enabledHooks...),
 			discover.WithLdconfigPath(o.ldconfigPath),
 			discover.WithDisabledHooks(o.disabledHooks...),
+			discover.WithEnv(o.hookEnv...),
 		),
 		editsFactory: o.editsFactory,
+		explainer:    o.explainer,
+
+		partialDiscoveries: newPartialDiscoveries(),
 	}
 
 	var factory deviceSpecGeneratorFactory
@@ -84,7 +134,7 @@ func New(opts ...Option) (Interface, error) {
 		factory = (*nvmllib)(l)
 	case ModeWsl:
 		factory = (*wsllib)(l)
-	case ModeGdrcopy, ModeGds, ModeMofed, ModeNvswitch:
+	case ModeGdrcopy, ModeGds, ModeMofed, ModeNvswitch, ModePeermem:
 		factory = &gatedlib{
 			nvcdilib: l,
 			mode:     o.mode,
@@ -100,6 +150,13 @@ func New(opts ...Option) (Interface, error) {
 		vendor:              o.getVendorOrDefault(),
 		class:               o.getClassOrDefault(),
 		mergedDeviceOptions: o.mergedDeviceOptions,
+
+		deviceNodePermissions: maps.Clone(o.deviceNodePermissions),
+		replicas:              o.replicas,
+
+		editsFactory:          o.editsFactory,
+		additionalDiscoverers: o.additionalDiscoverers,
+		partialDiscoveries:    l.partialDiscoveries,
 	}
 	return &w, nil
 }
@@ -216,17 +273,54 @@ func (o *options) getNvsandboxUtilsLib() nvsandboxutils.Interface {
 }
 
 func (o *options) getDriverOptions() []root.Option {
-	return []root.Option{
+	driverOptions := []root.Option{
 		root.WithLogger(o.logger),
 		root.WithDriverRoot(o.driverRoot),
 		root.WithDevRoot(o.devRoot),
 		root.WithLibrarySearchPaths(o.librarySearchPaths...),
 		root.WithConfigSearchPaths(o.configSearchPaths...),
+	}
+	// In a dry run the driver version is inferred from the driver libraries
+	// instead of being queried from NVML.
+	if o.explainer != nil {
+		return driverOptions
+	}
+	return append(driverOptions,
 		root.WithVersioner(
 			root.FirstOf(
 				nvsandboxutilslibWithVersion(o.nvsandboxutilslib),
 				nvmllibWithVersion(o.nvmllib),
 			),
 		),
+	)
+}
+
+// newLDCacheUpdateDiscoverer returns a discoverer that makes the libraries in
+// the specified mounts available to the dynamic linker in the container. By
+// default a hook is added to update the ldcache. If the use-ld-library-path
+// feature flag is set, LD_LIBRARY_PATH is set instead.
+func (l *nvcdilib) newLDCacheUpdateDiscoverer(mounts discover.Discover) (discover.Discover, error) {
+	if l.featureFlags[FeatureUseLDLibraryPath] {
+		return discover.NewLDLibraryPathEnvVar(mounts), nil
+	}
+	return discover.NewLDCacheUpdateHook(l.logger, mounts, l.hookCreator)
+}
+
+// dryRunReason is the reason reported for the discoverers that are skipped in a
+// dry run.
+const dryRunReason = "NVML is not queried in a dry run"
+
+// dryRun returns whether the library only explains the decisions made by its
+// discoverers. In a dry run NVML is not initialized or queried.
+func (l *nvcdilib) dryRun() bool {
+	return l.explainer != nil
+}
+
+// withDevCharPaths decorates the specified device node discoverer so that the
+// device nodes are referenced by their /dev/char paths if this was requested.
+func (l *nvcdilib) withDevCharPaths(d discover.Discover) discover.Discover {
+	if !l.devCharPaths {
+		return d
 	}
+	return discover.WithDevCharPaths(l.logger, l.hookCreator, d)
 }
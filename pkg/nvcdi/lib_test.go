@@ -355,6 +355,53 @@ func TestNew(t *testing.T) {
 				},
 			},
 		},
+		{
+			description:  "csv mode with libcuda soname symlink",
+			mode:         "csv",
+			driverRootfs: "rootfs-orin",
+			additionalOptions: []Option{
+				WithCSVFiles([]string{
+					filepath.Join(lookupRoot, "rootfs-orin", "/etc/nvidia-container-runtime/host-files-for-container.d/devices.csv"),
+					filepath.Join(lookupRoot, "rootfs-orin", "/etc/nvidia-container-runtime/host-files-for-container.d/drivers.csv"),
+				}),
+				WithFeatureFlags(FeatureEnableLibcudaSonameSymlink),
+			},
+			expectedSpec: &specs.Spec{
+				Version: specs.CurrentVersion,
+				Kind:    "nvidia.com/gpu",
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/dev/nvidia0", HostPath: "/dev/nvidia0"},
+							},
+						},
+					},
+				},
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+					Mounts: []*specs.Mount{
+						{HostPath: "/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1", ContainerPath: "/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1.1", Options: []string{"ro", "nosuid", "nodev", "rbind", "rprivate"}},
+						{HostPath: "/usr/lib/aarch64-linux-gnu/nvidia/libnvidia-ml.so.1", ContainerPath: "/usr/lib/aarch64-linux-gnu/nvidia/libnvidia-ml.so.1", Options: []string{"ro", "nosuid", "nodev", "rbind", "rprivate"}},
+					},
+					Hooks: []*specs.Hook{
+						{
+							HookName: "createContainer",
+							Path:     "/usr/bin/nvidia-cdi-hook",
+							Args:     []string{"nvidia-cdi-hook", "create-symlinks", "--link", "libcuda.so.1.1::/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so.1", "--link", "libcuda.so.1::/usr/lib/aarch64-linux-gnu/nvidia/libcuda.so"},
+							Env:      []string{"NVIDIA_CTK_DEBUG=false"},
+						},
+						{
+							HookName: "createContainer",
+							Path:     "/usr/bin/nvidia-cdi-hook",
+							Args:     []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/usr/lib/aarch64-linux-gnu/nvidia"},
+							Env:      []string{"NVIDIA_CTK_DEBUG=false"},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,56 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// libraryDenylistEditsFactory is an edits factory that removes the mounts
+// matching the library denylist from the discoverers that it creates edits
+// for. Since the edits for the device specs and common edits of all modes are
+// created using the edits factory, the denylist is applied for all modes.
+type libraryDenylistEditsFactory struct {
+	edits.Factory
+	logger   logger.Interface
+	patterns []string
+}
+
+// withLibraryDenylist decorates the specified edits factory so that the
+// mounts matching the specified glob patterns are removed from the created
+// edits. If no patterns are specified, the factory is returned as is.
+func withLibraryDenylist(logger logger.Interface, factory edits.Factory, patterns ...string) edits.Factory {
+	if len(patterns) == 0 {
+		return factory
+	}
+	return &libraryDenylistEditsFactory{
+		Factory:  factory,
+		logger:   logger,
+		patterns: patterns,
+	}
+}
+
+// FromDiscoverer creates the container edits for the specified discoverer
+// with the denied libraries removed.
+func (f *libraryDenylistEditsFactory) FromDiscoverer(d discover.Discover) (*cdi.ContainerEdits, error) {
+	return f.Factory.FromDiscoverer(discover.WithMountsDenylist(f.logger, d, f.patterns...))
+}
@@ -24,6 +24,7 @@ import (
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/dgpu"
 )
 
@@ -97,11 +98,17 @@ func (l *migDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
 		return nil, fmt.Errorf("failed to get device names: %w", err)
 	}
 
+	annotations, err := l.getAnnotations()
+	if err != nil {
+		return nil, err
+	}
+
 	var deviceSpecs []specs.Device
 	for _, name := range names {
 		deviceSpec := specs.Device{
 			Name:           name,
 			ContainerEdits: *deviceEdits.ContainerEdits,
+			Annotations:    annotations,
 		}
 		deviceSpecs = append(deviceSpecs, deviceSpec)
 	}
@@ -109,6 +116,30 @@ func (l *migDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
 	return deviceSpecs, nil
 }
 
+// getAnnotations returns the annotations for the MIG device as enabled by the
+// feature flags.
+func (l *migDeviceSpecGenerator) getAnnotations() (map[string]string, error) {
+	annotations := make(map[string]string)
+	if l.nvmllib.featureFlags[FeatureEnableDeviceTypeAnnotations] {
+		annotations[DeviceTypeAnnotation] = DeviceTypeMIG
+	}
+	if l.nvmllib.featureFlags[FeatureEnableMIGProfileAnnotations] {
+		migDevice, err := l.migDevice()
+		if err != nil {
+			return nil, err
+		}
+		profile, err := migDevice.GetProfile()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get MIG profile: %w", err)
+		}
+		annotations[MIGProfileAnnotation] = profile.String()
+	}
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+	return annotations, nil
+}
+
 func (l *migDeviceSpecGenerator) migDevice() (device.MigDevice, error) {
 	return l.devicelib.NewMigDeviceByUUID(l.migUUID)
 }
@@ -128,10 +159,13 @@ func (l *migDeviceSpecGenerator) getDeviceEdits() (*cdi.ContainerEdits, error) {
 		dgpu.WithLogger(l.logger),
 		dgpu.WithHookCreator(l.hookCreator),
 		dgpu.WithNvsandboxuitilsLib(l.nvsandboxutilslib),
+		dgpu.WithMIGCaps(l.migCaps),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create device discoverer: %v", err)
 	}
+	deviceNodes = discover.WithDeviceNodeAllowlist(l.logger, deviceNodes, l.deviceNodeAllowlist...)
+	deviceNodes = (*nvcdilib)(l.nvmllib).withDevCharPaths(deviceNodes)
 
 	editsForDevice, err := l.editsFactory.FromDiscoverer(deviceNodes)
 	if err != nil {
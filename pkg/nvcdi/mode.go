@@ -46,6 +46,10 @@ const (
 	ModeImex = Mode("imex")
 	// ModeNvswitch configures the CDI spec generator to generate a spec for the available nvswitch devices.
 	ModeNvswitch = Mode("nvswitch")
+	// ModePeermem configures the CDI spec generator to generate a spec for
+	// GPUDirect RDMA using the nvidia-peermem module. The spec is empty if the
+	// module is not loaded.
+	ModePeermem = Mode("peermem")
 )
 
 type modeConstraint interface {
@@ -72,6 +76,7 @@ func getModes() modes {
 			ModeMofed,
 			ModeNvml,
 			ModeNvswitch,
+			ModePeermem,
 			ModeWsl,
 		}
 		lookup := make(map[Mode]bool)
@@ -124,3 +129,14 @@ func (o *options) resolveMode() (rmode Mode) {
 	o.logger.Warningf("Unsupported platform detected: %v; assuming %v", platform, ModeNvml)
 	return ModeNvml
 }
+
+// dryRunPropertyExtractor wraps a property extractor so that NVML is not
+// queried to determine whether the system has an integrated GPU.
+type dryRunPropertyExtractor struct {
+	info.PropertyExtractor
+}
+
+// HasAnIntegratedGPU returns false without querying NVML.
+func (dryRunPropertyExtractor) HasAnIntegratedGPU() (bool, string) {
+	return false, dryRunReason
+}
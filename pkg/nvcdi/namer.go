@@ -19,6 +19,7 @@ package nvcdi
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 )
@@ -28,6 +29,11 @@ type UUIDer interface {
 	GetUUID() (string, error)
 }
 
+// PCIBusIDer is an interface for getting PCI bus IDs.
+type PCIBusIDer interface {
+	GetPCIBusID() (string, error)
+}
+
 // DeviceNamers represents a list of device namers
 type DeviceNamers []DeviceNamer
 
@@ -45,6 +51,10 @@ const (
 	DeviceNameStrategyTypeIndex = "type-index"
 	// DeviceNameStrategyUUID uses the device UUID as the name
 	DeviceNameStrategyUUID = "uuid"
+	// DeviceNameStrategyBDF uses the PCI bus ID of the (parent) device as the
+	// name. This generates device names such as 0000:65:00.0 or
+	// 0000:65:00.0:0 for MIG devices.
+	DeviceNameStrategyBDF = "bdf"
 )
 
 type deviceNameIndex struct {
@@ -52,6 +62,7 @@ type deviceNameIndex struct {
 	migPrefix string
 }
 type deviceNameUUID struct{}
+type deviceNameBDF struct{}
 
 // NewDeviceNamer creates a Device Namer based on the supplied strategy.
 // This namer can be used to construct the names for MIG and GPU devices when generating the CDI spec.
@@ -63,6 +74,8 @@ func NewDeviceNamer(strategy string) (DeviceNamer, error) {
 		return deviceNameIndex{gpuPrefix: "gpu", migPrefix: "mig"}, nil
 	case DeviceNameStrategyUUID:
 		return deviceNameUUID{}, nil
+	case DeviceNameStrategyBDF:
+		return deviceNameBDF{}, nil
 	}
 
 	return nil, fmt.Errorf("invalid device name strategy: %v", strategy)
@@ -96,6 +109,45 @@ func (s deviceNameUUID) GetMigDeviceName(i int, _ UUIDer, j int, mig UUIDer) (st
 	return uuid, nil
 }
 
+// GetDeviceName returns the name for the specified device based on the naming strategy
+func (s deviceNameBDF) GetDeviceName(i int, d UUIDer) (string, error) {
+	return getNormalizedPCIBusID(d)
+}
+
+// GetMigDeviceName returns the name for the specified device based on the naming strategy
+func (s deviceNameBDF) GetMigDeviceName(i int, d UUIDer, j int, _ UUIDer) (string, error) {
+	busID, err := getNormalizedPCIBusID(d)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%d", busID, j), nil
+}
+
+// getNormalizedPCIBusID returns the PCI bus ID of the specified device in the
+// form DDDD:BB:DD.F. The domain is shortened to four digits if the extended
+// (eight digit) form is returned and hex digits are lowercased so that the
+// name is stable and valid as a CDI device name.
+func getNormalizedPCIBusID(d UUIDer) (string, error) {
+	p, ok := d.(PCIBusIDer)
+	if !ok {
+		return "", errors.New("device does not support getting the PCI bus ID")
+	}
+	busID, err := p.GetPCIBusID()
+	if err != nil {
+		return "", fmt.Errorf("failed to get device PCI bus ID: %w", err)
+	}
+	busID = strings.ToLower(strings.TrimSpace(busID))
+
+	domain, rest, found := strings.Cut(busID, ":")
+	if !found || rest == "" {
+		return "", fmt.Errorf("invalid PCI bus ID %q", busID)
+	}
+	if len(domain) == 8 && strings.HasPrefix(domain, "0000") {
+		domain = domain[4:]
+	}
+	return domain + ":" + rest, nil
+}
+
 //go:generate moq -rm -fmt=goimports -stub -out namer_nvml_mock.go . nvmlUUIDer
 type nvmlUUIDer interface {
 	GetUUID() (string, nvml.Return)
@@ -17,10 +17,17 @@
 package nvcdi
 
 import (
+	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
 )
 
 func TestConvert(t *testing.T) {
@@ -65,3 +72,126 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+type testDevice struct {
+	uuid  string
+	busID string
+	err   error
+}
+
+func (d testDevice) GetUUID() (string, error) {
+	return d.uuid, nil
+}
+
+func (d testDevice) GetPCIBusID() (string, error) {
+	return d.busID, d.err
+}
+
+func TestDeviceNameBDF(t *testing.T) {
+	testCases := []struct {
+		description     string
+		device          UUIDer
+		expectedError   bool
+		expectedName    string
+		expectedMigName string
+	}{
+		{
+			description:     "short domain",
+			device:          testDevice{busID: "0000:65:00.0"},
+			expectedName:    "0000:65:00.0",
+			expectedMigName: "0000:65:00.0:1",
+		},
+		{
+			description:     "extended domain is shortened and lowercased",
+			device:          testDevice{busID: "00000000:B1:00.0"},
+			expectedName:    "0000:b1:00.0",
+			expectedMigName: "0000:b1:00.0:1",
+		},
+		{
+			description:   "invalid bus ID",
+			device:        testDevice{busID: "65"},
+			expectedError: true,
+		},
+		{
+			description:   "error getting bus ID",
+			device:        testDevice{err: nvml.ERROR_NOT_SUPPORTED},
+			expectedError: true,
+		},
+		{
+			description:   "device without bus ID",
+			device:        convert{},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			namer, err := NewDeviceNamer(DeviceNameStrategyBDF)
+			require.NoError(t, err)
+
+			name, err := namer.GetDeviceName(0, tc.device)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedName, name)
+
+			migName, err := namer.GetMigDeviceName(0, tc.device, 1, testDevice{uuid: "MIG-UUID"})
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedMigName, migName)
+		})
+	}
+}
+
+func TestGetAllDeviceSpecsWithBDFNames(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	server := dgxa100.New()
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 2, nvml.SUCCESS
+	}
+	for i, d := range server.Devices {
+		busID := fmt.Sprintf("00000000:%02X:00.0", 0x07+i*0x80)
+		device := d.(*dgxa100.Device)
+		device.GetPciInfoFunc = func() (nvml.PciInfo, nvml.Return) {
+			info := nvml.PciInfo{}
+			copy(info.BusId[:], busID)
+			return info, nvml.SUCCESS
+		}
+		device.GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+	}
+
+	bdf, err := NewDeviceNamer(DeviceNameStrategyBDF)
+	require.NoError(t, err)
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeNvml),
+		WithDriverRoot(driverRoot),
+		WithNvmlLib(server),
+		WithDeviceNamers(bdf),
+	)
+	require.NoError(t, err)
+
+	deviceSpecs, err := lib.GetAllDeviceSpecs()
+	require.NoError(t, err)
+
+	var names []string
+	for _, deviceSpec := range deviceSpecs {
+		names = append(names, deviceSpec.Name)
+	}
+	require.EqualValues(t, []string{"0000:07:00.0", "0000:87:00.0"}, names)
+}
@@ -33,14 +33,22 @@ import (
 type options struct {
 	logger logger.Interface
 	platformlibs
-	mode               Mode
-	deviceNamers       DeviceNamers
-	driverRoot         string
-	devRoot            string
-	nvidiaCDIHookPath  string
-	ldconfigPath       string
-	configSearchPaths  []string
-	librarySearchPaths []string
+	mode                Mode
+	deviceNamers        DeviceNamers
+	driverRoot          string
+	devRoot             string
+	nvidiaCDIHookPath   string
+	ldconfigPath        string
+	configSearchPaths   []string
+	librarySearchPaths  []string
+	libraryDenylist     []string
+	deviceNodeAllowlist []string
+	devCharPaths        bool
+	gpuType             GPUType
+	driverCapabilities  []string
+
+	deviceNodePermissions map[string]string
+	replicas              int
 
 	csv csvOptions
 
@@ -53,8 +61,13 @@ type options struct {
 
 	disabledHooks []discover.HookName
 	enabledHooks  []discover.HookName
+	hookEnv       []string
 
 	editsFactory edits.Factory
+
+	additionalDiscoverers []discover.Discover
+
+	explainer *Explainer
 }
 
 type platformlibs struct {
@@ -86,7 +99,8 @@ func populateOptions(opts ...Option) *options {
 	if o.devRoot == "" {
 		o.devRoot = o.driverRoot
 	}
-	if o.nvsandboxutilslib == nil {
+	// In a dry run the nvsandboxutils library is not initialized.
+	if o.nvsandboxutilslib == nil && o.explainer == nil {
 		o.nvsandboxutilslib = o.getNvsandboxUtilsLib()
 	}
 	if o.nvmllib == nil {
@@ -103,6 +117,12 @@ func populateOptions(opts ...Option) *options {
 			info.WithDeviceLib(o.devicelib),
 		)
 	}
+	if o.explainer != nil {
+		o.infolib = info.New(
+			info.WithLogger(o.logger),
+			info.WithPropertyExtractor(dryRunPropertyExtractor{o.infolib}),
+		)
+	}
 	o.mode = o.resolveMode()
 
 	if o.mode == ModeCSV && len(o.csv.Files) == 0 {
@@ -125,6 +145,7 @@ func populateOptions(opts ...Option) *options {
 			edits.WithNoAdditionalGIDsForDeviceNodes(o.featureFlags[FeatureNoAdditionalGIDsForDeviceNodes]),
 		)
 	}
+	o.editsFactory = withLibraryDenylist(o.logger, o.editsFactory, o.libraryDenylist...)
 
 	return o
 }
@@ -156,7 +177,7 @@ func (o *options) getClassOrDefault() string {
 	switch o.mode {
 	case ModeImex:
 		return classImexChannel
-	case ModeGdrcopy, ModeGds, ModeMofed, ModeNvswitch:
+	case ModeGdrcopy, ModeGds, ModeMofed, ModeNvswitch, ModePeermem:
 		return string(o.mode)
 	default:
 		return "gpu"
@@ -207,6 +228,25 @@ func WithEditsFactory(editsFactory edits.Factory) Option {
 	}
 }
 
+// WithAdditionalDiscoverers appends the specified discoverers to the
+// discoverers used to generate the common edits. Custom discoverers implement
+// the Discoverer interface defined in the pkg/nvcdi/discover package.
+func WithAdditionalDiscoverers(discoverers ...discover.Discover) Option {
+	return func(l *options) {
+		l.additionalDiscoverers = append(l.additionalDiscoverers, discoverers...)
+	}
+}
+
+// WithExplainer sets an explainer that records the decisions made by the
+// discoverers used to generate the CDI specifications. Setting an explainer
+// makes the library perform a dry run in which NVML is not initialized or
+// queried. The discoverers that require NVML are reported as skipped.
+func WithExplainer(explainer *Explainer) Option {
+	return func(o *options) {
+		o.explainer = explainer
+	}
+}
+
 // WithLogger sets the logger for the library
 func WithLogger(logger logger.Interface) Option {
 	return func(l *options) {
@@ -228,6 +268,14 @@ func WithNVIDIACDIHookPath(path string) Option {
 	}
 }
 
+// WithHookEnv sets additional environment variables for the generated hooks.
+// Each envvar is expected to be of the form KEY=VALUE.
+func WithHookEnv(env ...string) Option {
+	return func(o *options) {
+		o.hookEnv = append(o.hookEnv, env...)
+	}
+}
+
 // WithLdconfigPath sets the path to the ldconfig program
 func WithLdconfigPath(path string) Option {
 	return func(l *options) {
@@ -308,6 +356,81 @@ func WithLibrarySearchPaths(paths []string) Option {
 	}
 }
 
+// WithLibraryDenylist sets a list of glob patterns for driver libraries that
+// should not be included in the generated spec.
+func WithLibraryDenylist(patterns []string) Option {
+	return func(o *options) {
+		o.libraryDenylist = patterns
+	}
+}
+
+// WithDeviceNodeAllowlist sets a list of glob patterns that restricts the
+// device nodes included in the edits for each generated device. For example,
+// a pattern of /dev/nvidia1 includes only that node and not the associated DRM
+// device nodes. The shared control device nodes such as /dev/nvidiactl and
+// /dev/nvidia-uvm are included in the common edits and are not affected.
+func WithDeviceNodeAllowlist(patterns []string) Option {
+	return func(o *options) {
+		o.deviceNodeAllowlist = patterns
+	}
+}
+
+// WithDevCharPaths sets whether device nodes are referenced by their
+// /dev/char/MAJOR:MINOR paths in the container. A create-symlinks hook is
+// added so that the original device node paths remain available. This is
+// intended for use with the symlinks created by
+// nvidia-ctk system create-dev-char-symlinks.
+func WithDevCharPaths(devCharPaths bool) Option {
+	return func(o *options) {
+		o.devCharPaths = devCharPaths
+	}
+}
+
+// WithGPUType sets the type of the GPUs that are included when generating
+// specs for all devices. Explicitly requested devices are not filtered.
+func WithGPUType[T string | GPUType](gpuType T) Option {
+	return func(o *options) {
+		o.gpuType = GPUType(gpuType)
+	}
+}
+
+// WithDriverCapabilities restricts the driver files that are included in the
+// generated edits to those required for the specified driver capabilities
+// (e.g. compute or graphics). If no capabilities are specified, the files for
+// all capabilities are included.
+func WithDriverCapabilities(capabilities ...string) Option {
+	return func(o *options) {
+		o.driverCapabilities = capabilities
+	}
+}
+
+// WithDeviceNodePermissions sets the permissions of the generated device nodes
+// per device class. The permissions are a combination of r, w, and m. The
+// device class is one of gpu, mig, or imex-channel for the corresponding
+// devices, the class of the spec for other devices, or common for the device
+// nodes included in the common edits. If no permissions are set for a class,
+// the permissions field of its device nodes is left empty.
+func WithDeviceNodePermissions(permissions map[string]string) Option {
+	return func(o *options) {
+		if o.deviceNodePermissions == nil {
+			o.deviceNodePermissions = make(map[string]string)
+		}
+		for class, p := range permissions {
+			o.deviceNodePermissions[class] = p
+		}
+	}
+}
+
+// WithReplicas sets the number of replica devices that are generated for each
+// full GPU. This allows a GPU to be shared by time-slicing. The replicas are
+// named <name>::<index> and are annotated with their share index. A value of
+// 0 or 1 generates a single device per GPU.
+func WithReplicas(replicas int) Option {
+	return func(o *options) {
+		o.replicas = replicas
+	}
+}
+
 // WithDisabledHooks allows specific hooks to be disabled.
 func WithDisabledHooks[T string | HookName](hooks ...T) Option {
 	return func(o *options) {
@@ -0,0 +1,96 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ldcache"
+)
+
+// PartialDiscoveryAnnotationPrefix is the prefix of the spec-level annotations
+// that record that a discoverer fell back to a partial discovery. The name of
+// the degraded discoverer is appended to the prefix and the annotation value
+// describes the fallback. A spec with such an annotation may be incomplete.
+const PartialDiscoveryAnnotationPrefix = "nvidia.com/cdi-partial-discovery."
+
+// A PartialDiscoveryReporter reports the discoverers that fell back to a
+// partial discovery as spec-level annotations.
+type PartialDiscoveryReporter interface {
+	PartialDiscoveryAnnotations() map[string]string
+}
+
+// partialDiscoveries records the discoverers that fell back to a partial
+// discovery. A nil value is valid and records nothing.
+type partialDiscoveries struct {
+	sync.Mutex
+	reasons map[string]string
+}
+
+func newPartialDiscoveries() *partialDiscoveries {
+	return &partialDiscoveries{
+		reasons: make(map[string]string),
+	}
+}
+
+// record records that the named discoverer fell back to a partial discovery
+// for the specified reason.
+func (p *partialDiscoveries) record(name string, reason string) {
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.reasons[name] = reason
+}
+
+// annotations returns the spec-level annotations for the recorded partial
+// discoveries.
+func (p *partialDiscoveries) annotations() map[string]string {
+	if p == nil {
+		return nil
+	}
+	p.Lock()
+	defer p.Unlock()
+	if len(p.reasons) == 0 {
+		return nil
+	}
+	annotations := make(map[string]string)
+	for name, reason := range p.reasons {
+		annotations[PartialDiscoveryAnnotationPrefix+name] = reason
+	}
+	return annotations
+}
+
+// PartialDiscoveryAnnotations returns the annotations for the discoverers that
+// fell back to a partial discovery while generating the device specs or common
+// edits.
+func (l *wrapper) PartialDiscoveryAnnotations() map[string]string {
+	return maps.Clone(l.partialDiscoveries.annotations())
+}
+
+// checkLdcache records a partial discovery if the ldcache at the driver root
+// cannot be loaded. In this case driver libraries are only located in the
+// standard search paths and libraries in other locations are not found.
+func (l *nvcdilib) checkLdcache() {
+	if _, err := ldcache.New(l.logger, l.driver.Root); err != nil {
+		l.partialDiscoveries.record("ldcache", fmt.Sprintf("failed to load the ldcache (%v); driver libraries were located using search paths only", err))
+	}
+}
@@ -0,0 +1,107 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestPartialDiscoveryAnnotations(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	ldcache, err := os.ReadFile(filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1", "etc", "ld.so.cache"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description         string
+		withLdcache         bool
+		expectedAnnotations []string
+	}{
+		{
+			description: "ldcache is present",
+			withLdcache: true,
+		},
+		{
+			description:         "ldcache is absent",
+			expectedAnnotations: []string{PartialDiscoveryAnnotationPrefix + "ldcache"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			libDir := filepath.Join(driverRoot, "lib/x86_64-linux-gnu")
+			require.NoError(t, os.MkdirAll(libDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(libDir, "libcuda.so.550.54.15"), nil, 0600))
+			devDir := filepath.Join(driverRoot, "dev")
+			require.NoError(t, os.MkdirAll(devDir, 0755))
+			for _, deviceNode := range []string{"nvidia0", "nvidiactl"} {
+				require.NoError(t, os.WriteFile(filepath.Join(devDir, deviceNode), nil, 0600))
+			}
+			if tc.withLdcache {
+				require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "etc"), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "etc", "ld.so.cache"), ldcache, 0600))
+			}
+
+			server := dgxa100.New()
+			server.DeviceGetCountFunc = func() (int, nvml.Return) {
+				return 1, nvml.SUCCESS
+			}
+			for _, d := range server.Devices {
+				(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				}
+			}
+
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithNvmlLib(server),
+			)
+			require.NoError(t, err)
+
+			s, err := lib.GetSpec("all")
+			require.NoError(t, err)
+
+			var annotations []string
+			for key := range s.Raw().Annotations {
+				annotations = append(annotations, key)
+			}
+			require.ElementsMatch(t, tc.expectedAnnotations, annotations)
+
+			reporter, ok := lib.(PartialDiscoveryReporter)
+			require.True(t, ok)
+			require.Len(t, reporter.PartialDiscoveryAnnotations(), len(tc.expectedAnnotations))
+		})
+	}
+}
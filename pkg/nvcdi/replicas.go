@@ -0,0 +1,104 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+const (
+	// ReplicasAnnotation is the spec annotation that records the number of
+	// replicas that are generated for each full GPU. A scheduler can read
+	// this to determine the number of shares that a GPU is split into.
+	ReplicasAnnotation = "nvidia.com/gpu.replicas"
+	// ReplicaIndexAnnotation is the device annotation that records the share
+	// index of a replica device.
+	ReplicaIndexAnnotation = "nvidia.com/gpu.replica-index"
+	// ReplicaOfAnnotation is the device annotation that records the name of
+	// the GPU device that a replica device shares.
+	ReplicaOfAnnotation = "nvidia.com/gpu.replica-of"
+)
+
+// A ReplicasReporter reports the number of replicas that are generated for
+// each full GPU as spec-level annotations.
+type ReplicasReporter interface {
+	ReplicasAnnotations() map[string]string
+}
+
+// replicaName returns the name of the replica device with the specified
+// index.
+func replicaName(name string, index int) string {
+	return fmt.Sprintf("%s::%d", name, index)
+}
+
+// withReplicas returns the replica devices for the specified full GPU devices.
+// Each device is replaced by the configured number of replicas that are named
+// <name>::<index> and annotated with their share index. The devices are
+// returned unmodified if replicas are not configured.
+func (l *wrapper) withReplicas(deviceSpecs []specs.Device) ([]specs.Device, error) {
+	if l.replicas <= 1 {
+		return deviceSpecs, nil
+	}
+	var replicas []specs.Device
+	for _, deviceSpec := range deviceSpecs {
+		data, err := json.Marshal(deviceSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal device %q: %w", deviceSpec.Name, err)
+		}
+		for i := 0; i < l.replicas; i++ {
+			// Each replica is decoded separately so that the replicas do not
+			// share references to the container edits.
+			var replica specs.Device
+			if err := json.Unmarshal(data, &replica); err != nil {
+				return nil, fmt.Errorf("failed to copy device %q: %w", deviceSpec.Name, err)
+			}
+			replica.Name = replicaName(deviceSpec.Name, i)
+			if replica.Annotations == nil {
+				replica.Annotations = make(map[string]string)
+			}
+			replica.Annotations[ReplicaIndexAnnotation] = strconv.Itoa(i)
+			replica.Annotations[ReplicaOfAnnotation] = deviceSpec.Name
+			replicas = append(replicas, replica)
+		}
+	}
+	return replicas, nil
+}
+
+// ReplicasAnnotations returns the spec-level annotations that record the
+// number of replicas that are generated for each full GPU. No annotations are
+// returned if replicas are not configured.
+func (l *wrapper) ReplicasAnnotations() map[string]string {
+	return l.withReplicasAnnotation(nil)
+}
+
+// withReplicasAnnotation adds the replicas annotation to the specified spec
+// annotations if replicas are configured.
+func (l *wrapper) withReplicasAnnotation(annotations map[string]string) map[string]string {
+	if l.replicas <= 1 {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ReplicasAnnotation] = strconv.Itoa(l.replicas)
+	return annotations
+}
@@ -0,0 +1,140 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestReplicas(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*dgxa100.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+	}
+
+	lib, err := New(
+		WithLogger(logger),
+		WithMode(ModeNvml),
+		WithDriverRoot(driverRoot),
+		WithNvmlLib(server),
+		WithReplicas(4),
+	)
+	require.NoError(t, err)
+
+	spec, err := lib.GetSpec("all")
+	require.NoError(t, err)
+
+	raw := spec.Raw()
+	require.Equal(t, "4", raw.Annotations[ReplicasAnnotation])
+
+	var names []string
+	for i, device := range raw.Devices {
+		names = append(names, device.Name)
+		require.Equal(t, map[string]string{
+			ReplicaIndexAnnotation: []string{"0", "1", "2", "3"}[i],
+			ReplicaOfAnnotation:    "0",
+		}, device.Annotations)
+		require.Equal(t, raw.Devices[0].ContainerEdits, device.ContainerEdits)
+	}
+	require.Equal(t, []string{"0::0", "0::1", "0::2", "0::3"}, names)
+
+	// The replicas must not share references to their container edits.
+	require.NotEmpty(t, raw.Devices[0].ContainerEdits.DeviceNodes)
+	require.NotSame(t, raw.Devices[0].ContainerEdits.DeviceNodes[0], raw.Devices[1].ContainerEdits.DeviceNodes[0])
+}
+
+func TestReplicasWithMIGDevices(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	testCases := []struct {
+		description string
+		class       string
+	}{
+		{
+			description: "default class",
+		},
+		{
+			description: "class differs from device classes",
+			class:       "custom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeNvml),
+				WithDriverRoot(driverRoot),
+				WithDevRoot(newMIGEnabledDevRoot(t)),
+				WithNvmlLib(newMIGEnabledServer()),
+				WithClass(tc.class),
+				WithReplicas(2),
+			)
+			require.NoError(t, err)
+			withTestMIGCaps(lib)
+
+			deviceSpecs, err := lib.GetDeviceSpecsByID("all")
+			require.NoError(t, err)
+
+			var names []string
+			for _, deviceSpec := range deviceSpecs {
+				names = append(names, deviceSpec.Name)
+			}
+			require.Equal(t, []string{"1::0", "1::1", "0:0"}, names)
+		})
+	}
+}
+
+func TestInvalidReplicas(t *testing.T) {
+	_, err := New(
+		WithMode(ModeImex),
+		WithReplicas(-1),
+	)
+	require.EqualError(t, err, "invalid number of replicas -1")
+}
@@ -17,13 +17,16 @@
 package spec
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 
 	"tags.cncf.io/container-device-interface/pkg/parser"
 	cdi "tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform/root"
 )
 
 type builder struct {
@@ -38,6 +41,10 @@ type builder struct {
 	mergedDeviceOptions []transform.MergedDeviceOption
 	noSimplify          bool
 	permissions         os.FileMode
+	containerRoot       string
+	relativeTo          string
+	withDigest          bool
+	annotations         map[string]string
 
 	transformOnSave transform.Transformer
 }
@@ -95,6 +102,44 @@ func (o *builder) Build() (*spec, error) {
 	if raw.Version == "" {
 		raw.Version = o.version
 	}
+	if len(o.annotations) > 0 {
+		if raw.Annotations == nil {
+			raw.Annotations = make(map[string]string)
+		}
+		maps.Copy(raw.Annotations, o.annotations)
+	}
+
+	if o.containerRoot != "" && o.containerRoot != "/" {
+		// The container root transform is applied to a copy of the spec since
+		// the edits may be shared with other specs.
+		transformed, err := deepCopy(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy spec: %w", err)
+		}
+		err = root.New(
+			root.WithRoot("/"),
+			root.WithTargetRoot(o.containerRoot),
+			root.WithRelativeTo("container"),
+		).Transform(transformed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply container root: %w", err)
+		}
+		raw = transformed
+	}
+
+	if o.relativeTo != "" {
+		// The relative transform is applied to a copy of the spec since the
+		// edits may be shared with other specs.
+		transformed, err := deepCopy(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy spec: %w", err)
+		}
+		err = root.NewRelativeTransformer(o.relativeTo).Transform(transformed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record host paths relative to %v: %w", o.relativeTo, err)
+		}
+		raw = transformed
+	}
 
 	if !o.noSimplify {
 		err := transform.NewSimplifier().Transform(raw)
@@ -118,6 +163,7 @@ func (o *builder) Build() (*spec, error) {
 		format:          o.format,
 		permissions:     o.permissions,
 		transformOnSave: o.transformOnSave,
+		withDigest:      o.withDigest,
 	}
 	return &s, nil
 }
@@ -188,9 +234,55 @@ func WithPermissions(permissions os.FileMode) Option {
 	}
 }
 
+// WithContainerRoot sets the root in the container under which the container
+// paths in the generated spec are located. Host paths are not affected.
+func WithContainerRoot(containerRoot string) Option {
+	return func(o *builder) {
+		o.containerRoot = containerRoot
+	}
+}
+
+// WithRelativeTo sets the root relative to which the host paths in the
+// generated spec are recorded. Host paths outside this root are not affected.
+func WithRelativeTo(relativeTo string) Option {
+	return func(o *builder) {
+		o.relativeTo = relativeTo
+	}
+}
+
 // WithMergedDeviceOptions sets the options for generating a merged device.
 func WithMergedDeviceOptions(opts ...transform.MergedDeviceOption) Option {
 	return func(o *builder) {
 		o.mergedDeviceOptions = opts
 	}
 }
+
+// WithDigest sets whether a content digest annotation is added to the spec
+// when it is saved.
+func WithDigest(withDigest bool) Option {
+	return func(o *builder) {
+		o.withDigest = withDigest
+	}
+}
+
+// WithAnnotations sets spec-level annotations for the spec builder. These are
+// added to any annotations that are already present in the spec.
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *builder) {
+		o.annotations = annotations
+	}
+}
+
+// deepCopy returns a copy of the specified spec that does not share any
+// references with the original.
+func deepCopy(s *cdi.Spec) (*cdi.Spec, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var c cdi.Spec
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
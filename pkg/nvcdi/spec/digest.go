@@ -0,0 +1,97 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package spec
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+const (
+	// DigestAnnotation is the spec-level annotation that holds the content
+	// digest of a CDI specification.
+	DigestAnnotation = "nvidia.com/cdi-spec-digest"
+
+	digestPrefix = "sha256:"
+)
+
+var (
+	// ErrMissingDigest is returned if a spec has no digest annotation.
+	ErrMissingDigest = errors.New("spec does not contain a digest annotation")
+	// ErrDigestMismatch is returned if the digest annotation of a spec does
+	// not match its contents.
+	ErrDigestMismatch = errors.New("spec digest does not match contents")
+)
+
+// Digest computes the content digest of the specified CDI spec. The digest is
+// computed over the JSON representation of the spec with the digest
+// annotation removed, meaning that it does not depend on the format (YAML or
+// JSON) that the spec is stored in.
+//
+// Note that this is not a signature and only detects modifications of a spec
+// after it was generated.
+func Digest(s *specs.Spec) (string, error) {
+	c, err := deepCopy(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy spec: %w", err)
+	}
+	delete(c.Annotations, DigestAnnotation)
+	if len(c.Annotations) == 0 {
+		c.Annotations = nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	return fmt.Sprintf("%s%x", digestPrefix, sha256.Sum256(data)), nil
+}
+
+// VerifyDigest checks that the digest annotation of the specified CDI spec
+// matches its contents.
+func VerifyDigest(s *specs.Spec) error {
+	expected, ok := s.Annotations[DigestAnnotation]
+	if !ok {
+		return ErrMissingDigest
+	}
+	actual, err := Digest(s)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%w: expected %v; got %v", ErrDigestMismatch, expected, actual)
+	}
+	return nil
+}
+
+// setDigest sets the digest annotation for the specified spec.
+func setDigest(s *specs.Spec) error {
+	digest, err := Digest(s)
+	if err != nil {
+		return err
+	}
+	if s.Annotations == nil {
+		s.Annotations = make(map[string]string)
+	}
+	s.Annotations[DigestAnnotation] = digest
+	return nil
+}
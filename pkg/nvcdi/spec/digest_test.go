@@ -0,0 +1,104 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestVerifyDigest(t *testing.T) {
+	testCases := []struct {
+		description   string
+		modify        func(*specs.Spec)
+		expectedError error
+	}{
+		{
+			description: "unmodified spec matches",
+		},
+		{
+			description: "other annotations are included",
+			modify: func(s *specs.Spec) {
+				s.Annotations["foo"] = "bar"
+			},
+			expectedError: ErrDigestMismatch,
+		},
+		{
+			description: "modified device edits do not match",
+			modify: func(s *specs.Spec) {
+				s.Devices[0].ContainerEdits.Env = append(s.Devices[0].ContainerEdits.Env, "INJECTED=1")
+			},
+			expectedError: ErrDigestMismatch,
+		},
+		{
+			description: "modified digest does not match",
+			modify: func(s *specs.Spec) {
+				s.Annotations[DigestAnnotation] = "sha256:0000"
+			},
+			expectedError: ErrDigestMismatch,
+		},
+		{
+			description: "missing digest",
+			modify: func(s *specs.Spec) {
+				delete(s.Annotations, DigestAnnotation)
+			},
+			expectedError: ErrMissingDigest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			for _, format := range []string{FormatYAML, FormatJSON} {
+				s, err := New(
+					WithDeviceSpecs([]specs.Device{
+						{
+							Name: "one",
+							ContainerEdits: specs.ContainerEdits{
+								Env: []string{"DEVICE_FOO=bar"},
+							},
+						},
+					}),
+					WithFormat(format),
+					WithDigest(true),
+				)
+				require.NoError(t, err)
+
+				path := filepath.Join(t.TempDir(), "nvidia."+format)
+				require.NoError(t, s.Save(path))
+
+				contents, err := os.ReadFile(path)
+				require.NoError(t, err)
+				raw, err := cdi.ParseSpec(contents)
+				require.NoError(t, err)
+				require.Contains(t, raw.Annotations, DigestAnnotation)
+
+				if tc.modify != nil {
+					tc.modify(raw)
+				}
+
+				err = VerifyDigest(raw)
+				require.ErrorIs(t, err, tc.expectedError)
+			}
+		})
+	}
+}
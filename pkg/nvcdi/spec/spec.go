@@ -33,6 +33,7 @@ type spec struct {
 	format          string
 	permissions     os.FileMode
 	transformOnSave transform.Transformer
+	withDigest      bool
 }
 
 var _ Interface = (*spec)(nil)
@@ -44,12 +45,31 @@ func New(opts ...Option) (Interface, error) {
 
 // Save writes the spec to the specified path and overwrites the file if it exists.
 func (s *spec) Save(path string) error {
+	if s.withDigest {
+		// A placeholder annotation is added before the transform is applied
+		// so that the minimum required version accounts for it.
+		if err := setDigest(s.Raw()); err != nil {
+			return fmt.Errorf("failed to compute spec digest: %w", err)
+		}
+	}
 	if s.transformOnSave != nil {
 		err := s.transformOnSave.Transform(s.Raw())
 		if err != nil {
 			return fmt.Errorf("error applying transform: %w", err)
 		}
 	}
+	if s.withDigest {
+		if err := setDigest(s.Raw()); err != nil {
+			return fmt.Errorf("failed to compute spec digest: %w", err)
+		}
+	}
+	if s.transformOnSave == nil {
+		// If the version was explicitly requested we ensure that the spec
+		// does not require features that are not supported by this version.
+		if err := specs.ValidateVersion(s.Raw()); err != nil {
+			return fmt.Errorf("spec is incompatible with version %v: %w", s.Raw().Version, err)
+		}
+	}
 	path, err := s.normalizePath(path)
 	if err != nil {
 		return fmt.Errorf("failed to normalize path: %w", err)
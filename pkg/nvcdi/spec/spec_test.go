@@ -215,3 +215,269 @@ containerEdits:
 		})
 	}
 }
+
+func TestSpecWithContainerRoot(t *testing.T) {
+	commonEdits := specs.ContainerEdits{
+		Mounts: []*specs.Mount{
+			{
+				HostPath:      "/driver-root/usr/lib64/libcuda.so.1",
+				ContainerPath: "/usr/lib64/libcuda.so.1",
+				Options:       []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+			},
+		},
+		Hooks: []*specs.Hook{
+			{
+				HookName: "createContainer",
+				Path:     "/usr/bin/nvidia-cdi-hook",
+				Args:     []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/usr/lib64"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		description   string
+		containerRoot string
+		expectedSpec  string
+	}{
+		{
+			description: "default container root",
+			expectedSpec: `---
+cdiVersion: 0.3.0
+kind: nvidia.com/gpu
+devices:
+    - name: one
+      containerEdits:
+        env:
+            - DEVICE_FOO=bar
+containerEdits:
+    hooks:
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - update-ldcache
+            - --folder
+            - /usr/lib64
+    mounts:
+        - hostPath: /driver-root/usr/lib64/libcuda.so.1
+          containerPath: /usr/lib64/libcuda.so.1
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
+`,
+		},
+		{
+			description:   "non-default container root",
+			containerRoot: "/overlay/driver",
+			expectedSpec: `---
+cdiVersion: 0.3.0
+kind: nvidia.com/gpu
+devices:
+    - name: one
+      containerEdits:
+        env:
+            - DEVICE_FOO=bar
+containerEdits:
+    hooks:
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - update-ldcache
+            - --folder
+            - /overlay/driver/usr/lib64
+    mounts:
+        - hostPath: /driver-root/usr/lib64/libcuda.so.1
+          containerPath: /overlay/driver/usr/lib64/libcuda.so.1
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s, err := New(
+				WithDeviceSpecs([]specs.Device{
+					{
+						Name: "one",
+						ContainerEdits: specs.ContainerEdits{
+							Env: []string{"DEVICE_FOO=bar"},
+						},
+					},
+				}),
+				WithEdits(commonEdits),
+				WithContainerRoot(tc.containerRoot),
+			)
+			require.NoError(t, err)
+
+			buf := new(bytes.Buffer)
+			_, err = s.WriteTo(buf)
+			require.NoError(t, err)
+
+			require.EqualValues(t, tc.expectedSpec, buf.String())
+		})
+	}
+
+	// The original edits must not be modified.
+	require.Equal(t, "/usr/lib64/libcuda.so.1", commonEdits.Mounts[0].ContainerPath)
+}
+
+func TestSpecWithRelativeTo(t *testing.T) {
+	commonEdits := specs.ContainerEdits{
+		Mounts: []*specs.Mount{
+			{
+				HostPath:      "/driver-root/usr/lib64/libcuda.so.1",
+				ContainerPath: "/usr/lib64/libcuda.so.1",
+				Options:       []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+			},
+		},
+	}
+
+	s, err := New(
+		WithDeviceSpecs([]specs.Device{
+			{
+				Name: "one",
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"DEVICE_FOO=bar"},
+				},
+			},
+		}),
+		WithEdits(commonEdits),
+		WithRelativeTo("/driver-root"),
+	)
+	require.NoError(t, err)
+
+	buf := new(bytes.Buffer)
+	_, err = s.WriteTo(buf)
+	require.NoError(t, err)
+
+	require.EqualValues(t, `---
+cdiVersion: 0.3.0
+kind: nvidia.com/gpu
+devices:
+    - name: one
+      containerEdits:
+        env:
+            - DEVICE_FOO=bar
+containerEdits:
+    mounts:
+        - hostPath: usr/lib64/libcuda.so.1
+          containerPath: /usr/lib64/libcuda.so.1
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
+`, buf.String())
+
+	// The original edits must not be modified.
+	require.Equal(t, "/driver-root/usr/lib64/libcuda.so.1", commonEdits.Mounts[0].HostPath)
+}
+
+func TestSpecVersion(t *testing.T) {
+	testCases := []struct {
+		description   string
+		version       string
+		device        specs.Device
+		expectedError bool
+		expectedSpec  string
+	}{
+		{
+			description: "older version is used",
+			version:     "0.5.0",
+			device: specs.Device{
+				Name: "one",
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"DEVICE_FOO=bar"},
+				},
+			},
+			expectedSpec: `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+    - name: one
+      containerEdits:
+        env:
+            - DEVICE_FOO=bar
+`,
+		},
+		{
+			description: "device annotations are supported from 0.6.0",
+			version:     "0.6.0",
+			device: specs.Device{
+				Name:        "one",
+				Annotations: map[string]string{"foo": "bar"},
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"DEVICE_FOO=bar"},
+				},
+			},
+			expectedSpec: `---
+cdiVersion: 0.6.0
+kind: nvidia.com/gpu
+devices:
+    - name: one
+      annotations:
+        foo: bar
+      containerEdits:
+        env:
+            - DEVICE_FOO=bar
+`,
+		},
+		{
+			description: "device annotations are not supported before 0.6.0",
+			version:     "0.5.0",
+			device: specs.Device{
+				Name:        "one",
+				Annotations: map[string]string{"foo": "bar"},
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"DEVICE_FOO=bar"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			description: "host device paths are not supported before 0.5.0",
+			version:     "0.4.0",
+			device: specs.Device{
+				Name: "one",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{
+							HostPath: "/some/dev/dev0",
+							Path:     "/dev/dev0",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s, err := New(
+				WithVersion(tc.version),
+				WithDeviceSpecs([]specs.Device{tc.device}),
+			)
+			require.NoError(t, err)
+
+			buf := new(bytes.Buffer)
+			_, err = s.WriteTo(buf)
+			if tc.expectedError {
+				require.ErrorContains(t, err, "spec is incompatible with version "+tc.version)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, buf.String())
+		})
+	}
+}
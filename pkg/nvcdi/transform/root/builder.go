@@ -27,7 +27,7 @@ type builder struct {
 }
 
 func (b *builder) build() transform.Transformer {
-	if b.root == b.targetRoot {
+	if b.isIdentity() {
 		return noop.New()
 	}
 
@@ -36,3 +36,14 @@ func (b *builder) build() transform.Transformer {
 	}
 	return hostRootTransformer(b.transformer)
 }
+
+// isIdentity checks whether none of the configured roots are changed by the
+// transformer.
+func (b *builder) isIdentity() bool {
+	for _, m := range b.mappings() {
+		if !m.isIdentity() {
+			return false
+		}
+	}
+	return true
+}
@@ -75,23 +75,18 @@ func NewDriverTransformer(opts ...DriverOption) transform.Transformer {
 		d.targetDevRoot = d.targetDriverRoot
 	}
 
-	var transformers []transform.Transformer
-
-	if d.targetDevRoot != d.targetDriverRoot {
-		devRootTransformer := New(
-			WithRoot(ensureDev(d.devRoot)),
-			WithTargetRoot(ensureDev(d.targetDevRoot)),
-		)
-		transformers = append(transformers, devRootTransformer)
-	}
-
-	driverRootTransformer := New(
+	rootOptions := []Option{
 		WithRoot(d.driverRoot),
 		WithTargetRoot(d.targetDriverRoot),
-	)
-	transformers = append(transformers, driverRootTransformer)
+	}
+	// The dev root is transformed in the same pass as the driver root to
+	// ensure that device node paths are not prefixed with the target driver
+	// root in addition to the target dev root.
+	if d.targetDevRoot != d.targetDriverRoot {
+		rootOptions = append(rootOptions, WithAdditionalRoot(ensureDev(d.devRoot), ensureDev(d.targetDevRoot)))
+	}
 
-	return transform.Merge(transformers...)
+	return New(rootOptions...)
 }
 
 func ensureDev(p string) string {
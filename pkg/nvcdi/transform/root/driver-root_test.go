@@ -188,6 +188,129 @@ func TestDriverTransformer(t *testing.T) {
 				},
 			},
 		},
+		{
+			description:      "identical chroot roots are unchanged",
+			driverRoot:       "/chroot",
+			targetDriverRoot: "/chroot/",
+			devRoot:          "/chroot",
+			targetDevRoot:    "/chroot",
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{
+							HostPath:      "/chroot/usr/lib64/libcuda.so.1",
+							ContainerPath: "/usr/lib64/libcuda.so.1",
+						},
+					},
+					DeviceNodes: []*specs.DeviceNode{
+						{
+							HostPath: "/chroot/dev/nvidiactl",
+							Path:     "/dev/nvidiactl",
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{
+							HostPath:      "/chroot/usr/lib64/libcuda.so.1",
+							ContainerPath: "/usr/lib64/libcuda.so.1",
+						},
+					},
+					DeviceNodes: []*specs.DeviceNode{
+						{
+							HostPath: "/chroot/dev/nvidiactl",
+							Path:     "/dev/nvidiactl",
+						},
+					},
+				},
+			},
+		},
+		{
+			description:      "chroot paths are made relative to the chroot",
+			driverRoot:       "/chroot",
+			targetDriverRoot: "/",
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{
+							HostPath:      "/chroot/usr/lib64/libcuda.so.1",
+							ContainerPath: "/usr/lib64/libcuda.so.1",
+						},
+						{
+							HostPath:      "/chroot-other/usr/lib64/libnvidia-ml.so.1",
+							ContainerPath: "/usr/lib64/libnvidia-ml.so.1",
+						},
+					},
+					DeviceNodes: []*specs.DeviceNode{
+						{
+							HostPath: "/chroot/dev/nvidiactl",
+							Path:     "/dev/nvidiactl",
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{
+							HostPath:      "/usr/lib64/libcuda.so.1",
+							ContainerPath: "/usr/lib64/libcuda.so.1",
+						},
+						{
+							HostPath:      "/chroot-other/usr/lib64/libnvidia-ml.so.1",
+							ContainerPath: "/usr/lib64/libnvidia-ml.so.1",
+						},
+					},
+					DeviceNodes: []*specs.DeviceNode{
+						{
+							HostPath: "/dev/nvidiactl",
+							Path:     "/dev/nvidiactl",
+						},
+					},
+				},
+			},
+		},
+		{
+			description:      "dev root is not prefixed with the target driver root",
+			driverRoot:       "/",
+			targetDriverRoot: "/chroot",
+			devRoot:          "/",
+			targetDevRoot:    "/chroot-dev",
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{
+							HostPath:      "/usr/lib64/libcuda.so.1",
+							ContainerPath: "/usr/lib64/libcuda.so.1",
+						},
+					},
+					DeviceNodes: []*specs.DeviceNode{
+						{
+							HostPath: "/dev/nvidiactl",
+							Path:     "/dev/nvidiactl",
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					Mounts: []*specs.Mount{
+						{
+							HostPath:      "/chroot/usr/lib64/libcuda.so.1",
+							ContainerPath: "/usr/lib64/libcuda.so.1",
+						},
+					},
+					DeviceNodes: []*specs.DeviceNode{
+						{
+							HostPath: "/chroot-dev/dev/nvidiactl",
+							Path:     "/dev/nvidiactl",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
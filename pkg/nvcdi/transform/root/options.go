@@ -33,6 +33,15 @@ func WithTargetRoot(root string) Option {
 	}
 }
 
+// WithAdditionalRoot adds a further (from) root and the (to) target root that
+// it is transformed to. Each path is transformed using the longest matching
+// root.
+func WithAdditionalRoot(root string, targetRoot string) Option {
+	return func(b *builder) {
+		b.additionalRoots = append(b.additionalRoots, rootMapping{root: root, targetRoot: targetRoot})
+	}
+}
+
 // WithRelativeTo sets whether the specified root is relative to the host or container.
 func WithRelativeTo(relativeTo string) Option {
 	return func(b *builder) {
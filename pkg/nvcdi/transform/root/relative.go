@@ -0,0 +1,127 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"path/filepath"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+// relativeTransformer records the host paths in a CDI spec that are in the
+// specified root relative to this root.
+type relativeTransformer struct {
+	root string
+}
+
+// anchorTransformer anchors the relative host paths in a CDI spec to the
+// specified root. This is the inverse of the relative transformer.
+type anchorTransformer struct {
+	root string
+}
+
+var _ transform.Transformer = (*relativeTransformer)(nil)
+var _ transform.Transformer = (*anchorTransformer)(nil)
+
+// NewRelativeTransformer creates a transformer that records the host paths in
+// a CDI spec relative to the specified root. This allows a spec to be
+// generated on one host and applied on a host where the root is at a
+// different location. Host paths outside the root are not changed.
+func NewRelativeTransformer(root string) transform.Transformer {
+	return &relativeTransformer{root: filepath.Join("/", root)}
+}
+
+// NewAnchorTransformer creates a transformer that converts the relative host
+// paths in a CDI spec to absolute paths in the specified root.
+func NewAnchorTransformer(root string) transform.Transformer {
+	return &anchorTransformer{root: filepath.Join("/", root)}
+}
+
+// Transform records the host paths in the root relative to the root.
+func (t relativeTransformer) Transform(spec *specs.Spec) error {
+	return transformHostPaths(spec, t.transformPath)
+}
+
+func (t relativeTransformer) transformPath(path string) string {
+	m := rootMapping{root: t.root}
+	if !m.matches(path) {
+		return path
+	}
+	relative, err := filepath.Rel(t.root, path)
+	if err != nil {
+		return path
+	}
+	return relative
+}
+
+// Transform converts relative host paths to absolute paths in the root.
+func (t anchorTransformer) Transform(spec *specs.Spec) error {
+	return transformHostPaths(spec, t.transformPath)
+}
+
+func (t anchorTransformer) transformPath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(t.root, path)
+}
+
+// transformHostPaths applies the specified transform to the host paths of the
+// device nodes, mounts, and hooks in a CDI spec. Since hook arguments cannot
+// be distinguished from relative paths, these are not transformed.
+func transformHostPaths(spec *specs.Spec, transformPath func(string) string) error {
+	if spec == nil {
+		return nil
+	}
+
+	for i := range spec.Devices {
+		transformHostPathsInEdits(&spec.Devices[i].ContainerEdits, transformPath)
+	}
+	transformHostPathsInEdits(&spec.ContainerEdits, transformPath)
+	return nil
+}
+
+func transformHostPathsInEdits(edits *specs.ContainerEdits, transformPath func(string) string) {
+	for _, dn := range edits.DeviceNodes {
+		hostPath := dn.HostPath
+		if hostPath == "" {
+			hostPath = dn.Path
+		}
+		if transformed := transformPath(hostPath); transformed != hostPath {
+			dn.HostPath = transformed
+		}
+	}
+
+	for _, hook := range edits.Hooks {
+		// The Path in the startContainer hook MUST resolve in the container namespace.
+		if hook.HookName == "startContainer" {
+			continue
+		}
+		hook.Path = transformPath(hook.Path)
+	}
+
+	for _, mount := range edits.Mounts {
+		// Only bind mounts have host paths.
+		if mount.Type != "" && mount.Type != "bind" {
+			continue
+		}
+		mount.HostPath = transformPath(mount.HostPath)
+	}
+}
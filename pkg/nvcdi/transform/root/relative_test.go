@@ -0,0 +1,213 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestRelativeTransformer(t *testing.T) {
+	testCases := []struct {
+		description  string
+		root         string
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description:  "nil spec",
+			root:         "/driver-root",
+			spec:         nil,
+			expectedSpec: nil,
+		},
+		{
+			description: "host paths in root are made relative",
+			root:        "/driver-root",
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/driver-root/dev/nvidia0"},
+						{HostPath: "/dev/nvidiactl", Path: "/dev/nvidiactl"},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: "/driver-root/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/driver-root/lib"}},
+						{HookName: "startContainer", Path: "/driver-root/usr/bin/start"},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "/driver-root/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+						{HostPath: "/driver-root-other/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+						{HostPath: "tmpfs", ContainerPath: "/tmp", Type: "tmpfs"},
+					},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							Mounts: []*specs.Mount{
+								{HostPath: "/driver-root", ContainerPath: "/driver-root"},
+							},
+						},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{HostPath: "dev/nvidia0", Path: "/driver-root/dev/nvidia0"},
+						{HostPath: "/dev/nvidiactl", Path: "/dev/nvidiactl"},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: "usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/driver-root/lib"}},
+						{HookName: "startContainer", Path: "/driver-root/usr/bin/start"},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+						{HostPath: "/driver-root-other/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+						{HostPath: "tmpfs", ContainerPath: "/tmp", Type: "tmpfs"},
+					},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							Mounts: []*specs.Mount{
+								{HostPath: ".", ContainerPath: "/driver-root"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "host root",
+			root:        "/",
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/nvidia0"},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{HostPath: "dev/nvidia0", Path: "/dev/nvidia0"},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := NewRelativeTransformer(tc.root).Transform(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
+
+func TestAnchorTransformer(t *testing.T) {
+	testCases := []struct {
+		description  string
+		root         string
+		spec         *specs.Spec
+		expectedSpec *specs.Spec
+	}{
+		{
+			description:  "nil spec",
+			root:         "/run/nvidia/driver",
+			spec:         nil,
+			expectedSpec: nil,
+		},
+		{
+			description: "relative host paths are anchored",
+			root:        "/run/nvidia/driver",
+			spec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{HostPath: "dev/nvidia0", Path: "/dev/nvidia0"},
+						{Path: "/dev/nvidiactl"},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: "usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+						{HostPath: "/opt/lib/libcuda.so.1", ContainerPath: "/opt/lib/libcuda.so.1"},
+						{HostPath: "tmpfs", ContainerPath: "/tmp", Type: "tmpfs"},
+					},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{HostPath: "/run/nvidia/driver/dev/nvidia0", Path: "/dev/nvidia0"},
+						{Path: "/dev/nvidiactl"},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: "/run/nvidia/driver/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}},
+					},
+					Mounts: []*specs.Mount{
+						{HostPath: "/run/nvidia/driver/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+						{HostPath: "/opt/lib/libcuda.so.1", ContainerPath: "/opt/lib/libcuda.so.1"},
+						{HostPath: "tmpfs", ContainerPath: "/tmp", Type: "tmpfs"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := NewAnchorTransformer(tc.root).Transform(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
+
+func TestRelativeTransformerRoundTrip(t *testing.T) {
+	spec := &specs.Spec{
+		ContainerEdits: specs.ContainerEdits{
+			Mounts: []*specs.Mount{
+				{HostPath: "/driver-root/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+				{HostPath: "/opt/lib/libfoo.so", ContainerPath: "/opt/lib/libfoo.so"},
+			},
+		},
+	}
+
+	require.NoError(t, NewRelativeTransformer("/driver-root").Transform(spec))
+	require.NoError(t, NewAnchorTransformer("/run/nvidia/driver").Transform(spec))
+
+	require.EqualValues(t,
+		[]*specs.Mount{
+			{HostPath: "/run/nvidia/driver/lib/libcuda.so.1", ContainerPath: "/lib/libcuda.so.1"},
+			{HostPath: "/opt/lib/libfoo.so", ContainerPath: "/opt/lib/libfoo.so"},
+		},
+		spec.ContainerEdits.Mounts,
+	)
+}
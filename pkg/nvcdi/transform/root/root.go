@@ -27,6 +27,15 @@ import (
 type transformer struct {
 	root       string
 	targetRoot string
+	// additionalRoots defines further roots that are transformed by the
+	// transformer.
+	additionalRoots []rootMapping
+}
+
+// A rootMapping maps a (from) root to a (to) target root.
+type rootMapping struct {
+	root       string
+	targetRoot string
 }
 
 // New creates a root transformer using the specified options.
@@ -38,10 +47,49 @@ func New(opts ...Option) transform.Transformer {
 	return b.build()
 }
 
+// mappings returns all root mappings for the transformer.
+func (t transformer) mappings() []rootMapping {
+	return append([]rootMapping{{root: t.root, targetRoot: t.targetRoot}}, t.additionalRoots...)
+}
+
+// transformPath transforms the root of the specified path. Each path is
+// transformed at most once using the mapping with the longest matching root.
+// This ensures that a path that is transformed to a target root is not
+// prefixed again if the target root is itself in one of the other roots.
 func (t transformer) transformPath(path string) string {
-	if !strings.HasPrefix(path, t.root) {
+	var selected *rootMapping
+	for _, m := range t.mappings() {
+		if !m.matches(path) {
+			continue
+		}
+		if selected == nil || len(m.cleanRoot()) > len(selected.cleanRoot()) {
+			selected = &m
+		}
+	}
+	if selected == nil {
 		return path
 	}
 
-	return filepath.Join(t.targetRoot, strings.TrimPrefix(path, t.root))
+	return filepath.Join(selected.targetRoot, strings.TrimPrefix(path, selected.cleanRoot()))
+}
+
+// matches checks whether the specified path is in the root. Only complete
+// path elements are matched so that /root does not match /rootfs. Relative
+// paths never match.
+func (m rootMapping) matches(path string) bool {
+	root := m.cleanRoot()
+	if root == "/" {
+		return strings.HasPrefix(path, "/")
+	}
+	return path == root || strings.HasPrefix(path, root+"/")
+}
+
+// isIdentity checks whether the root and the target root are the same.
+func (m rootMapping) isIdentity() bool {
+	return m.cleanRoot() == filepath.Join("/", m.targetRoot)
+}
+
+// cleanRoot returns the cleaned absolute root. An empty root is treated as /.
+func (m rootMapping) cleanRoot() string {
+	return filepath.Join("/", m.root)
 }
@@ -0,0 +1,56 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"fmt"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+type stripHooks struct{}
+
+var _ Transformer = (*stripHooks)(nil)
+
+// NewHookStripper creates a transformer that removes all hooks from a spec.
+// This is intended for environments where the updates performed by the hooks
+// (e.g. updating the ldcache or creating symlinks) are handled externally.
+func NewHookStripper() Transformer {
+	return stripHooks{}
+}
+
+// Transform removes the hooks from the common and device-specific edits of
+// the supplied spec. Other edits such as mounts and device nodes are left
+// intact. Since a device without edits is invalid, an error is returned if a
+// device only defines hooks.
+func (s stripHooks) Transform(spec *specs.Spec) error {
+	if spec == nil {
+		return nil
+	}
+
+	for i := range spec.Devices {
+		device := &spec.Devices[i]
+		device.ContainerEdits.Hooks = nil
+		if containerEdits(device.ContainerEdits).IsEmpty() {
+			return fmt.Errorf("device %q has no edits after removing hooks", device.Name)
+		}
+	}
+	spec.ContainerEdits.Hooks = nil
+
+	return nil
+}
@@ -23,6 +23,8 @@ import (
 	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
 )
@@ -34,8 +36,22 @@ type wrapper struct {
 	class  string
 
 	mergedDeviceOptions []transform.MergedDeviceOption
+
+	// deviceNodePermissions maps device classes to the permissions that are
+	// set on the generated device nodes of that class.
+	deviceNodePermissions map[string]string
+	// replicas is the number of replica devices generated for each full GPU.
+	replicas int
+
+	editsFactory          edits.Factory
+	additionalDiscoverers []discover.Discover
+
+	partialDiscoveries *partialDiscoveries
 }
 
+var _ PartialDiscoveryReporter = (*wrapper)(nil)
+var _ ReplicasReporter = (*wrapper)(nil)
+
 // TODO: Rename this type
 type deviceSpecGeneratorFactory interface {
 	DeviceSpecGenerators(...string) (DeviceSpecGenerator, error)
@@ -69,6 +85,7 @@ func (l *wrapper) GetSpec(devices ...string) (spec.Interface, error) {
 		spec.WithVendor(l.vendor),
 		spec.WithClass(l.class),
 		spec.WithMergedDeviceOptions(l.mergedDeviceOptions...),
+		spec.WithAnnotations(l.withReplicasAnnotation(l.PartialDiscoveryAnnotations())),
 	)
 }
 
@@ -80,7 +97,7 @@ func (l *wrapper) GetDeviceSpecsByID(devices ...string) ([]specs.Device, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct device spec generators: %w", err)
 	}
-	return generators.GetDeviceSpecs()
+	return l.getDeviceSpecs(generators)
 }
 
 // GetAllDeviceSpecs returns the device specs for all available devices.
@@ -98,6 +115,15 @@ func (m *wrapper) GetCommonEdits() (*cdi.ContainerEdits, error) {
 	}
 	edits.Env = append(edits.Env, image.EnvVarNvidiaVisibleDevices+"=void")
 
+	if len(m.additionalDiscoverers) > 0 {
+		additionalEdits, err := m.editsFactory.FromDiscoverer(discover.Merge(m.additionalDiscoverers...))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create edits for additional discoverers: %w", err)
+		}
+		edits.Append(additionalEdits)
+	}
+	*edits.ContainerEdits = m.withDeviceNodePermissions(DeviceClassCommon, *edits.ContainerEdits)
+
 	return edits, nil
 }
 
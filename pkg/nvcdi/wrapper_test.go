@@ -0,0 +1,111 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2026 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/discover"
+)
+
+// customDiscoverer is a discoverer as would be implemented outside of this
+// module.
+type customDiscoverer struct {
+	envVars []discover.EnvVar
+	mounts  []discover.Mount
+}
+
+var _ discover.Discoverer = (*customDiscoverer)(nil)
+
+func (d *customDiscoverer) Devices() ([]discover.Device, error) {
+	return nil, nil
+}
+
+func (d *customDiscoverer) EnvVars() ([]discover.EnvVar, error) {
+	return d.envVars, nil
+}
+
+func (d *customDiscoverer) Mounts() ([]discover.Mount, error) {
+	return d.mounts, nil
+}
+
+func (d *customDiscoverer) Hooks() ([]discover.Hook, error) {
+	return nil, nil
+}
+
+func TestAdditionalDiscoverers(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	hostRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	testCases := []struct {
+		description   string
+		discoverers   []discover.Discoverer
+		expectedEdits specs.ContainerEdits
+	}{
+		{
+			description: "no additional discoverers",
+			expectedEdits: specs.ContainerEdits{
+				Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+			},
+		},
+		{
+			description: "additional discoverers are appended",
+			discoverers: []discover.Discoverer{
+				&customDiscoverer{
+					envVars: []discover.EnvVar{{Name: "CUSTOM", Value: "value"}},
+				},
+				&customDiscoverer{
+					mounts: []discover.Mount{
+						{HostPath: "/host/custom", Path: "/custom", Options: []string{"ro", "rbind"}},
+					},
+				},
+			},
+			expectedEdits: specs.ContainerEdits{
+				Env: []string{"NVIDIA_VISIBLE_DEVICES=void", "CUSTOM=value"},
+				Mounts: []*specs.Mount{
+					{HostPath: "/host/custom", ContainerPath: "/custom", Options: []string{"ro", "rbind"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			lib, err := New(
+				WithLogger(logger),
+				WithMode(ModeImex),
+				WithDriverRoot(hostRoot),
+				WithAdditionalDiscoverers(tc.discoverers...),
+			)
+			require.NoError(t, err)
+
+			edits, err := lib.GetCommonEdits()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedEdits, *edits.ContainerEdits)
+		})
+	}
+}